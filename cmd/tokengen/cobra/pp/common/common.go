@@ -11,11 +11,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
@@ -27,8 +30,32 @@ const (
 type PP interface {
 	// AddAuditor adds an auditor to the public parameters
 	AddAuditor(raw driver.Identity)
+	// Auditors returns the auditors in the public parameters
+	Auditors() []driver.Identity
+	// SetAuditorsThreshold sets the minimum number of auditors that must sign a token request
+	SetAuditorsThreshold(threshold uint64)
 	// AddIssuer adds an issuer to the public parameters
 	AddIssuer(raw driver.Identity)
+	// AddTokenTypeIssuers binds a token type, or a prefix of token types, to the passed issuers
+	AddTokenTypeIssuers(tokenType token.Type, issuers ...driver.Identity)
+	// TokenTypeIssuers returns the token types bound to issuers
+	TokenTypeIssuers() []*driver.TokenTypeIssuers
+	// SetSupplyCap sets the maximum circulating supply of a token type
+	SetSupplyCap(tokenType token.Type, supplyCap uint64)
+	// SupplyCaps returns the maximum circulating supply of the token types that have one
+	SupplyCaps() map[token.Type]uint64
+	// AddAdministrator adds an administrator to the public parameters
+	AddAdministrator(raw driver.Identity)
+	// Administrators returns the administrators
+	Administrators() []driver.Identity
+	// SetAdministratorsThreshold sets the minimum number of administrators that must sign an administrative action
+	SetAdministratorsThreshold(threshold uint64)
+	// AddComplianceAuthority adds a compliance authority to the public parameters
+	AddComplianceAuthority(raw driver.Identity)
+	// ComplianceAuthorities returns the compliance authorities
+	ComplianceAuthorities() []driver.Identity
+	// SetComplianceThreshold sets the minimum number of compliance authorities that must authorize a clawback
+	SetComplianceThreshold(threshold uint64)
 }
 
 // GetX509Identity returns the x509 identity from the passed entry.
@@ -71,6 +98,96 @@ func SetupIssuersAndAuditors(pp PP, Auditors, Issuers []string) error {
 	return nil
 }
 
+// SetupAdministrators adds the passed administrators to the given public parameters.
+// Administrators sign administrative actions, such as the updates of the revocation list of issuers and auditors.
+func SetupAdministrators(pp PP, administrators []string) error {
+	for _, admin := range administrators {
+		id, err := GetX509Identity(admin)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get administrator identity [%s]", admin)
+		}
+		pp.AddAdministrator(id)
+	}
+	return nil
+}
+
+// SetupAdministratorsThreshold sets the minimum number of administrators that must sign an administrative action.
+// Zero means that all administrators must sign.
+func SetupAdministratorsThreshold(pp PP, threshold uint64) error {
+	pp.SetAdministratorsThreshold(threshold)
+	return common.ValidateAdministrators(pp.Administrators(), threshold)
+}
+
+// SetupComplianceAuthorities adds the passed compliance authorities to the given public parameters.
+// Compliance authorities freeze, unfreeze, and claw back tokens.
+func SetupComplianceAuthorities(pp PP, authorities []string) error {
+	for _, authority := range authorities {
+		id, err := GetX509Identity(authority)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get compliance authority identity [%s]", authority)
+		}
+		pp.AddComplianceAuthority(id)
+	}
+	return nil
+}
+
+// SetupComplianceThreshold sets the minimum number of compliance authorities that must authorize a clawback.
+// Zero means that all compliance authorities must authorize it.
+func SetupComplianceThreshold(pp PP, threshold uint64) error {
+	pp.SetComplianceThreshold(threshold)
+	return common.ValidateComplianceAuthorities(pp.ComplianceAuthorities(), threshold)
+}
+
+// SetupAuditorsThreshold sets the minimum number of auditors that must sign a token request.
+// Zero means that all auditors must sign.
+func SetupAuditorsThreshold(pp PP, threshold uint64) error {
+	pp.SetAuditorsThreshold(threshold)
+	return common.ValidateAuditors(pp.Auditors(), threshold)
+}
+
+// SetupTokenTypeIssuers binds token types to issuers in the given public parameters.
+// Each entry has the form `<token type>=<comma-separated list of issuer MSP directories>`.
+// The token type can end with the wildcard `*` to match all the token types with the given prefix.
+// An empty list of issuers allows the token type to be issued by the general issuers.
+func SetupTokenTypeIssuers(pp PP, entries []string) error {
+	for _, entry := range entries {
+		tokenType, dirs, found := strings.Cut(entry, "=")
+		if !found || len(tokenType) == 0 {
+			return errors.Errorf("invalid token type issuers [%s], expected <token type>=<issuer MSP directories>", entry)
+		}
+		var issuers []driver.Identity
+		for _, dir := range strings.Split(dirs, ",") {
+			if len(dir) == 0 {
+				continue
+			}
+			id, err := GetX509Identity(dir)
+			if err != nil {
+				return errors.WithMessagef(err, "failed to get issuer identity [%s] for token type [%s]", dir, tokenType)
+			}
+			issuers = append(issuers, id)
+		}
+		pp.AddTokenTypeIssuers(token.Type(tokenType), issuers...)
+	}
+	return common.ValidateTokenTypeIssuers(pp.TokenTypeIssuers())
+}
+
+// SetupSupplyCaps sets the maximum circulating supply of token types in the given public parameters.
+// Each entry has the form `<token type>=<maximum circulating supply>`.
+func SetupSupplyCaps(pp PP, entries []string) error {
+	for _, entry := range entries {
+		tokenType, value, found := strings.Cut(entry, "=")
+		if !found || len(tokenType) == 0 {
+			return errors.Errorf("invalid supply cap [%s], expected <token type>=<supply>", entry)
+		}
+		supplyCap, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "invalid supply cap [%s] for token type [%s]", value, tokenType)
+		}
+		pp.SetSupplyCap(token.Type(tokenType), supplyCap)
+	}
+	return common.ValidateSupplyCaps(pp.SupplyCaps())
+}
+
 // ReadSingleCertificateFromFile reads the passed file and checks that it contains only one
 // certificate in the PEM format.
 // It returns an error if the file contains more than one certificate.
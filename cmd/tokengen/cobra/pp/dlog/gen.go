@@ -31,12 +31,32 @@ type GeneratorArgs struct {
 	Issuers []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// TypeIssuers binds token types to issuers, each entry has the form <token type>=<comma-separated issuer MSP directories>
+	TypeIssuers []string
+	// AuditorsThreshold is the minimum number of auditors that must sign a token request, zero means all of them
+	AuditorsThreshold uint64
+	// SupplyCaps binds token types to their maximum circulating supply, each entry has the form <token type>=<supply>
+	SupplyCaps []string
+	// Administrators is the list of administrator MSP directories containing the corresponding administrator certificate
+	Administrators []string
+	// AdministratorsThreshold is the minimum number of administrators that must sign an administrative action, zero means all of them
+	AdministratorsThreshold uint64
+	// ComplianceAuthorities is the list of compliance authority MSP directories containing the corresponding certificate
+	ComplianceAuthorities []string
+	// ComplianceThreshold is the minimum number of compliance authorities that must authorize a clawback, zero means all of them
+	ComplianceThreshold uint64
+	// RevocationPK is the path of the PEM file of the public key of the revocation authority of the idemix owners
+	RevocationPK string
+	// RevocationEpoch is the number of blocks of an epoch of the revocation authority
+	RevocationEpoch uint64
 	// Base is a dlog driver related parameter
 	Base uint
 	// Exponent is a dlog driver related parameter
 	Exponent uint
 	// Aries is a flag to indicate that aries should be used as backend for idemix
 	Aries bool
+	// AggregatedRangeProofs is a flag to indicate that the range proofs of the outputs of a transfer must be aggregated
+	AggregatedRangeProofs bool
 }
 
 var (
@@ -50,6 +70,24 @@ var (
 	Issuers []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// TypeIssuers binds token types to issuers, each entry has the form <token type>=<comma-separated issuer MSP directories>
+	TypeIssuers []string
+	// AuditorsThreshold is the minimum number of auditors that must sign a token request, zero means all of them
+	AuditorsThreshold uint64
+	// SupplyCaps binds token types to their maximum circulating supply, each entry has the form <token type>=<supply>
+	SupplyCaps []string
+	// Administrators is the list of administrator MSP directories containing the corresponding administrator certificate
+	Administrators []string
+	// AdministratorsThreshold is the minimum number of administrators that must sign an administrative action, zero means all of them
+	AdministratorsThreshold uint64
+	// ComplianceAuthorities is the list of compliance authority MSP directories containing the corresponding certificate
+	ComplianceAuthorities []string
+	// ComplianceThreshold is the minimum number of compliance authorities that must authorize a clawback, zero means all of them
+	ComplianceThreshold uint64
+	// RevocationPK is the path of the PEM file of the public key of the revocation authority of the idemix owners
+	RevocationPK string
+	// RevocationEpoch is the number of blocks of an epoch of the revocation authority
+	RevocationEpoch uint64
 	// Base is a dlog driver related parameter.
 	// It is used to define the maximum quantity a token can contain as Base^Exponent
 	Base uint
@@ -58,6 +96,8 @@ var (
 	Exponent uint
 	// Aries is a flag to indicate that aries should be used as backend for idemix
 	Aries bool
+	// AggregatedRangeProofs is a flag to indicate that the range proofs of the outputs of a transfer must be aggregated
+	AggregatedRangeProofs bool
 )
 
 // Cmd returns the Cobra Command for Version
@@ -68,10 +108,20 @@ func Cmd() *cobra.Command {
 	flags.BoolVarP(&GenerateCCPackage, "cc", "", false, "generate chaincode package")
 	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of auditor MSP directories containing the corresponding auditor certificate")
 	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of issuer MSP directories containing the corresponding issuer certificate")
+	flags.StringArrayVarP(&TypeIssuers, "type-issuers", "t", nil, "token type, or token type prefix ending with '*', bound to a comma-separated list of issuer MSP directories, in the form <token type>=<dirs>. Can be repeated. If set, only the listed token types can be issued")
+	flags.Uint64VarP(&AuditorsThreshold, "auditors-threshold", "", 0, "minimum number of auditors that must sign a token request, zero means all of them")
+	flags.StringArrayVarP(&SupplyCaps, "supply-caps", "", nil, "token type bound to its maximum circulating supply, in the form <token type>=<supply>. Can be repeated")
+	flags.StringSliceVarP(&Administrators, "administrators", "", nil, "list of administrator MSP directories containing the corresponding administrator certificate. Administrators sign the updates of the revocation list")
+	flags.Uint64VarP(&AdministratorsThreshold, "administrators-threshold", "", 0, "minimum number of administrators that must sign an administrative action, zero means all of them")
+	flags.StringSliceVarP(&ComplianceAuthorities, "compliance", "", nil, "list of compliance authority MSP directories containing the corresponding certificate. Compliance authorities freeze, unfreeze, and claw back tokens")
+	flags.Uint64VarP(&ComplianceThreshold, "compliance-threshold", "", 0, "minimum number of compliance authorities that must authorize a clawback, zero means all of them")
+	flags.StringVarP(&RevocationPK, "revocation-pk", "", "", "PEM file of the public key of the revocation authority, such as the RevocationPublicKey generated by idemixgen. If set, the idemix owners must attach a non-revocation proof to their signatures")
+	flags.Uint64VarP(&RevocationEpoch, "revocation-epoch", "", 1000, "number of blocks of an epoch of the revocation authority, a non-revocation proof is valid in the epoch it has been generated for and in the next one")
 	flags.StringVarP(&IdemixMSPDir, "idemix", "i", "", "idemix msp dir")
 	flags.UintVarP(&Base, "base", "b", 100, "base is used to define the maximum quantity a token can contain as Base^Exponent")
 	flags.UintVarP(&Exponent, "exponent", "e", 2, "exponent is used to define the maximum quantity a token can contain as Base^Exponent")
 	flags.BoolVarP(&Aries, "aries", "r", false, "flag to indicate that aries should be used as backend for idemix")
+	flags.BoolVarP(&AggregatedRangeProofs, "aggregated-range-proofs", "", false, "flag to indicate that the range proofs of the outputs of a transfer must be aggregated into a single proof")
 
 	return cobraCommand
 }
@@ -87,14 +137,24 @@ var cobraCommand = &cobra.Command{
 		// Parsing of the command line is done so silence cmd usage
 		cmd.SilenceUsage = true
 		raw, err := Gen(&GeneratorArgs{
-			IdemixMSPDir:      IdemixMSPDir,
-			OutputDir:         OutputDir,
-			GenerateCCPackage: GenerateCCPackage,
-			Issuers:           Issuers,
-			Auditors:          Auditors,
-			Base:              Base,
-			Exponent:          Exponent,
-			Aries:             Aries,
+			IdemixMSPDir:            IdemixMSPDir,
+			OutputDir:               OutputDir,
+			GenerateCCPackage:       GenerateCCPackage,
+			Issuers:                 Issuers,
+			Auditors:                Auditors,
+			TypeIssuers:             TypeIssuers,
+			AuditorsThreshold:       AuditorsThreshold,
+			SupplyCaps:              SupplyCaps,
+			Administrators:          Administrators,
+			AdministratorsThreshold: AdministratorsThreshold,
+			ComplianceAuthorities:   ComplianceAuthorities,
+			ComplianceThreshold:     ComplianceThreshold,
+			RevocationPK:            RevocationPK,
+			RevocationEpoch:         RevocationEpoch,
+			Base:                    Base,
+			Exponent:                Exponent,
+			Aries:                   Aries,
+			AggregatedRangeProofs:   AggregatedRangeProofs,
 		})
 		if err != nil {
 			return errors.Wrap(err, "failed to generate public parameters")
@@ -129,12 +189,39 @@ func Gen(args *GeneratorArgs) ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed setting up public parameters")
 	}
+	if args.AggregatedRangeProofs {
+		pp.EnableAggregatedRangeProofs()
+	}
 	if err := pp.Validate(); err != nil {
 		return nil, errors.Wrapf(err, "failed to validate public parameters")
 	}
 	if err := common.SetupIssuersAndAuditors(pp, args.Auditors, args.Issuers); err != nil {
 		return nil, err
 	}
+	if err := common.SetupTokenTypeIssuers(pp, args.TypeIssuers); err != nil {
+		return nil, err
+	}
+	if err := common.SetupAuditorsThreshold(pp, args.AuditorsThreshold); err != nil {
+		return nil, err
+	}
+	if err := common.SetupSupplyCaps(pp, args.SupplyCaps); err != nil {
+		return nil, err
+	}
+	if err := common.SetupAdministrators(pp, args.Administrators); err != nil {
+		return nil, err
+	}
+	if err := common.SetupAdministratorsThreshold(pp, args.AdministratorsThreshold); err != nil {
+		return nil, err
+	}
+	if err := common.SetupComplianceAuthorities(pp, args.ComplianceAuthorities); err != nil {
+		return nil, err
+	}
+	if err := common.SetupComplianceThreshold(pp, args.ComplianceThreshold); err != nil {
+		return nil, err
+	}
+	if err := SetupRevocationPublicKey(pp, args.RevocationPK, args.RevocationEpoch); err != nil {
+		return nil, err
+	}
 
 	// Store Public Params
 	raw, err := pp.Serialize()
@@ -148,3 +235,17 @@ func Gen(args *GeneratorArgs) ([]byte, error) {
 
 	return raw, nil
 }
+
+// SetupRevocationPublicKey sets the public key of the revocation authority of the idemix owners to the one in the passed PEM file.
+// If the path is empty, the public parameters are not changed.
+func SetupRevocationPublicKey(pp *v1.PublicParams, revocationPK string, epoch uint64) error {
+	if len(revocationPK) == 0 {
+		return nil
+	}
+	raw, err := os.ReadFile(revocationPK)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read revocation public key [%s]", revocationPK)
+	}
+	pp.SetRevocationPublicKey(raw, epoch)
+	return pp.Validate()
+}
@@ -30,6 +30,26 @@ type UpdateArgs struct {
 	Issuers []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// TypeIssuers binds token types to issuers, each entry has the form <token type>=<comma-separated issuer MSP directories>
+	TypeIssuers []string
+	// AuditorsThreshold is the minimum number of auditors that must sign a token request, zero means all of them
+	AuditorsThreshold uint64
+	// SupplyCaps binds token types to their maximum circulating supply, each entry has the form <token type>=<supply>
+	SupplyCaps []string
+	// Administrators is the list of administrator MSP directories containing the corresponding administrator certificate
+	Administrators []string
+	// AdministratorsThreshold is the minimum number of administrators that must sign an administrative action, zero means all of them
+	AdministratorsThreshold uint64
+	// ComplianceAuthorities is the list of compliance authority MSP directories containing the corresponding certificate
+	ComplianceAuthorities []string
+	// ComplianceThreshold is the minimum number of compliance authorities that must authorize a clawback, zero means all of them
+	ComplianceThreshold uint64
+	// RevocationPK is the path of the PEM file of the public key of the revocation authority of the idemix owners
+	RevocationPK string
+	// RevocationEpoch is the number of blocks of an epoch of the revocation authority
+	RevocationEpoch uint64
+	// AggregatedRangeProofs is a flag to indicate that the public parameters must be upgraded to aggregate the range proofs of the outputs of a transfer
+	AggregatedRangeProofs bool
 }
 
 // UpdateCmd returns the Cobra Command for Update
@@ -40,6 +60,16 @@ func UpdateCmd() *cobra.Command {
 	flags.StringVarP(&OutputDir, "output", "o", ".", "output folder")
 	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of auditor MSP directories containing the corresponding auditor certificate")
 	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of issuer MSP directories containing the corresponding issuer certificate")
+	flags.StringArrayVarP(&TypeIssuers, "type-issuers", "t", nil, "token type, or token type prefix ending with '*', bound to a comma-separated list of issuer MSP directories, in the form <token type>=<dirs>. Can be repeated. If set, only the listed token types can be issued")
+	flags.Uint64VarP(&AuditorsThreshold, "auditors-threshold", "", 0, "minimum number of auditors that must sign a token request, zero means all of them")
+	flags.StringArrayVarP(&SupplyCaps, "supply-caps", "", nil, "token type bound to its maximum circulating supply, in the form <token type>=<supply>. Can be repeated")
+	flags.StringSliceVarP(&Administrators, "administrators", "", nil, "list of administrator MSP directories containing the corresponding administrator certificate. Administrators sign the updates of the revocation list")
+	flags.Uint64VarP(&AdministratorsThreshold, "administrators-threshold", "", 0, "minimum number of administrators that must sign an administrative action, zero means all of them")
+	flags.StringSliceVarP(&ComplianceAuthorities, "compliance", "", nil, "list of compliance authority MSP directories containing the corresponding certificate. Compliance authorities freeze, unfreeze, and claw back tokens")
+	flags.Uint64VarP(&ComplianceThreshold, "compliance-threshold", "", 0, "minimum number of compliance authorities that must authorize a clawback, zero means all of them")
+	flags.StringVarP(&RevocationPK, "revocation-pk", "", "", "PEM file of the public key of the revocation authority, such as the RevocationPublicKey generated by idemixgen. If set, the idemix owners must attach a non-revocation proof to their signatures")
+	flags.Uint64VarP(&RevocationEpoch, "revocation-epoch", "", 1000, "number of blocks of an epoch of the revocation authority, a non-revocation proof is valid in the epoch it has been generated for and in the next one")
+	flags.BoolVarP(&AggregatedRangeProofs, "aggregated-range-proofs", "", false, "flag to indicate that the public parameters must be upgraded to aggregate the range proofs of the outputs of a transfer into a single proof")
 
 	return cmd
 }
@@ -55,10 +85,20 @@ var cmd = &cobra.Command{
 		// Parsing of the command line is done so silence cmd usage
 		cmd.SilenceUsage = true
 		err := Update(&UpdateArgs{
-			InputFile: InputFile,
-			OutputDir: OutputDir,
-			Issuers:   Issuers,
-			Auditors:  Auditors,
+			InputFile:               InputFile,
+			OutputDir:               OutputDir,
+			Issuers:                 Issuers,
+			Auditors:                Auditors,
+			TypeIssuers:             TypeIssuers,
+			AuditorsThreshold:       AuditorsThreshold,
+			SupplyCaps:              SupplyCaps,
+			Administrators:          Administrators,
+			AdministratorsThreshold: AdministratorsThreshold,
+			ComplianceAuthorities:   ComplianceAuthorities,
+			ComplianceThreshold:     ComplianceThreshold,
+			RevocationPK:            RevocationPK,
+			RevocationEpoch:         RevocationEpoch,
+			AggregatedRangeProofs:   AggregatedRangeProofs,
 		})
 		if err != nil {
 			return errors.Wrap(err, "failed to generate public parameters")
@@ -91,13 +131,64 @@ func Update(args *UpdateArgs) error {
 	// If not provided, do not change them.
 	if len(args.Auditors) > 0 {
 		pp.Auditor = []byte{}
+		pp.AdditionalAuditors = nil
 	}
 	if len(args.Issuers) > 0 {
 		pp.IssuerIDs = []driver.Identity{}
 	}
+	if len(args.TypeIssuers) > 0 {
+		pp.TypeIssuers = nil
+	}
+	if len(args.SupplyCaps) > 0 {
+		pp.MaxSupply = nil
+	}
+	if len(args.Administrators) > 0 {
+		pp.AdminIDs = nil
+	}
+	if len(args.ComplianceAuthorities) > 0 {
+		pp.ComplianceIDs = nil
+	}
 	if err := common.SetupIssuersAndAuditors(pp, args.Auditors, args.Issuers); err != nil {
 		return err
 	}
+	if err := common.SetupTokenTypeIssuers(pp, args.TypeIssuers); err != nil {
+		return err
+	}
+	if err := common.SetupSupplyCaps(pp, args.SupplyCaps); err != nil {
+		return err
+	}
+	if err := common.SetupAdministrators(pp, args.Administrators); err != nil {
+		return err
+	}
+	if err := common.SetupComplianceAuthorities(pp, args.ComplianceAuthorities); err != nil {
+		return err
+	}
+	if err := SetupRevocationPublicKey(pp, args.RevocationPK, args.RevocationEpoch); err != nil {
+		return err
+	}
+	if args.AggregatedRangeProofs {
+		pp.EnableAggregatedRangeProofs()
+	}
+	// The threshold is set again if the auditors are provided, or if a new threshold is passed
+	if len(args.Auditors) > 0 || args.AuditorsThreshold != 0 {
+		if err := common.SetupAuditorsThreshold(pp, args.AuditorsThreshold); err != nil {
+			return err
+		}
+	}
+
+	// The administrators threshold is set again if the administrators are provided, or if a new threshold is passed
+	if len(args.Administrators) > 0 || args.AdministratorsThreshold != 0 {
+		if err := common.SetupAdministratorsThreshold(pp, args.AdministratorsThreshold); err != nil {
+			return err
+		}
+	}
+
+	// The compliance threshold is set again if the compliance authorities are provided, or if a new threshold is passed
+	if len(args.ComplianceAuthorities) > 0 || args.ComplianceThreshold != 0 {
+		if err := common.SetupComplianceThreshold(pp, args.ComplianceThreshold); err != nil {
+			return err
+		}
+	}
 
 	// Store Public Params
 	raw, err := pp.Serialize()
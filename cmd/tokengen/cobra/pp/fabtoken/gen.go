@@ -30,6 +30,20 @@ var (
 	Issuers []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// TypeIssuers binds token types to issuers, each entry has the form <token type>=<comma-separated issuer MSP directories>
+	TypeIssuers []string
+	// AuditorsThreshold is the minimum number of auditors that must sign a token request, zero means all of them
+	AuditorsThreshold uint64
+	// SupplyCaps binds token types to their maximum circulating supply, each entry has the form <token type>=<supply>
+	SupplyCaps []string
+	// Administrators is the list of administrator MSP directories containing the corresponding administrator certificate
+	Administrators []string
+	// AdministratorsThreshold is the minimum number of administrators that must sign an administrative action, zero means all of them
+	AdministratorsThreshold uint64
+	// ComplianceAuthorities is the list of compliance authority MSP directories containing the corresponding certificate
+	ComplianceAuthorities []string
+	// ComplianceThreshold is the minimum number of compliance authorities that must authorize a clawback, zero means all of them
+	ComplianceThreshold uint64
 )
 
 // Cmd returns the Cobra Command for Version
@@ -40,6 +54,13 @@ func Cmd() *cobra.Command {
 	flags.BoolVarP(&GenerateCCPackage, "cc", "", false, "generate chaincode package")
 	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of auditor MSP directories containing the corresponding auditor certificate")
 	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of issuer MSP directories containing the corresponding issuer certificate")
+	flags.StringArrayVarP(&TypeIssuers, "type-issuers", "t", nil, "token type, or token type prefix ending with '*', bound to a comma-separated list of issuer MSP directories, in the form <token type>=<dirs>. Can be repeated. If set, only the listed token types can be issued")
+	flags.Uint64VarP(&AuditorsThreshold, "auditors-threshold", "", 0, "minimum number of auditors that must sign a token request, zero means all of them")
+	flags.StringArrayVarP(&SupplyCaps, "supply-caps", "", nil, "token type bound to its maximum circulating supply, in the form <token type>=<supply>. Can be repeated")
+	flags.StringSliceVarP(&Administrators, "administrators", "", nil, "list of administrator MSP directories containing the corresponding administrator certificate. Administrators sign the updates of the revocation list")
+	flags.Uint64VarP(&AdministratorsThreshold, "administrators-threshold", "", 0, "minimum number of administrators that must sign an administrative action, zero means all of them")
+	flags.StringSliceVarP(&ComplianceAuthorities, "compliance", "", nil, "list of compliance authority MSP directories containing the corresponding certificate. Compliance authorities freeze, unfreeze, and claw back tokens")
+	flags.Uint64VarP(&ComplianceThreshold, "compliance-threshold", "", 0, "minimum number of compliance authorities that must authorize a clawback, zero means all of them")
 	return cobraCommand
 }
 
@@ -54,10 +75,17 @@ var cobraCommand = &cobra.Command{
 		// Parsing of the command line is done so silence cmd usage
 		cmd.SilenceUsage = true
 		raw, err := Gen(&GeneratorArgs{
-			OutputDir:         OutputDir,
-			GenerateCCPackage: GenerateCCPackage,
-			Issuers:           Issuers,
-			Auditors:          Auditors,
+			OutputDir:               OutputDir,
+			GenerateCCPackage:       GenerateCCPackage,
+			Issuers:                 Issuers,
+			Auditors:                Auditors,
+			TypeIssuers:             TypeIssuers,
+			AuditorsThreshold:       AuditorsThreshold,
+			SupplyCaps:              SupplyCaps,
+			Administrators:          Administrators,
+			AdministratorsThreshold: AdministratorsThreshold,
+			ComplianceAuthorities:   ComplianceAuthorities,
+			ComplianceThreshold:     ComplianceThreshold,
 		})
 		if err != nil {
 			return errors.Wrap(err, "failed to generate public parameters")
@@ -82,6 +110,20 @@ type GeneratorArgs struct {
 	Issuers []string
 	// Auditors is the list of auditor MSP directories containing the corresponding auditor certificate
 	Auditors []string
+	// TypeIssuers binds token types to issuers, each entry has the form <token type>=<comma-separated issuer MSP directories>
+	TypeIssuers []string
+	// AuditorsThreshold is the minimum number of auditors that must sign a token request, zero means all of them
+	AuditorsThreshold uint64
+	// SupplyCaps binds token types to their maximum circulating supply, each entry has the form <token type>=<supply>
+	SupplyCaps []string
+	// Administrators is the list of administrator MSP directories containing the corresponding administrator certificate
+	Administrators []string
+	// AdministratorsThreshold is the minimum number of administrators that must sign an administrative action, zero means all of them
+	AdministratorsThreshold uint64
+	// ComplianceAuthorities is the list of compliance authority MSP directories containing the corresponding certificate
+	ComplianceAuthorities []string
+	// ComplianceThreshold is the minimum number of compliance authorities that must authorize a clawback, zero means all of them
+	ComplianceThreshold uint64
 }
 
 // Gen generates the public parameters for the FabToken driver
@@ -94,6 +136,27 @@ func Gen(args *GeneratorArgs) ([]byte, error) {
 	if err := common.SetupIssuersAndAuditors(pp, args.Auditors, args.Issuers); err != nil {
 		return nil, err
 	}
+	if err := common.SetupTokenTypeIssuers(pp, args.TypeIssuers); err != nil {
+		return nil, err
+	}
+	if err := common.SetupAuditorsThreshold(pp, args.AuditorsThreshold); err != nil {
+		return nil, err
+	}
+	if err := common.SetupSupplyCaps(pp, args.SupplyCaps); err != nil {
+		return nil, err
+	}
+	if err := common.SetupAdministrators(pp, args.Administrators); err != nil {
+		return nil, err
+	}
+	if err := common.SetupAdministratorsThreshold(pp, args.AdministratorsThreshold); err != nil {
+		return nil, err
+	}
+	if err := common.SetupComplianceAuthorities(pp, args.ComplianceAuthorities); err != nil {
+		return nil, err
+	}
+	if err := common.SetupComplianceThreshold(pp, args.ComplianceThreshold); err != nil {
+		return nil, err
+	}
 	// Store Public Params
 	raw, err := pp.Serialize()
 	if err != nil {
@@ -9,10 +9,13 @@ package printpp
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
 	fabtoken "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/driver"
 	dlog "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -69,6 +72,97 @@ func Print(args *Args) error {
 	}
 
 	fmt.Println(pp.String())
+	printTokenTypeIssuers(pp.TokenTypeIssuers())
+	printAuditors(pp.Auditors(), pp.AuditorsThreshold())
+	printSupplyCaps(pp.SupplyCaps())
+	printAdministrators(pp.Administrators(), pp.AdministratorsThreshold())
+	printComplianceAuthorities(pp.ComplianceAuthorities(), pp.ComplianceThreshold())
+	if rapp, ok := pp.(driver.RevocationAuthorityPublicParameters); ok {
+		printRevocationAuthority(rapp.RevocationPublicKey(), rapp.RevocationEpochLength())
+	}
 
 	return nil
 }
+
+// printAuditors prints the auditors and the number of them that must sign a token request
+func printAuditors(auditors []driver.Identity, threshold uint64) {
+	if len(auditors) == 0 {
+		fmt.Println("Auditors: auditing is disabled")
+		return
+	}
+	fmt.Printf("Auditors: [%d] out of [%d] must sign\n", threshold, len(auditors))
+	for _, auditor := range auditors {
+		fmt.Printf("  - [%s]\n", auditor)
+	}
+}
+
+// printAdministrators prints the identities authorized to sign administrative actions,
+// and the number of them that must sign an administrative action
+func printAdministrators(administrators []driver.Identity, threshold uint64) {
+	if len(administrators) == 0 {
+		fmt.Println("Administrators: administrative actions are disabled")
+		return
+	}
+	fmt.Printf("Administrators: [%d] out of [%d] must sign an administrative action\n", threshold, len(administrators))
+	for _, admin := range administrators {
+		fmt.Printf("  - [%s]\n", admin)
+	}
+}
+
+// printComplianceAuthorities prints the identities authorized to freeze, unfreeze, and claw back tokens,
+// and the number of them that must authorize a clawback
+func printComplianceAuthorities(authorities []driver.Identity, threshold uint64) {
+	if len(authorities) == 0 {
+		fmt.Println("Compliance authorities: freezing and clawback are disabled")
+		return
+	}
+	fmt.Printf("Compliance authorities: [%d] out of [%d] must authorize a clawback\n", threshold, len(authorities))
+	for _, authority := range authorities {
+		fmt.Printf("  - [%s]\n", authority)
+	}
+}
+
+// printRevocationAuthority prints the public key the credential revocation information of the idemix owners is signed with
+func printRevocationAuthority(pk []byte, epoch uint64) {
+	if len(pk) == 0 {
+		fmt.Println("Revocation authority: owner revocation is disabled")
+		return
+	}
+	fmt.Printf("Revocation authority: epoch [%d] blocks, public key\n%s", epoch, pk)
+}
+
+// printSupplyCaps prints the maximum circulating supply of the token types that have one
+func printSupplyCaps(supplyCaps map[token.Type]uint64) {
+	if len(supplyCaps) == 0 {
+		fmt.Println("Supply caps: none")
+		return
+	}
+	tokenTypes := make([]token.Type, 0, len(supplyCaps))
+	for tokenType := range supplyCaps {
+		tokenTypes = append(tokenTypes, tokenType)
+	}
+	slices.Sort(tokenTypes)
+	fmt.Println("Supply caps:")
+	for _, tokenType := range tokenTypes {
+		fmt.Printf("  [%s]: [%d]\n", tokenType, supplyCaps[tokenType])
+	}
+}
+
+// printTokenTypeIssuers prints the token types that can be issued, each with its authorized issuers
+func printTokenTypeIssuers(typeIssuers []*driver.TokenTypeIssuers) {
+	if len(typeIssuers) == 0 {
+		fmt.Println("Token type issuers: any token type can be issued by any of the issuers")
+		return
+	}
+	fmt.Println("Token type issuers:")
+	for _, entry := range typeIssuers {
+		if len(entry.Issuers) == 0 {
+			fmt.Printf("  [%s]: any of the issuers\n", entry.TokenType)
+			continue
+		}
+		fmt.Printf("  [%s]:\n", entry.TokenType)
+		for _, issuer := range entry.Issuers {
+			fmt.Printf("    - [%s]\n", issuer)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
+	fabtoken "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/driver"
+	dlog "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/replay"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// InputFile is the file that contains the token requests to replay
+	InputFile string
+	// PPFile is the file that contains the public parameters
+	PPFile string
+	// OutputFile is the file the unspent outputs are written to
+	OutputFile string
+	// Namespace is the namespace the token requests are replayed in
+	Namespace string
+)
+
+type Args struct {
+	// InputFile is the file that contains the token requests to replay, a JSON array of replay.Request
+	InputFile string
+	// PPFile is the file that contains the public parameters
+	PPFile string
+	// OutputFile is the file the unspent outputs are written to, as a JSON array of replay.Token.
+	// If empty, the unspent outputs are written to the standard output
+	OutputFile string
+	// Namespace is the namespace the token requests are replayed in.
+	// It is required to replay administrative actions, whose signatures cover it.
+	Namespace string
+}
+
+// Cmd returns the Cobra Command for Replay
+func Cmd() *cobra.Command {
+	// Set the flags on the node start command.
+	flags := cobraCommand.Flags()
+	flags.StringVarP(&InputFile, "input", "i", "", "path of the JSON file that contains the ordered list of token requests")
+	flags.StringVarP(&PPFile, "pp", "p", "", "path of the public param file")
+	flags.StringVarP(&OutputFile, "output", "o", "", "path of the file the unspent outputs are written to, standard output if empty")
+	flags.StringVarP(&Namespace, "namespace", "n", "", "namespace the token requests are replayed in, required to replay administrative actions")
+
+	return cobraCommand
+}
+
+var cobraCommand = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay a chain of token requests.",
+	Long:  `Replay an ordered list of token requests against an in-memory ledger and output the final set of unspent outputs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		err := Replay(&Args{
+			InputFile:  InputFile,
+			PPFile:     PPFile,
+			OutputFile: OutputFile,
+			Namespace:  Namespace,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to replay token requests")
+		}
+		return nil
+	},
+}
+
+// Replay replays the token requests and writes the unspent outputs.
+// The outcome of each token request is printed on the standard error.
+// It returns an error if any of the token requests has been rejected.
+func Replay(args *Args) error {
+	ppRaw, err := os.ReadFile(args.PPFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file at [%s]", args.PPFile)
+	}
+	s := core.NewPPManagerFactoryService(fabtoken.NewPPMFactory(), dlog.NewPPMFactory())
+	pp, err := s.PublicParametersFromBytes(ppRaw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal pp from [%s]", args.PPFile)
+	}
+	validator, err := s.DefaultValidator(pp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to instantiate validator for [%s]", pp.Identifier())
+	}
+	raw, err := os.ReadFile(args.InputFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file at [%s]", args.InputFile)
+	}
+	var requests []*replay.Request
+	if err := json.Unmarshal(raw, &requests); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal token requests from [%s]", args.InputFile)
+	}
+
+	r, err := replay.New(ppRaw, validator)
+	if err != nil {
+		return err
+	}
+	r.Namespace = args.Namespace
+	rejected := 0
+	for i, result := range r.ReplayAll(context.Background(), requests) {
+		if result.Err != nil {
+			rejected++
+			fmt.Fprintf(os.Stderr, "[%d] [%s]: rejected [%s]\n", i, result.Anchor, result.Err)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "[%d] [%s]: applied\n", i, result.Anchor)
+	}
+
+	unspent, err := r.Unspent()
+	if err != nil {
+		return errors.Wrap(err, "failed to get unspent outputs")
+	}
+	if unspent == nil {
+		unspent = []*replay.Token{}
+	}
+	out, err := json.MarshalIndent(unspent, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal unspent outputs")
+	}
+	if len(args.OutputFile) == 0 {
+		fmt.Println(string(out))
+	} else if err := os.WriteFile(args.OutputFile, out, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write unspent outputs to [%s]", args.OutputFile)
+	}
+
+	if rejected != 0 {
+		return errors.Errorf("[%d] out of [%d] token requests have been rejected", rejected, len(requests))
+	}
+	return nil
+}
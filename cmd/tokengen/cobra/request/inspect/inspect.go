@@ -0,0 +1,312 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package inspect
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	fabtoken "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/driver"
+	dlog "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// RequestFile is the file that contains the serialized token request
+	RequestFile string
+	// Request is the base64 encoding of the serialized token request
+	Request string
+	// PPFile is the file that contains the public parameters
+	PPFile string
+	// LedgerFile is the file that contains the snapshot of the ledger state
+	LedgerFile string
+	// Anchor is the anchor, the transaction id, the token request is bound to
+	Anchor string
+	// BlockHeight is the height of the ledger the token request is validated at
+	BlockHeight uint64
+	// Namespace is the namespace the token request is validated in
+	Namespace string
+)
+
+type Args struct {
+	// RequestFile is the file that contains the serialized token request
+	RequestFile string
+	// Request is the base64 encoding of the serialized token request. It is used if RequestFile is empty
+	Request string
+	// PPFile is the file that contains the public parameters
+	PPFile string
+	// LedgerFile is the file that contains the snapshot of the ledger state, see LedgerEntry. It is optional
+	LedgerFile string
+	// Anchor is the anchor, the transaction id, the token request is bound to
+	Anchor string
+	// BlockHeight is the height of the ledger the token request is validated at. Zero means unknown
+	BlockHeight uint64
+	// Namespace is the namespace the token request is validated in.
+	// It is required to validate administrative actions, whose signatures cover it.
+	Namespace string
+}
+
+// LedgerEntry is an entry of the ledger snapshot against which the token request is validated.
+// The snapshot is a JSON array of entries, each value is base64 encoded.
+type LedgerEntry struct {
+	ID    token.ID `json:"id"`
+	Value []byte   `json:"value"`
+}
+
+// Cmd returns the Cobra Command for Inspect
+func Cmd() *cobra.Command {
+	// Set the flags on the node start command.
+	flags := cobraCommand.Flags()
+	flags.StringVarP(&RequestFile, "input", "i", "", "path of the file that contains the serialized token request")
+	flags.StringVarP(&Request, "request", "r", "", "base64 encoding of the serialized token request, used if no input file is passed")
+	flags.StringVarP(&PPFile, "pp", "p", "", "path of the public param file")
+	flags.StringVarP(&LedgerFile, "ledger", "l", "", "path of the JSON file that contains the snapshot of the ledger state")
+	flags.StringVarP(&Anchor, "anchor", "a", "", "anchor (transaction id) the token request is bound to")
+	flags.Uint64Var(&BlockHeight, "height", 0, "height of the ledger the token request is validated at")
+	flags.StringVarP(&Namespace, "namespace", "n", "", "namespace the token request is validated in, required to validate administrative actions")
+
+	return cobraCommand
+}
+
+var cobraCommand = &cobra.Command{
+	Use:   "inspect",
+	Short: "Inspect and validate a token request.",
+	Long:  `Inspect a serialized token request and validate it offline against a snapshot of the ledger state.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		err := Inspect(&Args{
+			RequestFile: RequestFile,
+			Request:     Request,
+			PPFile:      PPFile,
+			LedgerFile:  LedgerFile,
+			Anchor:      Anchor,
+			BlockHeight: BlockHeight,
+			Namespace:   Namespace,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to inspect token request")
+		}
+		return nil
+	},
+}
+
+// Inspect prints the content of the token request and the outcome of its validation.
+// It returns an error if the token request is invalid.
+func Inspect(args *Args) error {
+	raw, err := loadRequest(args)
+	if err != nil {
+		return err
+	}
+	ppRaw, err := os.ReadFile(args.PPFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read file at [%s]", args.PPFile)
+	}
+	s := core.NewPPManagerFactoryService(fabtoken.NewPPMFactory(), dlog.NewPPMFactory())
+	pp, err := s.PublicParametersFromBytes(ppRaw)
+	if err != nil {
+		return errors.Wrapf(err, "failed to unmarshal pp from [%s]", args.PPFile)
+	}
+	validator, err := s.DefaultValidator(pp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to instantiate validator for [%s]", pp.Identifier())
+	}
+	ledger, err := loadLedger(args.LedgerFile)
+	if err != nil {
+		return err
+	}
+
+	tr := &driver.TokenRequest{}
+	if err := tr.FromBytes(raw); err != nil {
+		return errors.Wrap(err, "failed to unmarshal token request")
+	}
+	actions, err := validator.UnmarshalActions(raw)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal actions")
+	}
+	fmt.Printf("Token request: [%d] issue action(s), [%d] transfer action(s), [%d] administrative action(s)\n", len(tr.Issues), len(tr.Transfers), len(tr.AdminActions))
+	printActions(actions)
+	printSignatures("Signatures", tr.Signatures)
+	printSignatures("Auditor signatures", tr.AuditorSignatures)
+
+	ctx := context.Background()
+	if args.BlockHeight != 0 {
+		ctx = driver.WithBlockHeight(ctx, args.BlockHeight)
+	}
+	if len(args.Namespace) != 0 {
+		ctx = driver.WithNamespace(ctx, args.Namespace)
+	}
+	_, _, err = validator.VerifyTokenRequestFromRaw(ctx, ledger.GetState, args.Anchor, raw)
+	if err == nil {
+		fmt.Println("Validation: [valid]")
+		return nil
+	}
+	fmt.Println("Validation: [invalid]")
+	funcErr := &common.ValidateFuncError{}
+	if errors.As(err, &funcErr) {
+		fmt.Printf("  Action: [%s] at [%d]\n", funcErr.ActionType, funcErr.ActionIndex)
+		fmt.Printf("  Failed check: [%s]\n", funcErr.Func)
+	}
+	fmt.Printf("  Error: [%s]\n", err)
+	return errors.New("invalid token request")
+}
+
+func loadRequest(args *Args) ([]byte, error) {
+	if len(args.RequestFile) != 0 {
+		raw, err := os.ReadFile(args.RequestFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read file at [%s]", args.RequestFile)
+		}
+		return raw, nil
+	}
+	if len(args.Request) == 0 {
+		return nil, errors.New("no token request passed")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(args.Request))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode token request")
+	}
+	return raw, nil
+}
+
+// snapshot is a read-only ledger backed by a snapshot of the ledger state
+type snapshot map[token.ID][]byte
+
+func (s snapshot) GetState(id token.ID) ([]byte, error) {
+	return s[id], nil
+}
+
+func loadLedger(path string) (snapshot, error) {
+	s := snapshot{}
+	if len(path) == 0 {
+		return s, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read file at [%s]", path)
+	}
+	var entries []LedgerEntry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal ledger snapshot from [%s]", path)
+	}
+	for _, entry := range entries {
+		s[entry.ID] = entry.Value
+	}
+	return s, nil
+}
+
+// printActions prints the issue and transfer actions with their inputs, outputs, and metadata keys
+func printActions(actions []interface{}) {
+	// the owner type does not depend on the wallets, no authorization checker is needed
+	authorization := common.NewAuthorizationMultiplexer()
+	issues, transfers := 0, 0
+	for _, action := range actions {
+		switch a := action.(type) {
+		case driver.IssueAction:
+			fmt.Printf("Issue action [%d]:\n", issues)
+			issues++
+			fmt.Printf("  Issuer: [%s]\n", driver.Identity(a.GetIssuer()))
+			fmt.Printf("  Anonymous: [%v]\n", a.IsAnonymous())
+			printInputs(a.GetInputs())
+			printOutputs(authorization, a.GetOutputs())
+			printExtraSigners(a.ExtraSigners())
+			printMetadataKeys(a.GetMetadata())
+		case driver.TransferAction:
+			fmt.Printf("Transfer action [%d]:\n", transfers)
+			transfers++
+			fmt.Printf("  Graph hiding: [%v]\n", a.IsGraphHiding())
+			printInputs(a.GetInputs())
+			printOutputs(authorization, a.GetOutputs())
+			printExtraSigners(a.ExtraSigners())
+			printMetadataKeys(a.GetMetadata())
+		default:
+			fmt.Printf("Unknown action [%T]\n", action)
+		}
+	}
+}
+
+func printInputs(inputs []*token.ID) {
+	if len(inputs) == 0 {
+		fmt.Println("  Inputs: none")
+		return
+	}
+	fmt.Println("  Inputs:")
+	for i, input := range inputs {
+		if input == nil {
+			fmt.Printf("    [%d]: hidden\n", i)
+			continue
+		}
+		fmt.Printf("    [%d]: %s\n", i, input)
+	}
+}
+
+func printOutputs(authorization driver.Authorization, outputs []driver.Output) {
+	if len(outputs) == 0 {
+		fmt.Println("  Outputs: none")
+		return
+	}
+	fmt.Println("  Outputs:")
+	for i, output := range outputs {
+		if output == nil {
+			fmt.Printf("    [%d]: nil\n", i)
+			continue
+		}
+		if output.IsRedeem() {
+			fmt.Printf("    [%d]: redeem\n", i)
+			continue
+		}
+		ownerType, owner, err := authorization.OwnerType(output.GetOwner())
+		if err != nil {
+			fmt.Printf("    [%d]: owner [%s], unknown owner type [%s]\n", i, driver.Identity(output.GetOwner()), err)
+			continue
+		}
+		fmt.Printf("    [%d]: owner type [%s], owner [%s]\n", i, ownerType, driver.Identity(owner))
+	}
+}
+
+func printExtraSigners(signers []driver.Identity) {
+	if len(signers) == 0 {
+		return
+	}
+	fmt.Println("  Extra signers:")
+	for _, signer := range signers {
+		fmt.Printf("    - [%s]\n", signer)
+	}
+}
+
+func printMetadataKeys(metadata map[string][]byte) {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	fmt.Printf("  Metadata keys: [%s]\n", strings.Join(keys, ", "))
+}
+
+func printSignatures(label string, signatures [][]byte) {
+	if len(signatures) == 0 {
+		fmt.Printf("%s: none\n", label)
+		return
+	}
+	fmt.Printf("%s:\n", label)
+	for i, sigma := range signatures {
+		fmt.Printf("  [%d]: [%s]\n", i, base64.StdEncoding.EncodeToString(sigma))
+	}
+}
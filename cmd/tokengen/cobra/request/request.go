@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package request
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/request/inspect"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/request/revoke"
+	"github.com/spf13/cobra"
+)
+
+// Cmd returns the Cobra Command for the Token Request utils command
+func Cmd() *cobra.Command {
+	requestCobraCommand.AddCommand(inspect.Cmd())
+	requestCobraCommand.AddCommand(revoke.Cmd())
+
+	return requestCobraCommand
+}
+
+var requestCobraCommand = &cobra.Command{
+	Use:   "request",
+	Short: "Token request utils.",
+	Long:  `Token request utility commands`,
+}
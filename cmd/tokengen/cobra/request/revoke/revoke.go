@@ -0,0 +1,189 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package revoke
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/storage/kvs"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RequestFileName is the name of the file, in the output folder, that contains the serialized token request
+const RequestFileName = "revocation_request"
+
+var (
+	// OutputDir is the directory to output the generated files
+	OutputDir string
+	// Issuers is the list of MSP directories containing the certificates of the issuers to revoke
+	Issuers []string
+	// Auditors is the list of MSP directories containing the certificates of the auditors to revoke
+	Auditors []string
+	// Owners is the list of revocation handles of the idemix owners to revoke
+	Owners []string
+	// Administrators is the list of administrator MSP directories containing the certificate and the signing key of each administrator
+	Administrators []string
+	// Sequence is the sequence number of the new revocation list
+	Sequence uint64
+	// PPFile is the file that contains the public parameters the update is validated with
+	PPFile string
+	// Namespace is the namespace the update is meant for
+	Namespace string
+)
+
+type Args struct {
+	// OutputDir is the directory to output the generated files
+	OutputDir string
+	// Issuers is the list of MSP directories containing the certificates of the issuers to revoke
+	Issuers []string
+	// Auditors is the list of MSP directories containing the certificates of the auditors to revoke
+	Auditors []string
+	// Owners is the list of revocation handles of the idemix owners to revoke,
+	// as set in the signer configuration of their credentials
+	Owners []string
+	// Administrators is the list of administrator MSP directories containing the certificate and the signing key of each administrator.
+	// At least the administrators threshold set in the public parameters must sign.
+	Administrators []string
+	// Sequence is the sequence number of the new revocation list, it must be the one of the current list plus one
+	Sequence uint64
+	// PPFile is the file that contains the public parameters the update is validated with.
+	// The administrators sign their hash, together with the namespace.
+	PPFile string
+	// Namespace is the namespace the update is meant for
+	Namespace string
+}
+
+// Cmd returns the Cobra Command for Revoke
+func Cmd() *cobra.Command {
+	// Set the flags on the node start command.
+	flags := cobraCommand.Flags()
+	flags.StringVarP(&OutputDir, "output", "o", ".", "output folder")
+	flags.StringSliceVarP(&Issuers, "issuers", "s", nil, "list of MSP directories containing the certificates of the issuers to revoke")
+	flags.StringSliceVarP(&Auditors, "auditors", "a", nil, "list of MSP directories containing the certificates of the auditors to revoke")
+	flags.StringSliceVarP(&Owners, "owners", "", nil, "list of revocation handles of the idemix owners to revoke")
+	flags.StringSliceVarP(&Administrators, "administrators", "", nil, "list of administrator MSP directories containing the certificate and the signing key of each administrator")
+	flags.Uint64VarP(&Sequence, "sequence", "", 1, "sequence number of the new revocation list, the one of the current list plus one")
+	flags.StringVarP(&PPFile, "pp", "p", "", "path of the public param file the update is validated with")
+	flags.StringVarP(&Namespace, "namespace", "n", "", "namespace the update is meant for")
+
+	return cobraCommand
+}
+
+var cobraCommand = &cobra.Command{
+	Use:   "revoke",
+	Short: "Generate a revocation list update.",
+	Long: `Generates a token request that replaces the revocation list of issuers, auditors, and owners stored on the ledger.
+The new list contains exactly the passed issuers, auditors, and owners, therefore, the identities revoked by the current list
+must be passed again to keep them revoked. The request is signed by the passed administrators,
+and it is valid only in the passed namespace and with the passed public parameters.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+		raw, err := Revoke(&Args{
+			OutputDir:      OutputDir,
+			Issuers:        Issuers,
+			Auditors:       Auditors,
+			Owners:         Owners,
+			Administrators: Administrators,
+			Sequence:       Sequence,
+			PPFile:         PPFile,
+			Namespace:      Namespace,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to generate revocation list update")
+		}
+		fmt.Printf("Revocation list update written to [%s], [%d] bytes\n", filepath.Join(OutputDir, RequestFileName), len(raw))
+		return nil
+	},
+}
+
+// Revoke generates the token request carrying the revocation list update described by the passed arguments,
+// and stores it in the output folder.
+func Revoke(args *Args) ([]byte, error) {
+	if len(args.Administrators) == 0 {
+		return nil, errors.New("no administrator passed")
+	}
+	if len(args.Namespace) == 0 {
+		return nil, errors.New("no namespace passed")
+	}
+	ppRaw, err := os.ReadFile(args.PPFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read file at [%s]", args.PPFile)
+	}
+	ppHash := driver.PPHash(utils.Hashable(ppRaw).Raw())
+	list := &driver.RevocationList{Sequence: args.Sequence}
+	for _, dir := range args.Issuers {
+		id, err := common.GetX509Identity(dir)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get issuer identity [%s]", dir)
+		}
+		list.Issuers = append(list.Issuers, id)
+	}
+	for _, dir := range args.Auditors {
+		id, err := common.GetX509Identity(dir)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get auditor identity [%s]", dir)
+		}
+		list.Auditors = append(list.Auditors, id)
+	}
+	for _, rh := range args.Owners {
+		list.OwnerRevocationHandles = append(list.OwnerRevocationHandles, []byte(rh))
+	}
+	listRaw, err := list.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revocation list")
+	}
+
+	update := &driver.RevocationListUpdate{List: listRaw}
+	message, err := update.MessageToSign(args.Namespace, ppHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revocation list update to sign")
+	}
+	keyStore := x509.NewKeyStore(kvs.NewTrackedMemory())
+	for _, dir := range args.Administrators {
+		id, err := common.GetX509Identity(dir)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get administrator identity [%s]", dir)
+		}
+		km, _, err := x509.NewKeyManager(dir, nil, nil, keyStore)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to load administrator [%s]", dir)
+		}
+		if km.IsRemote() {
+			return nil, errors.Errorf("no signing key found for administrator [%s]", dir)
+		}
+		sigma, err := km.SigningIdentity().Sign(message)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to sign with administrator [%s]", dir)
+		}
+		update.Signatures = append(update.Signatures, &driver.AdminSignature{Signer: id, Sigma: sigma})
+	}
+	updateRaw, err := update.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revocation list update")
+	}
+
+	tr := &driver.TokenRequest{AdminActions: [][]byte{updateRaw}, PublicParamsHash: ppHash}
+	raw, err := tr.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal token request")
+	}
+	if err := os.WriteFile(filepath.Join(args.OutputDir, RequestFileName), raw, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed writing token request to file")
+	}
+	return raw, nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/artifactgen/gen"
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/certfier"
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/replay"
+	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/request"
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/version"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -47,6 +49,8 @@ func main() {
 	mainCmd.AddCommand(pp.GenCmd())
 	mainCmd.AddCommand(pp.UpdateCmd())
 	mainCmd.AddCommand(pp.UtilsCmd())
+	mainCmd.AddCommand(request.Cmd())
+	mainCmd.AddCommand(replay.Cmd())
 	mainCmd.AddCommand(certfier.KeyPairGenCmd())
 	mainCmd.AddCommand(gen.Cmd())
 	mainCmd.AddCommand(version.Cmd())
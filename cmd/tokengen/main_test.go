@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,10 +15,14 @@ import (
 
 	"github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen/cobra/pp/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
+	fabtokenv1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/core"
 	fabtoken "github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/driver"
 	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto"
 	dlog "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/replay"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gexec"
 )
@@ -68,6 +73,280 @@ func TestGenFullSuccess(t *testing.T) {
 	)
 }
 
+func TestGenWithTokenTypeIssuers(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+
+	testGenRun(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"dlog",
+			"--idemix",
+			"./testdata/idemix",
+			"--issuers",
+			"./testdata/issuers/msp",
+			"--type-issuers",
+			"USD=./testdata/auditors/msp",
+			"--type-issuers",
+			"EUR*=",
+			"--output",
+			tempOutput,
+		},
+	)
+
+	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "zkatdlog_pp.json"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := v1.NewPublicParamsFromBytes(ppRaw, v1.DLogPublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Validate()).NotTo(HaveOccurred())
+
+	usdIssuer, err := common.GetX509Identity("./testdata/auditors/msp")
+	gt.Expect(err).NotTo(HaveOccurred())
+	typeIssuers := pp.TokenTypeIssuers()
+	gt.Expect(typeIssuers).To(HaveLen(2))
+	gt.Expect(string(typeIssuers[0].TokenType)).To(Equal("USD"))
+	gt.Expect(typeIssuers[0].Issuers).To(ConsistOf(BeEquivalentTo(usdIssuer)))
+	gt.Expect(string(typeIssuers[1].TokenType)).To(Equal("EUR*"))
+	gt.Expect(typeIssuers[1].Issuers).To(BeEmpty())
+
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "fabtoken", "--type-issuers", "USD", "--output", tempOutput},
+		"invalid token type issuers [USD], expected <token type>=<issuer MSP directories>",
+	)
+}
+
+func TestGenWithAuditorsThreshold(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+
+	testGenRun(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"fabtoken",
+			"--auditors",
+			"./testdata/auditors/msp,./testdata/issuers/msp",
+			"--auditors-threshold",
+			"1",
+			"--output",
+			tempOutput,
+		},
+	)
+
+	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "fabtoken_pp.json"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := fabtokenv1.NewPublicParamsFromBytes(ppRaw, fabtokenv1.PublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Validate()).NotTo(HaveOccurred())
+	gt.Expect(pp.Auditors()).To(HaveLen(2))
+	gt.Expect(pp.AuditorsThreshold()).To(Equal(uint64(1)))
+
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "fabtoken", "--auditors", "./testdata/auditors/msp", "--auditors-threshold", "2", "--output", tempOutput},
+		"invalid auditors threshold [2], must be at most the number of auditors [1]",
+	)
+}
+
+func TestGenWithComplianceThreshold(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+
+	testGenRun(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"fabtoken",
+			"--compliance",
+			"./testdata/auditors/msp,./testdata/administrators/msp",
+			"--compliance-threshold",
+			"1",
+			"--output",
+			tempOutput,
+		},
+	)
+
+	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "fabtoken_pp.json"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := fabtokenv1.NewPublicParamsFromBytes(ppRaw, fabtokenv1.PublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Validate()).NotTo(HaveOccurred())
+	gt.Expect(pp.ComplianceAuthorities()).To(HaveLen(2))
+	gt.Expect(pp.ComplianceThreshold()).To(Equal(uint64(1)))
+
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "fabtoken", "--compliance", "./testdata/auditors/msp", "--compliance-threshold", "2", "--output", tempOutput},
+		"invalid compliance threshold [2], must be at most the number of compliance authorities [1]",
+	)
+}
+
+func TestGenWithAdministratorsThreshold(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+
+	testGenRun(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"fabtoken",
+			"--administrators",
+			"./testdata/administrators/msp,./testdata/auditors/msp",
+			"--administrators-threshold",
+			"1",
+			"--output",
+			tempOutput,
+		},
+	)
+
+	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "fabtoken_pp.json"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := fabtokenv1.NewPublicParamsFromBytes(ppRaw, fabtokenv1.PublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Validate()).NotTo(HaveOccurred())
+	gt.Expect(pp.Administrators()).To(HaveLen(2))
+	gt.Expect(pp.AdministratorsThreshold()).To(Equal(uint64(1)))
+
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "fabtoken", "--administrators", "./testdata/administrators/msp", "--administrators-threshold", "2", "--output", tempOutput},
+		"invalid administrators threshold [2], must be at most the number of administrators [1]",
+	)
+}
+
+func TestGenWithSupplyCaps(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+
+	testGenRun(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"fabtoken",
+			"--supply-caps",
+			"USD=1000",
+			"--supply-caps",
+			"EUR=500",
+			"--output",
+			tempOutput,
+		},
+	)
+
+	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "fabtoken_pp.json"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := fabtokenv1.NewPublicParamsFromBytes(ppRaw, fabtokenv1.PublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Validate()).NotTo(HaveOccurred())
+	gt.Expect(pp.SupplyCaps()).To(HaveLen(2))
+	gt.Expect(pp.SupplyCaps()).To(HaveKeyWithValue(token.Type("USD"), uint64(1000)))
+	gt.Expect(pp.SupplyCaps()).To(HaveKeyWithValue(token.Type("EUR"), uint64(500)))
+
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "fabtoken", "--supply-caps", "USD=0", "--output", tempOutput},
+		"invalid supply cap for token type [USD], must be greater than 0",
+	)
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "fabtoken", "--supply-caps", "USD", "--output", tempOutput},
+		"invalid supply cap [USD], expected <token type>=<supply>",
+	)
+}
+
+func TestGenWithRevocationPublicKey(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+
+	testGenRun(
+		gt,
+		tokengen,
+		[]string{
+			"gen",
+			"dlog",
+			"--idemix",
+			"./testdata/idemix",
+			"--revocation-pk",
+			"./testdata/idemix/msp/RevocationPublicKey",
+			"--revocation-epoch",
+			"500",
+			"--output",
+			tempOutput,
+		},
+	)
+
+	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "zkatdlog_pp.json"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := v1.NewPublicParamsFromBytes(ppRaw, v1.DLogPublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Validate()).NotTo(HaveOccurred())
+	revocationPK, err := os.ReadFile("./testdata/idemix/msp/RevocationPublicKey")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.RevocationPublicKey()).To(Equal(revocationPK))
+	gt.Expect(pp.RevocationEpochLength()).To(Equal(uint64(500)))
+
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "dlog", "--idemix", "./testdata/idemix", "--revocation-pk", "./testdata/idemix/msp/RevocationPublicKey", "--revocation-epoch", "0", "--output", tempOutput},
+		"invalid revocation epoch, it must be at least one block",
+	)
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"gen", "dlog", "--idemix", "./testdata/idemix", "--revocation-pk", "./testdata/auditors/msp/signcerts/auditor.Orgauditor.example.com-cert.pem", "--output", tempOutput},
+		"invalid revocation public key",
+	)
+}
+
 func TestFullUpdate(t *testing.T) {
 	gt := NewWithT(t)
 	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
@@ -235,6 +514,176 @@ func TestGenFailure(t *testing.T) {
 	gt.Expect(err).NotTo(HaveOccurred())
 }
 
+func TestRequestInspect(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+	testGenRun(gt, tokengen, []string{"gen", "fabtoken", "--output", tempOutput})
+
+	// a transfer whose input is not signed by its owner
+	owner, err := common.GetX509Identity("./testdata/issuers/msp")
+	gt.Expect(err).NotTo(HaveOccurred())
+	action := &fabtokenv1.TransferAction{
+		Inputs:      []*token.ID{{TxId: "a_transaction", Index: 0}},
+		InputTokens: []*fabtokenv1.Output{{Owner: owner, Type: "USD", Quantity: "0x0a"}},
+		Outputs:     []*fabtokenv1.Output{{Owner: owner, Type: "USD", Quantity: "0x0a"}},
+	}
+	rawAction, err := action.Serialize()
+	gt.Expect(err).NotTo(HaveOccurred())
+	rawRequest, err := (&driver.TokenRequest{Transfers: [][]byte{rawAction}}).Bytes()
+	gt.Expect(err).NotTo(HaveOccurred())
+	requestFile := filepath.Join(tempOutput, "request")
+	gt.Expect(os.WriteFile(requestFile, rawRequest, 0600)).To(Succeed())
+	ledgerFile := filepath.Join(tempOutput, "ledger.json")
+	gt.Expect(os.WriteFile(ledgerFile, []byte(`[{"id":{"tx_id":"a_transaction","index":0},"value":"AA=="}]`), 0600)).To(Succeed())
+
+	b, err := exec.Command(tokengen, "request", "inspect",
+		"--input", requestFile,
+		"--pp", filepath.Join(tempOutput, "fabtoken_pp.json"),
+		"--ledger", ledgerFile,
+		"--anchor", "a_transaction",
+	).CombinedOutput()
+	gt.Expect(err).To(HaveOccurred())
+	output := string(b)
+	gt.Expect(output).To(ContainSubstring("Token request: [0] issue action(s), [1] transfer action(s), [0] administrative action(s)"))
+	gt.Expect(output).To(ContainSubstring("[0]: [a_transaction:0]"))
+	gt.Expect(output).To(ContainSubstring("[0]: owner type [x509]"))
+	gt.Expect(output).To(ContainSubstring("Validation: [invalid]"))
+	gt.Expect(output).To(ContainSubstring("Action: [transfer] at [0]"))
+	gt.Expect(output).To(ContainSubstring("Failed check: [github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/validator.TransferSignatureValidate]"))
+	gt.Expect(output).To(ContainSubstring("Error: failed to inspect token request: invalid token request"))
+}
+
+func TestRequestRevoke(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+	testGenRun(gt, tokengen, []string{
+		"gen", "fabtoken",
+		"--issuers", "./testdata/issuers/msp",
+		"--administrators", "./testdata/administrators/msp",
+		"--output", tempOutput,
+	})
+	ppFile := filepath.Join(tempOutput, "fabtoken_pp.json")
+	ppRaw, err := os.ReadFile(ppFile)
+	gt.Expect(err).NotTo(HaveOccurred())
+	pp, err := fabtokenv1.NewPublicParamsFromBytes(ppRaw, fabtokenv1.PublicParameters)
+	gt.Expect(err).NotTo(HaveOccurred())
+	admin, err := common.GetX509Identity("./testdata/administrators/msp")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(pp.Administrators()).To(HaveLen(1))
+	gt.Expect(pp.Administrators()[0]).To(BeEquivalentTo(admin))
+
+	testGenRun(gt, tokengen, []string{
+		"request", "revoke",
+		"--issuers", "./testdata/issuers/msp",
+		"--owners", "rh1,rh2",
+		"--administrators", "./testdata/administrators/msp",
+		"--pp", ppFile,
+		"--namespace", "token-chaincode",
+		"--output", tempOutput,
+	})
+	raw, err := os.ReadFile(filepath.Join(tempOutput, "revocation_request"))
+	gt.Expect(err).NotTo(HaveOccurred())
+	tr := &driver.TokenRequest{}
+	gt.Expect(tr.FromBytes(raw)).To(Succeed())
+	gt.Expect(tr.AdminActions).To(HaveLen(1))
+	update := &driver.RevocationListUpdate{}
+	gt.Expect(update.FromBytes(tr.AdminActions[0])).To(Succeed())
+	list := &driver.RevocationList{}
+	gt.Expect(list.FromBytes(update.List)).To(Succeed())
+	issuer, err := common.GetX509Identity("./testdata/issuers/msp")
+	gt.Expect(err).NotTo(HaveOccurred())
+	gt.Expect(list.Sequence).To(Equal(uint64(1)))
+	gt.Expect(list.IsIssuerRevoked(issuer)).To(BeTrue())
+	gt.Expect(list.IsOwnerRevoked([]byte("rh2"))).To(BeTrue())
+	gt.Expect(list.IsOwnerRevoked([]byte("rh3"))).To(BeFalse())
+
+	// the validator accepts the update
+	b, err := exec.Command(tokengen, "request", "inspect",
+		"--input", filepath.Join(tempOutput, "revocation_request"),
+		"--pp", ppFile,
+		"--anchor", "a_transaction",
+		"--namespace", "token-chaincode",
+	).CombinedOutput()
+	gt.Expect(err).NotTo(HaveOccurred(), string(b))
+	gt.Expect(string(b)).To(ContainSubstring("[1] administrative action(s)"))
+	gt.Expect(string(b)).To(ContainSubstring("Validation: [valid]"))
+
+	// the update cannot be replayed in another namespace
+	b, err = exec.Command(tokengen, "request", "inspect",
+		"--input", filepath.Join(tempOutput, "revocation_request"),
+		"--pp", ppFile,
+		"--anchor", "a_transaction",
+		"--namespace", "another-chaincode",
+	).CombinedOutput()
+	gt.Expect(err).To(HaveOccurred(), string(b))
+	gt.Expect(string(b)).To(ContainSubstring("failed to verify signature of administrator at [0]"))
+
+	// the administrators must be able to sign
+	testGenRunWithError(
+		gt,
+		tokengen,
+		[]string{"request", "revoke", "--issuers", "./testdata/issuers/msp", "--administrators", "./testdata/issuers/msp", "--pp", ppFile, "--namespace", "token-chaincode", "--output", tempOutput},
+		"no signing key found for administrator [./testdata/issuers/msp]",
+	)
+}
+
+func TestReplay(t *testing.T) {
+	gt := NewGomegaWithT(t)
+	tokengen, err := gexec.Build("github.com/hyperledger-labs/fabric-token-sdk/cmd/tokengen")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer gexec.CleanupBuildArtifacts()
+
+	tempOutput, err := os.MkdirTemp("", "tokengen-test")
+	gt.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(tempOutput)
+	testGenRun(gt, tokengen, []string{"gen", "fabtoken", "--output", tempOutput})
+
+	// a transfer of a token that does not exist
+	owner, err := common.GetX509Identity("./testdata/issuers/msp")
+	gt.Expect(err).NotTo(HaveOccurred())
+	action := &fabtokenv1.TransferAction{
+		Inputs:      []*token.ID{{TxId: "a_transaction", Index: 0}},
+		InputTokens: []*fabtokenv1.Output{{Owner: owner, Type: "USD", Quantity: "0x0a"}},
+		Outputs:     []*fabtokenv1.Output{{Owner: owner, Type: "USD", Quantity: "0x0a"}},
+	}
+	rawAction, err := action.Serialize()
+	gt.Expect(err).NotTo(HaveOccurred())
+	rawRequest, err := (&driver.TokenRequest{Transfers: [][]byte{rawAction}}).Bytes()
+	gt.Expect(err).NotTo(HaveOccurred())
+	requests, err := json.Marshal([]*replay.Request{{Anchor: "another_transaction", Raw: rawRequest}})
+	gt.Expect(err).NotTo(HaveOccurred())
+	requestsFile := filepath.Join(tempOutput, "requests.json")
+	gt.Expect(os.WriteFile(requestsFile, requests, 0600)).To(Succeed())
+	unspentFile := filepath.Join(tempOutput, "unspent.json")
+
+	b, err := exec.Command(tokengen, "replay",
+		"--input", requestsFile,
+		"--pp", filepath.Join(tempOutput, "fabtoken_pp.json"),
+		"--output", unspentFile,
+	).CombinedOutput()
+	gt.Expect(err).To(HaveOccurred())
+	output := string(b)
+	gt.Expect(output).To(ContainSubstring("[0] [another_transaction]: rejected"))
+	gt.Expect(output).To(ContainSubstring("Error: failed to replay token requests: [1] out of [1] token requests have been rejected"))
+	raw, err := os.ReadFile(unspentFile)
+	gt.Expect(err).NotTo(HaveOccurred())
+	var unspent []*replay.Token
+	gt.Expect(json.Unmarshal(raw, &unspent)).To(Succeed())
+	gt.Expect(unspent).To(BeEmpty())
+}
+
 func validateOutputEquivalent(gt *WithT, tempOutput, auditorsMSPdir, issuersMSPdir, idemixMSPdir string) {
 	ppRaw, err := os.ReadFile(filepath.Join(tempOutput, "zkatdlog_pp.json"))
 	gt.Expect(err).NotTo(HaveOccurred())
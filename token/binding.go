@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+const (
+	// bindingMetadataKey is the key of the application metadata that holds the binding of a request
+	bindingMetadataKey = "token.request.binding"
+	// bindingSeparator separates the anchor from the binding in a bound anchor
+	bindingSeparator = ":"
+)
+
+// NamespacedRequest is the token request of a namespace of a multi-namespace transaction
+type NamespacedRequest struct {
+	Namespace string
+	Request   *driver.TokenRequest
+}
+
+type namespacedActions struct {
+	Namespace string
+	Actions   []byte
+}
+
+// Binding returns the hash of the actions of the passed token requests, in the passed order.
+// The signatures of the requests are not included, therefore the binding can be computed before signing.
+// A request whose messages to sign carry the binding can only be committed together with all the others.
+func Binding(requests []*NamespacedRequest) ([]byte, error) {
+	if len(requests) < 2 {
+		return nil, errors.Errorf("at least two token requests are needed to compute a binding, got [%d]", len(requests))
+	}
+	legs := make([]namespacedActions, len(requests))
+	for i, request := range requests {
+		if request.Request == nil {
+			return nil, errors.Errorf("empty token request for namespace [%s]", request.Namespace)
+		}
+		actions, err := request.Request.MarshalToMessageToSign(nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed marshalling token request for namespace [%s]", request.Namespace)
+		}
+		legs[i] = namespacedActions{Namespace: request.Namespace, Actions: actions}
+	}
+	raw, err := asn1.Marshal(legs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed marshalling token requests")
+	}
+	h := sha256.Sum256(raw)
+	return h[:], nil
+}
+
+// BoundAnchorFromRaw returns the anchor the signatures of the passed token requests, one per namespace, are computed over.
+// A single token request is not bound, therefore its anchor is the passed one.
+// Validators must verify each token request of a multi-namespace transaction against this anchor,
+// so that a token request is never valid without all the others.
+func BoundAnchorFromRaw(anchor string, namespaces []string, requestsRaw [][]byte) (string, error) {
+	if len(namespaces) != len(requestsRaw) {
+		return "", errors.Errorf("expected [%d] token requests, got [%d]", len(namespaces), len(requestsRaw))
+	}
+	if len(requestsRaw) < 2 {
+		return anchor, nil
+	}
+	requests := make([]*NamespacedRequest, len(namespaces))
+	for i, raw := range requestsRaw {
+		tr := &driver.TokenRequest{}
+		if err := tr.FromBytes(raw); err != nil {
+			return "", errors.Wrapf(err, "failed unmarshalling token request for namespace [%s]", namespaces[i])
+		}
+		requests[i] = &NamespacedRequest{Namespace: namespaces[i], Request: tr}
+	}
+	binding, err := Binding(requests)
+	if err != nil {
+		return "", err
+	}
+	return BoundAnchor(anchor, binding), nil
+}
+
+// BoundAnchor returns the anchor the signatures of a request bound to the passed binding are computed over
+func BoundAnchor(anchor string, binding []byte) string {
+	if len(binding) == 0 {
+		return anchor
+	}
+	return anchor + bindingSeparator + hex.EncodeToString(binding)
+}
+
+// IsBoundAnchor returns true if the passed anchor carries a binding
+func IsBoundAnchor(anchor string) bool {
+	return strings.Contains(anchor, bindingSeparator)
+}
+
+// Bind binds this request to the other requests of a multi-namespace transaction.
+// From now on, the messages to sign and to audit cover the passed binding, see Binding.
+func (r *Request) Bind(binding []byte) {
+	r.SetApplicationMetadata(bindingMetadataKey, binding)
+}
+
+// Binding returns the binding of this request, nil if the request is not bound
+func (r *Request) Binding() []byte {
+	if r.Metadata == nil {
+		return nil
+	}
+	return r.ApplicationMetadata(bindingMetadataKey)
+}
+
+// boundAnchor returns the anchor the messages to sign and to audit are computed over
+func (r *Request) boundAnchor() string {
+	return BoundAnchor(r.Anchor, r.Binding())
+}
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBinding(t *testing.T) {
+	payment := &driver.TokenRequest{Transfers: [][]byte{[]byte("payment")}}
+	delivery := &driver.TokenRequest{Transfers: [][]byte{[]byte("delivery")}}
+	paymentRaw, err := payment.Bytes()
+	assert.NoError(t, err)
+	deliveryRaw, err := delivery.Bytes()
+	assert.NoError(t, err)
+
+	binding, err := Binding([]*NamespacedRequest{{Namespace: "cash", Request: payment}, {Namespace: "bonds", Request: delivery}})
+	assert.NoError(t, err)
+
+	// a single token request is not bound
+	_, err = Binding([]*NamespacedRequest{{Namespace: "cash", Request: payment}})
+	assert.Error(t, err)
+	anchor, err := BoundAnchorFromRaw("tx1", []string{"cash"}, [][]byte{paymentRaw})
+	assert.NoError(t, err)
+	assert.Equal(t, "tx1", anchor)
+	assert.False(t, IsBoundAnchor(anchor))
+
+	// the binding does not depend on the signatures, but on the actions and the namespaces of all the token requests
+	signed := &driver.TokenRequest{Transfers: payment.Transfers, Signatures: [][]byte{[]byte("sigma")}}
+	other, err := Binding([]*NamespacedRequest{{Namespace: "cash", Request: signed}, {Namespace: "bonds", Request: delivery}})
+	assert.NoError(t, err)
+	assert.Equal(t, binding, other)
+	other, err = Binding([]*NamespacedRequest{{Namespace: "bonds", Request: payment}, {Namespace: "cash", Request: delivery}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, binding, other)
+	other, err = Binding([]*NamespacedRequest{{Namespace: "cash", Request: payment}, {Namespace: "bonds", Request: payment}})
+	assert.NoError(t, err)
+	assert.NotEqual(t, binding, other)
+
+	anchor, err = BoundAnchorFromRaw("tx1", []string{"cash", "bonds"}, [][]byte{paymentRaw, deliveryRaw})
+	assert.NoError(t, err)
+	assert.Equal(t, BoundAnchor("tx1", binding), anchor)
+	assert.True(t, IsBoundAnchor(anchor))
+
+	// the messages to sign and to audit of a bound request cover the binding
+	r := NewRequest(nil, "tx1")
+	r.Actions = payment
+	unbound, err := r.MarshalToSign()
+	assert.NoError(t, err)
+	r.Bind(binding)
+	assert.Equal(t, binding, r.Binding())
+	toSign, err := r.MarshalToSign()
+	assert.NoError(t, err)
+	assert.NotEqual(t, unbound, toSign)
+	expected, err := payment.MarshalToMessageToSign([]byte(anchor))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, toSign)
+	toAudit, err := r.MarshalToAudit()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, toAudit)
+
+	// the binding survives serialization
+	raw, err := r.Bytes()
+	assert.NoError(t, err)
+	r2 := NewRequest(nil, "")
+	assert.NoError(t, r2.FromBytes(raw))
+	assert.Equal(t, binding, r2.Binding())
+}
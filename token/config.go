@@ -8,6 +8,7 @@ package token
 
 import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
 )
 
 // Configuration manages the configuration of the token-sdk
@@ -24,3 +25,19 @@ func (m *Configuration) IsSet(key string) bool {
 func (m *Configuration) UnmarshalKey(key string, rawVal interface{}) error {
 	return m.cm.UnmarshalKey(key, rawVal)
 }
+
+// ActionLimits returns the limits on the actions of the token requests of the TMS, see ActionLimitsKey.
+// If no limit is configured, the returned limits accept any action.
+func (m *Configuration) ActionLimits() (*ActionLimits, error) {
+	limits := &ActionLimits{}
+	if !m.cm.IsSet(ActionLimitsKey) {
+		return limits, nil
+	}
+	if err := m.cm.UnmarshalKey(ActionLimitsKey, limits); err != nil {
+		return nil, errors.Wrapf(err, "invalid config for key [%s]", ActionLimitsKey)
+	}
+	if limits.MaxInputs < 0 || limits.MaxOutputs < 0 {
+		return nil, errors.Errorf("invalid config for key [%s]: limits cannot be negative", ActionLimitsKey)
+	}
+	return limits, nil
+}
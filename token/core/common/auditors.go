@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"slices"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// AuditorsThreshold returns the number of auditors, out of the passed number of auditors, that must sign a token request.
+// A threshold equal to zero, or larger than the number of auditors, means that all auditors must sign.
+func AuditorsThreshold(numAuditors int, threshold uint64) uint64 {
+	if numAuditors == 0 {
+		return 0
+	}
+	if threshold == 0 || threshold > uint64(numAuditors) {
+		return uint64(numAuditors)
+	}
+	return threshold
+}
+
+// ValidateAuditors checks that the passed auditors and threshold are well-formed
+func ValidateAuditors(auditors []driver.Identity, threshold uint64) error {
+	for i, auditor := range auditors {
+		if auditor.IsNone() {
+			return errors.Errorf("invalid auditors: empty auditor at [%d]", i)
+		}
+		if slices.ContainsFunc(auditors[:i], auditor.Equal) {
+			return errors.Errorf("invalid auditors: duplicate auditor at [%d]", i)
+		}
+	}
+	if threshold > uint64(len(auditors)) {
+		return errors.Errorf("invalid auditors threshold [%d], must be at most the number of auditors [%d]", threshold, len(auditors))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditorsThreshold(t *testing.T) {
+	assert.Equal(t, uint64(0), AuditorsThreshold(0, 0))
+	assert.Equal(t, uint64(0), AuditorsThreshold(0, 2))
+	assert.Equal(t, uint64(1), AuditorsThreshold(1, 0))
+	assert.Equal(t, uint64(3), AuditorsThreshold(3, 0))
+	assert.Equal(t, uint64(2), AuditorsThreshold(3, 2))
+	assert.Equal(t, uint64(3), AuditorsThreshold(3, 5))
+}
+
+func TestValidateAuditors(t *testing.T) {
+	a1 := driver.Identity("a1")
+	a2 := driver.Identity("a2")
+
+	assert.NoError(t, ValidateAuditors(nil, 0))
+	assert.NoError(t, ValidateAuditors([]driver.Identity{a1}, 0))
+	assert.NoError(t, ValidateAuditors([]driver.Identity{a1, a2}, 1))
+	assert.NoError(t, ValidateAuditors([]driver.Identity{a1, a2}, 2))
+
+	assert.EqualError(t, ValidateAuditors([]driver.Identity{a1, nil}, 1), "invalid auditors: empty auditor at [1]")
+	assert.EqualError(t, ValidateAuditors([]driver.Identity{a1, a1}, 1), "invalid auditors: duplicate auditor at [1]")
+	assert.EqualError(t, ValidateAuditors([]driver.Identity{a1, a2}, 3), "invalid auditors threshold [3], must be at most the number of auditors [2]")
+	assert.EqualError(t, ValidateAuditors(nil, 1), "invalid auditors threshold [1], must be at most the number of auditors [0]")
+}
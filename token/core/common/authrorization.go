@@ -32,22 +32,31 @@ type WalletBasedAuthorization struct {
 	Logger           logging.Logger
 	PublicParameters driver.PublicParameters
 	WalletService    driver.WalletService
-	amIAnAuditor     bool
+	// RevocationList gives access to the revocation list of issuers and auditors, if nil nothing is revoked
+	RevocationList RevocationListProvider
+	// auditors are the auditor identities, among those in the public parameters, this TMS has a wallet for
+	auditors []driver.Identity
 }
 
-func NewTMSAuthorization(logger logging.Logger, publicParameters driver.PublicParameters, walletService driver.WalletService) *WalletBasedAuthorization {
-	amIAnAuditor := false
+func NewTMSAuthorization(logger logging.Logger, publicParameters driver.PublicParameters, walletService driver.WalletService, revocationList RevocationListProvider) *WalletBasedAuthorization {
+	var auditors []driver.Identity
 	var errs []error
 	for _, identity := range publicParameters.Auditors() {
 		_, err := walletService.AuditorWallet(identity)
 		if err == nil {
-			amIAnAuditor = true
-			break
+			auditors = append(auditors, identity)
+			continue
 		}
 		errs = append(errs, errors.Wrapf(err, "I'm not this auditor identity [%s]", identity))
 	}
-	logger.Debugf("am I an auditor? [%v], with errs [%v]", amIAnAuditor, errs)
-	return &WalletBasedAuthorization{Logger: logger, PublicParameters: publicParameters, WalletService: walletService, amIAnAuditor: amIAnAuditor}
+	logger.Debugf("am I an auditor? [%v], with errs [%v]", len(auditors) != 0, errs)
+	return &WalletBasedAuthorization{
+		Logger:           logger,
+		PublicParameters: publicParameters,
+		WalletService:    walletService,
+		RevocationList:   revocationList,
+		auditors:         auditors,
+	}
 }
 
 // IsMine returns true if the passed token is owned by an owner wallet.
@@ -61,14 +70,39 @@ func (w *WalletBasedAuthorization) IsMine(tok *token2.Token) (string, []string,
 }
 
 // AmIAnAuditor return true if the passed TMS contains an auditor wallet for any of the auditor identities
-// defined in the public parameters of the passed TMS.
+// defined in the public parameters of the passed TMS, that has not been revoked.
 func (w *WalletBasedAuthorization) AmIAnAuditor() bool {
-	return w.amIAnAuditor
+	if len(w.auditors) == 0 {
+		return false
+	}
+	list := w.revocationList()
+	for _, auditor := range w.auditors {
+		if !list.IsAuditorRevoked(auditor) {
+			return true
+		}
+	}
+	return false
 }
 
+// Issued returns true if the passed issuer identity has an issuer wallet and has not been revoked
 func (w *WalletBasedAuthorization) Issued(issuer token.Identity, tok *token2.Token) bool {
-	_, err := w.WalletService.IssuerWallet(issuer)
-	return err == nil
+	if _, err := w.WalletService.IssuerWallet(issuer); err != nil {
+		return false
+	}
+	return !w.revocationList().IsIssuerRevoked(issuer)
+}
+
+// revocationList returns the current revocation list, nil if not available
+func (w *WalletBasedAuthorization) revocationList() *driver.RevocationList {
+	if w.RevocationList == nil {
+		return nil
+	}
+	list, err := w.RevocationList.RevocationList()
+	if err != nil {
+		w.Logger.Warnf("failed to get the revocation list: [%s]", err)
+		return nil
+	}
+	return list
 }
 
 // AuthorizationMultiplexer iterates over multiple authorization checker
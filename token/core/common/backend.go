@@ -16,6 +16,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ErrEmptySignature is returned when the signature to be verified is empty
+var ErrEmptySignature = errors.New("empty signature")
+
 type Backend struct {
 	Logger logging.Logger
 	// Ledger to access the ledger state
@@ -40,6 +43,9 @@ func (b *Backend) HasBeenSignedBy(id driver.Identity, verifier driver.Verifier)
 	}
 	sigma := b.Sigs[b.Cursor]
 	b.Cursor++
+	if len(sigma) == 0 {
+		return nil, ErrEmptySignature
+	}
 
 	// if b.Logger.IsEnabledFor(zapcore.DebugLevel) {
 	b.Logger.Infof("verify signature [%s][%s][%s]", id, base64.StdEncoding.EncodeToString(sigma), utils.Hashable(b.Message))
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"slices"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// ComplianceThreshold returns the number of compliance authorities, out of the passed number of authorities, that must authorize a clawback.
+// A threshold equal to zero, or larger than the number of authorities, means that all authorities must authorize it.
+func ComplianceThreshold(numAuthorities int, threshold uint64) uint64 {
+	return AuditorsThreshold(numAuthorities, threshold)
+}
+
+// ValidateComplianceAuthorities checks that the passed compliance authorities and threshold are well-formed
+func ValidateComplianceAuthorities(authorities []driver.Identity, threshold uint64) error {
+	for i, authority := range authorities {
+		if authority.IsNone() {
+			return errors.Errorf("invalid compliance authorities: empty authority at [%d]", i)
+		}
+		if slices.ContainsFunc(authorities[:i], authority.Equal) {
+			return errors.Errorf("invalid compliance authorities: duplicate authority at [%d]", i)
+		}
+	}
+	if threshold > uint64(len(authorities)) {
+		return errors.Errorf("invalid compliance threshold [%d], must be at most the number of compliance authorities [%d]", threshold, len(authorities))
+	}
+	return nil
+}
+
+// IsClawback returns true if the passed transfer action carries a clawback
+func IsClawback(action driver.TransferAction) bool {
+	return len(driver.ClawbackAuthorizers(action.GetMetadata())) != 0
+}
+
+// VerifyClawback checks that the passed transfer action is a clawback authorized by a quorum of the compliance authorities.
+// The authorizers must be distinct compliance authorities, and their signatures are consumed from the passed signature provider,
+// in place of those of the owners of the inputs.
+// The metadata key of the clawback must be bound to the first input of the action.
+// It returns the clawback and its metadata key. The caller checks that all the outputs go to the recovery owner.
+func VerifyClawback(pp driver.PublicParameters, deserializer driver.Deserializer, signatureProvider driver.SignatureProvider, action driver.TransferAction) (*driver.Clawback, string, error) {
+	key, clawback, err := driver.GetClawback(action.GetMetadata())
+	if err != nil {
+		return nil, "", errors.Wrap(err, "invalid clawback")
+	}
+	if clawback == nil {
+		return nil, "", errors.New("invalid clawback: no clawback found in the action metadata")
+	}
+	inputs := action.GetInputs()
+	if len(inputs) == 0 || inputs[0] == nil {
+		return nil, "", errors.New("invalid clawback: no input")
+	}
+	if key != driver.ClawbackMetadataKey(inputs[0]) {
+		return nil, "", errors.Errorf("invalid clawback: metadata key [%s] is not bound to the first input [%s]", key, inputs[0])
+	}
+	if clawback.RecoveryOwner.IsNone() {
+		return nil, "", errors.New("invalid clawback: no recovery owner")
+	}
+
+	authorities := pp.ComplianceAuthorities()
+	if len(authorities) == 0 {
+		return nil, "", errors.New("clawbacks are not allowed, no compliance authority is set in the public parameters")
+	}
+	if threshold := pp.ComplianceThreshold(); uint64(len(clawback.Authorizers)) < threshold {
+		return nil, "", errors.Errorf("insufficient number of compliance authorities authorizing the clawback, expected at least [%d], got [%d]", threshold, len(clawback.Authorizers))
+	}
+	for i, authorizer := range clawback.Authorizers {
+		if !slices.ContainsFunc(authorities, authorizer.Equal) {
+			return nil, "", errors.Errorf("invalid clawback: authorizer at [%d] is not a compliance authority", i)
+		}
+		if slices.ContainsFunc(clawback.Authorizers[:i], authorizer.Equal) {
+			return nil, "", errors.Errorf("invalid clawback: duplicate authorizer at [%d]", i)
+		}
+		verifier, err := deserializer.GetComplianceVerifier(authorizer)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "failed to deserialize compliance authority at [%d]", i)
+		}
+		if _, err := signatureProvider.HasBeenSignedBy(authorizer, verifier); err != nil {
+			return nil, "", errors.Wrapf(err, "failed to verify signature of compliance authority at [%d]", i)
+		}
+	}
+	return clawback, key, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// sequentialSignatures consumes its signatures in order, as the backend of the validator does
+type sequentialSignatures struct {
+	signatures [][]byte
+	cursor     int
+}
+
+func (s *sequentialSignatures) HasBeenSignedBy(id driver.Identity, verifier driver.Verifier) ([]byte, error) {
+	if s.cursor >= len(s.signatures) {
+		return nil, errors.New("invalid state, insufficient number of signatures")
+	}
+	sigma := s.signatures[s.cursor]
+	s.cursor++
+	return sigma, verifier.Verify(nil, sigma)
+}
+
+func (s *sequentialSignatures) Signatures() [][]byte {
+	return s.signatures
+}
+
+func clawbackAction(t *testing.T, key string, clawback *driver.Clawback, inputs ...*token.ID) *mock.TransferAction {
+	raw, err := clawback.Bytes()
+	assert.NoError(t, err)
+	action := &mock.TransferAction{}
+	action.GetInputsReturns(inputs)
+	action.GetMetadataReturns(map[string][]byte{key: raw})
+	return action
+}
+
+func TestVerifyClawback(t *testing.T) {
+	pp := &mock.PublicParameters{}
+	pp.ComplianceAuthoritiesReturns([]driver.Identity{driver.Identity("compliance1"), driver.Identity("compliance2"), driver.Identity("compliance3")})
+	pp.ComplianceThresholdReturns(2)
+	deserializer := newRevocationDeserializer()
+	tokenID := &token.ID{TxId: "tx1", Index: 1}
+	key := driver.ClawbackMetadataKey(tokenID)
+	recovery := driver.Identity("recovery")
+
+	// signatures equal to the signer identity are accepted
+	authorizers := []driver.Identity{driver.Identity("compliance3"), driver.Identity("compliance1")}
+	action := clawbackAction(t, key, &driver.Clawback{Authorizers: authorizers, RecoveryOwner: recovery}, tokenID)
+	assert.True(t, IsClawback(action))
+	clawback, k, err := VerifyClawback(pp, deserializer, &sequentialSignatures{signatures: [][]byte{authorizers[0], authorizers[1]}}, action)
+	assert.NoError(t, err)
+	assert.Equal(t, key, k)
+	assert.Equal(t, recovery, clawback.RecoveryOwner)
+	// the compliance authorities are verified with their own deserializer
+	assert.Equal(t, 2, deserializer.GetComplianceVerifierCallCount())
+	assert.Zero(t, deserializer.GetAuditorVerifierCallCount())
+
+	// signatures in the wrong order
+	_, _, err = VerifyClawback(pp, deserializer, &sequentialSignatures{signatures: [][]byte{authorizers[1], authorizers[0]}}, action)
+	assert.ErrorContains(t, err, "failed to verify signature of compliance authority at [0]")
+
+	// not a clawback
+	assert.False(t, IsClawback(&mock.TransferAction{}))
+	_, _, err = VerifyClawback(pp, deserializer, &sequentialSignatures{}, &mock.TransferAction{})
+	assert.ErrorContains(t, err, "no clawback found")
+
+	// key not bound to the first input
+	action = clawbackAction(t, driver.ClawbackMetadataKey(&token.ID{TxId: "tx2"}), &driver.Clawback{Authorizers: authorizers, RecoveryOwner: recovery}, tokenID)
+	_, _, err = VerifyClawback(pp, deserializer, &sequentialSignatures{}, action)
+	assert.ErrorContains(t, err, "is not bound to the first input")
+
+	// no recovery owner
+	action = clawbackAction(t, key, &driver.Clawback{Authorizers: authorizers}, tokenID)
+	_, _, err = VerifyClawback(pp, deserializer, &sequentialSignatures{}, action)
+	assert.ErrorContains(t, err, "no recovery owner")
+
+	// below the threshold, unknown, and duplicate authorizers
+	action = clawbackAction(t, key, &driver.Clawback{Authorizers: authorizers[:1], RecoveryOwner: recovery}, tokenID)
+	_, _, err = VerifyClawback(pp, deserializer, &sequentialSignatures{signatures: [][]byte{authorizers[0]}}, action)
+	assert.ErrorContains(t, err, "expected at least [2], got [1]")
+	action = clawbackAction(t, key, &driver.Clawback{Authorizers: []driver.Identity{driver.Identity("compliance1"), driver.Identity("alice")}, RecoveryOwner: recovery}, tokenID)
+	_, _, err = VerifyClawback(pp, deserializer, &sequentialSignatures{signatures: [][]byte{[]byte("compliance1"), []byte("alice")}}, action)
+	assert.ErrorContains(t, err, "authorizer at [1] is not a compliance authority")
+	action = clawbackAction(t, key, &driver.Clawback{Authorizers: []driver.Identity{driver.Identity("compliance1"), driver.Identity("compliance1")}, RecoveryOwner: recovery}, tokenID)
+	_, _, err = VerifyClawback(pp, deserializer, &sequentialSignatures{signatures: [][]byte{[]byte("compliance1"), []byte("compliance1")}}, action)
+	assert.ErrorContains(t, err, "duplicate authorizer at [1]")
+
+	// no compliance authorities
+	action = clawbackAction(t, key, &driver.Clawback{Authorizers: authorizers, RecoveryOwner: recovery}, tokenID)
+	_, _, err = VerifyClawback(&mock.PublicParameters{}, deserializer, &sequentialSignatures{}, action)
+	assert.ErrorContains(t, err, "no compliance authority is set")
+}
@@ -32,14 +32,16 @@ type RecipientExtractor interface {
 	Recipients(id driver.Identity) ([]driver.Identity, error)
 }
 
-// Deserializer deserializes verifiers associated with issuers, owners, and auditors
+// Deserializer deserializes verifiers associated with issuers, owners, auditors, administrators, and compliance authorities
 type Deserializer struct {
-	identityType         string
-	auditorDeserializer  VerifierDeserializer
-	ownerDeserializer    VerifierDeserializer
-	issuerDeserializer   VerifierDeserializer
-	auditMatcherProvider AuditMatcherProvider
-	recipientExtractor   RecipientExtractor
+	identityType              string
+	auditorDeserializer       VerifierDeserializer
+	ownerDeserializer         VerifierDeserializer
+	issuerDeserializer        VerifierDeserializer
+	administratorDeserializer VerifierDeserializer
+	complianceDeserializer    VerifierDeserializer
+	auditMatcherProvider      AuditMatcherProvider
+	recipientExtractor        RecipientExtractor
 }
 
 func NewDeserializer(
@@ -47,16 +49,20 @@ func NewDeserializer(
 	auditorDeserializer VerifierDeserializer,
 	ownerDeserializer VerifierDeserializer,
 	issuerDeserializer VerifierDeserializer,
+	administratorDeserializer VerifierDeserializer,
+	complianceDeserializer VerifierDeserializer,
 	auditMatcherProvider AuditMatcherProvider,
 	recipientExtractor RecipientExtractor,
 ) *Deserializer {
 	return &Deserializer{
-		identityType:         identityType,
-		auditorDeserializer:  auditorDeserializer,
-		ownerDeserializer:    ownerDeserializer,
-		issuerDeserializer:   issuerDeserializer,
-		auditMatcherProvider: auditMatcherProvider,
-		recipientExtractor:   recipientExtractor,
+		identityType:              identityType,
+		auditorDeserializer:       auditorDeserializer,
+		ownerDeserializer:         ownerDeserializer,
+		issuerDeserializer:        issuerDeserializer,
+		administratorDeserializer: administratorDeserializer,
+		complianceDeserializer:    complianceDeserializer,
+		auditMatcherProvider:      auditMatcherProvider,
+		recipientExtractor:        recipientExtractor,
 	}
 }
 
@@ -72,6 +78,14 @@ func (d *Deserializer) GetAuditorVerifier(id driver.Identity) (driver.Verifier,
 	return d.auditorDeserializer.DeserializeVerifier(id)
 }
 
+func (d *Deserializer) GetAdministratorVerifier(id driver.Identity) (driver.Verifier, error) {
+	return d.administratorDeserializer.DeserializeVerifier(id)
+}
+
+func (d *Deserializer) GetComplianceVerifier(id driver.Identity) (driver.Verifier, error) {
+	return d.complianceDeserializer.DeserializeVerifier(id)
+}
+
 func (d *Deserializer) Recipients(id driver.Identity) ([]driver.Identity, error) {
 	return d.recipientExtractor.Recipients(id)
 }
@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"slices"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// FreezeAction carries the tokens frozen, or unfrozen, by a driver.Freeze.
+// The validator appends it to the actions of the token request so that the network stores the frozen markers in the namespace.
+type FreezeAction struct {
+	TokenIDs []*token.ID
+	Unfreeze bool
+}
+
+// GetFrozenTokens returns the identifiers of the tokens to freeze, or unfreeze
+func (f *FreezeAction) GetFrozenTokens() []*token.ID {
+	return f.TokenIDs
+}
+
+// IsUnfreeze returns true if the tokens must be unfrozen
+func (f *FreezeAction) IsUnfreeze() bool {
+	return f.Unfreeze
+}
+
+// VerifyFreezes checks the passed freeze actions.
+// Each action must list tokens stored on the ledger, and must be signed by compliance authorities listed in the public parameters.
+// The signatures are bound to the passed anchor.
+// It returns the actions to store, or remove, the frozen markers.
+func VerifyFreezes(pp driver.PublicParameters, deserializer driver.Deserializer, ledger driver.Ledger, anchor string, freezeActions [][]byte) ([]*FreezeAction, error) {
+	if len(freezeActions) == 0 {
+		return nil, nil
+	}
+	authorities := pp.ComplianceAuthorities()
+	if len(authorities) == 0 {
+		return nil, errors.New("freeze actions are not allowed, no compliance authority is set in the public parameters")
+	}
+	actions := make([]*FreezeAction, 0, len(freezeActions))
+	for i, raw := range freezeActions {
+		freeze := &driver.Freeze{}
+		if err := freeze.FromBytes(raw); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal freeze action at [%d]", i)
+		}
+		if len(freeze.TokenIDs) == 0 {
+			return nil, errors.Errorf("invalid freeze action at [%d], no token", i)
+		}
+		for j, id := range freeze.TokenIDs {
+			if id == nil {
+				return nil, errors.Errorf("invalid freeze action at [%d], nil token at [%d]", i, j)
+			}
+			if driver.IsFrozenTokenTxID(id.TxId) || driver.IsSupplyCounterTxID(id.TxId) || id.TxId == driver.RevocationListTxID {
+				return nil, errors.Errorf("invalid freeze action at [%d], reserved token [%s]", i, id)
+			}
+			state, err := ledger.GetState(*id)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read token [%s]", id)
+			}
+			if len(state) == 0 {
+				return nil, errors.Errorf("invalid freeze action at [%d], token [%s] does not exist", i, id)
+			}
+		}
+		message, err := freeze.MessageToSign(anchor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid freeze action at [%d]", i)
+		}
+		if err := verifyComplianceSignatures(authorities, deserializer, message, freeze.Signatures); err != nil {
+			return nil, errors.Wrapf(err, "invalid freeze action at [%d]", i)
+		}
+		actions = append(actions, &FreezeAction{TokenIDs: freeze.TokenIDs, Unfreeze: freeze.Unfreeze})
+	}
+	return actions, nil
+}
+
+// VerifyInputsNotFrozen checks that none of the passed inputs has been frozen.
+// If the public parameters do not list any compliance authority, no token can be frozen and the ledger is not read.
+func VerifyInputsNotFrozen(pp driver.PublicParameters, ledger driver.Ledger, inputs []*token.ID) error {
+	if len(pp.ComplianceAuthorities()) == 0 {
+		return nil
+	}
+	for _, id := range inputs {
+		if id == nil {
+			continue
+		}
+		frozen, err := driver.IsTokenFrozen(ledger, id)
+		if err != nil {
+			return err
+		}
+		if frozen {
+			return errors.Errorf("input [%s] is frozen", id)
+		}
+	}
+	return nil
+}
+
+// verifyComplianceSignatures checks that the passed message has been signed by at least one of the passed compliance authorities, and only by them
+func verifyComplianceSignatures(authorities []driver.Identity, deserializer driver.Deserializer, message []byte, signatures []*driver.AdminSignature) error {
+	if len(signatures) == 0 {
+		return errors.New("no compliance authority signature")
+	}
+	for i, signature := range signatures {
+		if signature == nil {
+			return errors.Errorf("nil compliance authority signature at [%d]", i)
+		}
+		if !slices.ContainsFunc(authorities, signature.Signer.Equal) {
+			return errors.Errorf("signer at [%d] is not a compliance authority", i)
+		}
+		if slices.ContainsFunc(signatures[:i], func(s *driver.AdminSignature) bool { return s.Signer.Equal(signature.Signer) }) {
+			return errors.Errorf("duplicate compliance authority signature at [%d]", i)
+		}
+		verifier, err := deserializer.GetComplianceVerifier(signature.Signer)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize compliance authority at [%d]", i)
+		}
+		if err := verifier.Verify(message, signature.Sigma); err != nil {
+			return errors.Wrapf(err, "failed to verify signature of compliance authority at [%d]", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFreezeLedger(states map[token.ID][]byte) *mock.ValidatorLedger {
+	ledger := &mock.ValidatorLedger{}
+	ledger.GetStateStub = func(id token.ID) ([]byte, error) {
+		return states[id], nil
+	}
+	return ledger
+}
+
+func freezeAction(t *testing.T, freeze *driver.Freeze, signers ...driver.Identity) []byte {
+	for _, signer := range signers {
+		// signedBy accepts the signatures equal to the signer identity
+		freeze.Signatures = append(freeze.Signatures, &driver.AdminSignature{Signer: signer, Sigma: signer})
+	}
+	raw, err := freeze.Bytes()
+	assert.NoError(t, err)
+	return raw
+}
+
+func TestVerifyFreezes(t *testing.T) {
+	pp := &mock.PublicParameters{}
+	pp.ComplianceAuthoritiesReturns([]driver.Identity{driver.Identity("compliance1"), driver.Identity("compliance2")})
+	deserializer := newRevocationDeserializer()
+	tokenID := &token.ID{TxId: "tx1", Index: 1}
+	ledger := newFreezeLedger(map[token.ID][]byte{*tokenID: []byte("token")})
+
+	actions, err := VerifyFreezes(pp, deserializer, ledger, "anchor", [][]byte{
+		freezeAction(t, &driver.Freeze{TokenIDs: []*token.ID{tokenID}}, driver.Identity("compliance2")),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 1)
+	assert.Equal(t, []*token.ID{tokenID}, actions[0].GetFrozenTokens())
+	assert.False(t, actions[0].IsUnfreeze())
+	assert.Equal(t, 1, deserializer.GetComplianceVerifierCallCount())
+	assert.Zero(t, deserializer.GetAuditorVerifierCallCount())
+
+	// missing token
+	_, err = VerifyFreezes(pp, deserializer, ledger, "anchor", [][]byte{
+		freezeAction(t, &driver.Freeze{TokenIDs: []*token.ID{{TxId: "tx2"}}}, driver.Identity("compliance1")),
+	})
+	assert.ErrorContains(t, err, "token [[tx2:0]] does not exist")
+
+	// reserved token
+	_, err = VerifyFreezes(pp, deserializer, ledger, "anchor", [][]byte{
+		freezeAction(t, &driver.Freeze{TokenIDs: []*token.ID{{TxId: driver.RevocationListTxID}}}, driver.Identity("compliance1")),
+	})
+	assert.ErrorContains(t, err, "reserved token")
+
+	// missing, unknown, and duplicate signers
+	_, err = VerifyFreezes(pp, deserializer, ledger, "anchor", [][]byte{
+		freezeAction(t, &driver.Freeze{TokenIDs: []*token.ID{tokenID}}),
+	})
+	assert.ErrorContains(t, err, "no compliance authority signature")
+	_, err = VerifyFreezes(pp, deserializer, ledger, "anchor", [][]byte{
+		freezeAction(t, &driver.Freeze{TokenIDs: []*token.ID{tokenID}}, driver.Identity("alice")),
+	})
+	assert.ErrorContains(t, err, "signer at [0] is not a compliance authority")
+	_, err = VerifyFreezes(pp, deserializer, ledger, "anchor", [][]byte{
+		freezeAction(t, &driver.Freeze{TokenIDs: []*token.ID{tokenID}}, driver.Identity("compliance1"), driver.Identity("compliance1")),
+	})
+	assert.ErrorContains(t, err, "duplicate compliance authority signature at [1]")
+
+	// no compliance authorities
+	_, err = VerifyFreezes(&mock.PublicParameters{}, deserializer, ledger, "anchor", [][]byte{
+		freezeAction(t, &driver.Freeze{TokenIDs: []*token.ID{tokenID}}, driver.Identity("compliance1")),
+	})
+	assert.ErrorContains(t, err, "no compliance authority is set")
+}
+
+func TestVerifyInputsNotFrozen(t *testing.T) {
+	tokenID := &token.ID{TxId: "tx1", Index: 1}
+	ledger := newFreezeLedger(map[token.ID][]byte{
+		*tokenID:                      []byte("token"),
+		driver.FrozenTokenID(tokenID): []byte{1},
+	})
+
+	// without compliance authorities, nothing can be frozen
+	pp := &mock.PublicParameters{}
+	assert.NoError(t, VerifyInputsNotFrozen(pp, ledger, []*token.ID{tokenID}))
+	assert.Equal(t, 0, ledger.GetStateCallCount())
+
+	pp.ComplianceAuthoritiesReturns([]driver.Identity{driver.Identity("compliance")})
+	assert.EqualError(t, VerifyInputsNotFrozen(pp, ledger, []*token.ID{tokenID}), "input [[tx1:1]] is frozen")
+	assert.NoError(t, VerifyInputsNotFrozen(pp, ledger, []*token.ID{{TxId: "tx1", Index: 2}}))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// AuthorizedIssuers returns the identities authorized to issue tokens of the passed type.
+// If typeIssuers is empty, any token type can be issued and the general list of issuers is returned.
+// Otherwise, the token type must match one of the entries. An exact match takes precedence over a prefix match,
+// and the longest prefix wins among prefix matches. If the matching entry has no issuers, the general list of issuers is returned.
+// An empty list of issuers means that anyone can issue.
+func AuthorizedIssuers(issuers []driver.Identity, typeIssuers []*driver.TokenTypeIssuers, tokenType token.Type) ([]driver.Identity, error) {
+	if len(typeIssuers) == 0 {
+		return issuers, nil
+	}
+	var match *driver.TokenTypeIssuers
+	for _, entry := range typeIssuers {
+		if entry == nil || !entry.Matches(tokenType) {
+			continue
+		}
+		if !entry.IsPrefix() {
+			match = entry
+			break
+		}
+		if match == nil || len(entry.TokenType) > len(match.TokenType) {
+			match = entry
+		}
+	}
+	if match == nil {
+		return nil, errors.Errorf("token type [%s] is not allowed", tokenType)
+	}
+	if len(match.Issuers) == 0 {
+		return issuers, nil
+	}
+	return match.Issuers, nil
+}
+
+// IsAuthorizedIssuer returns nil if the passed issuer is authorized to issue tokens of the passed type,
+// an error otherwise.
+func IsAuthorizedIssuer(issuers []driver.Identity, typeIssuers []*driver.TokenTypeIssuers, tokenType token.Type, issuer driver.Identity) error {
+	authorized, err := AuthorizedIssuers(issuers, typeIssuers, tokenType)
+	if err != nil {
+		return err
+	}
+	if len(authorized) == 0 {
+		return nil
+	}
+	if !slices.ContainsFunc(authorized, issuer.Equal) {
+		return errors.Errorf("issuer [%s] is not authorized to issue tokens of type [%s]", issuer, tokenType)
+	}
+	return nil
+}
+
+// AllIssuers returns the general list of issuers extended with the issuers bound to specific token types, without duplicates.
+func AllIssuers(issuers []driver.Identity, typeIssuers []*driver.TokenTypeIssuers) []driver.Identity {
+	res := slices.Clone(issuers)
+	for _, entry := range typeIssuers {
+		if entry == nil {
+			continue
+		}
+		for _, issuer := range entry.Issuers {
+			if !slices.ContainsFunc(res, issuer.Equal) {
+				res = append(res, issuer)
+			}
+		}
+	}
+	return res
+}
+
+// ValidateTokenTypeIssuers checks that the passed token type issuers are well-formed
+func ValidateTokenTypeIssuers(typeIssuers []*driver.TokenTypeIssuers) error {
+	seen := map[token.Type]struct{}{}
+	for i, entry := range typeIssuers {
+		if entry == nil {
+			return errors.Errorf("invalid token type issuers at [%d]: nil entry", i)
+		}
+		if len(entry.TokenType) == 0 {
+			return errors.Errorf("invalid token type issuers at [%d]: empty token type", i)
+		}
+		if strings.Contains(strings.TrimSuffix(string(entry.TokenType), driver.TokenTypeWildcard), driver.TokenTypeWildcard) {
+			return errors.Errorf("invalid token type issuers at [%d]: the wildcard can only appear at the end of [%s]", i, entry.TokenType)
+		}
+		if _, ok := seen[entry.TokenType]; ok {
+			return errors.Errorf("invalid token type issuers at [%d]: duplicate token type [%s]", i, entry.TokenType)
+		}
+		seen[entry.TokenType] = struct{}{}
+		for j, issuer := range entry.Issuers {
+			if issuer.IsNone() {
+				return errors.Errorf("invalid token type issuers at [%d]: empty issuer at [%d]", i, j)
+			}
+		}
+	}
+	return nil
+}
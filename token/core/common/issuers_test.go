@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizedIssuers(t *testing.T) {
+	general := []driver.Identity{driver.Identity("general")}
+	usd := driver.Identity("usd")
+	eur := driver.Identity("eur")
+	eurc := driver.Identity("eurc")
+	typeIssuers := []*driver.TokenTypeIssuers{
+		{TokenType: "USD", Issuers: []driver.Identity{usd}},
+		{TokenType: "EUR*", Issuers: []driver.Identity{eur}},
+		{TokenType: "EURC*", Issuers: []driver.Identity{eurc}},
+		{TokenType: "GOLD"},
+	}
+
+	// no allowlist
+	issuers, err := AuthorizedIssuers(general, nil, "ANY")
+	assert.NoError(t, err)
+	assert.Equal(t, general, issuers)
+
+	// exact match
+	issuers, err = AuthorizedIssuers(general, typeIssuers, "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Identity{usd}, issuers)
+
+	// prefix match, the longest prefix wins
+	issuers, err = AuthorizedIssuers(general, typeIssuers, "EUR.2025")
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Identity{eur}, issuers)
+	issuers, err = AuthorizedIssuers(general, typeIssuers, "EURC")
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Identity{eurc}, issuers)
+
+	// entry without issuers falls back to the general issuers
+	issuers, err = AuthorizedIssuers(general, typeIssuers, "GOLD")
+	assert.NoError(t, err)
+	assert.Equal(t, general, issuers)
+
+	// not in the allowlist
+	_, err = AuthorizedIssuers(general, typeIssuers, "USDC")
+	assert.EqualError(t, err, "token type [USDC] is not allowed")
+
+	assert.NoError(t, IsAuthorizedIssuer(general, typeIssuers, "USD", usd))
+	assert.Error(t, IsAuthorizedIssuer(general, typeIssuers, "USD", eur))
+	assert.NoError(t, IsAuthorizedIssuer(nil, nil, "USD", eur))
+}
+
+func TestAllIssuers(t *testing.T) {
+	a := driver.Identity("a")
+	b := driver.Identity("b")
+	issuers := AllIssuers([]driver.Identity{a}, []*driver.TokenTypeIssuers{
+		{TokenType: "USD", Issuers: []driver.Identity{a, b}},
+		{TokenType: "EUR", Issuers: []driver.Identity{b}},
+	})
+	assert.Equal(t, []driver.Identity{a, b}, issuers)
+}
+
+func TestValidateTokenTypeIssuers(t *testing.T) {
+	assert.NoError(t, ValidateTokenTypeIssuers(nil))
+	assert.NoError(t, ValidateTokenTypeIssuers([]*driver.TokenTypeIssuers{
+		{TokenType: "USD", Issuers: []driver.Identity{driver.Identity("usd")}},
+		{TokenType: "EUR*"},
+	}))
+	assert.EqualError(t, ValidateTokenTypeIssuers([]*driver.TokenTypeIssuers{{TokenType: ""}}), "invalid token type issuers at [0]: empty token type")
+	assert.EqualError(t, ValidateTokenTypeIssuers([]*driver.TokenTypeIssuers{{TokenType: "E*R"}}), "invalid token type issuers at [0]: the wildcard can only appear at the end of [E*R]")
+	assert.EqualError(t, ValidateTokenTypeIssuers([]*driver.TokenTypeIssuers{{TokenType: "USD"}, {TokenType: "USD"}}), "invalid token type issuers at [1]: duplicate token type [USD]")
+	assert.EqualError(t, ValidateTokenTypeIssuers([]*driver.TokenTypeIssuers{{TokenType: "USD", Issuers: []driver.Identity{nil}}}), "invalid token type issuers at [0]: empty issuer at [0]")
+}
@@ -8,6 +8,7 @@ package common
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
@@ -24,7 +25,7 @@ type TokenVault interface {
 	UnspentTokensIteratorBy(ctx context.Context, id string, tokenType token.Type) (driver.UnspentTokensIterator, error)
 	ListHistoryIssuedTokens() (*token.IssuedTokens, error)
 	PublicParams() ([]byte, error)
-	Balance(id string, tokenType token.Type) (uint64, error)
+	Balance(id string, tokenType token.Type) (*big.Int, error)
 }
 
 type LedgerToken interface {
@@ -0,0 +1,185 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// RevocationAction carries the revocation list set by a RevocationListUpdate.
+// The validator appends it to the actions of the token request so that the network stores the list in the namespace.
+type RevocationAction struct {
+	List []byte
+}
+
+// GetRevocationList returns the new revocation list, encoded as stored on the ledger
+func (r *RevocationAction) GetRevocationList() []byte {
+	return r.List
+}
+
+// AdministratorsThreshold returns the number of administrators, out of the passed number of administrators, that must sign an administrative action.
+// A threshold equal to zero, or larger than the number of administrators, means that all administrators must sign it.
+func AdministratorsThreshold(numAdmins int, threshold uint64) uint64 {
+	return AuditorsThreshold(numAdmins, threshold)
+}
+
+// ValidateAdministrators checks that the passed administrators and threshold are well-formed
+func ValidateAdministrators(admins []driver.Identity, threshold uint64) error {
+	for i, admin := range admins {
+		if admin.IsNone() {
+			return errors.Errorf("invalid administrators: empty administrator at [%d]", i)
+		}
+		if slices.ContainsFunc(admins[:i], admin.Equal) {
+			return errors.Errorf("invalid administrators: duplicate administrator at [%d]", i)
+		}
+	}
+	if threshold > uint64(len(admins)) {
+		return errors.Errorf("invalid administrators threshold [%d], must be at most the number of administrators [%d]", threshold, len(admins))
+	}
+	return nil
+}
+
+// IsAdministrative returns true if the passed token request carries administrative, or compliance, actions only.
+// Such a request is authorized by the signatures of the administrators, or of the compliance authorities,
+// therefore, it does not carry auditor signatures.
+func IsAdministrative(tr *driver.TokenRequest) bool {
+	return len(tr.Issues) == 0 && len(tr.Transfers) == 0 && (len(tr.AdminActions) != 0 || len(tr.FreezeActions) != 0)
+}
+
+// VerifyRevocationListUpdates checks the passed administrative actions against the passed current revocation list.
+// Each action must be a RevocationListUpdate whose list has the next sequence number, and must be signed by
+// a quorum of the administrators listed in the public parameters.
+// The signatures must cover the passed namespace and public parameters hash, see driver.RevocationListUpdate.MessageToSign.
+// It returns the actions to store the new revocation lists.
+func VerifyRevocationListUpdates(pp driver.PublicParameters, deserializer driver.Deserializer, namespace string, ppHash driver.PPHash, current *driver.RevocationList, adminActions [][]byte) ([]*RevocationAction, error) {
+	if len(adminActions) == 0 {
+		return nil, nil
+	}
+	admins := pp.Administrators()
+	if len(admins) == 0 {
+		return nil, errors.New("administrative actions are not allowed, no administrator is set in the public parameters")
+	}
+	if len(namespace) == 0 {
+		return nil, errors.New("administrative actions are not allowed, the namespace is not known to the validator")
+	}
+	if len(ppHash) == 0 {
+		return nil, errors.New("administrative actions are not allowed, the token request does not carry the public parameters hash")
+	}
+	var actions []*RevocationAction
+	for i, raw := range adminActions {
+		update := &driver.RevocationListUpdate{}
+		if err := update.FromBytes(raw); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal administrative action at [%d]", i)
+		}
+		if len(update.List) == 0 {
+			return nil, errors.Errorf("invalid revocation list at [%d], empty", i)
+		}
+		list := &driver.RevocationList{}
+		if err := list.FromBytes(update.List); err != nil {
+			return nil, errors.Wrapf(err, "invalid revocation list at [%d]", i)
+		}
+		if list.Sequence != current.Sequence+1 {
+			return nil, errors.Errorf("invalid revocation list at [%d], expected sequence [%d], got [%d]", i, current.Sequence+1, list.Sequence)
+		}
+		message, err := update.MessageToSign(namespace, ppHash)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid revocation list at [%d]", i)
+		}
+		if err := verifyAdminSignatures(admins, pp.AdministratorsThreshold(), deserializer, message, update.Signatures); err != nil {
+			return nil, errors.Wrapf(err, "invalid revocation list at [%d]", i)
+		}
+		actions = append(actions, &RevocationAction{List: update.List})
+		current = list
+	}
+	return actions, nil
+}
+
+// verifyAdminSignatures checks that the passed message has been signed by at least threshold distinct administrators, and only by them
+func verifyAdminSignatures(admins []driver.Identity, threshold uint64, deserializer driver.Deserializer, message []byte, signatures []*driver.AdminSignature) error {
+	if threshold == 0 {
+		threshold = uint64(len(admins))
+	}
+	if uint64(len(signatures)) < threshold {
+		return errors.Errorf("not enough administrator signatures, expected at least [%d], got [%d]", threshold, len(signatures))
+	}
+	for i, signature := range signatures {
+		if signature == nil {
+			return errors.Errorf("nil administrator signature at [%d]", i)
+		}
+		if !slices.ContainsFunc(admins, signature.Signer.Equal) {
+			return errors.Errorf("signer at [%d] is not an administrator", i)
+		}
+		if slices.ContainsFunc(signatures[:i], func(s *driver.AdminSignature) bool { return s.Signer.Equal(signature.Signer) }) {
+			return errors.Errorf("duplicate administrator signature at [%d]", i)
+		}
+		verifier, err := deserializer.GetAdministratorVerifier(signature.Signer)
+		if err != nil {
+			return errors.Wrapf(err, "failed to deserialize administrator at [%d]", i)
+		}
+		if err := verifier.Verify(message, signature.Sigma); err != nil {
+			return errors.Wrapf(err, "failed to verify signature of administrator at [%d]", i)
+		}
+	}
+	return nil
+}
+
+// revokedVerifier rejects any signature, it is used for the identities that have been revoked
+type revokedVerifier struct{}
+
+func (revokedVerifier) Verify(message, sigma []byte) error {
+	return errors.New("the signer has been revoked")
+}
+
+// DefaultRevocationListRefreshInterval is the interval after which the drivers fetch again the revocation list from the ledger
+const DefaultRevocationListRefreshInterval = time.Minute
+
+// RevocationListProvider gives access to the revocation list stored on the ledger
+type RevocationListProvider interface {
+	// RevocationList returns the current revocation list
+	RevocationList() (*driver.RevocationList, error)
+}
+
+// CachedRevocationList is a RevocationListProvider that fetches the revocation list at most once per refresh interval.
+// If the fetch fails, the last fetched list is returned.
+type CachedRevocationList struct {
+	Fetch           func() ([]byte, error)
+	RefreshInterval time.Duration
+
+	lock      sync.Mutex
+	list      *driver.RevocationList
+	fetchedAt time.Time
+}
+
+func NewCachedRevocationList(fetch func() ([]byte, error), refreshInterval time.Duration) *CachedRevocationList {
+	return &CachedRevocationList{Fetch: fetch, RefreshInterval: refreshInterval}
+}
+
+func (c *CachedRevocationList) RevocationList() (*driver.RevocationList, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.list != nil && time.Since(c.fetchedAt) < c.RefreshInterval {
+		return c.list, nil
+	}
+	raw, err := c.Fetch()
+	if err == nil {
+		list := &driver.RevocationList{}
+		if err = list.FromBytes(raw); err == nil {
+			c.list = list
+			c.fetchedAt = time.Now()
+			return list, nil
+		}
+	}
+	if c.list != nil {
+		return c.list, nil
+	}
+	return nil, errors.WithMessagef(err, "failed to fetch the revocation list")
+}
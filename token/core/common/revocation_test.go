@@ -0,0 +1,199 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// signedBy returns a verifier that accepts only the signatures equal to the passed signer identity
+func signedBy(signer driver.Identity) *mock.Verifier {
+	verifier := &mock.Verifier{}
+	verifier.VerifyStub = func(message, sigma []byte) error {
+		if !bytes.Equal(sigma, signer) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	return verifier
+}
+
+func newRevocationDeserializer() *mock.Deserializer {
+	deserializer := &mock.Deserializer{}
+	deserializer.GetAuditorVerifierStub = func(id driver.Identity) (driver.Verifier, error) {
+		return signedBy(id), nil
+	}
+	deserializer.GetAdministratorVerifierStub = func(id driver.Identity) (driver.Verifier, error) {
+		return adminSignedBy(id), nil
+	}
+	deserializer.GetComplianceVerifierStub = func(id driver.Identity) (driver.Verifier, error) {
+		return signedBy(id), nil
+	}
+	return deserializer
+}
+
+// adminSignedBy returns a verifier that accepts only the signatures equal to the passed signer identity followed by the signed message
+func adminSignedBy(signer driver.Identity) *mock.Verifier {
+	verifier := &mock.Verifier{}
+	verifier.VerifyStub = func(message, sigma []byte) error {
+		if !bytes.Equal(sigma, append(bytes.Clone(signer), message...)) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	}
+	return verifier
+}
+
+const (
+	testNamespace = "ns"
+	testPPHash    = "pp"
+)
+
+func revocationListUpdate(t *testing.T, list *driver.RevocationList, signers ...driver.Identity) []byte {
+	return revocationListUpdateFor(t, testNamespace, driver.PPHash(testPPHash), list, signers...)
+}
+
+func revocationListUpdateFor(t *testing.T, namespace string, ppHash driver.PPHash, list *driver.RevocationList, signers ...driver.Identity) []byte {
+	raw, err := list.Bytes()
+	assert.NoError(t, err)
+	update := &driver.RevocationListUpdate{List: raw}
+	message, err := update.MessageToSign(namespace, ppHash)
+	assert.NoError(t, err)
+	for _, signer := range signers {
+		update.Signatures = append(update.Signatures, &driver.AdminSignature{Signer: signer, Sigma: append(bytes.Clone(signer), message...)})
+	}
+	res, err := update.Bytes()
+	assert.NoError(t, err)
+	return res
+}
+
+func TestVerifyRevocationListUpdates(t *testing.T) {
+	pp := &mock.PublicParameters{}
+	pp.AdministratorsReturns([]driver.Identity{driver.Identity("admin1"), driver.Identity("admin2")})
+	deserializer := newRevocationDeserializer()
+	current := &driver.RevocationList{Sequence: 3}
+	next := &driver.RevocationList{Sequence: 4, Issuers: []driver.Identity{driver.Identity("issuer")}}
+
+	actions, err := VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next, driver.Identity("admin2"), driver.Identity("admin1"))})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 1)
+	stored := &driver.RevocationList{}
+	assert.NoError(t, stored.FromBytes(actions[0].GetRevocationList()))
+	assert.Equal(t, next, stored)
+	assert.True(t, stored.IsIssuerRevoked(driver.Identity("issuer")))
+
+	// wrong sequence
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, &driver.RevocationList{Sequence: 5}, driver.Identity("admin1"), driver.Identity("admin2"))})
+	assert.ErrorContains(t, err, "expected sequence [4], got [5]")
+
+	// missing, unknown, and duplicate signers
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next, driver.Identity("admin1"))})
+	assert.ErrorContains(t, err, "not enough administrator signatures, expected at least [2], got [1]")
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next, driver.Identity("admin1"), driver.Identity("alice"))})
+	assert.ErrorContains(t, err, "signer at [1] is not an administrator")
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next, driver.Identity("admin1"), driver.Identity("admin1"))})
+	assert.ErrorContains(t, err, "duplicate administrator signature at [1]")
+
+	// with a threshold, a quorum of the administrators suffices
+	pp.AdministratorsThresholdReturns(1)
+	actions, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next, driver.Identity("admin2"))})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 1)
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next)})
+	assert.ErrorContains(t, err, "not enough administrator signatures, expected at least [1], got [0]")
+	assert.Zero(t, deserializer.GetAuditorVerifierCallCount())
+
+	// an update signed for another namespace, or for other public parameters, is rejected
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdateFor(t, "another", driver.PPHash(testPPHash), next, driver.Identity("admin1"))})
+	assert.ErrorContains(t, err, "failed to verify signature of administrator at [0]")
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdateFor(t, testNamespace, driver.PPHash("another"), next, driver.Identity("admin1"))})
+	assert.ErrorContains(t, err, "failed to verify signature of administrator at [0]")
+
+	// the namespace and the public parameters hash must be known
+	_, err = VerifyRevocationListUpdates(pp, deserializer, "", driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next, driver.Identity("admin1"))})
+	assert.ErrorContains(t, err, "the namespace is not known to the validator")
+	_, err = VerifyRevocationListUpdates(pp, deserializer, testNamespace, nil, current, [][]byte{revocationListUpdate(t, next, driver.Identity("admin1"))})
+	assert.ErrorContains(t, err, "does not carry the public parameters hash")
+
+	// no administrators
+	_, err = VerifyRevocationListUpdates(&mock.PublicParameters{}, deserializer, testNamespace, driver.PPHash(testPPHash), current, [][]byte{revocationListUpdate(t, next)})
+	assert.ErrorContains(t, err, "no administrator is set")
+}
+
+func TestVerifyAuditorSignatureWithRevocations(t *testing.T) {
+	auditors := []driver.Identity{driver.Identity("auditor1"), driver.Identity("auditor2")}
+	pp := &mock.PublicParameters{}
+	pp.AuditorsReturns(auditors)
+	pp.AuditorsThresholdReturns(2)
+	v := NewValidator[*mock.PublicParameters, any, *mock.TransferAction, *mock.IssueAction, *mock.Deserializer](
+		logging.MustGetLogger("test"),
+		pp,
+		newRevocationDeserializer(),
+		nil,
+		nil,
+		nil,
+	)
+	backend := func(sigs ...[]byte) driver.SignatureProvider {
+		return NewBackend(logging.MustGetLogger("test"), nil, []byte("message"), sigs)
+	}
+
+	assert.NoError(t, v.verifyAuditorSignature(backend([]byte("auditor1"), []byte("auditor2")), nil))
+	assert.ErrorContains(t, v.verifyAuditorSignature(backend([]byte("auditor1"), nil), nil), "insufficient number of auditor signatures")
+
+	// revoking an auditor does not lower the threshold
+	revoked := &driver.RevocationList{Auditors: []driver.Identity{driver.Identity("auditor2")}}
+	assert.EqualError(t, v.verifyAuditorSignature(backend([]byte("auditor1"), nil), revoked), "insufficient number of auditors not revoked, expected at least [2], got [1]")
+	assert.EqualError(t, v.verifyAuditorSignature(backend([]byte("auditor1"), []byte("auditor2")), revoked), "insufficient number of auditors not revoked, expected at least [2], got [1]")
+
+	revoked.Auditors = auditors
+	assert.EqualError(t, v.verifyAuditorSignature(backend([]byte("auditor1"), []byte("auditor2")), revoked), "insufficient number of auditors not revoked, expected at least [2], got [0]")
+
+	// with a 1-of-2 threshold, the signature of a revoked auditor is not counted
+	pp.AuditorsThresholdReturns(1)
+	revoked.Auditors = []driver.Identity{driver.Identity("auditor2")}
+	assert.NoError(t, v.verifyAuditorSignature(backend([]byte("auditor1"), nil), revoked))
+	assert.ErrorContains(t, v.verifyAuditorSignature(backend(nil, []byte("auditor2")), revoked), "insufficient number of auditor signatures")
+}
+
+func TestCachedRevocationList(t *testing.T) {
+	calls := 0
+	var fetchErr error
+	list := &driver.RevocationList{Sequence: 1}
+	cache := NewCachedRevocationList(func() ([]byte, error) {
+		calls++
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		return list.Bytes()
+	}, time.Hour)
+
+	res, err := cache.RevocationList()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), res.Sequence)
+	_, err = cache.RevocationList()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// on failure, the last fetched list is returned
+	cache.RefreshInterval = 0
+	fetchErr = errors.New("unavailable")
+	res, err = cache.RevocationList()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), res.Sequence)
+	assert.Equal(t, 2, calls)
+
+	_, err = NewCachedRevocationList(func() ([]byte, error) { return nil, fetchErr }, time.Hour).RevocationList()
+	assert.ErrorContains(t, err, "unavailable")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// SupplyAttributePrefix prefixes the validation attributes that carry the circulating supply of a token type,
+// as updated by the actions of the token request validated so far.
+const SupplyAttributePrefix driver.ValidationAttributeID = "supply."
+
+// ValidateSupplyCaps checks that the passed supply caps are well-formed
+func ValidateSupplyCaps(supplyCaps map[token.Type]uint64) error {
+	for tokenType, supplyCap := range supplyCaps {
+		if len(tokenType) == 0 {
+			return errors.New("invalid supply caps: empty token type")
+		}
+		if supplyCap == 0 {
+			return errors.Errorf("invalid supply cap for token type [%s], must be greater than 0", tokenType)
+		}
+	}
+	return nil
+}
+
+// IncreaseSupply adds the passed value to the circulating supply of the passed token type, if the token type has a supply cap.
+// It returns an error if the new circulating supply exceeds the cap.
+// The new circulating supply is recorded in the validation attributes.
+func IncreaseSupply(ledger driver.Ledger, attributes driver.ValidationAttributes, supplyCaps map[token.Type]uint64, tokenType token.Type, value uint64) error {
+	supplyCap, ok := supplyCaps[tokenType]
+	if !ok {
+		return nil
+	}
+	supply, err := currentSupply(ledger, attributes, tokenType)
+	if err != nil {
+		return err
+	}
+	if value > supplyCap || supply > supplyCap-value {
+		return errors.Errorf("issuing [%d] tokens of type [%s] exceeds the supply cap [%d], the circulating supply is [%d]", value, tokenType, supplyCap, supply)
+	}
+	attributes[SupplyAttributePrefix+string(tokenType)] = driver.MarshalSupply(supply + value)
+	return nil
+}
+
+// DecreaseSupply subtracts the passed value from the circulating supply of the passed token type, if the token type has a supply cap.
+// The circulating supply does not go below zero, this happens when the cap has been introduced after some tokens were already issued.
+// The new circulating supply is recorded in the validation attributes.
+func DecreaseSupply(ledger driver.Ledger, attributes driver.ValidationAttributes, supplyCaps map[token.Type]uint64, tokenType token.Type, value uint64) error {
+	if _, ok := supplyCaps[tokenType]; !ok {
+		return nil
+	}
+	supply, err := currentSupply(ledger, attributes, tokenType)
+	if err != nil {
+		return err
+	}
+	if value > supply {
+		value = supply
+	}
+	attributes[SupplyAttributePrefix+string(tokenType)] = driver.MarshalSupply(supply - value)
+	return nil
+}
+
+// currentSupply returns the circulating supply of the passed token type, as updated by the actions validated so far,
+// or as stored on the ledger if none of them touched it.
+func currentSupply(ledger driver.Ledger, attributes driver.ValidationAttributes, tokenType token.Type) (uint64, error) {
+	raw, ok := attributes[SupplyAttributePrefix+string(tokenType)]
+	if !ok {
+		var err error
+		raw, err = ledger.GetState(driver.SupplyCounterID(tokenType))
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to read the circulating supply of token type [%s]", tokenType)
+		}
+	}
+	supply, err := driver.UnmarshalSupply(raw)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to read the circulating supply of token type [%s]", tokenType)
+	}
+	return supply, nil
+}
+
+// SupplyAction carries the new circulating supply of the token types whose supply has been changed by a token request.
+// The validator appends it to the actions of the token request so that the network stores the supply counters in the namespace.
+type SupplyAction struct {
+	Supplies map[token.Type][]byte
+}
+
+// NewSupplyActionFromAttributes returns the SupplyAction for the circulating supplies recorded in the passed validation attributes.
+// It returns nil if there are none.
+func NewSupplyActionFromAttributes(attributes driver.ValidationAttributes) *SupplyAction {
+	supplies := map[token.Type][]byte{}
+	for k, v := range attributes {
+		if tokenType, ok := strings.CutPrefix(k, SupplyAttributePrefix); ok {
+			supplies[token.Type(tokenType)] = v
+		}
+	}
+	if len(supplies) == 0 {
+		return nil
+	}
+	return &SupplyAction{Supplies: supplies}
+}
+
+// GetSupplyCounters returns the new circulating supply of each token type, encoded as stored on the ledger
+func (s *SupplyAction) GetSupplyCounters() map[token.Type][]byte {
+	return s.Supplies
+}
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSupplyCaps(t *testing.T) {
+	assert.NoError(t, ValidateSupplyCaps(nil))
+	assert.NoError(t, ValidateSupplyCaps(map[token.Type]uint64{"USD": 100}))
+	assert.EqualError(t, ValidateSupplyCaps(map[token.Type]uint64{"": 100}), "invalid supply caps: empty token type")
+	assert.EqualError(t, ValidateSupplyCaps(map[token.Type]uint64{"USD": 0}), "invalid supply cap for token type [USD], must be greater than 0")
+}
+
+func TestSupply(t *testing.T) {
+	supplyCaps := map[token.Type]uint64{"USD": 100}
+	ledger := &mock.ValidatorLedger{}
+	ledger.GetStateStub = func(id token.ID) ([]byte, error) {
+		if id == driver.SupplyCounterID("USD") {
+			return driver.MarshalSupply(40), nil
+		}
+		return nil, nil
+	}
+	attributes := driver.ValidationAttributes{}
+
+	// token types without a cap are not tracked
+	assert.NoError(t, IncreaseSupply(ledger, attributes, supplyCaps, "EUR", 1000))
+	assert.NoError(t, DecreaseSupply(ledger, attributes, supplyCaps, "EUR", 1000))
+	assert.Nil(t, NewSupplyActionFromAttributes(attributes))
+	assert.Equal(t, 0, ledger.GetStateCallCount())
+
+	// the supply read from the ledger is updated
+	assert.NoError(t, IncreaseSupply(ledger, attributes, supplyCaps, "USD", 50))
+	assert.Equal(t, 1, ledger.GetStateCallCount())
+	// the running supply is used for the next actions
+	assert.EqualError(t, IncreaseSupply(ledger, attributes, supplyCaps, "USD", 11), "issuing [11] tokens of type [USD] exceeds the supply cap [100], the circulating supply is [90]")
+	assert.NoError(t, IncreaseSupply(ledger, attributes, supplyCaps, "USD", 10))
+	assert.Equal(t, 1, ledger.GetStateCallCount())
+	assert.EqualError(t, IncreaseSupply(ledger, attributes, supplyCaps, "USD", 1<<63), "issuing [9223372036854775808] tokens of type [USD] exceeds the supply cap [100], the circulating supply is [100]")
+	assert.NoError(t, DecreaseSupply(ledger, attributes, supplyCaps, "USD", 30))
+
+	action := NewSupplyActionFromAttributes(attributes)
+	assert.NotNil(t, action)
+	assert.Equal(t, map[token.Type][]byte{"USD": []byte("70")}, action.GetSupplyCounters())
+
+	// the supply does not go below zero
+	assert.NoError(t, DecreaseSupply(ledger, attributes, supplyCaps, "USD", 1000))
+	assert.Equal(t, map[token.Type][]byte{"USD": []byte("0")}, NewSupplyActionFromAttributes(attributes).GetSupplyCounters())
+
+	// ledger failures are reported
+	ledger.GetStateReturns(nil, errors.New("ledger failure"))
+	assert.EqualError(t, IncreaseSupply(ledger, driver.ValidationAttributes{}, supplyCaps, "USD", 1), "failed to read the circulating supply of token type [USD]: ledger failure")
+	ledger.GetStateReturns([]byte("invalid"), nil)
+	assert.Error(t, IncreaseSupply(ledger, driver.ValidationAttributes{}, supplyCaps, "USD", 1))
+}
@@ -9,6 +9,10 @@ package common
 import (
 	"context"
 	"encoding/json"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
@@ -20,6 +24,8 @@ type MetadataCounterID = string
 const (
 	TokenRequestToSign     driver.ValidationAttributeID = "trs"
 	TokenRequestSignatures driver.ValidationAttributeID = "sigs"
+	BlockHeight            driver.ValidationAttributeID = "height"
+	Namespace              driver.ValidationAttributeID = "ns"
 )
 
 type Context[P driver.PublicParameters, T any, TA driver.TransferAction, IA driver.IssueAction, DS driver.Deserializer] struct {
@@ -34,6 +40,10 @@ type Context[P driver.PublicParameters, T any, TA driver.TransferAction, IA driv
 	Ledger            driver.Ledger
 	MetadataCounter   map[MetadataCounterID]int
 	Attributes        driver.ValidationAttributes
+	// RevocationList lists the issuers and auditors whose signatures are no longer accepted
+	RevocationList *driver.RevocationList
+	// Workers is the worker pool of the validator, to run the independent checks of an action concurrently
+	Workers *WorkerPool
 }
 
 func (c *Context[P, T, TA, IA, DS]) CountMetadataKey(key string) {
@@ -44,6 +54,45 @@ type ValidateTransferFunc[P driver.PublicParameters, T any, TA driver.TransferAc
 
 type ValidateIssueFunc[P driver.PublicParameters, T any, TA driver.TransferAction, IA driver.IssueAction, DS driver.Deserializer] func(ctx *Context[P, T, TA, IA, DS]) error
 
+// ValidateFuncError reports the validation function that rejected an action of a token request.
+// Its message is the one of the wrapped error.
+type ValidateFuncError struct {
+	// ActionType is either IssueActionType, TransferActionType, or ClawbackActionType
+	ActionType string
+	// ActionIndex is the position of the rejected action among the actions of the same type
+	ActionIndex int
+	// Func is the fully qualified name of the validation function
+	Func string
+	// Err is the error returned by the validation function
+	Err error
+}
+
+const (
+	IssueActionType    = "issue"
+	TransferActionType = "transfer"
+	ClawbackActionType = "clawback"
+)
+
+func (e *ValidateFuncError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidateFuncError) Unwrap() error {
+	return e.Err
+}
+
+// FuncName returns the fully qualified name of the passed function
+func FuncName(f any) string {
+	v := reflect.ValueOf(f)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	if fn := runtime.FuncForPC(v.Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
+
 type ActionDeserializer[TA driver.TransferAction, IA driver.IssueAction] interface {
 	DeserializeActions(tr *driver.TokenRequest) ([]IA, []TA, error)
 }
@@ -55,6 +104,16 @@ type Validator[P driver.PublicParameters, T any, TA driver.TransferAction, IA dr
 	ActionDeserializer ActionDeserializer[TA, IA]
 	TransferValidators []ValidateTransferFunc[P, T, TA, IA, DS]
 	IssueValidators    []ValidateIssueFunc[P, T, TA, IA, DS]
+	// ClawbackValidators validate the transfer actions that carry a clawback, in place of TransferValidators.
+	// If empty, clawbacks are rejected.
+	ClawbackValidators []ValidateTransferFunc[P, T, TA, IA, DS]
+	// Parallelism is the size of the worker pool shared by the verification of the token requests of a batch
+	// and of the range proofs of their actions. If not positive, the number of CPUs is used.
+	// It must be set before the first verification.
+	Parallelism int
+
+	workersOnce sync.Once
+	workers     *WorkerPool
 }
 
 func NewValidator[P driver.PublicParameters, T any, TA driver.TransferAction, IA driver.IssueAction, DS driver.Deserializer](
@@ -91,7 +150,10 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequestFromRaw(ctx context.Cont
 		return nil, nil, errors.Wrap(err, "failed to marshal signed token request")
 	}
 	var signatures [][]byte
-	if len(v.PublicParams.Auditors()) != 0 {
+	if auditors := v.PublicParams.Auditors(); len(auditors) != 0 && !IsAdministrative(tr) {
+		if len(tr.AuditorSignatures) != len(auditors) {
+			return nil, nil, errors.Errorf("invalid number of auditor signatures, expected [%d], got [%d]", len(auditors), len(tr.AuditorSignatures))
+		}
 		signatures = append(signatures, tr.AuditorSignatures...)
 		signatures = append(signatures, tr.Signatures...)
 	} else {
@@ -104,24 +166,91 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequestFromRaw(ctx context.Cont
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to marshal token request signatures")
 	}
+	if height, ok := driver.BlockHeightFromContext(ctx); ok {
+		attributes[BlockHeight] = []byte(strconv.FormatUint(height, 10))
+	}
+	if namespace, ok := driver.NamespaceFromContext(ctx); ok {
+		attributes[Namespace] = []byte(namespace)
+	}
 
 	backend := NewBackend(v.Logger, getState, signed, signatures)
 	return v.VerifyTokenRequest(backend, backend, anchor, tr, attributes)
 }
 
+// VerifyTokenRequestsFromRaw verifies the passed token requests concurrently, on the worker pool of the validator, see Parallelism.
+// Each request is verified against its own ledger and anchor, therefore the failure of a request does not affect the others.
+func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequestsFromRaw(ctx context.Context, requests []*driver.ValidationRequest) []*driver.ValidationResult {
+	results := make([]*driver.ValidationResult, len(requests))
+	tasks := make([]func() error, len(requests))
+	for i, request := range requests {
+		tasks[i] = func() error {
+			if ctx != nil && ctx.Err() != nil {
+				results[i] = &driver.ValidationResult{Err: errors.Wrapf(ctx.Err(), "failed to verify token request [%s]", request.Anchor)}
+				return nil
+			}
+			actions, attributes, err := v.VerifyTokenRequestFromRaw(ctx, request.GetState, request.Anchor, request.Raw)
+			results[i] = &driver.ValidationResult{Actions: actions, Attributes: attributes, Err: err}
+			return nil
+		}
+	}
+	_ = v.workerPool().Run(tasks...)
+	return results
+}
+
+// workerPool returns the worker pool of this validator, of size Parallelism
+func (v *Validator[P, T, TA, IA, DS]) workerPool() *WorkerPool {
+	v.workersOnce.Do(func() {
+		parallelism := v.Parallelism
+		if parallelism <= 0 {
+			parallelism = runtime.NumCPU()
+		}
+		v.workers = NewWorkerPool(parallelism)
+	})
+	return v.workers
+}
+
 func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequest(ledger driver.Ledger, signatureProvider driver.SignatureProvider, anchor string, tr *driver.TokenRequest, attributes driver.ValidationAttributes) ([]interface{}, driver.ValidationAttributes, error) {
-	if err := v.verifyAuditorSignature(signatureProvider, attributes); err != nil {
+	revocationList, err := driver.ReadRevocationList(ledger)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read revocation list [%s]", anchor)
+	}
+	if IsAdministrative(tr) {
+		revocationActions, err := VerifyRevocationListUpdates(v.PublicParams, v.Deserializer, string(attributes[Namespace]), tr.PublicParamsHash, revocationList, tr.AdminActions)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to verify administrative actions [%s]", anchor)
+		}
+		freezeActions, err := VerifyFreezes(v.PublicParams, v.Deserializer, ledger, anchor, tr.FreezeActions)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to verify freeze actions [%s]", anchor)
+		}
+		actions := make([]interface{}, 0, len(revocationActions)+len(freezeActions))
+		for _, action := range revocationActions {
+			actions = append(actions, action)
+		}
+		for _, action := range freezeActions {
+			actions = append(actions, action)
+		}
+		return actions, attributes, nil
+	}
+	if len(tr.AdminActions) != 0 {
+		return nil, nil, errors.Errorf("administrative actions cannot be mixed with issue and transfer actions [%s]", anchor)
+	}
+	if len(tr.FreezeActions) != 0 {
+		return nil, nil, errors.Errorf("freeze actions cannot be mixed with issue and transfer actions [%s]", anchor)
+	}
+
+	if err := v.verifyAuditorSignature(signatureProvider, revocationList); err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to verifier auditor's signature [%s]", anchor)
 	}
 	ia, ta, err := v.ActionDeserializer.DeserializeActions(tr)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to unmarshal actions [%s]", anchor)
 	}
-	err = v.verifyIssues(ledger, ia, signatureProvider, attributes)
+	err = v.verifyIssues(ledger, ia, signatureProvider, attributes, revocationList)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to verify issue actions [%s]", anchor)
 	}
-	err = v.verifyTransfers(ledger, ta, signatureProvider, attributes)
+	err = v.verifyTransfers(ledger, ta, signatureProvider, attributes, revocationList)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to verify transfer actions [%s]", anchor)
 	}
@@ -133,6 +262,17 @@ func (v *Validator[P, T, TA, IA, DS]) VerifyTokenRequest(ledger driver.Ledger, s
 	for _, action := range ta {
 		actions = append(actions, action)
 	}
+	if action := NewSupplyActionFromAttributes(attributes); action != nil {
+		actions = append(actions, action)
+	}
+	// the tokens spent by a clawback are no longer frozen
+	if len(v.PublicParams.ComplianceAuthorities()) != 0 {
+		for _, action := range ta {
+			if IsClawback(action) {
+				actions = append(actions, &FreezeAction{TokenIDs: action.GetInputs(), Unfreeze: true})
+			}
+		}
+	}
 	return actions, attributes, nil
 }
 
@@ -157,30 +297,64 @@ func (v *Validator[P, T, TA, IA, DS]) UnmarshalActions(raw []byte) ([]interface{
 	return res, nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyAuditorSignature(signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
-	if len(v.PublicParams.Auditors()) != 0 {
-		auditor := v.PublicParams.Auditors()[0]
+// verifyAuditorSignature checks that the token request has been signed by at least as many auditors as required by the public parameters.
+// The signature provider is expected to return one signature per auditor, in the same order of the auditors in the public parameters.
+// An empty signature means that the corresponding auditor did not sign.
+// The signatures of revoked auditors are not counted. Revoking auditors does not lower the threshold,
+// therefore, the request is rejected if fewer auditors than the threshold are not revoked.
+func (v *Validator[P, T, TA, IA, DS]) verifyAuditorSignature(signatureProvider driver.SignatureProvider, revocationList *driver.RevocationList) error {
+	auditors := v.PublicParams.Auditors()
+	if len(auditors) == 0 {
+		return nil
+	}
+	threshold := AuditorsThreshold(len(auditors), v.PublicParams.AuditorsThreshold())
+	active := uint64(0)
+	for _, auditor := range auditors {
+		if !revocationList.IsAuditorRevoked(auditor) {
+			active++
+		}
+	}
+	if active < threshold {
+		return errors.Errorf("insufficient number of auditors not revoked, expected at least [%d], got [%d]", threshold, active)
+	}
+	signed := uint64(0)
+	for i, auditor := range auditors {
+		if revocationList.IsAuditorRevoked(auditor) {
+			// consume the signature slot of the auditor without counting it
+			_, _ = signatureProvider.HasBeenSignedBy(auditor, revokedVerifier{})
+			v.Logger.Debugf("auditor [%s] has been revoked", auditor)
+			continue
+		}
 		verifier, err := v.Deserializer.GetAuditorVerifier(auditor)
 		if err != nil {
-			return errors.Errorf("failed to deserialize auditor's public key")
+			return errors.Errorf("failed to deserialize auditor's public key at [%d]", i)
 		}
 		v.Logger.Infof("verify auditor signature for [%s]", auditor)
-		_, err = signatureProvider.HasBeenSignedBy(auditor, verifier)
-		return err
+		if _, err := signatureProvider.HasBeenSignedBy(auditor, verifier); err != nil {
+			if errors.Is(err, ErrEmptySignature) {
+				v.Logger.Debugf("auditor [%s] did not sign", auditor)
+				continue
+			}
+			return errors.Wrapf(err, "failed to verify signature of auditor at [%d]", i)
+		}
+		signed++
+	}
+	if signed < threshold {
+		return errors.Errorf("insufficient number of auditor signatures, expected at least [%d], got [%d]", threshold, signed)
 	}
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyIssues(ledger driver.Ledger, issues []IA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyIssues(ledger driver.Ledger, issues []IA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes, revocationList *driver.RevocationList) error {
 	for i, issue := range issues {
-		if err := v.verifyIssue(issue, ledger, signatureProvider, attributes); err != nil {
+		if err := v.verifyIssue(i, issue, ledger, signatureProvider, attributes, revocationList); err != nil {
 			return errors.Wrapf(err, "failed to verify issue action at [%d]", i)
 		}
 	}
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyIssue(tr IA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyIssue(index int, tr IA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes, revocationList *driver.RevocationList) error {
 	context := &Context[P, T, TA, IA, DS]{
 		Logger:            v.Logger,
 		PP:                v.PublicParams,
@@ -190,10 +364,12 @@ func (v *Validator[P, T, TA, IA, DS]) verifyIssue(tr IA, ledger driver.Ledger, s
 		SignatureProvider: signatureProvider,
 		MetadataCounter:   map[string]int{},
 		Attributes:        attributes,
+		RevocationList:    revocationList,
+		Workers:           v.workerPool(),
 	}
 	for _, v := range v.IssueValidators {
 		if err := v(context); err != nil {
-			return err
+			return &ValidateFuncError{ActionType: IssueActionType, ActionIndex: index, Func: FuncName(v), Err: err}
 		}
 	}
 
@@ -212,18 +388,39 @@ func (v *Validator[P, T, TA, IA, DS]) verifyIssue(tr IA, ledger driver.Ledger, s
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyTransfers(ledger driver.Ledger, transferActions []TA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyTransfers(ledger driver.Ledger, transferActions []TA, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes, revocationList *driver.RevocationList) error {
 	v.Logger.Debugf("check sender start...")
 	defer v.Logger.Debugf("check sender finished.")
 	for i, action := range transferActions {
-		if err := v.verifyTransfer(action, ledger, signatureProvider, attributes); err != nil {
+		if IsClawback(action) {
+			// a clawback can spend frozen tokens
+			if err := v.verifyClawback(i, action, ledger, signatureProvider, attributes, revocationList); err != nil {
+				return errors.Wrapf(err, "failed to verify clawback action at [%d]", i)
+			}
+			continue
+		}
+		if err := VerifyInputsNotFrozen(v.PublicParams, ledger, action.GetInputs()); err != nil {
+			return errors.Wrapf(err, "failed to verify transfer action at [%d]", i)
+		}
+		if err := v.verifyTransfer(i, action, ledger, signatureProvider, attributes, revocationList); err != nil {
 			return errors.Wrapf(err, "failed to verify transfer action at [%d]", i)
 		}
 	}
 	return nil
 }
 
-func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(tr TA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes) error {
+func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(index int, tr TA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes, revocationList *driver.RevocationList) error {
+	return v.runTransferValidators(TransferActionType, v.TransferValidators, index, tr, ledger, signatureProvider, attributes, revocationList)
+}
+
+func (v *Validator[P, T, TA, IA, DS]) verifyClawback(index int, tr TA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes, revocationList *driver.RevocationList) error {
+	if len(v.ClawbackValidators) == 0 {
+		return errors.New("clawbacks are not supported")
+	}
+	return v.runTransferValidators(ClawbackActionType, v.ClawbackValidators, index, tr, ledger, signatureProvider, attributes, revocationList)
+}
+
+func (v *Validator[P, T, TA, IA, DS]) runTransferValidators(actionType string, validators []ValidateTransferFunc[P, T, TA, IA, DS], index int, tr TA, ledger driver.Ledger, signatureProvider driver.SignatureProvider, attributes driver.ValidationAttributes, revocationList *driver.RevocationList) error {
 	context := &Context[P, T, TA, IA, DS]{
 		Logger:            v.Logger,
 		PP:                v.PublicParams,
@@ -233,10 +430,12 @@ func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(tr TA, ledger driver.Ledger
 		SignatureProvider: signatureProvider,
 		MetadataCounter:   map[MetadataCounterID]int{},
 		Attributes:        attributes,
+		RevocationList:    revocationList,
+		Workers:           v.workerPool(),
 	}
-	for _, v := range v.TransferValidators {
+	for _, v := range validators {
 		if err := v(context); err != nil {
-			return err
+			return &ValidateFuncError{ActionType: actionType, ActionIndex: index, Func: FuncName(v), Err: err}
 		}
 	}
 
@@ -255,6 +454,27 @@ func (v *Validator[P, T, TA, IA, DS]) verifyTransfer(tr TA, ledger driver.Ledger
 	return nil
 }
 
+// GetBlockHeight returns the ledger height recorded in the passed validation attributes.
+// It returns zero if the height is not available.
+func GetBlockHeight(attributes driver.ValidationAttributes) (uint64, error) {
+	raw, ok := attributes[BlockHeight]
+	if !ok {
+		return 0, nil
+	}
+	height, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid block height [%s]", string(raw))
+	}
+	return height, nil
+}
+
+// IsBlockHeightAvailable returns true if the passed validation attributes record the ledger height,
+// that is, if the network passes it to the validator
+func IsBlockHeightAvailable(attributes driver.ValidationAttributes) bool {
+	_, ok := attributes[BlockHeight]
+	return ok
+}
+
 func IsAnyNil[T any](args ...*T) bool {
 	for _, arg := range args {
 		if arg == nil {
@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBlockHeight(t *testing.T) {
+	height, err := GetBlockHeight(driver.ValidationAttributes{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), height)
+
+	height, err = GetBlockHeight(driver.ValidationAttributes{BlockHeight: []byte("42")})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), height)
+
+	_, err = GetBlockHeight(driver.ValidationAttributes{BlockHeight: []byte("invalid")})
+	assert.Error(t, err)
+
+	assert.False(t, IsBlockHeightAvailable(driver.ValidationAttributes{}))
+	assert.True(t, IsBlockHeightAvailable(driver.ValidationAttributes{BlockHeight: []byte("0")}))
+
+	_, ok := driver.BlockHeightFromContext(context.Background())
+	assert.False(t, ok)
+	height, ok = driver.BlockHeightFromContext(driver.WithBlockHeight(context.Background(), 42))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), height)
+}
+
+type testContext = Context[*mock.PublicParameters, any, *mock.TransferAction, *mock.IssueAction, *mock.Deserializer]
+
+func acceptTransfer(*testContext) error { return nil }
+
+func rejectTransfer(*testContext) error { return errors.New("invalid transfer") }
+
+func TestValidateFuncError(t *testing.T) {
+	v := NewValidator[*mock.PublicParameters, any, *mock.TransferAction, *mock.IssueAction, *mock.Deserializer](
+		logging.MustGetLogger("test"),
+		&mock.PublicParameters{},
+		&mock.Deserializer{},
+		nil,
+		[]ValidateTransferFunc[*mock.PublicParameters, any, *mock.TransferAction, *mock.IssueAction, *mock.Deserializer]{acceptTransfer, rejectTransfer},
+		nil,
+	)
+	err := v.verifyTransfers(nil, []*mock.TransferAction{{}, {}}, nil, driver.ValidationAttributes{}, nil)
+	assert.EqualError(t, err, "failed to verify transfer action at [0]: invalid transfer")
+
+	var funcErr *ValidateFuncError
+	assert.True(t, errors.As(err, &funcErr))
+	assert.Equal(t, TransferActionType, funcErr.ActionType)
+	assert.Equal(t, 0, funcErr.ActionIndex)
+	assert.Equal(t, "github.com/hyperledger-labs/fabric-token-sdk/token/core/common.rejectTransfer", funcErr.Func)
+	assert.Equal(t, "", FuncName(nil))
+}
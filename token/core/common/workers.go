@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"sync"
+)
+
+// WorkerPool runs tasks on a bounded number of goroutines shared by all its users.
+// When all the workers are busy, a task runs on the goroutine of the caller.
+// Therefore, the tasks can use the pool themselves without deadlocking, and the number of goroutines never exceeds the size of the pool.
+type WorkerPool struct {
+	slots chan struct{}
+}
+
+// NewWorkerPool returns a WorkerPool with the passed number of workers, at least one
+func NewWorkerPool(size int) *WorkerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &WorkerPool{slots: make(chan struct{}, size)}
+}
+
+// Run runs the passed tasks, concurrently if workers are available, and waits for all of them.
+// It returns the error of the first failed task, in the order of the tasks.
+// A nil pool runs the tasks one after the other.
+func (p *WorkerPool) Run(tasks ...func() error) error {
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		if p == nil {
+			errs[i] = task()
+			continue
+		}
+		select {
+		case p.slots <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-p.slots
+					wg.Done()
+				}()
+				errs[i] = task()
+			}()
+		default:
+			errs[i] = task()
+		}
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
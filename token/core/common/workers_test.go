@@ -0,0 +1,49 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	for _, pool := range []*WorkerPool{nil, NewWorkerPool(0), NewWorkerPool(2)} {
+		var running, peak atomic.Int32
+		task := func() error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			return nil
+		}
+		// tasks that use the pool themselves do not deadlock
+		nested := func() error {
+			return pool.Run(task, task, task)
+		}
+		assert.NoError(t, pool.Run(nested, nested, nested, nested))
+		// the workers and the caller are the only goroutines running tasks
+		assert.LessOrEqual(t, peak.Load(), int32(3))
+		assert.Equal(t, int32(0), running.Load())
+	}
+
+	// the first error in the order of the tasks is returned
+	pool := NewWorkerPool(4)
+	err := pool.Run(
+		func() error { return nil },
+		func() error { return errors.New("second") },
+		func() error { return errors.New("third") },
+	)
+	assert.EqualError(t, err, "second")
+}
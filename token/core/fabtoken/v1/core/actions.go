@@ -281,6 +281,7 @@ func (t *TransferAction) Validate() error {
 	return nil
 }
 
+// ExtraSigners returns the compliance authorities that authorize the clawback carried by the action, if any
 func (t *TransferAction) ExtraSigners() []driver.Identity {
-	return nil
+	return driver.ClawbackAuthorizers(t.Metadata)
 }
@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package core
 
 import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	pp2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver/protos-go/pp"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
@@ -31,10 +33,31 @@ type PublicParams struct {
 	QuantityPrecision uint64
 	// This is set when audit is enabled
 	Auditor []byte
+	// AdditionalAuditors encodes the list of authorized auditors besides Auditor
+	AdditionalAuditors []driver.Identity
+	// AuditorsQuorum is the minimum number of auditors that must sign a token request.
+	// Zero means that all auditors must sign.
+	AuditorsQuorum uint64
 	// This encodes the list of authorized issuers
 	IssuerIDs []driver.Identity
 	// MaxToken is the maximum quantity a token can hold
 	MaxToken uint64
+	// TypeIssuers binds token types, or prefixes of token types, to the issuers authorized to issue them.
+	// If not empty, only the listed token types can be issued.
+	TypeIssuers []*driver.TokenTypeIssuers
+	// MaxSupply binds token types to their maximum circulating supply.
+	// The token types not listed here have no supply cap.
+	MaxSupply map[token.Type]uint64
+	// AdminIDs encodes the list of identities authorized to sign administrative actions
+	AdminIDs []driver.Identity
+	// ComplianceIDs encodes the list of identities authorized to freeze, unfreeze, and claw back tokens
+	ComplianceIDs []driver.Identity
+	// ComplianceQuorum is the minimum number of compliance authorities that must authorize a clawback.
+	// Zero means that all compliance authorities must.
+	ComplianceQuorum uint64
+	// AdministratorsQuorum is the minimum number of administrators that must sign an administrative action.
+	// Zero means that all administrators must sign.
+	AdministratorsQuorum uint64
 }
 
 // Setup initializes PublicParams
@@ -131,9 +154,25 @@ func (pp *PublicParams) AuditorIdentity() driver.Identity {
 	return pp.Auditor
 }
 
-// AddAuditor sets the Auditor field in PublicParams to the passed identity
+// AddAuditor adds the passed auditor to PublicParams.
+// The first auditor is stored in the Auditor field, the others in AdditionalAuditors.
 func (pp *PublicParams) AddAuditor(auditor driver.Identity) {
-	pp.Auditor = auditor
+	if len(pp.Auditor) == 0 {
+		pp.Auditor = auditor
+		return
+	}
+	pp.AdditionalAuditors = append(pp.AdditionalAuditors, auditor)
+}
+
+// SetAuditorsThreshold sets the minimum number of auditors that must sign a token request.
+// Zero means that all auditors must sign.
+func (pp *PublicParams) SetAuditorsThreshold(threshold uint64) {
+	pp.AuditorsQuorum = threshold
+}
+
+// AuditorsThreshold returns the minimum number of auditors that must sign a token request
+func (pp *PublicParams) AuditorsThreshold() uint64 {
+	return common.AuditorsThreshold(len(pp.Auditors()), pp.AuditorsQuorum)
 }
 
 // AddIssuer adds the passed issuer to the array of Issuers in PublicParams
@@ -142,17 +181,84 @@ func (pp *PublicParams) AddIssuer(issuer driver.Identity) {
 }
 
 // Auditors returns the list of authorized auditors
-// fabtoken only supports a single auditor
 func (pp *PublicParams) Auditors() []driver.Identity {
 	if len(pp.Auditor) == 0 {
 		return []driver.Identity{}
 	}
-	return []driver.Identity{pp.Auditor}
+	return append([]driver.Identity{pp.Auditor}, pp.AdditionalAuditors...)
 }
 
-// Issuers returns the list of authorized issuers
+// AddTokenTypeIssuers binds the passed token type, or prefix of token types, to the passed issuers
+func (pp *PublicParams) AddTokenTypeIssuers(tokenType token.Type, issuers ...driver.Identity) {
+	pp.TypeIssuers = append(pp.TypeIssuers, &driver.TokenTypeIssuers{
+		TokenType: tokenType,
+		Issuers:   issuers,
+	})
+}
+
+// Issuers returns the list of authorized issuers, including those bound to specific token types
 func (pp *PublicParams) Issuers() []driver.Identity {
-	return pp.IssuerIDs
+	return common.AllIssuers(pp.IssuerIDs, pp.TypeIssuers)
+}
+
+// AddAdministrator adds the passed identity to the administrators in PublicParams
+func (pp *PublicParams) AddAdministrator(admin driver.Identity) {
+	pp.AdminIDs = append(pp.AdminIDs, admin)
+}
+
+// Administrators returns the list of identities authorized to sign administrative actions
+func (pp *PublicParams) Administrators() []driver.Identity {
+	return pp.AdminIDs
+}
+
+// SetAdministratorsThreshold sets the minimum number of administrators that must sign an administrative action.
+// Zero means that all administrators must sign.
+func (pp *PublicParams) SetAdministratorsThreshold(threshold uint64) {
+	pp.AdministratorsQuorum = threshold
+}
+
+// AdministratorsThreshold returns the minimum number of administrators that must sign an administrative action
+func (pp *PublicParams) AdministratorsThreshold() uint64 {
+	return common.AdministratorsThreshold(len(pp.AdminIDs), pp.AdministratorsQuorum)
+}
+
+// AddComplianceAuthority adds the passed identity to the compliance authorities in PublicParams
+func (pp *PublicParams) AddComplianceAuthority(id driver.Identity) {
+	pp.ComplianceIDs = append(pp.ComplianceIDs, id)
+}
+
+// ComplianceAuthorities returns the list of identities authorized to freeze, unfreeze, and claw back tokens
+func (pp *PublicParams) ComplianceAuthorities() []driver.Identity {
+	return pp.ComplianceIDs
+}
+
+// SetComplianceThreshold sets the minimum number of compliance authorities that must authorize a clawback.
+// Zero means that all compliance authorities must.
+func (pp *PublicParams) SetComplianceThreshold(threshold uint64) {
+	pp.ComplianceQuorum = threshold
+}
+
+// ComplianceThreshold returns the minimum number of compliance authorities that must authorize a clawback
+func (pp *PublicParams) ComplianceThreshold() uint64 {
+	return common.ComplianceThreshold(len(pp.ComplianceIDs), pp.ComplianceQuorum)
+}
+
+// TokenTypeIssuers returns the token types that can be issued, each bound to its authorized issuers
+func (pp *PublicParams) TokenTypeIssuers() []*driver.TokenTypeIssuers {
+	return pp.TypeIssuers
+}
+
+// SetSupplyCap sets the maximum circulating supply of the passed token type
+func (pp *PublicParams) SetSupplyCap(tokenType token.Type, supplyCap uint64) {
+	if pp.MaxSupply == nil {
+		pp.MaxSupply = map[token.Type]uint64{}
+	}
+	pp.MaxSupply[tokenType] = supplyCap
+}
+
+// SupplyCaps returns the maximum circulating supply of the token types that have one
+func (pp *PublicParams) SupplyCaps() map[token.Type]uint64 {
+	return pp.MaxSupply
 }
 
 // Precision returns the quantity precision encoded in PublicParams
@@ -172,6 +278,24 @@ func (pp *PublicParams) Validate() error {
 	if pp.MaxToken > maxTokenValue {
 		return errors.Errorf("max token value is invalid [%d]>[%d]", pp.MaxToken, maxTokenValue)
 	}
+	if err := common.ValidateTokenTypeIssuers(pp.TypeIssuers); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if len(pp.Auditor) == 0 && len(pp.AdditionalAuditors) != 0 {
+		return errors.New("invalid public parameters: additional auditors set without an auditor")
+	}
+	if err := common.ValidateAuditors(pp.Auditors(), pp.AuditorsQuorum); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := common.ValidateComplianceAuthorities(pp.ComplianceIDs, pp.ComplianceQuorum); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := common.ValidateAdministrators(pp.AdminIDs, pp.AdministratorsQuorum); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := common.ValidateSupplyCaps(pp.MaxSupply); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
 	return nil
 }
 
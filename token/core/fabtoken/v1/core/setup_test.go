@@ -69,3 +69,65 @@ func TestPublicParams_Validate_InvalidMaxToken(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "max token value is invalid [4294967296]>[4294967295]", err.Error())
 }
+
+func TestNewPublicParamsFromBytes_TokenTypeIssuers(t *testing.T) {
+	pp, err := Setup(32)
+	assert.NoError(t, err)
+	pp.AddTokenTypeIssuers("USD", []byte("usd issuer"))
+	pp.AddTokenTypeIssuers("EUR*")
+	assert.NoError(t, pp.Validate())
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(raw, "fabtoken")
+	assert.NoError(t, err)
+	assert.Equal(t, pp.TokenTypeIssuers(), pp2.TokenTypeIssuers())
+
+	pp2.AddTokenTypeIssuers("E*R")
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: invalid token type issuers at [2]: the wildcard can only appear at the end of [E*R]")
+}
+
+func TestNewPublicParamsFromBytes_Auditors(t *testing.T) {
+	pp, err := Setup(32)
+	assert.NoError(t, err)
+	assert.Empty(t, pp.Auditors())
+	assert.Equal(t, uint64(0), pp.AuditorsThreshold())
+
+	pp.AddAuditor([]byte("auditor1"))
+	assert.Equal(t, uint64(1), pp.AuditorsThreshold())
+	pp.AddAuditor([]byte("auditor2"))
+	pp.AddAuditor([]byte("auditor3"))
+	assert.Equal(t, uint64(3), pp.AuditorsThreshold())
+	pp.SetAuditorsThreshold(2)
+	assert.NoError(t, pp.Validate())
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(raw, "fabtoken")
+	assert.NoError(t, err)
+	assert.Len(t, pp2.Auditors(), 3)
+	assert.Equal(t, pp.Auditors(), pp2.Auditors())
+	assert.Equal(t, uint64(2), pp2.AuditorsThreshold())
+
+	pp2.SetAuditorsThreshold(4)
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: invalid auditors threshold [4], must be at most the number of auditors [3]")
+	pp2.SetAuditorsThreshold(1)
+	pp2.AddAuditor([]byte("auditor2"))
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: invalid auditors: duplicate auditor at [3]")
+}
+
+func TestNewPublicParamsFromBytes_SupplyCaps(t *testing.T) {
+	pp, err := Setup(32)
+	assert.NoError(t, err)
+	assert.Empty(t, pp.SupplyCaps())
+
+	pp.SetSupplyCap("USD", 1000)
+	pp.SetSupplyCap("EUR", 500)
+	assert.NoError(t, pp.Validate())
+	raw, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(raw, "fabtoken")
+	assert.NoError(t, err)
+	assert.Equal(t, pp.SupplyCaps(), pp2.SupplyCaps())
+
+	pp2.SetSupplyCap("GOLD", 0)
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: invalid supply cap for token type [GOLD], must be greater than 0")
+}
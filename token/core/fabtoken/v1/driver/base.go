@@ -34,9 +34,17 @@ func (d *base) PublicParametersFromBytes(params []byte) (driver.PublicParameters
 }
 
 func (d *base) DefaultValidator(pp driver.PublicParameters) (driver.Validator, error) {
+	return d.newValidator(pp, 0), nil
+}
+
+// newValidator returns a validator for the passed public parameters with a worker pool of the passed size,
+// see common.Validator.Parallelism
+func (d *base) newValidator(pp driver.PublicParameters, parallelism int) *validator.Validator {
 	logger := logging.DriverLoggerFromPP("token-sdk.driver.fabtoken", pp.Identifier())
 	deserializer := NewDeserializer()
-	return validator.NewValidator(logger, pp.(*core2.PublicParams), deserializer), nil
+	v := validator.NewValidator(logger, pp.(*core2.PublicParams), deserializer)
+	v.Parallelism = parallelism
+	return v
 }
 
 func (d *base) newWalletService(
@@ -51,14 +51,17 @@ func NewValidator(logger logging.Logger, pp *core.PublicParams, deserializer dri
 		TransferSignatureValidate,
 		TransferBalanceValidate,
 		TransferHTLCValidate,
+		TransferTimelockValidate,
+		TransferSupplyValidate,
 	}
 	transferValidators = append(transferValidators, extraValidators...)
 
 	issueValidators := []ValidateIssueFunc{
 		IssueValidate,
+		IssueSupplyValidate,
 	}
 
-	return common.NewValidator[*core.PublicParams, *core.Output, *core.TransferAction, *core.IssueAction, driver.Deserializer](
+	v := common.NewValidator[*core.PublicParams, *core.Output, *core.TransferAction, *core.IssueAction, driver.Deserializer](
 		logger,
 		pp,
 		deserializer,
@@ -66,4 +69,10 @@ func NewValidator(logger logging.Logger, pp *core.PublicParams, deserializer dri
 		transferValidators,
 		issueValidators,
 	)
+	v.ClawbackValidators = []ValidateTransferFunc{
+		TransferActionValidate,
+		TransferClawbackValidate,
+		TransferBalanceValidate,
+	}
+	return v
 }
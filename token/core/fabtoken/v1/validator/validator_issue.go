@@ -7,8 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package validator
 
 import (
-	"bytes"
-
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/core"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
@@ -37,21 +36,18 @@ func IssueValidate(ctx *Context) error {
 		}
 	}
 
-	issuers := ctx.PP.IssuerIDs
-	if len(issuers) != 0 {
-		// check that issuer of this issue action is authorized
-		found := false
-		for _, issuer := range issuers {
-			if bytes.Equal(action.Issuer, issuer) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return errors.Errorf("issuer [%s] is not in issuers", action.Issuer.String())
+	// check that the issuer of this issue action is authorized to issue the output token types
+	for _, output := range action.GetOutputs() {
+		out := output.(*core.Output)
+		if err := common.IsAuthorizedIssuer(ctx.PP.IssuerIDs, ctx.PP.TypeIssuers, out.Type, action.Issuer); err != nil {
+			return errors.Wrapf(err, "issuer [%s] is not authorized", action.Issuer.String())
 		}
 	}
 
+	if ctx.RevocationList.IsIssuerRevoked(action.Issuer) {
+		return errors.Errorf("issuer [%s] has been revoked", action.Issuer.String())
+	}
+
 	// deserialize verifier for the issuer
 	verifier, err := ctx.Deserializer.GetIssuerVerifier(action.Issuer)
 	if err != nil {
@@ -63,3 +59,22 @@ func IssueValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// IssueSupplyValidate checks that the issue action does not make the circulating supply of a token type exceed its supply cap
+func IssueSupplyValidate(ctx *Context) error {
+	supplyCaps := ctx.PP.SupplyCaps()
+	if len(supplyCaps) == 0 {
+		return nil
+	}
+	for _, output := range ctx.IssueAction.GetOutputs() {
+		out := output.(*core.Output)
+		q, err := token.ToQuantity(out.Quantity, ctx.PP.QuantityPrecision)
+		if err != nil {
+			return errors.Wrapf(err, "failed parsing quantity [%s]", out.Quantity)
+		}
+		if err := common.IncreaseSupply(ctx.Ledger, ctx.Attributes, supplyCaps, out.Type, q.ToBigInt().Uint64()); err != nil {
+			return errors.Wrapf(err, "failed checking supply cap")
+		}
+	}
+	return nil
+}
@@ -9,12 +9,15 @@ package validator
 import (
 	"time"
 
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/core"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	htlc2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	timelock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
@@ -44,6 +47,28 @@ func TransferSignatureValidate(ctx *Context) error {
 	return nil
 }
 
+// TransferClawbackValidate checks that the transfer action is a clawback authorized by a quorum of the compliance authorities,
+// and that all its outputs go to the recovery owner.
+// It replaces TransferSignatureValidate, the owners of the inputs do not sign a clawback.
+func TransferClawbackValidate(ctx *Context) error {
+	clawback, key, err := common.VerifyClawback(ctx.PP, ctx.Deserializer, ctx.SignatureProvider, ctx.TransferAction)
+	if err != nil {
+		return err
+	}
+	for i, output := range ctx.TransferAction.GetOutputs() {
+		out, ok := output.(*core.Output)
+		if !ok {
+			return errors.New("invalid output")
+		}
+		if out.IsRedeem() || !clawback.RecoveryOwner.Equal(out.Owner) {
+			return errors.Errorf("invalid clawback: output at [%d] does not go to the recovery owner", i)
+		}
+	}
+	ctx.InputTokens = ctx.TransferAction.InputTokens
+	ctx.CountMetadataKey(key)
+	return nil
+}
+
 // TransferBalanceValidate checks that the sum of the inputs is equal to the sum of the outputs
 func TransferBalanceValidate(ctx *Context) error {
 	if ctx.TransferAction.NumOutputs() == 0 {
@@ -172,3 +197,94 @@ func TransferHTLCValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// TransferTimelockValidate checks the validity of the timelock scripts, if any
+func TransferTimelockValidate(ctx *Context) error {
+	now := time.Now()
+
+	for _, in := range ctx.InputTokens {
+		owner, err := identity.UnmarshalTypedIdentity(in.GetOwner())
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal owner of input token")
+		}
+		// is it owned by a timelock script?
+		if owner.Type != timelock.ScriptType {
+			continue
+		}
+		// Then, the only output must be compatible with this input.
+		if len(ctx.InputTokens) != 1 || len(ctx.TransferAction.GetOutputs()) != 1 {
+			return errors.New("invalid transfer action: a timelock script only transfers the ownership of a token")
+		}
+
+		// check type and quantity
+		output := ctx.TransferAction.GetOutputs()[0].(*core.Output)
+		if in.Type != output.Type {
+			return errors.New("invalid transfer action: type of input does not match type of output")
+		}
+		if in.Quantity != output.Quantity {
+			return errors.New("invalid transfer action: quantity of input does not match quantity of output")
+		}
+		if output.IsRedeem() {
+			return errors.New("invalid transfer action: the output corresponding to a timelock spending should not be a redeem")
+		}
+
+		// check owner field and release conditions
+		blockHeight, err := common.GetBlockHeight(ctx.Attributes)
+		if err != nil {
+			return err
+		}
+		if _, err := timelock2.VerifyOwner(in.GetOwner(), output.Owner, now, blockHeight); err != nil {
+			return errors.Wrap(err, "failed to verify transfer from timelock script")
+		}
+	}
+
+	for _, o := range ctx.TransferAction.GetOutputs() {
+		out, ok := o.(*core.Output)
+		if !ok {
+			return errors.New("invalid output")
+		}
+		if out.IsRedeem() {
+			continue
+		}
+		owner, err := identity.UnmarshalTypedIdentity(out.Owner)
+		if err != nil {
+			return err
+		}
+		if owner.Type == timelock.ScriptType {
+			script := &timelock.Script{}
+			if err := script.FromBytes(owner.Identity); err != nil {
+				return err
+			}
+			if err := script.Validate(now); err != nil {
+				return errors.WithMessagef(err, "timelock script invalid")
+			}
+			// the release of a token locked until a block height can be checked only if the network passes the height
+			if script.BlockHeight != 0 && !common.IsBlockHeightAvailable(ctx.Attributes) {
+				return errors.Errorf("timelock script invalid: locked until block height [%d], but the block height is not available", script.BlockHeight)
+			}
+		}
+	}
+	return nil
+}
+
+// TransferSupplyValidate subtracts the redeemed tokens from the circulating supply of their type, if the type has a supply cap
+func TransferSupplyValidate(ctx *Context) error {
+	supplyCaps := ctx.PP.SupplyCaps()
+	if len(supplyCaps) == 0 {
+		return nil
+	}
+	for _, output := range ctx.TransferAction.GetOutputs() {
+		out := output.(*core.Output)
+		if !out.IsRedeem() {
+			continue
+		}
+		q, err := token.ToQuantity(out.Quantity, ctx.PP.QuantityPrecision)
+		if err != nil {
+			return errors.Wrapf(err, "failed parsing quantity [%s]", out.Quantity)
+		}
+		if err := common.DecreaseSupply(ctx.Ledger, ctx.Attributes, supplyCaps, out.Type, q.ToBigInt().Uint64()); err != nil {
+			return errors.Wrapf(err, "failed updating supply")
+		}
+	}
+	return nil
+}
@@ -5,7 +5,7 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.33.0
+// 	protoc-gen-go v1.36.1
 // 	protoc        v5.28.1
 // source: noghactions.proto
 
@@ -30,21 +30,18 @@ const (
 )
 
 type Token struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         []byte                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"` // Owner is the owner of the token
+	Data          *math.G1               `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`   // Data is the Pedersen commitment to type and value
 	unknownFields protoimpl.UnknownFields
-
-	Owner []byte   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"` // Owner is the owner of the token
-	Data  *math.G1 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`   // Data is the Pedersen commitment to type and value
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Token) Reset() {
 	*x = Token{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Token) String() string {
@@ -55,7 +52,7 @@ func (*Token) ProtoMessage() {}
 
 func (x *Token) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -85,23 +82,20 @@ func (x *Token) GetData() *math.G1 {
 }
 
 type TokenMetadata struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Type           string       `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                           // Type is the type of the token
-	Value          *math.Zr     `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`                                         // Value is the quantity of the token
-	BlindingFactor *math.Zr     `protobuf:"bytes,3,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"` // BlindingFactor is the blinding factor used to commit type and value
-	Issuer         *pp.Identity `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`                                       // Issuer is the issuer of the token, if defined
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                           // Type is the type of the token
+	Value          *math.Zr               `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`                                         // Value is the quantity of the token
+	BlindingFactor *math.Zr               `protobuf:"bytes,3,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"` // BlindingFactor is the blinding factor used to commit type and value
+	Issuer         *pp.Identity           `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`                                       // Issuer is the issuer of the token, if defined
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TokenMetadata) Reset() {
 	*x = TokenMetadata{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TokenMetadata) String() string {
@@ -112,7 +106,7 @@ func (*TokenMetadata) ProtoMessage() {}
 
 func (x *TokenMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -156,21 +150,18 @@ func (x *TokenMetadata) GetIssuer() *pp.Identity {
 }
 
 type TokenID struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Index         uint64                 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Index uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TokenID) Reset() {
 	*x = TokenID{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TokenID) String() string {
@@ -181,7 +172,7 @@ func (*TokenID) ProtoMessage() {}
 
 func (x *TokenID) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -211,22 +202,19 @@ func (x *TokenID) GetIndex() uint64 {
 }
 
 type TransferActionInput struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
+	state          protoimpl.MessageState             `protogen:"open.v1"`
 	TokenId        *TokenID                           `protobuf:"bytes,1,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
 	Input          *Token                             `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
 	UpgradeWitness *TransferActionInputUpgradeWitness `protobuf:"bytes,3,opt,name=upgrade_witness,json=upgradeWitness,proto3" json:"upgrade_witness,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TransferActionInput) Reset() {
 	*x = TransferActionInput{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransferActionInput) String() string {
@@ -237,7 +225,7 @@ func (*TransferActionInput) ProtoMessage() {}
 
 func (x *TransferActionInput) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -274,21 +262,18 @@ func (x *TransferActionInput) GetUpgradeWitness() *TransferActionInputUpgradeWit
 }
 
 type TransferActionInputUpgradeWitness struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Output         *actions.Token `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
-	BlindingFactor *math.Zr       `protobuf:"bytes,2,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"`
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Output         *actions.Token         `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+	BlindingFactor *math.Zr               `protobuf:"bytes,2,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TransferActionInputUpgradeWitness) Reset() {
 	*x = TransferActionInputUpgradeWitness{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransferActionInputUpgradeWitness) String() string {
@@ -299,7 +284,7 @@ func (*TransferActionInputUpgradeWitness) ProtoMessage() {}
 
 func (x *TransferActionInputUpgradeWitness) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -329,20 +314,17 @@ func (x *TransferActionInputUpgradeWitness) GetBlindingFactor() *math.Zr {
 }
 
 type TransferActionOutput struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *Token                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Token is the new token
 	unknownFields protoimpl.UnknownFields
-
-	Token *Token `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Token is the new token
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransferActionOutput) Reset() {
 	*x = TransferActionOutput{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransferActionOutput) String() string {
@@ -353,7 +335,7 @@ func (*TransferActionOutput) ProtoMessage() {}
 
 func (x *TransferActionOutput) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -376,20 +358,17 @@ func (x *TransferActionOutput) GetToken() *Token {
 }
 
 type Proof struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Proof         []byte                 `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Proof []byte `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Proof) Reset() {
 	*x = Proof{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Proof) String() string {
@@ -400,7 +379,7 @@ func (*Proof) ProtoMessage() {}
 
 func (x *Proof) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -423,23 +402,20 @@ func (x *Proof) GetProof() []byte {
 }
 
 type TransferAction struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Inputs        []*TransferActionInput  `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                               // inputs
+	Outputs       []*TransferActionOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                             // outputs
+	Proof         *Proof                  `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                 // ZK Proof that shows that the transfer is correct
+	Metadata      map[string][]byte       `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Metadata contains the transfer action's metadata
 	unknownFields protoimpl.UnknownFields
-
-	Inputs   []*TransferActionInput  `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                                             // inputs
-	Outputs  []*TransferActionOutput `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // outputs
-	Proof    *Proof                  `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                               // ZK Proof that shows that the transfer is correct
-	Metadata map[string][]byte       `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata contains the transfer action's metadata
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TransferAction) Reset() {
 	*x = TransferAction{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *TransferAction) String() string {
@@ -450,7 +426,7 @@ func (*TransferAction) ProtoMessage() {}
 
 func (x *TransferAction) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -494,21 +470,18 @@ func (x *TransferAction) GetMetadata() map[string][]byte {
 }
 
 type IssueActionInput struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *TokenID               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // is the token id of the token to be redeemed
+	Token         []byte                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // is the actual token to be redeemed
 	unknownFields protoimpl.UnknownFields
-
-	Id    *TokenID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // is the token id of the token to be redeemed
-	Token []byte   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // is the actual token to be redeemed
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *IssueActionInput) Reset() {
 	*x = IssueActionInput{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[8]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *IssueActionInput) String() string {
@@ -519,7 +492,7 @@ func (*IssueActionInput) ProtoMessage() {}
 
 func (x *IssueActionInput) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[8]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -549,20 +522,17 @@ func (x *IssueActionInput) GetToken() []byte {
 }
 
 type IssueActionOutput struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         *Token                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // is the newly issued token
 	unknownFields protoimpl.UnknownFields
-
-	Token *Token `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // is the newly issued token
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *IssueActionOutput) Reset() {
 	*x = IssueActionOutput{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[9]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *IssueActionOutput) String() string {
@@ -573,7 +543,7 @@ func (*IssueActionOutput) ProtoMessage() {}
 
 func (x *IssueActionOutput) ProtoReflect() protoreflect.Message {
 	mi := &file_noghactions_proto_msgTypes[9]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -595,25 +565,75 @@ func (x *IssueActionOutput) GetToken() *Token {
 	return nil
 }
 
+type IssueActionTypeOpening struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                           // is the type of the issued tokens
+	BlindingFactor *math.Zr               `protobuf:"bytes,2,opt,name=blinding_factor,json=blindingFactor,proto3" json:"blinding_factor,omitempty"` // is the blinding factor of the commitment to type carried by the issue proof
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *IssueActionTypeOpening) Reset() {
+	*x = IssueActionTypeOpening{}
+	mi := &file_noghactions_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueActionTypeOpening) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueActionTypeOpening) ProtoMessage() {}
+
+func (x *IssueActionTypeOpening) ProtoReflect() protoreflect.Message {
+	mi := &file_noghactions_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueActionTypeOpening.ProtoReflect.Descriptor instead.
+func (*IssueActionTypeOpening) Descriptor() ([]byte, []int) {
+	return file_noghactions_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *IssueActionTypeOpening) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *IssueActionTypeOpening) GetBlindingFactor() *math.Zr {
+	if x != nil {
+		return x.BlindingFactor
+	}
+	return nil
+}
+
 type IssueAction struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Issuer        *pp.Identity            `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`                                                                               // is the identity of issuer
+	Inputs        []*IssueActionInput     `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                               // are the tokens to be redeemed by this issue action
+	Outputs       []*IssueActionOutput    `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                             // are the newly issued tokens
+	Proof         *Proof                  `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                 // carries the ZKP of IssueAction validity
+	Metadata      map[string][]byte       `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Metadata of the issue action
+	TypeOpening   *IssueActionTypeOpening `protobuf:"bytes,6,opt,name=type_opening,json=typeOpening,proto3" json:"type_opening,omitempty"`                                                  // discloses the type of the issued tokens, if required by the public parameters
 	unknownFields protoimpl.UnknownFields
-
-	Issuer   *pp.Identity         `protobuf:"bytes,1,opt,name=issuer,proto3" json:"issuer,omitempty"`                                                                                             // is the identity of issuer
-	Inputs   []*IssueActionInput  `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`                                                                                             // are the tokens to be redeemed by this issue action
-	Outputs  []*IssueActionOutput `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`                                                                                           // are the newly issued tokens
-	Proof    *Proof               `protobuf:"bytes,4,opt,name=proof,proto3" json:"proof,omitempty"`                                                                                               // carries the ZKP of IssueAction validity
-	Metadata map[string][]byte    `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Metadata of the issue action
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *IssueAction) Reset() {
 	*x = IssueAction{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghactions_proto_msgTypes[10]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghactions_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *IssueAction) String() string {
@@ -623,8 +643,8 @@ func (x *IssueAction) String() string {
 func (*IssueAction) ProtoMessage() {}
 
 func (x *IssueAction) ProtoReflect() protoreflect.Message {
-	mi := &file_noghactions_proto_msgTypes[10]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_noghactions_proto_msgTypes[11]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -636,7 +656,7 @@ func (x *IssueAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IssueAction.ProtoReflect.Descriptor instead.
 func (*IssueAction) Descriptor() ([]byte, []int) {
-	return file_noghactions_proto_rawDescGZIP(), []int{10}
+	return file_noghactions_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *IssueAction) GetIssuer() *pp.Identity {
@@ -674,6 +694,13 @@ func (x *IssueAction) GetMetadata() map[string][]byte {
 	return nil
 }
 
+func (x *IssueAction) GetTypeOpening() *IssueActionTypeOpening {
+	if x != nil {
+		return x.TypeOpening
+	}
+	return nil
+}
+
 var File_noghactions_proto protoreflect.FileDescriptor
 
 var file_noghactions_proto_rawDesc = []byte{
@@ -749,33 +776,44 @@ var file_noghactions_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74,
 	0x70, 0x75, 0x74, 0x12, 0x21, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb5, 0x02, 0x0a, 0x0b, 0x49, 0x73, 0x73, 0x75, 0x65,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x2e,
-	0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x31,
-	0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x17, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
-	0x73, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05, 0x70,
-	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x3b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73,
-	0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x59,
-	0x5a, 0x57, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70,
-	0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61,
-	0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64, 0x6c,
-	0x6f, 0x67, 0x2f, 0x6e, 0x6f, 0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67,
-	0x6f, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5f, 0x0a, 0x16, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x31, 0x0a, 0x0f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x08, 0x2e,
+	0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x5a, 0x72, 0x52, 0x0e, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x46, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x8b, 0x03, 0x0a, 0x0b, 0x49, 0x73, 0x73, 0x75,
+	0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12,
+	0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12,
+	0x31, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x73, 0x12, 0x21, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x05,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x3b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49,
+	0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x3f, 0x0a, 0x0c, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x6f, 0x70, 0x65, 0x6e, 0x69,
+	0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e,
+	0x49, 0x73, 0x73, 0x75, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x4f,
+	0x70, 0x65, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x4f, 0x70, 0x65, 0x6e,
+	0x69, 0x6e, 0x67, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x4a, 0x04, 0x08, 0x07, 0x10, 0x08, 0x52, 0x0d, 0x75, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x59, 0x5a, 0x57, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d,
+	0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65,
+	0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64, 0x6c, 0x6f, 0x67, 0x2f, 0x6e, 0x6f, 0x67, 0x68, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -790,8 +828,8 @@ func file_noghactions_proto_rawDescGZIP() []byte {
 	return file_noghactions_proto_rawDescData
 }
 
-var file_noghactions_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
-var file_noghactions_proto_goTypes = []interface{}{
+var file_noghactions_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_noghactions_proto_goTypes = []any{
 	(*Token)(nil),                             // 0: nogh.Token
 	(*TokenMetadata)(nil),                     // 1: nogh.TokenMetadata
 	(*TokenID)(nil),                           // 2: nogh.TokenID
@@ -802,41 +840,44 @@ var file_noghactions_proto_goTypes = []interface{}{
 	(*TransferAction)(nil),                    // 7: nogh.TransferAction
 	(*IssueActionInput)(nil),                  // 8: nogh.IssueActionInput
 	(*IssueActionOutput)(nil),                 // 9: nogh.IssueActionOutput
-	(*IssueAction)(nil),                       // 10: nogh.IssueAction
-	nil,                                       // 11: nogh.TransferAction.MetadataEntry
-	nil,                                       // 12: nogh.IssueAction.MetadataEntry
-	(*math.G1)(nil),                           // 13: nogh.G1
-	(*math.Zr)(nil),                           // 14: nogh.Zr
-	(*pp.Identity)(nil),                       // 15: nogh.Identity
-	(*actions.Token)(nil),                     // 16: fabtoken.Token
+	(*IssueActionTypeOpening)(nil),            // 10: nogh.IssueActionTypeOpening
+	(*IssueAction)(nil),                       // 11: nogh.IssueAction
+	nil,                                       // 12: nogh.TransferAction.MetadataEntry
+	nil,                                       // 13: nogh.IssueAction.MetadataEntry
+	(*math.G1)(nil),                           // 14: nogh.G1
+	(*math.Zr)(nil),                           // 15: nogh.Zr
+	(*pp.Identity)(nil),                       // 16: nogh.Identity
+	(*actions.Token)(nil),                     // 17: fabtoken.Token
 }
 var file_noghactions_proto_depIdxs = []int32{
-	13, // 0: nogh.Token.data:type_name -> nogh.G1
-	14, // 1: nogh.TokenMetadata.value:type_name -> nogh.Zr
-	14, // 2: nogh.TokenMetadata.blinding_factor:type_name -> nogh.Zr
-	15, // 3: nogh.TokenMetadata.issuer:type_name -> nogh.Identity
+	14, // 0: nogh.Token.data:type_name -> nogh.G1
+	15, // 1: nogh.TokenMetadata.value:type_name -> nogh.Zr
+	15, // 2: nogh.TokenMetadata.blinding_factor:type_name -> nogh.Zr
+	16, // 3: nogh.TokenMetadata.issuer:type_name -> nogh.Identity
 	2,  // 4: nogh.TransferActionInput.token_id:type_name -> nogh.TokenID
 	0,  // 5: nogh.TransferActionInput.input:type_name -> nogh.Token
 	4,  // 6: nogh.TransferActionInput.upgrade_witness:type_name -> nogh.TransferActionInputUpgradeWitness
-	16, // 7: nogh.TransferActionInputUpgradeWitness.output:type_name -> fabtoken.Token
-	14, // 8: nogh.TransferActionInputUpgradeWitness.blinding_factor:type_name -> nogh.Zr
+	17, // 7: nogh.TransferActionInputUpgradeWitness.output:type_name -> fabtoken.Token
+	15, // 8: nogh.TransferActionInputUpgradeWitness.blinding_factor:type_name -> nogh.Zr
 	0,  // 9: nogh.TransferActionOutput.token:type_name -> nogh.Token
 	3,  // 10: nogh.TransferAction.inputs:type_name -> nogh.TransferActionInput
 	5,  // 11: nogh.TransferAction.outputs:type_name -> nogh.TransferActionOutput
 	6,  // 12: nogh.TransferAction.proof:type_name -> nogh.Proof
-	11, // 13: nogh.TransferAction.metadata:type_name -> nogh.TransferAction.MetadataEntry
+	12, // 13: nogh.TransferAction.metadata:type_name -> nogh.TransferAction.MetadataEntry
 	2,  // 14: nogh.IssueActionInput.id:type_name -> nogh.TokenID
 	0,  // 15: nogh.IssueActionOutput.token:type_name -> nogh.Token
-	15, // 16: nogh.IssueAction.issuer:type_name -> nogh.Identity
-	8,  // 17: nogh.IssueAction.inputs:type_name -> nogh.IssueActionInput
-	9,  // 18: nogh.IssueAction.outputs:type_name -> nogh.IssueActionOutput
-	6,  // 19: nogh.IssueAction.proof:type_name -> nogh.Proof
-	12, // 20: nogh.IssueAction.metadata:type_name -> nogh.IssueAction.MetadataEntry
-	21, // [21:21] is the sub-list for method output_type
-	21, // [21:21] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	15, // 16: nogh.IssueActionTypeOpening.blinding_factor:type_name -> nogh.Zr
+	16, // 17: nogh.IssueAction.issuer:type_name -> nogh.Identity
+	8,  // 18: nogh.IssueAction.inputs:type_name -> nogh.IssueActionInput
+	9,  // 19: nogh.IssueAction.outputs:type_name -> nogh.IssueActionOutput
+	6,  // 20: nogh.IssueAction.proof:type_name -> nogh.Proof
+	13, // 21: nogh.IssueAction.metadata:type_name -> nogh.IssueAction.MetadataEntry
+	10, // 22: nogh.IssueAction.type_opening:type_name -> nogh.IssueActionTypeOpening
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_noghactions_proto_init() }
@@ -844,147 +885,13 @@ func file_noghactions_proto_init() {
 	if File_noghactions_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_noghactions_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Token); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokenMetadata); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokenID); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferActionInput); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferActionInputUpgradeWitness); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferActionOutput); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Proof); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransferAction); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IssueActionInput); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IssueActionOutput); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghactions_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IssueAction); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_noghactions_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
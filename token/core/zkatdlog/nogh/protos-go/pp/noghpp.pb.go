@@ -5,19 +5,18 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.33.0
+// 	protoc-gen-go v1.36.1
 // 	protoc        v5.28.1
 // source: noghpp.proto
 
 package pp
 
 import (
-	reflect "reflect"
-	sync "sync"
-
 	math "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/math"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
 )
 
 const (
@@ -28,20 +27,17 @@ const (
 )
 
 type Identity struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Raw           []byte                 `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Raw []byte `protobuf:"bytes,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Identity) Reset() {
 	*x = Identity{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghpp_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghpp_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *Identity) String() string {
@@ -52,7 +48,7 @@ func (*Identity) ProtoMessage() {}
 
 func (x *Identity) ProtoReflect() protoreflect.Message {
 	mi := &file_noghpp_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -75,21 +71,18 @@ func (x *Identity) GetRaw() []byte {
 }
 
 type IdemixIssuerPublicKey struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublicKey     []byte                 `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	CurverId      *math.CurveID          `protobuf:"bytes,2,opt,name=curver_id,json=curverId,proto3" json:"curver_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	PublicKey []byte        `protobuf:"bytes,1,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
-	CurverId  *math.CurveID `protobuf:"bytes,2,opt,name=curver_id,json=curverId,proto3" json:"curver_id,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *IdemixIssuerPublicKey) Reset() {
 	*x = IdemixIssuerPublicKey{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghpp_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghpp_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *IdemixIssuerPublicKey) String() string {
@@ -100,7 +93,7 @@ func (*IdemixIssuerPublicKey) ProtoMessage() {}
 
 func (x *IdemixIssuerPublicKey) ProtoReflect() protoreflect.Message {
 	mi := &file_noghpp_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -130,25 +123,22 @@ func (x *IdemixIssuerPublicKey) GetCurverId() *math.CurveID {
 }
 
 type RangeProofParams struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	LeftGenerators  []*math.G1 `protobuf:"bytes,1,rep,name=left_generators,json=leftGenerators,proto3" json:"left_generators,omitempty"`
-	RightGenerators []*math.G1 `protobuf:"bytes,2,rep,name=right_generators,json=rightGenerators,proto3" json:"right_generators,omitempty"`
-	P               *math.G1   `protobuf:"bytes,3,opt,name=P,proto3" json:"P,omitempty"`
-	Q               *math.G1   `protobuf:"bytes,4,opt,name=Q,proto3" json:"Q,omitempty"`
-	BitLength       uint64     `protobuf:"varint,5,opt,name=bit_length,json=bitLength,proto3" json:"bit_length,omitempty"`
-	NumberOfRounds  uint64     `protobuf:"varint,6,opt,name=number_of_rounds,json=numberOfRounds,proto3" json:"number_of_rounds,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	LeftGenerators  []*math.G1             `protobuf:"bytes,1,rep,name=left_generators,json=leftGenerators,proto3" json:"left_generators,omitempty"`
+	RightGenerators []*math.G1             `protobuf:"bytes,2,rep,name=right_generators,json=rightGenerators,proto3" json:"right_generators,omitempty"`
+	P               *math.G1               `protobuf:"bytes,3,opt,name=P,proto3" json:"P,omitempty"`
+	Q               *math.G1               `protobuf:"bytes,4,opt,name=Q,proto3" json:"Q,omitempty"`
+	BitLength       uint64                 `protobuf:"varint,5,opt,name=bit_length,json=bitLength,proto3" json:"bit_length,omitempty"`
+	NumberOfRounds  uint64                 `protobuf:"varint,6,opt,name=number_of_rounds,json=numberOfRounds,proto3" json:"number_of_rounds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *RangeProofParams) Reset() {
 	*x = RangeProofParams{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghpp_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghpp_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *RangeProofParams) String() string {
@@ -159,7 +149,7 @@ func (*RangeProofParams) ProtoMessage() {}
 
 func (x *RangeProofParams) ProtoReflect() protoreflect.Message {
 	mi := &file_noghpp_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -216,12 +206,113 @@ func (x *RangeProofParams) GetNumberOfRounds() uint64 {
 	return 0
 }
 
-// PublicParameters describes typed public parameters
-type PublicParameters struct {
-	state         protoimpl.MessageState
+type TokenTypeIssuers struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenType     string                 `protobuf:"bytes,1,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // is a token type, or a prefix of token types if it ends with the wildcard '*'
+	Issuers       []*Identity            `protobuf:"bytes,2,rep,name=issuers,proto3" json:"issuers,omitempty"`                      // is the list of identities authorized to issue tokens of the matching types. If empty, the general list of issuers applies.
+	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenTypeIssuers) Reset() {
+	*x = TokenTypeIssuers{}
+	mi := &file_noghpp_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenTypeIssuers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenTypeIssuers) ProtoMessage() {}
+
+func (x *TokenTypeIssuers) ProtoReflect() protoreflect.Message {
+	mi := &file_noghpp_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenTypeIssuers.ProtoReflect.Descriptor instead.
+func (*TokenTypeIssuers) Descriptor() ([]byte, []int) {
+	return file_noghpp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TokenTypeIssuers) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *TokenTypeIssuers) GetIssuers() []*Identity {
+	if x != nil {
+		return x.Issuers
+	}
+	return nil
+}
+
+// PublicParameters describes typed public parameters
+type SupplyCap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenType     string                 `protobuf:"bytes,1,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"` // is the token type
+	Cap           uint64                 `protobuf:"varint,2,opt,name=cap,proto3" json:"cap,omitempty"`                             // is the maximum circulating supply of the token type
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
+func (x *SupplyCap) Reset() {
+	*x = SupplyCap{}
+	mi := &file_noghpp_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SupplyCap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupplyCap) ProtoMessage() {}
+
+func (x *SupplyCap) ProtoReflect() protoreflect.Message {
+	mi := &file_noghpp_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupplyCap.ProtoReflect.Descriptor instead.
+func (*SupplyCap) Descriptor() ([]byte, []int) {
+	return file_noghpp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SupplyCap) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *SupplyCap) GetCap() uint64 {
+	if x != nil {
+		return x.Cap
+	}
+	return 0
+}
+
+type PublicParameters struct {
+	state                  protoimpl.MessageState   `protogen:"open.v1"`
 	Identifier             string                   `protobuf:"bytes,1,opt,name=identifier,proto3" json:"identifier,omitempty"`                                                           // the identifier of the public parameters
 	Version                string                   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`                                                                 // the version of these public params
 	CurveId                *math.CurveID            `protobuf:"bytes,3,opt,name=curve_id,json=curveId,proto3" json:"curve_id,omitempty"`                                                  // the pairing-friendly elliptic curve used for everything but Idemix.
@@ -232,15 +323,25 @@ type PublicParameters struct {
 	Issuers                []*Identity              `protobuf:"bytes,8,rep,name=issuers,proto3" json:"issuers,omitempty"`                                                                 // is a list of public keys of the entities that can issue tokens.
 	MaxToken               uint64                   `protobuf:"varint,9,opt,name=max_token,json=maxToken,proto3" json:"max_token,omitempty"`                                              // is the maximum quantity a token can hold
 	QuantityPrecision      uint64                   `protobuf:"varint,10,opt,name=quantity_precision,json=quantityPrecision,proto3" json:"quantity_precision,omitempty"`                  // is the precision used to represent quantities
+	TokenTypeIssuers       []*TokenTypeIssuers      `protobuf:"bytes,11,rep,name=token_type_issuers,json=tokenTypeIssuers,proto3" json:"token_type_issuers,omitempty"`                    // binds token types, or prefixes of token types, to the issuers authorized to issue them. If not empty, only the listed token types can be issued.
+	AdditionalAuditors     []*Identity              `protobuf:"bytes,12,rep,name=additional_auditors,json=additionalAuditors,proto3" json:"additional_auditors,omitempty"`                // is a list of public keys of the auditors besides auditor.
+	AuditorsQuorum         uint64                   `protobuf:"varint,13,opt,name=auditors_quorum,json=auditorsQuorum,proto3" json:"auditors_quorum,omitempty"`                           // is the minimum number of auditors that must sign a token request. Zero means that all auditors must sign.
+	SupplyCaps             []*SupplyCap             `protobuf:"bytes,14,rep,name=supply_caps,json=supplyCaps,proto3" json:"supply_caps,omitempty"`                                        // binds token types to their maximum circulating supply. The token types not listed here have no supply cap.
+	Administrators         []*Identity              `protobuf:"bytes,15,rep,name=administrators,proto3" json:"administrators,omitempty"`                                                  // is a list of public keys of the entities that can sign administrative actions, such as the updates of the revocation list.
+	RevocationPk           []byte                   `protobuf:"bytes,16,opt,name=revocation_pk,json=revocationPk,proto3" json:"revocation_pk,omitempty"`                                  // is the PEM encoding of the public key the revocation authority signs, epoch by epoch, the credential revocation information with. If set, the signatures of idemix owners must carry a non-revocation proof.
+	RevocationEpochLength  uint64                   `protobuf:"varint,17,opt,name=revocation_epoch_length,json=revocationEpochLength,proto3" json:"revocation_epoch_length,omitempty"`    // is the number of blocks of an epoch of the revocation authority. The epoch of a transaction is determined by the height of the ledger it is validated at.
+	ComplianceAuthorities  []*Identity              `protobuf:"bytes,18,rep,name=compliance_authorities,json=complianceAuthorities,proto3" json:"compliance_authorities,omitempty"`       // is a list of public keys of the entities that can freeze, unfreeze, and claw back tokens.
+	ComplianceQuorum       uint64                   `protobuf:"varint,19,opt,name=compliance_quorum,json=complianceQuorum,proto3" json:"compliance_quorum,omitempty"`                     // is the minimum number of compliance authorities that must authorize a clawback. Zero means that all of them must.
+	AdministratorsQuorum   uint64                   `protobuf:"varint,20,opt,name=administrators_quorum,json=administratorsQuorum,proto3" json:"administrators_quorum,omitempty"`         // is the minimum number of administrators that must sign an administrative action. Zero means that all of them must.
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
 }
 
 func (x *PublicParameters) Reset() {
 	*x = PublicParameters{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_noghpp_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+	mi := &file_noghpp_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
 func (x *PublicParameters) String() string {
@@ -250,8 +351,8 @@ func (x *PublicParameters) String() string {
 func (*PublicParameters) ProtoMessage() {}
 
 func (x *PublicParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_noghpp_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
+	mi := &file_noghpp_proto_msgTypes[5]
+	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
@@ -263,7 +364,7 @@ func (x *PublicParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PublicParameters.ProtoReflect.Descriptor instead.
 func (*PublicParameters) Descriptor() ([]byte, []int) {
-	return file_noghpp_proto_rawDescGZIP(), []int{3}
+	return file_noghpp_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PublicParameters) GetIdentifier() string {
@@ -336,6 +437,76 @@ func (x *PublicParameters) GetQuantityPrecision() uint64 {
 	return 0
 }
 
+func (x *PublicParameters) GetTokenTypeIssuers() []*TokenTypeIssuers {
+	if x != nil {
+		return x.TokenTypeIssuers
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAdditionalAuditors() []*Identity {
+	if x != nil {
+		return x.AdditionalAuditors
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAuditorsQuorum() uint64 {
+	if x != nil {
+		return x.AuditorsQuorum
+	}
+	return 0
+}
+
+func (x *PublicParameters) GetSupplyCaps() []*SupplyCap {
+	if x != nil {
+		return x.SupplyCaps
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetAdministrators() []*Identity {
+	if x != nil {
+		return x.Administrators
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetRevocationPk() []byte {
+	if x != nil {
+		return x.RevocationPk
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetRevocationEpochLength() uint64 {
+	if x != nil {
+		return x.RevocationEpochLength
+	}
+	return 0
+}
+
+func (x *PublicParameters) GetComplianceAuthorities() []*Identity {
+	if x != nil {
+		return x.ComplianceAuthorities
+	}
+	return nil
+}
+
+func (x *PublicParameters) GetComplianceQuorum() uint64 {
+	if x != nil {
+		return x.ComplianceQuorum
+	}
+	return 0
+}
+
+func (x *PublicParameters) GetAdministratorsQuorum() uint64 {
+	if x != nil {
+		return x.AdministratorsQuorum
+	}
+	return 0
+}
+
 var File_noghpp_proto protoreflect.FileDescriptor
 
 var file_noghpp_proto_rawDesc = []byte{
@@ -364,44 +535,88 @@ var file_noghpp_proto_rawDesc = []byte{
 	0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x69, 0x74, 0x4c, 0x65, 0x6e, 0x67,
 	0x74, 0x68, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f,
 	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0xef, 0x03, 0x0a,
-	0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x08, 0x63,
-	0x75, 0x72, 0x76, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x43, 0x75, 0x72, 0x76, 0x65, 0x49, 0x44, 0x52, 0x07, 0x63, 0x75,
-	0x72, 0x76, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x13, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65,
-	0x6e, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x08, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x12, 0x70, 0x65,
-	0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73,
-	0x12, 0x44, 0x0a, 0x12, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f,
-	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e,
-	0x6f, 0x67, 0x68, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x52, 0x10, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x56, 0x0a, 0x19, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78,
-	0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b,
-	0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
-	0x2e, 0x49, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x52, 0x16, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x49, 0x73,
-	0x73, 0x75, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x28,
-	0x0a, 0x07, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52,
-	0x07, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75,
-	0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68,
-	0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65,
-	0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
-	0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
-	0x2d, 0x0a, 0x12, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x63,
-	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x71, 0x75, 0x61,
-	0x6e, 0x74, 0x69, 0x74, 0x79, 0x50, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x54,
-	0x5a, 0x52, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70,
-	0x65, 0x72, 0x6c, 0x65, 0x64, 0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61,
-	0x62, 0x72, 0x69, 0x63, 0x2d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64, 0x6c,
-	0x6f, 0x67, 0x2f, 0x6e, 0x6f, 0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67,
-	0x6f, 0x2f, 0x70, 0x70, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x22, 0x5b, 0x0a, 0x10,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x28, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x22, 0x3c, 0x0a, 0x09, 0x53, 0x75, 0x70,
+	0x70, 0x6c, 0x79, 0x43, 0x61, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x61, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x63, 0x61, 0x70, 0x22, 0x8f, 0x08, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x76, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e,
+	0x43, 0x75, 0x72, 0x76, 0x65, 0x49, 0x44, 0x52, 0x07, 0x63, 0x75, 0x72, 0x76, 0x65, 0x49, 0x64,
+	0x12, 0x39, 0x0a, 0x13, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65, 0x6e, 0x5f, 0x67, 0x65, 0x6e,
+	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e,
+	0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x47, 0x31, 0x52, 0x12, 0x70, 0x65, 0x64, 0x65, 0x72, 0x73, 0x65,
+	0x6e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x44, 0x0a, 0x12, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52,
+	0x10, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x12, 0x56, 0x0a, 0x19, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x5f, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x72, 0x5f, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6d,
+	0x69, 0x78, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65,
+	0x79, 0x52, 0x16, 0x69, 0x64, 0x65, 0x6d, 0x69, 0x78, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x50,
+	0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x28, 0x0a, 0x07, 0x61, 0x75, 0x64,
+	0x69, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67,
+	0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x61, 0x75, 0x64, 0x69,
+	0x74, 0x6f, 0x72, 0x12, 0x28, 0x0a, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x70, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x50, 0x72, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x12, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x52, 0x10, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x49, 0x73, 0x73, 0x75, 0x65, 0x72, 0x73, 0x12,
+	0x3f, 0x0a, 0x13, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x75,
+	0x64, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e,
+	0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x12, 0x61, 0x64,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x73,
+	0x12, 0x27, 0x0a, 0x0f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x6f, 0x72, 0x73, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x12, 0x30, 0x0a, 0x0b, 0x73, 0x75, 0x70,
+	0x70, 0x6c, 0x79, 0x5f, 0x63, 0x61, 0x70, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x43, 0x61, 0x70, 0x52,
+	0x0a, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x43, 0x61, 0x70, 0x73, 0x12, 0x36, 0x0a, 0x0e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x0f, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x74, 0x79, 0x52, 0x0e, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x70, 0x6b, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x72, 0x65, 0x76, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6b, 0x12, 0x36, 0x0a, 0x17, 0x72, 0x65, 0x76, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x5f, 0x6c, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x72, 0x65, 0x76, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x12, 0x45, 0x0a, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x6e, 0x6f, 0x67, 0x68, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x52, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x70, 0x6c,
+	0x69, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x69, 0x61, 0x6e, 0x63, 0x65, 0x51, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x12, 0x33, 0x0a, 0x15, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x14, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x51, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x42, 0x54, 0x5a, 0x52, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x6c, 0x65, 0x64,
+	0x67, 0x65, 0x72, 0x2d, 0x6c, 0x61, 0x62, 0x73, 0x2f, 0x66, 0x61, 0x62, 0x72, 0x69, 0x63, 0x2d,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2d, 0x73, 0x64, 0x6b, 0x2f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x7a, 0x6b, 0x61, 0x74, 0x64, 0x6c, 0x6f, 0x67, 0x2f, 0x6e, 0x6f,
+	0x67, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x70, 0x70, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -416,32 +631,40 @@ func file_noghpp_proto_rawDescGZIP() []byte {
 	return file_noghpp_proto_rawDescData
 }
 
-var file_noghpp_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
-var file_noghpp_proto_goTypes = []interface{}{
+var file_noghpp_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_noghpp_proto_goTypes = []any{
 	(*Identity)(nil),              // 0: nogh.Identity
 	(*IdemixIssuerPublicKey)(nil), // 1: nogh.IdemixIssuerPublicKey
 	(*RangeProofParams)(nil),      // 2: nogh.RangeProofParams
-	(*PublicParameters)(nil),      // 3: nogh.PublicParameters
-	(*math.CurveID)(nil),          // 4: nogh.CurveID
-	(*math.G1)(nil),               // 5: nogh.G1
+	(*TokenTypeIssuers)(nil),      // 3: nogh.TokenTypeIssuers
+	(*SupplyCap)(nil),             // 4: nogh.SupplyCap
+	(*PublicParameters)(nil),      // 5: nogh.PublicParameters
+	(*math.CurveID)(nil),          // 6: nogh.CurveID
+	(*math.G1)(nil),               // 7: nogh.G1
 }
 var file_noghpp_proto_depIdxs = []int32{
-	4,  // 0: nogh.IdemixIssuerPublicKey.curver_id:type_name -> nogh.CurveID
-	5,  // 1: nogh.RangeProofParams.left_generators:type_name -> nogh.G1
-	5,  // 2: nogh.RangeProofParams.right_generators:type_name -> nogh.G1
-	5,  // 3: nogh.RangeProofParams.P:type_name -> nogh.G1
-	5,  // 4: nogh.RangeProofParams.Q:type_name -> nogh.G1
-	4,  // 5: nogh.PublicParameters.curve_id:type_name -> nogh.CurveID
-	5,  // 6: nogh.PublicParameters.pedersen_generators:type_name -> nogh.G1
-	2,  // 7: nogh.PublicParameters.range_proof_params:type_name -> nogh.RangeProofParams
-	1,  // 8: nogh.PublicParameters.idemix_issuer_public_keys:type_name -> nogh.IdemixIssuerPublicKey
-	0,  // 9: nogh.PublicParameters.auditor:type_name -> nogh.Identity
-	0,  // 10: nogh.PublicParameters.issuers:type_name -> nogh.Identity
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	6,  // 0: nogh.IdemixIssuerPublicKey.curver_id:type_name -> nogh.CurveID
+	7,  // 1: nogh.RangeProofParams.left_generators:type_name -> nogh.G1
+	7,  // 2: nogh.RangeProofParams.right_generators:type_name -> nogh.G1
+	7,  // 3: nogh.RangeProofParams.P:type_name -> nogh.G1
+	7,  // 4: nogh.RangeProofParams.Q:type_name -> nogh.G1
+	0,  // 5: nogh.TokenTypeIssuers.issuers:type_name -> nogh.Identity
+	6,  // 6: nogh.PublicParameters.curve_id:type_name -> nogh.CurveID
+	7,  // 7: nogh.PublicParameters.pedersen_generators:type_name -> nogh.G1
+	2,  // 8: nogh.PublicParameters.range_proof_params:type_name -> nogh.RangeProofParams
+	1,  // 9: nogh.PublicParameters.idemix_issuer_public_keys:type_name -> nogh.IdemixIssuerPublicKey
+	0,  // 10: nogh.PublicParameters.auditor:type_name -> nogh.Identity
+	0,  // 11: nogh.PublicParameters.issuers:type_name -> nogh.Identity
+	3,  // 12: nogh.PublicParameters.token_type_issuers:type_name -> nogh.TokenTypeIssuers
+	0,  // 13: nogh.PublicParameters.additional_auditors:type_name -> nogh.Identity
+	4,  // 14: nogh.PublicParameters.supply_caps:type_name -> nogh.SupplyCap
+	0,  // 15: nogh.PublicParameters.administrators:type_name -> nogh.Identity
+	0,  // 16: nogh.PublicParameters.compliance_authorities:type_name -> nogh.Identity
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_noghpp_proto_init() }
@@ -449,63 +672,13 @@ func file_noghpp_proto_init() {
 	if File_noghpp_proto != nil {
 		return
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_noghpp_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Identity); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghpp_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IdemixIssuerPublicKey); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghpp_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RangeProofParams); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_noghpp_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PublicParameters); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_noghpp_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
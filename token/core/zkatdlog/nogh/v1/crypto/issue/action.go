@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/pp"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/utils"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/upgrade"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
@@ -44,6 +45,51 @@ func (i *ActionInput) FromProtos(p *actions.IssueActionInput) error {
 	return nil
 }
 
+// TypeOpening discloses the type of the tokens issued by an Action
+type TypeOpening struct {
+	// Type is the type of the issued tokens
+	Type token2.Type
+	// BlindingFactor is the blinding factor of the commitment to type carried by the issue proof
+	BlindingFactor *math.Zr
+}
+
+func (o *TypeOpening) ToProtos() (*actions.IssueActionTypeOpening, error) {
+	bf, err := utils.ToProtoZr(o.BlindingFactor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to serialize blinding factor")
+	}
+	return &actions.IssueActionTypeOpening{
+		Type:           string(o.Type),
+		BlindingFactor: bf,
+	}, nil
+}
+
+func (o *TypeOpening) FromProtos(p *actions.IssueActionTypeOpening) error {
+	bf, err := utils.FromZrProto(p.BlindingFactor)
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize blinding factor")
+	}
+	o.Type = token2.Type(p.Type)
+	o.BlindingFactor = bf
+	return nil
+}
+
+// Verify checks that this opening matches the passed commitment to type
+func (o *TypeOpening) Verify(commitmentToType *math.G1, pedersenGenerators []*math.G1, c *math.Curve) error {
+	if o.BlindingFactor == nil {
+		return errors.New("invalid type opening: nil blinding factor")
+	}
+	if commitmentToType == nil {
+		return errors.New("invalid type opening: nil commitment to type")
+	}
+	com := pedersenGenerators[0].Mul(c.HashToZr([]byte(o.Type)))
+	com.Add(pedersenGenerators[2].Mul(o.BlindingFactor))
+	if !com.Equals(commitmentToType) {
+		return errors.Errorf("invalid type opening: commitment to type does not match type [%s]", o.Type)
+	}
+	return nil
+}
+
 // Action specifies an issue of one or more tokens
 type Action struct {
 	// Issuer is the identity of issuer
@@ -56,6 +102,9 @@ type Action struct {
 	Proof []byte
 	// Metadata of the issue action
 	Metadata map[string][]byte
+	// TypeOpening discloses the type of the issued tokens.
+	// It is set only when the public parameters bind token types to issuers.
+	TypeOpening *TypeOpening
 }
 
 // NewAction instantiates an IssueAction given the passed arguments
@@ -172,6 +221,9 @@ func (i *Action) Validate() error {
 		if len(input.ID.TxId) == 0 {
 			return errors.Errorf("nil input id in issue action")
 		}
+		if _, err := upgrade.OwnerOf(input.Token); err != nil {
+			return errors.Wrapf(err, "invalid input token in issue action")
+		}
 	}
 	if len(i.Outputs) == 0 {
 		return errors.Errorf("no outputs in issue action")
@@ -184,8 +236,21 @@ func (i *Action) Validate() error {
 	return nil
 }
 
+// ExtraSigners returns the owners of the upgraded tokens, if any.
+// They sign the token request to agree to the upgrade.
 func (i *Action) ExtraSigners() []driver.Identity {
-	return nil
+	var signers []driver.Identity
+	for _, input := range i.Inputs {
+		if input == nil {
+			continue
+		}
+		owner, err := upgrade.OwnerOf(input.Token)
+		if err != nil {
+			continue
+		}
+		signers = append(signers, owner)
+	}
+	return signers
 }
 
 // Serialize marshal IssueAction
@@ -213,6 +278,15 @@ func (i *Action) Serialize() ([]byte, error) {
 		return nil, errors.Wrap(err, "failed to serialize outputs")
 	}
 
+	// type opening
+	var typeOpening *actions.IssueActionTypeOpening
+	if i.TypeOpening != nil {
+		typeOpening, err = i.TypeOpening.ToProtos()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to serialize type opening")
+		}
+	}
+
 	issueAction := &actions.IssueAction{
 		Issuer: &pp.Identity{
 			Raw: i.Issuer,
@@ -222,7 +296,8 @@ func (i *Action) Serialize() ([]byte, error) {
 		Proof: &actions.Proof{
 			Proof: i.Proof,
 		},
-		Metadata: i.Metadata,
+		Metadata:    i.Metadata,
+		TypeOpening: typeOpening,
 	}
 	return proto.Marshal(issueAction)
 }
@@ -265,6 +340,12 @@ func (i *Action) Deserialize(raw []byte) error {
 		i.Issuer = issueAction.Issuer.Raw
 	}
 	i.Metadata = issueAction.Metadata
+	if issueAction.TypeOpening != nil {
+		i.TypeOpening = &TypeOpening{}
+		if err := i.TypeOpening.FromProtos(issueAction.TypeOpening); err != nil {
+			return errors.Wrap(err, "failed to deserialize type opening")
+		}
+	}
 
 	return nil
 }
@@ -13,6 +13,8 @@ import (
 
 	math "github.com/IBM/mathlib"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/core"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,6 +38,21 @@ func TestSerialization(t *testing.T) {
 	assert.Equal(t, action2, action3, "deserialized action is not equal to the original one")
 }
 
+func TestExtraSigners(t *testing.T) {
+	action := randomAction(math.Curves[math.BN254], rand.Reader, t)
+	assert.Empty(t, action.ExtraSigners())
+
+	// the owners of the upgraded tokens sign the request
+	owners := []driver.Identity{[]byte("alice"), []byte("bob")}
+	for i, owner := range owners {
+		raw, err := (&core.Output{Owner: owner, Type: "USD", Quantity: "0x0a"}).Serialize()
+		assert.NoError(t, err)
+		action.Inputs[i].Token = raw
+	}
+	assert.NoError(t, action.Validate())
+	assert.Equal(t, owners, action.ExtraSigners())
+}
+
 func BenchmarkActionMarshalling(b *testing.B) {
 	curve := math.Curves[math.BN254]
 
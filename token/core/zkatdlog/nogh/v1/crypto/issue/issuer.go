@@ -69,6 +69,13 @@ func (i *Issuer) GenerateZKIssue(values []uint64, owners [][]byte) (*Action, []*
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(i.PublicParams.TypeIssuers) != 0 {
+		// the public parameters bind token types to issuers, the validator needs to know the type
+		issue.TypeOpening = &TypeOpening{
+			Type:           i.Type,
+			BlindingFactor: prover.SameType.blindingFactor,
+		}
+	}
 
 	inf := make([]*token.Metadata, len(values))
 	for j := 0; j < len(inf); j++ {
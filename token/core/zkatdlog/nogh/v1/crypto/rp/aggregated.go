@@ -0,0 +1,458 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rp
+
+import (
+	"math/bits"
+
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/common"
+	"github.com/pkg/errors"
+)
+
+// MaxAggregationSize is the maximum number of values an aggregated range proof can cover
+const MaxAggregationSize = 128
+
+// GeneratorsFunc returns the left and right generators needed to prove or verify
+// an aggregated range proof whose vectors have the passed size
+type GeneratorsFunc func(size uint64) ([]*math.G1, []*math.G1, error)
+
+// AggregationSize returns the number of values covered by an aggregated range proof for the passed number of values.
+// This is the smallest power of two greater or equal to the passed number, the missing values are set to zero.
+func AggregationSize(values int) uint64 {
+	if values <= 1 {
+		return 1
+	}
+	return 1 << bits.Len64(uint64(values-1))
+}
+
+// aggregatedRangeProver produces a single RangeProof that shows that each of the committed values is < 2^BitLength.
+// For a single value, the proof is the same as the one produced by the rangeProver.
+type aggregatedRangeProver struct {
+	// Commitments are hiding Pedersen commitments to Values: Commitments[j] = G^Values[j]H^BlindingFactors[j]
+	Commitments []*math.G1
+	// Values are the committed values
+	Values []uint64
+	// BlindingFactors are the randomness used to compute Commitments
+	BlindingFactors []*math.Zr
+	// CommitmentGenerators are the generators (G, H) used to compute Commitments
+	CommitmentGenerators []*math.G1
+	// LeftGenerators are the generators used to commit to the bits of all values
+	LeftGenerators []*math.G1
+	// RightGenerators are the generators used to commit to the bits of all values minus one
+	RightGenerators []*math.G1
+	// P is a random generator of G1
+	P *math.G1
+	// Q is a random generator of G1
+	Q *math.G1
+	// NumberOfRounds is log_2 of the size of the generators
+	NumberOfRounds uint64
+	// BitLength is the size of the binary representation of each value
+	BitLength uint64
+	// Curve is the curve over which the computation is performed
+	Curve *math.Curve
+}
+
+// NewAggregatedRangeProver returns a prover of an aggregated range proof for the passed commitments.
+// The passed generators must contain at least BitLength*AggregationSize(len(coms)) elements.
+func NewAggregatedRangeProver(
+	coms []*math.G1,
+	values []uint64,
+	blindingFactors []*math.Zr,
+	commitmentGen []*math.G1,
+	leftGen []*math.G1,
+	rightGen []*math.G1,
+	P, Q *math.G1,
+	bitLength uint64,
+	curve *math.Curve,
+) (*aggregatedRangeProver, error) {
+	if len(coms) == 0 || len(coms) != len(values) || len(coms) != len(blindingFactors) {
+		return nil, errors.New("invalid aggregated range proof witness")
+	}
+	leftGen, rightGen, rounds, err := aggregationGenerators(len(coms), leftGen, rightGen, bitLength)
+	if err != nil {
+		return nil, err
+	}
+	return &aggregatedRangeProver{
+		Commitments:          coms,
+		Values:               values,
+		BlindingFactors:      blindingFactors,
+		CommitmentGenerators: commitmentGen,
+		LeftGenerators:       leftGen,
+		RightGenerators:      rightGen,
+		P:                    P,
+		Q:                    Q,
+		NumberOfRounds:       rounds,
+		BitLength:            bitLength,
+		Curve:                curve,
+	}, nil
+}
+
+// Prove produces a RangeProof that shows that each committed value
+// v_j = \sum_{i=0}^{BitLength} b_{j,i} 2^i; b_{j,i} in {0, 1}
+func (p *aggregatedRangeProver) Prove() (*RangeProof, error) {
+	n := p.BitLength
+	size := uint64(len(p.LeftGenerators))
+	left := make([]*math.Zr, size)
+	right := make([]*math.Zr, size)
+	randomLeft := make([]*math.Zr, size)
+	randomRight := make([]*math.Zr, size)
+
+	rand, err := p.Curve.Rand()
+	if err != nil {
+		return nil, err
+	}
+	rho := p.Curve.NewRandomZr(rand)
+	eta := p.Curve.NewRandomZr(rand)
+	for k := uint64(0); k < size; k++ {
+		// the values beyond len(p.Values) are zero
+		value := uint64(0)
+		if j := k / n; j < uint64(len(p.Values)) {
+			value = p.Values[j]
+		}
+		b := uint64(1) << (k % n) & value
+		if b > 0 {
+			b = 1
+		}
+		// this is the concatenation of the bits of the values
+		left[k] = p.Curve.NewZrFromUint64(b)
+		// this is the concatenation of the bits of the values minus one
+		right[k] = p.Curve.ModSub(left[k], p.Curve.NewZrFromInt(1), p.Curve.GroupOrder)
+		// these are randomly generated arrays
+		randomLeft[k] = p.Curve.NewRandomZr(rand)
+		randomRight[k] = p.Curve.NewRandomZr(rand)
+	}
+
+	// C commits to L and R, and it is hiding thanks to rho
+	C := commitVector(left, right, p.LeftGenerators, p.RightGenerators, p.Curve)
+	C.Add(p.P.Mul(rho))
+	// D commits two random vectors U and V, and it is hiding thanks to eta
+	D := commitVector(randomLeft, randomRight, p.LeftGenerators, p.RightGenerators, p.Curve)
+	D.Add(p.P.Mul(eta))
+
+	// compute challenges y and z
+	y, z, err := aggregatedChallengesYZ(C, D, p.Commitments, p.Curve)
+	if err != nil {
+		return nil, err
+	}
+	zPow := aggregationWeights(z, size/n, p.Curve)
+	power2 := powersOfTwo(n, p.Curve)
+
+	leftPrime := make([]*math.Zr, size)
+	rightPrime := make([]*math.Zr, size)
+	randRightPrime := make([]*math.Zr, size)
+	zPrime := make([]*math.Zr, size)
+	var yk *math.Zr
+	for k := uint64(0); k < size; k++ {
+		// compute L_k - z
+		leftPrime[k] = p.Curve.ModSub(left[k], z, p.Curve.GroupOrder)
+		// compute y^k
+		if k == 0 {
+			yk = p.Curve.NewZrFromInt(1)
+		} else {
+			yk = p.Curve.ModMul(y, yk, p.Curve.GroupOrder)
+		}
+		// compute (R_k+z)y^k
+		rightPrime[k] = p.Curve.ModAdd(right[k], z, p.Curve.GroupOrder)
+		rightPrime[k] = p.Curve.ModMul(rightPrime[k], yk, p.Curve.GroupOrder)
+		// compute V_ky^k
+		randRightPrime[k] = p.Curve.ModMul(randomRight[k], yk, p.Curve.GroupOrder)
+		// compute z^{j+2}2^i, where k = j*BitLength + i
+		zPrime[k] = p.Curve.ModMul(zPow[k/n], power2[k%n], p.Curve.GroupOrder)
+	}
+
+	// compute \sum y^kV_k(L_k-z) + (R_k+z)y^kU_k + U_kz^{j+2}2^i
+	t1 := innerProduct(leftPrime, randRightPrime, p.Curve)
+	t1 = p.Curve.ModAdd(t1, innerProduct(rightPrime, randomLeft, p.Curve), p.Curve.GroupOrder)
+	t1 = p.Curve.ModAdd(t1, innerProduct(zPrime, randomLeft, p.Curve), p.Curve.GroupOrder)
+	tau1 := p.Curve.NewRandomZr(rand)
+	T1 := p.CommitmentGenerators[0].Mul(t1)
+	T1.Add(p.CommitmentGenerators[1].Mul(tau1))
+
+	// compute \sum y^kU_kV_k
+	t2 := innerProduct(randomLeft, randRightPrime, p.Curve)
+	tau2 := p.Curve.NewRandomZr(rand)
+	T2 := p.CommitmentGenerators[0].Mul(t2)
+	T2.Add(p.CommitmentGenerators[1].Mul(tau2))
+
+	// compute challenge x
+	array := common.GetG1Array([]*math.G1{T1, T2})
+	bytesToHash, err := array.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	x := p.Curve.HashToZr(bytesToHash)
+
+	// compute the vectors against which the IPA is produced
+	for k := uint64(0); k < size; k++ {
+		// compute (L_k-z) + xU_k
+		left[k] = p.Curve.ModAdd(leftPrime[k], p.Curve.ModMul(x, randomLeft[k], p.Curve.GroupOrder), p.Curve.GroupOrder)
+		// compute y^k((R_k+z)+xV_k)+z^{j+2}2^i
+		right[k] = p.Curve.ModAdd(rightPrime[k], p.Curve.ModMul(x, randRightPrime[k], p.Curve.GroupOrder), p.Curve.GroupOrder)
+		right[k] = p.Curve.ModAdd(right[k], zPrime[k], p.Curve.GroupOrder)
+	}
+	// tau = tau1x + tau2x^2 + \sum z^{j+2}blindingFactor_j
+	tau := p.Curve.ModMul(x, tau1, p.Curve.GroupOrder)
+	tau = p.Curve.ModAdd(tau, p.Curve.ModMul(tau2, x.PowMod(p.Curve.NewZrFromInt(2)), p.Curve.GroupOrder), p.Curve.GroupOrder)
+	for j, bf := range p.BlindingFactors {
+		tau = p.Curve.ModAdd(tau, p.Curve.ModMul(zPow[j], bf, p.Curve.GroupOrder), p.Curve.GroupOrder)
+	}
+	// delta = rho + eta*x
+	delta := p.Curve.ModAdd(rho, p.Curve.ModMul(eta, x, p.Curve.GroupOrder), p.Curve.GroupOrder)
+
+	rp := &RangeProof{
+		Data: &RangeProofData{
+			T1:    T1,
+			T2:    T2,
+			C:     C,
+			D:     D,
+			Tau:   tau,
+			Delta: delta,
+		},
+	}
+
+	// compute the new generators H'_k = H_k^{1/y^k}
+	yInv := y.Copy()
+	yInv.InvModP(p.Curve.GroupOrder)
+	rightGeneratorsPrime := make([]*math.G1, size)
+	yInvk := p.Curve.NewZrFromInt(1)
+	for k := uint64(0); k < size; k++ {
+		if k > 0 {
+			yInvk = p.Curve.ModMul(yInvk, yInv, p.Curve.GroupOrder)
+		}
+		rightGeneratorsPrime[k] = p.RightGenerators[k].Mul(yInvk)
+	}
+	com := commitVector(left, right, p.LeftGenerators, rightGeneratorsPrime, p.Curve)
+	rp.Data.InnerProduct = innerProduct(left, right, p.Curve)
+	ipp := NewIPAProver(
+		rp.Data.InnerProduct,
+		left,
+		right,
+		p.Q,
+		p.LeftGenerators,
+		rightGeneratorsPrime,
+		com,
+		p.NumberOfRounds,
+		p.Curve,
+	)
+	rp.IPA, err = ipp.Prove()
+	if err != nil {
+		return nil, err
+	}
+	return rp, nil
+}
+
+// aggregatedRangeVerifier verifies that each of the committed values is < 2^BitLength
+type aggregatedRangeVerifier struct {
+	// Commitments are hiding Pedersen commitments to the values
+	Commitments []*math.G1
+	// CommitmentGenerators are the generators (G, H) used to compute Commitments
+	CommitmentGenerators []*math.G1
+	// LeftGenerators are the generators used to commit to the bits of all values
+	LeftGenerators []*math.G1
+	// RightGenerators are the generators used to commit to the bits of all values minus one
+	RightGenerators []*math.G1
+	// P is a random generator of G1
+	P *math.G1
+	// Q is a random generator of G1
+	Q *math.G1
+	// NumberOfRounds is log_2 of the size of the generators
+	NumberOfRounds uint64
+	// BitLength is the size of the binary representation of each value
+	BitLength uint64
+	// Curve is the curve over which the computation is performed
+	Curve *math.Curve
+}
+
+// NewAggregatedRangeVerifier returns a verifier of an aggregated range proof for the passed commitments.
+// The passed generators must contain at least BitLength*AggregationSize(len(coms)) elements.
+func NewAggregatedRangeVerifier(
+	coms []*math.G1,
+	commitmentGen []*math.G1,
+	leftGen []*math.G1,
+	rightGen []*math.G1,
+	P, Q *math.G1,
+	bitLength uint64,
+	curve *math.Curve,
+) (*aggregatedRangeVerifier, error) {
+	if len(coms) == 0 {
+		return nil, errors.New("invalid aggregated range proof: no commitment")
+	}
+	leftGen, rightGen, rounds, err := aggregationGenerators(len(coms), leftGen, rightGen, bitLength)
+	if err != nil {
+		return nil, err
+	}
+	return &aggregatedRangeVerifier{
+		Commitments:          coms,
+		CommitmentGenerators: commitmentGen,
+		LeftGenerators:       leftGen,
+		RightGenerators:      rightGen,
+		P:                    P,
+		Q:                    Q,
+		NumberOfRounds:       rounds,
+		BitLength:            bitLength,
+		Curve:                curve,
+	}, nil
+}
+
+// Verify checks the validity of the passed aggregated RangeProof
+func (v *aggregatedRangeVerifier) Verify(rp *RangeProof) error {
+	if err := checkWellFormedness(rp); err != nil {
+		return err
+	}
+	n := v.BitLength
+	size := uint64(len(v.LeftGenerators))
+
+	// compute x and x^2
+	array := common.GetG1Array([]*math.G1{rp.Data.T1, rp.Data.T2})
+	bytesToHash, err := array.Bytes()
+	if err != nil {
+		return err
+	}
+	x := v.Curve.HashToZr(bytesToHash)
+	xSquare := x.PowMod(v.Curve.NewZrFromInt(2))
+
+	// compute y and z
+	y, z, err := aggregatedChallengesYZ(rp.Data.C, rp.Data.D, v.Commitments, v.Curve)
+	if err != nil {
+		return err
+	}
+	zSquare := z.PowMod(v.Curve.NewZrFromInt(2))
+	zPow := aggregationWeights(z, size/n, v.Curve)
+	power2 := powersOfTwo(n, v.Curve)
+
+	yPow := make([]*math.Zr, size)
+	ipy := v.Curve.NewZrFromInt(0)
+	for k := uint64(0); k < size; k++ {
+		if k == 0 {
+			yPow[0] = v.Curve.NewZrFromInt(1)
+		} else {
+			yPow[k] = v.Curve.ModMul(y, yPow[k-1], v.Curve.GroupOrder)
+		}
+		// ipy = \sum y^k
+		ipy = v.Curve.ModAdd(ipy, yPow[k], v.Curve.GroupOrder)
+	}
+	// ip2 = \sum 2^i
+	ip2 := v.Curve.NewZrFromInt(0)
+	for _, p2 := range power2 {
+		ip2 = v.Curve.ModAdd(ip2, p2, v.Curve.GroupOrder)
+	}
+	// polEval = (z - z^2)\sum y^k - \sum_j z^{j+3}\sum 2^i
+	polEval := v.Curve.ModSub(z, zSquare, v.Curve.GroupOrder)
+	polEval = v.Curve.ModMul(polEval, ipy, v.Curve.GroupOrder)
+	for _, w := range zPow {
+		polEval = v.Curve.ModSub(polEval, v.Curve.ModMul(v.Curve.ModMul(w, z, v.Curve.GroupOrder), ip2, v.Curve.GroupOrder), v.Curve.GroupOrder)
+	}
+
+	// com should be equal to \prod Commitments_j^{z^{j+2}} G^polEval if the values fall within range
+	com := v.CommitmentGenerators[0].Mul(rp.Data.InnerProduct)
+	com.Add(v.CommitmentGenerators[1].Mul(rp.Data.Tau))
+	com.Sub(rp.Data.T1.Mul(x))
+	com.Sub(rp.Data.T2.Mul(xSquare))
+
+	comPrime := v.CommitmentGenerators[0].Mul(polEval)
+	for j, commitment := range v.Commitments {
+		comPrime.Add(commitment.Mul(zPow[j]))
+	}
+	if !com.Equals(comPrime) {
+		return errors.New("invalid range proof")
+	}
+
+	// compute the commitment to the vectors for which the inner product is computed, with generators
+	// (G_0, ..., G_{size}, H'_0, ..., H'_{size}), where H'_k = H_k^{1/y^k}
+	ipaCom := rp.Data.D.Mul(x)
+	ipaCom.Add(rp.Data.C)
+	rightGeneratorsPrime := make([]*math.G1, size)
+	for k := uint64(0); k < size; k++ {
+		ipaCom.Sub(v.LeftGenerators[k].Mul(z))
+		// 1/y^k
+		yInvk := yPow[k].Copy()
+		yInvk.InvModP(v.Curve.GroupOrder)
+		// zy^k + z^{j+2}2^i
+		zk := v.Curve.ModMul(z, yPow[k], v.Curve.GroupOrder)
+		zk = v.Curve.ModAdd(zk, v.Curve.ModMul(zPow[k/n], power2[k%n], v.Curve.GroupOrder), v.Curve.GroupOrder)
+		rightGeneratorsPrime[k] = v.RightGenerators[k].Mul(yInvk)
+		ipaCom.Add(rightGeneratorsPrime[k].Mul(zk))
+	}
+	ipaCom.Sub(v.P.Mul(rp.Data.Delta))
+
+	ipv := NewIPAVerifier(
+		rp.Data.InnerProduct,
+		v.Q,
+		v.LeftGenerators,
+		rightGeneratorsPrime,
+		ipaCom,
+		v.NumberOfRounds,
+		v.Curve,
+	)
+	return ipv.Verify(rp.IPA)
+}
+
+// checkWellFormedness checks that the passed proof has no nil elements
+func checkWellFormedness(rp *RangeProof) error {
+	if rp == nil || rp.Data == nil || rp.IPA == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	if rp.Data.InnerProduct == nil || rp.Data.C == nil || rp.Data.D == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	if rp.Data.T1 == nil || rp.Data.T2 == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	if rp.Data.Tau == nil || rp.Data.Delta == nil {
+		return errors.New("invalid range proof: nil elements")
+	}
+	return nil
+}
+
+// aggregationGenerators returns the prefix of the passed generators needed to aggregate the passed number of values,
+// and the number of rounds of the corresponding IPA
+func aggregationGenerators(values int, leftGen, rightGen []*math.G1, bitLength uint64) ([]*math.G1, []*math.G1, uint64, error) {
+	if values > MaxAggregationSize {
+		return nil, nil, 0, errors.Errorf("cannot aggregate more than [%d] range proofs, got [%d]", MaxAggregationSize, values)
+	}
+	if bitLength == 0 || bitLength&(bitLength-1) != 0 {
+		return nil, nil, 0, errors.Errorf("invalid bit length [%d], it must be a power of two", bitLength)
+	}
+	size := bitLength * AggregationSize(values)
+	if uint64(len(leftGen)) < size || uint64(len(rightGen)) < size {
+		return nil, nil, 0, errors.Errorf("insufficient number of generators, expected at least [%d], got [%d,%d]", size, len(leftGen), len(rightGen))
+	}
+	return leftGen[:size], rightGen[:size], uint64(bits.TrailingZeros64(size)), nil
+}
+
+// aggregatedChallengesYZ computes the challenges y and z from the passed commitments
+func aggregatedChallengesYZ(C, D *math.G1, commitments []*math.G1, curve *math.Curve) (*math.Zr, *math.Zr, error) {
+	array := common.GetG1Array(append([]*math.G1{C, D}, commitments...))
+	bytesToHash, err := array.Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	y := curve.HashToZr(bytesToHash)
+	z := curve.HashToZr(y.Bytes())
+	return y, z, nil
+}
+
+// aggregationWeights returns (z^2, ..., z^{m+1}), the weights of the m aggregated values
+func aggregationWeights(z *math.Zr, m uint64, curve *math.Curve) []*math.Zr {
+	weights := make([]*math.Zr, m)
+	weights[0] = curve.ModMul(z, z, curve.GroupOrder)
+	for j := uint64(1); j < m; j++ {
+		weights[j] = curve.ModMul(weights[j-1], z, curve.GroupOrder)
+	}
+	return weights
+}
+
+// powersOfTwo returns (2^0, ..., 2^{n-1})
+func powersOfTwo(n uint64, curve *math.Curve) []*math.Zr {
+	powers := make([]*math.Zr, n)
+	powers[0] = curve.NewZrFromInt(1)
+	for i := uint64(1); i < n; i++ {
+		powers[i] = curve.ModMul(curve.NewZrFromInt(2), powers[i-1], curve.GroupOrder)
+	}
+	return powers
+}
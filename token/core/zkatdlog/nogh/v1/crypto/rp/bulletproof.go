@@ -250,23 +250,42 @@ func (p *rangeProver) Prove() (*RangeProof, error) {
 
 // Verify enable a rangeVerifier to checks the validity of a RangeProof
 func (v *rangeVerifier) Verify(rp *RangeProof) error {
-	// check that the proof is well-formed
-	if rp.Data.InnerProduct == nil || rp.Data.C == nil || rp.Data.D == nil {
-		return errors.New("invalid range proof: nil elements")
-	}
-	if rp.Data.T1 == nil || rp.Data.T2 == nil {
-		return errors.New("invalid range proof: nil elements")
+	c, err := v.challenges(rp)
+	if err != nil {
+		return err
 	}
-	if rp.Data.Tau == nil || rp.Data.Delta == nil {
-		return errors.New("invalid range proof: nil elements")
+	// com is should be equal to v.Commitment^{z^2} if p.Value falls within range
+	com, comPrime := v.polynomialCheck(rp, c)
+	if !com.Equals(comPrime) {
+		return errors.New("invalid range proof")
 	}
-	if rp.IPA == nil {
-		return errors.New("invalid range proof: nil elements")
+
+	// verify the IPA
+	return v.verifyIPA(rp, c.x, c.yPow, c.z, c.zSquare)
+}
+
+// rangeChallenges contains the challenges of a RangeProof, and the values derived from them,
+// that the rangeVerifier needs to check the proof
+type rangeChallenges struct {
+	x       *math.Zr
+	xSquare *math.Zr
+	z       *math.Zr
+	zSquare *math.Zr
+	yPow    []*math.Zr
+	// polEval = (z -z^2)\sum y^i - z^3\sum 2^i
+	polEval *math.Zr
+}
+
+// challenges checks that the passed proof is well-formed, and computes its challenges
+func (v *rangeVerifier) challenges(rp *RangeProof) (*rangeChallenges, error) {
+	// check that the proof is well-formed
+	if err := checkWellFormedness(rp); err != nil {
+		return nil, err
 	}
 	array := common.GetG1Array([]*math.G1{rp.Data.T1, rp.Data.T2})
 	bytesToHash, err := array.Bytes()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// compute x and x^2
 	x := v.Curve.HashToZr(bytesToHash)
@@ -276,7 +295,7 @@ func (v *rangeVerifier) Verify(rp *RangeProof) error {
 	array = common.GetG1Array([]*math.G1{rp.Data.C, rp.Data.D, v.Commitment})
 	bytesToHash, err = array.Bytes()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	y := v.Curve.HashToZr(bytesToHash)
 	z := v.Curve.HashToZr(y.Bytes())
@@ -310,26 +329,27 @@ func (v *rangeVerifier) Verify(rp *RangeProof) error {
 
 	polEval = v.Curve.ModSub(polEval, zCube, v.Curve.GroupOrder)
 
-	// com is should be equal to v.Commitment^{z^2} if p.Value falls within range
+	return &rangeChallenges{
+		x:       x,
+		xSquare: xSquare,
+		z:       z,
+		zSquare: zSquare,
+		yPow:    yPow,
+		polEval: polEval,
+	}, nil
+}
+
+// polynomialCheck returns the two sides of the equation that binds the committed value to the inner product of the proof:
+// G^InnerProduct H^Tau / (T1^x T2^{x^2}) = Commitment^{z^2} G^polEval
+func (v *rangeVerifier) polynomialCheck(rp *RangeProof, c *rangeChallenges) (*math.G1, *math.G1) {
 	com := v.CommitmentGenerators[0].Mul(rp.Data.InnerProduct)
 	com.Add(v.CommitmentGenerators[1].Mul(rp.Data.Tau))
-	com.Sub(rp.Data.T1.Mul(x))
-	com.Sub(rp.Data.T2.Mul(xSquare))
-
-	comPrime := v.Commitment.Mul(zSquare)
-	comPrime.Add(v.CommitmentGenerators[0].Mul(polEval))
-
-	if !com.Equals(comPrime) {
-		return errors.New("invalid range proof")
-	}
-
-	// verify the IPA
-	err = v.verifyIPA(rp, x, yPow, z, zSquare)
-	if err != nil {
-		return err
-	}
+	com.Sub(rp.Data.T1.Mul(c.x))
+	com.Sub(rp.Data.T2.Mul(c.xSquare))
 
-	return nil
+	comPrime := v.Commitment.Mul(c.zSquare)
+	comPrime.Add(v.CommitmentGenerators[0].Mul(c.polEval))
+	return com, comPrime
 }
 
 // preprocess prepares data for the inner product argument
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package rp_test
 
 import (
+	"io"
 	"strconv"
 
 	math "github.com/IBM/mathlib"
@@ -52,4 +53,177 @@ var _ = Describe("Range Proof", func() {
 			})
 		})
 	})
+	Describe("Range Correctness", func() {
+		var (
+			curve    *math.Curve
+			prover   *rp.RangeCorrectnessProver
+			verifier *rp.RangeCorrectnessVerifier
+		)
+
+		BeforeEach(func() {
+			curve = math.Curves[1]
+			nr := uint64(3)
+			l := uint64(1 << nr)
+			leftGens := make([]*math.G1, l)
+			rightGens := make([]*math.G1, l)
+
+			rand, err := curve.Rand()
+			Expect(err).NotTo(HaveOccurred())
+
+			Q := curve.GenG1.Mul(curve.NewRandomZr(rand))
+			P := curve.GenG1.Mul(curve.NewRandomZr(rand))
+			H := curve.GenG1.Mul(curve.NewRandomZr(rand))
+			G := curve.GenG1.Mul(curve.NewRandomZr(rand))
+			for i := 0; i < len(leftGens); i++ {
+				leftGens[i] = curve.HashToG1([]byte(strconv.Itoa(2 * i)))
+				rightGens[i] = curve.HashToG1([]byte(strconv.Itoa(2*i + 1)))
+			}
+			values := []uint64{115, 3, 0, 255}
+			coms := make([]*math.G1, len(values))
+			bfs := make([]*math.Zr, len(values))
+			for i, value := range values {
+				bfs[i] = curve.NewRandomZr(rand)
+				coms[i] = G.Mul(curve.NewZrFromUint64(value))
+				coms[i].Add(H.Mul(bfs[i]))
+			}
+			prover = rp.NewRangeCorrectnessProver(coms, values, bfs, []*math.G1{G, H}, leftGens, rightGens, P, Q, l, nr, curve)
+			verifier = rp.NewRangeCorrectnessVerifier([]*math.G1{G, H}, leftGens, rightGens, P, Q, l, nr, curve)
+			verifier.Commitments = coms
+		})
+
+		Context("If the proofs are generated correctly", func() {
+			It("Succeeds", func() {
+				proof, err := prover.Prove()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(verifier.Verify(proof)).To(Succeed())
+			})
+		})
+
+		Context("If one of the proofs is not valid", func() {
+			It("Fails and reports the invalid proof", func() {
+				proof, err := prover.Prove()
+				Expect(err).NotTo(HaveOccurred())
+				proof.Proofs[2].Data.Tau = curve.ModAdd(proof.Proofs[2].Data.Tau, curve.NewZrFromInt(1), curve.GroupOrder)
+				err = verifier.Verify(proof)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid range proof at index 2"))
+			})
+		})
+	})
+	Describe("Aggregated Range Proof", func() {
+		var (
+			curve     *math.Curve
+			nr        uint64
+			l         uint64
+			G, H      *math.G1
+			P, Q      *math.G1
+			leftGens  []*math.G1
+			rightGens []*math.G1
+			rand      io.Reader
+		)
+
+		commit := func(values []uint64) ([]*math.G1, []*math.Zr) {
+			coms := make([]*math.G1, len(values))
+			bfs := make([]*math.Zr, len(values))
+			for i, value := range values {
+				bfs[i] = curve.NewRandomZr(rand)
+				coms[i] = G.Mul(curve.NewZrFromUint64(value))
+				coms[i].Add(H.Mul(bfs[i]))
+			}
+			return coms, bfs
+		}
+
+		BeforeEach(func() {
+			var err error
+			curve = math.Curves[1]
+			nr = uint64(3)
+			l = uint64(1 << nr)
+			rand, err = curve.Rand()
+			Expect(err).NotTo(HaveOccurred())
+			Q = curve.GenG1.Mul(curve.NewRandomZr(rand))
+			P = curve.GenG1.Mul(curve.NewRandomZr(rand))
+			H = curve.GenG1.Mul(curve.NewRandomZr(rand))
+			G = curve.GenG1.Mul(curve.NewRandomZr(rand))
+			// enough generators to aggregate up to 4 values
+			leftGens = make([]*math.G1, 4*l)
+			rightGens = make([]*math.G1, 4*l)
+			for i := 0; i < len(leftGens); i++ {
+				leftGens[i] = curve.HashToG1([]byte(strconv.Itoa(2 * i)))
+				rightGens[i] = curve.HashToG1([]byte(strconv.Itoa(2*i + 1)))
+			}
+		})
+
+		It("aggregates the range proofs of several values", func() {
+			values := []uint64{115, 3, 255}
+			coms, bfs := commit(values)
+			prover, err := rp.NewAggregatedRangeProver(coms, values, bfs, []*math.G1{G, H}, leftGens, rightGens, P, Q, l, curve)
+			Expect(err).NotTo(HaveOccurred())
+			proof, err := prover.Prove()
+			Expect(err).NotTo(HaveOccurred())
+			// the vectors of the proof cover 4 values, the closest power of two
+			Expect(proof.IPA.L).To(HaveLen(int(nr) + 2))
+
+			verifier, err := rp.NewAggregatedRangeVerifier(coms, []*math.G1{G, H}, leftGens, rightGens, P, Q, l, curve)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier.Verify(proof)).To(Succeed())
+
+			// the proof does not verify against different commitments
+			coms[1] = coms[1].Copy()
+			coms[1].Add(G)
+			verifier, err = rp.NewAggregatedRangeVerifier(coms, []*math.G1{G, H}, leftGens, rightGens, P, Q, l, curve)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier.Verify(proof)).NotTo(Succeed())
+		})
+
+		It("fails if a value is out of range", func() {
+			values := []uint64{115, 256}
+			coms, bfs := commit(values)
+			prover, err := rp.NewAggregatedRangeProver(coms, values, bfs, []*math.G1{G, H}, leftGens, rightGens, P, Q, l, curve)
+			Expect(err).NotTo(HaveOccurred())
+			proof, err := prover.Prove()
+			Expect(err).NotTo(HaveOccurred())
+			verifier, err := rp.NewAggregatedRangeVerifier(coms, []*math.G1{G, H}, leftGens, rightGens, P, Q, l, curve)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(verifier.Verify(proof)).NotTo(Succeed())
+		})
+
+		It("produces a regular range proof for a single value", func() {
+			values := []uint64{115}
+			coms, bfs := commit(values)
+			prover, err := rp.NewAggregatedRangeProver(coms, values, bfs, []*math.G1{G, H}, leftGens, rightGens, P, Q, l, curve)
+			Expect(err).NotTo(HaveOccurred())
+			proof, err := prover.Prove()
+			Expect(err).NotTo(HaveOccurred())
+			verifier := rp.NewRangeVerifier(coms[0], []*math.G1{G, H}, leftGens[:l], rightGens[:l], P, Q, nr, l, curve)
+			Expect(verifier.Verify(proof)).To(Succeed())
+		})
+
+		It("is accepted by the range correctness verifier only if aggregation is enabled", func() {
+			values := []uint64{115, 3, 255}
+			coms, bfs := commit(values)
+			generators := func(size uint64) ([]*math.G1, []*math.G1, error) {
+				return leftGens[:size], rightGens[:size], nil
+			}
+			prover := rp.NewRangeCorrectnessProver(coms, values, bfs, []*math.G1{G, H}, leftGens[:l], rightGens[:l], P, Q, l, nr, curve)
+			prover.AggregationGenerators = generators
+			proof, err := prover.Prove()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proof.Proofs).To(HaveLen(1))
+
+			// serialization round-trip
+			raw, err := proof.Serialize()
+			Expect(err).NotTo(HaveOccurred())
+			proof = &rp.RangeCorrectness{}
+			Expect(proof.Deserialize(raw)).To(Succeed())
+
+			verifier := rp.NewRangeCorrectnessVerifier([]*math.G1{G, H}, leftGens[:l], rightGens[:l], P, Q, l, nr, curve)
+			verifier.Commitments = coms
+			err = verifier.Verify(proof)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("aggregated range proofs are not supported"))
+
+			verifier.AggregationGenerators = generators
+			Expect(verifier.Verify(proof)).To(Succeed())
+		})
+	})
 })
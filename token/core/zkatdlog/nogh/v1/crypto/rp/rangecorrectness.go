@@ -51,6 +51,8 @@ type RangeCorrectnessProver struct {
 	P                  *math.G1
 	Q                  *math.G1
 	Curve              *math.Curve
+	// AggregationGenerators, if set, enables the aggregation of the range proofs of multiple commitments into a single proof
+	AggregationGenerators GeneratorsFunc
 }
 
 func NewRangeCorrectnessProver(
@@ -77,7 +79,12 @@ func NewRangeCorrectnessProver(
 	}
 }
 
+// Prove produces the range proofs of the commitments.
+// If aggregation is enabled and there are multiple commitments, a single aggregated range proof is produced.
 func (p *RangeCorrectnessProver) Prove() (*RangeCorrectness, error) {
+	if p.AggregationGenerators != nil && len(p.Commitments) > 1 && len(p.Commitments) <= MaxAggregationSize {
+		return p.proveAggregated()
+	}
 	rc := &RangeCorrectness{}
 	rc.Proofs = make([]*RangeProof, len(p.Commitments))
 	for i := 0; i < len(p.Commitments); i++ {
@@ -103,6 +110,33 @@ func (p *RangeCorrectnessProver) Prove() (*RangeCorrectness, error) {
 	return rc, nil
 }
 
+func (p *RangeCorrectnessProver) proveAggregated() (*RangeCorrectness, error) {
+	leftGen, rightGen, err := p.AggregationGenerators(p.BitLength * AggregationSize(len(p.Commitments)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get generators for aggregated range proof")
+	}
+	bp, err := NewAggregatedRangeProver(
+		p.Commitments,
+		p.Values,
+		p.BlindingFactors,
+		p.PedersenParameters,
+		leftGen,
+		rightGen,
+		p.P,
+		p.Q,
+		p.BitLength,
+		p.Curve,
+	)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := bp.Prove()
+	if err != nil {
+		return nil, err
+	}
+	return &RangeCorrectness{Proofs: []*RangeProof{proof}}, nil
+}
+
 type RangeCorrectnessVerifier struct {
 	Commitments        []*math.G1
 	PedersenParameters []*math.G1
@@ -113,6 +147,16 @@ type RangeCorrectnessVerifier struct {
 	P                  *math.G1
 	Q                  *math.G1
 	Curve              *math.Curve
+	// AggregationGenerators, if set, enables the verification of aggregated range proofs
+	AggregationGenerators GeneratorsFunc
+	// Executor, if set, runs the verification of the inner product arguments of the proofs.
+	// Otherwise, they are verified one after the other.
+	Executor Executor
+}
+
+// Executor runs the passed tasks, possibly concurrently, and returns the first error
+type Executor interface {
+	Run(tasks ...func() error) error
 }
 
 func NewRangeCorrectnessVerifier(
@@ -134,15 +178,27 @@ func NewRangeCorrectnessVerifier(
 
 }
 
+// Verify checks the validity of the passed range proofs.
+// A single proof for multiple commitments is an aggregated range proof, that is accepted only if aggregation is enabled.
+// Otherwise, there is a proof for each commitment.
+// The equations that bind each committed value to the inner product of its proof are checked at once, by means of a random
+// linear combination that folds the exponentiations of the generators shared by all proofs.
+// The inner product arguments are then verified, concurrently if an Executor is set.
+// The proofs of different actions are not combined, each action is accepted or rejected on its own.
 func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
+	if len(rc.Proofs) == 1 && len(v.Commitments) > 1 {
+		return v.verifyAggregated(rc.Proofs[0])
+	}
 	if len(rc.Proofs) != len(v.Commitments) {
 		return errors.New("invalid range proof")
 	}
+	verifiers := make([]*rangeVerifier, len(rc.Proofs))
+	challenges := make([]*rangeChallenges, len(rc.Proofs))
 	for i := 0; i < len(rc.Proofs); i++ {
 		if rc.Proofs[i] == nil {
 			return errors.Errorf("invalid range proof: nil proof at index %d", i)
 		}
-		bv := NewRangeVerifier(
+		verifiers[i] = NewRangeVerifier(
 			v.Commitments[i],
 			v.PedersenParameters,
 			v.LeftGenerators,
@@ -153,10 +209,114 @@ func (v *RangeCorrectnessVerifier) Verify(rc *RangeCorrectness) error {
 			v.BitLength,
 			v.Curve,
 		)
-		err := bv.Verify(rc.Proofs[i])
+		var err error
+		challenges[i], err = verifiers[i].challenges(rc.Proofs[i])
 		if err != nil {
 			return errors.Wrapf(err, "invalid range proof at index %d", i)
 		}
 	}
+
+	ok, err := v.batchPolynomialCheck(rc.Proofs, verifiers, challenges)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// find the culprit
+		for i := 0; i < len(rc.Proofs); i++ {
+			com, comPrime := verifiers[i].polynomialCheck(rc.Proofs[i], challenges[i])
+			if !com.Equals(comPrime) {
+				return errors.Errorf("invalid range proof at index %d: invalid range proof", i)
+			}
+		}
+	}
+
+	tasks := make([]func() error, len(rc.Proofs))
+	for i := 0; i < len(rc.Proofs); i++ {
+		tasks[i] = func() error {
+			if err := verifiers[i].verifyIPA(rc.Proofs[i], challenges[i].x, challenges[i].yPow, challenges[i].z, challenges[i].zSquare); err != nil {
+				return errors.Wrapf(err, "invalid range proof at index %d", i)
+			}
+			return nil
+		}
+	}
+	if v.Executor == nil {
+		for _, task := range tasks {
+			if err := task(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return v.Executor.Run(tasks...)
+}
+
+func (v *RangeCorrectnessVerifier) verifyAggregated(proof *RangeProof) error {
+	if v.AggregationGenerators == nil {
+		return errors.New("invalid range proof: aggregated range proofs are not supported")
+	}
+	if len(v.Commitments) > MaxAggregationSize {
+		return errors.Errorf("invalid range proof: cannot aggregate more than [%d] range proofs, got [%d]", MaxAggregationSize, len(v.Commitments))
+	}
+	leftGen, rightGen, err := v.AggregationGenerators(v.BitLength * AggregationSize(len(v.Commitments)))
+	if err != nil {
+		return errors.Wrap(err, "failed to get generators for aggregated range proof")
+	}
+	bv, err := NewAggregatedRangeVerifier(
+		v.Commitments,
+		v.PedersenParameters,
+		leftGen,
+		rightGen,
+		v.P,
+		v.Q,
+		v.BitLength,
+		v.Curve,
+	)
+	if err != nil {
+		return errors.Wrap(err, "invalid aggregated range proof")
+	}
+	if err := bv.Verify(proof); err != nil {
+		return errors.Wrap(err, "invalid aggregated range proof")
+	}
 	return nil
 }
+
+// batchPolynomialCheck checks the polynomial equations of all the passed proofs at once.
+// Given random weights r_i, it checks that
+// G^{\sum r_i(InnerProduct_i - polEval_i)} H^{\sum r_i Tau_i} = \prod T1_i^{r_i x_i} T2_i^{r_i x_i^2} Commitment_i^{r_i z_i^2}
+// It returns false if the check fails, meaning that at least one of the proofs is invalid.
+func (v *RangeCorrectnessVerifier) batchPolynomialCheck(proofs []*RangeProof, verifiers []*rangeVerifier, challenges []*rangeChallenges) (bool, error) {
+	if len(proofs) == 0 {
+		return true, nil
+	}
+	if len(proofs) == 1 {
+		com, comPrime := verifiers[0].polynomialCheck(proofs[0], challenges[0])
+		return com.Equals(comPrime), nil
+	}
+	rand, err := v.Curve.Rand()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get random generator")
+	}
+	g := v.Curve.NewZrFromInt(0)
+	h := v.Curve.NewZrFromInt(0)
+	var rhs *math.G1
+	for i, proof := range proofs {
+		r := v.Curve.NewRandomZr(rand)
+		c := challenges[i]
+		// g = \sum r_i(InnerProduct_i - polEval_i)
+		g = v.Curve.ModAdd(g, v.Curve.ModMul(r, v.Curve.ModSub(proof.Data.InnerProduct, c.polEval, v.Curve.GroupOrder), v.Curve.GroupOrder), v.Curve.GroupOrder)
+		// h = \sum r_i Tau_i
+		h = v.Curve.ModAdd(h, v.Curve.ModMul(r, proof.Data.Tau, v.Curve.GroupOrder), v.Curve.GroupOrder)
+
+		term := proof.Data.T1.Mul(v.Curve.ModMul(r, c.x, v.Curve.GroupOrder))
+		term.Add(proof.Data.T2.Mul(v.Curve.ModMul(r, c.xSquare, v.Curve.GroupOrder)))
+		term.Add(verifiers[i].Commitment.Mul(v.Curve.ModMul(r, c.zSquare, v.Curve.GroupOrder)))
+		if rhs == nil {
+			rhs = term
+		} else {
+			rhs.Add(term)
+		}
+	}
+	lhs := v.PedersenParameters[0].Mul(g)
+	lhs.Add(v.PedersenParameters[1].Mul(h))
+	return lhs.Equals(rhs), nil
+}
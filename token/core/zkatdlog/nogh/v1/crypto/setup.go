@@ -7,29 +7,40 @@ SPDX-License-Identifier: Apache-2.0
 package crypto
 
 import (
+	"crypto/ecdsa"
 	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
 	"math/bits"
+	slices2 "slices"
 	"strconv"
+	"sync"
 
 	mathlib "github.com/IBM/mathlib"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/collections"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
 	pp3 "github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/pp"
 	math2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/math"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/pp"
 	utils2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/protos-go/utils"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/math"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/rp"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	pp2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver/protos-go/pp"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/protos"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
 const (
 	DLogPublicParameters = "zkatdlog"
 	Version              = "1.0.0"
+	// AggregatedRangeProofsVersion is the version of the public parameters
+	// that requires the range proofs of the outputs of a transfer to be aggregated into a single proof
+	AggregatedRangeProofsVersion = "1.1.0"
 )
 
 var (
@@ -129,6 +140,56 @@ func (rpp *RangeProofParams) FromProto(params *pp.RangeProofParams) error {
 	return nil
 }
 
+// AggregationGenerators returns a function that gives access to the generators needed to aggregate range proofs.
+// The first BitLength generators are those of these parameters, the others are derived in the same way.
+func (rpp *RangeProofParams) AggregationGenerators(curveID mathlib.CurveID) rp.GeneratorsFunc {
+	return func(size uint64) ([]*mathlib.G1, []*mathlib.G1, error) {
+		if size < rpp.BitLength {
+			return nil, nil, errors.Errorf("invalid number of generators [%d], expected at least [%d]", size, rpp.BitLength)
+		}
+		if size > rpp.BitLength*rp.MaxAggregationSize {
+			return nil, nil, errors.Errorf("invalid number of generators [%d], expected at most [%d]", size, rpp.BitLength*rp.MaxAggregationSize)
+		}
+		leftGen, rightGen := rangeProofGenerators.get(curveID, size)
+		left := make([]*mathlib.G1, size)
+		right := make([]*mathlib.G1, size)
+		copy(left, rpp.LeftGenerators[:rpp.BitLength])
+		copy(right, rpp.RightGenerators[:rpp.BitLength])
+		copy(left[rpp.BitLength:], leftGen[rpp.BitLength:])
+		copy(right[rpp.BitLength:], rightGen[rpp.BitLength:])
+		return left, right, nil
+	}
+}
+
+// generatorsCache caches, for each curve, the range proof generators derived by hashing
+type generatorsCache struct {
+	lock  sync.Mutex
+	left  map[mathlib.CurveID][]*mathlib.G1
+	right map[mathlib.CurveID][]*mathlib.G1
+}
+
+var rangeProofGenerators = &generatorsCache{
+	left:  map[mathlib.CurveID][]*mathlib.G1{},
+	right: map[mathlib.CurveID][]*mathlib.G1{},
+}
+
+// get returns at least size left and right generators for the passed curve
+func (c *generatorsCache) get(curveID mathlib.CurveID, size uint64) ([]*mathlib.G1, []*mathlib.G1) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	left, right := c.left[curveID], c.right[curveID]
+	if uint64(len(left)) >= size {
+		return left, right
+	}
+	curve := mathlib.Curves[curveID]
+	for i := uint64(len(left)); i < size; i++ {
+		left = append(left, curve.HashToG1([]byte("RangeProof."+strconv.FormatUint(2*(i+1), 10))))
+		right = append(right, curve.HashToG1([]byte("RangeProof."+strconv.FormatUint(2*(i+1)+1, 10))))
+	}
+	c.left[curveID], c.right[curveID] = left, right
+	return left, right
+}
+
 type IdemixIssuerPublicKey struct {
 	PublicKey []byte
 	Curve     mathlib.CurveID
@@ -172,12 +233,38 @@ type PublicParams struct {
 	IdemixIssuerPublicKeys []*IdemixIssuerPublicKey
 	// Auditor is the public key of the auditor.
 	Auditor driver.Identity
+	// AdditionalAuditors is a list of public keys of the auditors besides Auditor.
+	AdditionalAuditors []driver.Identity
+	// AuditorsQuorum is the minimum number of auditors that must sign a token request.
+	// Zero means that all auditors must sign.
+	AuditorsQuorum uint64
 	// IssuerIDs is a list of public keys of the entities that can issue tokens.
 	IssuerIDs []driver.Identity
 	// MaxToken is the maximum quantity a token can hold
 	MaxToken uint64
 	// QuantityPrecision is the precision used to represent quantities
 	QuantityPrecision uint64
+	// TypeIssuers binds token types, or prefixes of token types, to the issuers authorized to issue them.
+	// If not empty, only the listed token types can be issued, and issue actions must disclose the type of the issued tokens.
+	TypeIssuers []*driver.TokenTypeIssuers
+	// MaxSupply binds token types to their maximum circulating supply.
+	// Token quantities are hidden, therefore, the supply caps are enforced only by the issuers.
+	MaxSupply map[token.Type]uint64
+	// AdminIDs is a list of public keys of the entities that can sign administrative actions.
+	AdminIDs []driver.Identity
+	// AdministratorsQuorum is the minimum number of administrators that must sign an administrative action.
+	// Zero means that all administrators must sign.
+	AdministratorsQuorum uint64
+	// RevocationPK is the PEM encoding of the public key the revocation authority signs, epoch by epoch,
+	// the credential revocation information with. If set, the signatures of idemix owners must carry a non-revocation proof.
+	RevocationPK []byte
+	// RevocationEpoch is the number of blocks of an epoch of the revocation authority
+	RevocationEpoch uint64
+	// ComplianceIDs is a list of public keys of the entities that can freeze, unfreeze, and claw back tokens.
+	ComplianceIDs []driver.Identity
+	// ComplianceQuorum is the minimum number of compliance authorities that must authorize a clawback.
+	// Zero means that all compliance authorities must.
+	ComplianceQuorum uint64
 }
 
 func NewPublicParamsFromBytes(raw []byte, label string) (*PublicParams, error) {
@@ -232,6 +319,16 @@ func (p *PublicParams) Version() string {
 	return p.Ver
 }
 
+// AggregatedRangeProofs returns true if the range proofs of the outputs of a transfer must be aggregated into a single proof
+func (p *PublicParams) AggregatedRangeProofs() bool {
+	return p.Ver == AggregatedRangeProofsVersion
+}
+
+// EnableAggregatedRangeProofs upgrades these public parameters to the version that aggregates the range proofs of the outputs of a transfer
+func (p *PublicParams) EnableAggregatedRangeProofs() {
+	p.Ver = AggregatedRangeProofsVersion
+}
+
 func (p *PublicParams) CertificationDriver() string {
 	return p.Label
 }
@@ -256,12 +353,32 @@ func (p *PublicParams) Auditors() []driver.Identity {
 	if len(p.Auditor) == 0 {
 		return []driver.Identity{}
 	}
-	return []driver.Identity{p.Auditor}
+	return append([]driver.Identity{p.Auditor}, p.AdditionalAuditors...)
 }
 
-// Issuers returns the list of authorized issuers
+// AuditorsThreshold returns the minimum number of auditors that must sign a token request
+func (p *PublicParams) AuditorsThreshold() uint64 {
+	return common.AuditorsThreshold(len(p.Auditors()), p.AuditorsQuorum)
+}
+
+// Issuers returns the list of authorized issuers, including those bound to specific token types
 func (p *PublicParams) Issuers() []driver.Identity {
-	return p.IssuerIDs
+	return common.AllIssuers(p.IssuerIDs, p.TypeIssuers)
+}
+
+// Administrators returns the list of identities authorized to sign administrative actions
+func (p *PublicParams) Administrators() []driver.Identity {
+	return p.AdminIDs
+}
+
+// AdministratorsThreshold returns the minimum number of administrators that must sign an administrative action
+func (p *PublicParams) AdministratorsThreshold() uint64 {
+	return common.AdministratorsThreshold(len(p.AdminIDs), p.AdministratorsQuorum)
+}
+
+// TokenTypeIssuers returns the token types that can be issued, each bound to its authorized issuers
+func (p *PublicParams) TokenTypeIssuers() []*driver.TokenTypeIssuers {
+	return p.TypeIssuers
 }
 
 func (p *PublicParams) Precision() uint64 {
@@ -277,13 +394,47 @@ func (p *PublicParams) Serialize() ([]byte, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to serialize range proof parameters")
 	}
-	issuers, err := protos.ToProtosSliceFunc(p.IssuerIDs, func(id driver.Identity) (*pp.Identity, error) {
-		return &pp.Identity{
-			Raw: id,
+	issuers, err := protos.ToProtosSliceFunc(p.IssuerIDs, toProtoIdentity)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize issuer")
+	}
+	additionalAuditors, err := protos.ToProtosSliceFunc(p.AdditionalAuditors, toProtoIdentity)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize additional auditors")
+	}
+	administrators, err := protos.ToProtosSliceFunc(p.AdminIDs, toProtoIdentity)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize administrators")
+	}
+	complianceAuthorities, err := protos.ToProtosSliceFunc(p.ComplianceIDs, toProtoIdentity)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize compliance authorities")
+	}
+	typeIssuers, err := protos.ToProtosSliceFunc(p.TypeIssuers, func(entry *driver.TokenTypeIssuers) (*pp.TokenTypeIssuers, error) {
+		issuers, err := protos.ToProtosSliceFunc(entry.Issuers, toProtoIdentity)
+		if err != nil {
+			return nil, err
+		}
+		return &pp.TokenTypeIssuers{
+			TokenType: string(entry.TokenType),
+			Issuers:   issuers,
 		}, nil
 	})
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to serialize issuer")
+		return nil, errors.Wrapf(err, "failed to serialize token type issuers")
+	}
+	// sort the supply caps by token type to get a deterministic serialization
+	tokenTypes := make([]token.Type, 0, len(p.MaxSupply))
+	for tokenType := range p.MaxSupply {
+		tokenTypes = append(tokenTypes, tokenType)
+	}
+	slices2.Sort(tokenTypes)
+	supplyCaps := make([]*pp.SupplyCap, 0, len(tokenTypes))
+	for _, tokenType := range tokenTypes {
+		supplyCaps = append(supplyCaps, &pp.SupplyCap{
+			TokenType: string(tokenType),
+			Cap:       p.MaxSupply[tokenType],
+		})
 	}
 	idemixIssuerPublicKeys, err := protos.ToProtosSlice[pp.IdemixIssuerPublicKey, *IdemixIssuerPublicKey](p.IdemixIssuerPublicKeys)
 	if err != nil {
@@ -302,9 +453,21 @@ func (p *PublicParams) Serialize() ([]byte, error) {
 		Auditor: &pp.Identity{
 			Raw: p.Auditor,
 		},
-		Issuers:           issuers,
-		MaxToken:          p.MaxToken,
-		QuantityPrecision: p.QuantityPrecision,
+		Issuers:               issuers,
+		MaxToken:              p.MaxToken,
+		QuantityPrecision:     p.QuantityPrecision,
+		TokenTypeIssuers:      typeIssuers,
+		AdditionalAuditors:    additionalAuditors,
+		AuditorsQuorum:        p.AuditorsQuorum,
+		SupplyCaps:            supplyCaps,
+		Administrators:        administrators,
+		AdministratorsQuorum:  p.AdministratorsQuorum,
+		ComplianceAuthorities: complianceAuthorities,
+		ComplianceQuorum:      p.ComplianceQuorum,
+	}
+	if len(p.RevocationPK) != 0 {
+		publicParams.RevocationPk = p.RevocationPK
+		publicParams.RevocationEpochLength = p.RevocationEpoch
 	}
 	raw, err := proto.Marshal(publicParams)
 	if err != nil {
@@ -343,16 +506,27 @@ func (p *PublicParams) Deserialize(raw []byte) error {
 		return errors.Wrapf(err, "failed to deserialize public parameters")
 	}
 	p.PedersenGenerators = pg
-	issuers, err := protos.FromProtosSliceFunc2(publicParams.Issuers, func(id *pp.Identity) (driver.Identity, error) {
-		if id == nil {
+	issuers, err := protos.FromProtosSliceFunc2(publicParams.Issuers, fromProtoIdentity)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize issuers")
+	}
+	p.IssuerIDs = issuers
+	p.TypeIssuers, err = protos.FromProtosSliceFunc2(publicParams.TokenTypeIssuers, func(entry *pp.TokenTypeIssuers) (*driver.TokenTypeIssuers, error) {
+		if entry == nil {
 			return nil, nil
 		}
-		return id.Raw, nil
+		issuers, err := protos.FromProtosSliceFunc2(entry.Issuers, fromProtoIdentity)
+		if err != nil {
+			return nil, err
+		}
+		return &driver.TokenTypeIssuers{
+			TokenType: token.Type(entry.TokenType),
+			Issuers:   issuers,
+		}, nil
 	})
 	if err != nil {
-		return errors.Wrapf(err, "failed to deserialize issuers")
+		return errors.Wrapf(err, "failed to deserialize token type issuers")
 	}
-	p.IssuerIDs = issuers
 
 	p.IdemixIssuerPublicKeys = slices.GenericSliceOfPointers[IdemixIssuerPublicKey](len(publicParams.IdemixIssuerPublicKeys))
 	err = protos.FromProtosSlice[pp.IdemixIssuerPublicKey, *IdemixIssuerPublicKey](publicParams.IdemixIssuerPublicKeys, p.IdemixIssuerPublicKeys)
@@ -362,6 +536,30 @@ func (p *PublicParams) Deserialize(raw []byte) error {
 	if publicParams.Auditor != nil {
 		p.Auditor = publicParams.Auditor.Raw
 	}
+	p.AdditionalAuditors, err = protos.FromProtosSliceFunc2(publicParams.AdditionalAuditors, fromProtoIdentity)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize additional auditors")
+	}
+	p.AuditorsQuorum = publicParams.AuditorsQuorum
+	p.AdminIDs, err = protos.FromProtosSliceFunc2(publicParams.Administrators, fromProtoIdentity)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize administrators")
+	}
+	p.AdministratorsQuorum = publicParams.AdministratorsQuorum
+	p.ComplianceIDs, err = protos.FromProtosSliceFunc2(publicParams.ComplianceAuthorities, fromProtoIdentity)
+	if err != nil {
+		return errors.Wrapf(err, "failed to deserialize compliance authorities")
+	}
+	p.ComplianceQuorum = publicParams.ComplianceQuorum
+	p.RevocationPK = publicParams.RevocationPk
+	p.RevocationEpoch = publicParams.RevocationEpochLength
+	p.MaxSupply = nil
+	for _, supplyCap := range publicParams.SupplyCaps {
+		if supplyCap == nil {
+			continue
+		}
+		p.SetSupplyCap(token.Type(supplyCap.TokenType), supplyCap.Cap)
+	}
 
 	p.RangeProofParams = &RangeProofParams{}
 	if err := p.RangeProofParams.FromProto(publicParams.RangeProofParams); err != nil {
@@ -405,14 +603,96 @@ func (p *PublicParams) GenerateRangeProofParameters(bitLength uint64) error {
 	return nil
 }
 
+// AddAuditor adds the passed auditor.
+// The first auditor is stored in the Auditor field, the others in AdditionalAuditors.
 func (p *PublicParams) AddAuditor(auditor driver.Identity) {
-	p.Auditor = auditor
+	if len(p.Auditor) == 0 {
+		p.Auditor = auditor
+		return
+	}
+	p.AdditionalAuditors = append(p.AdditionalAuditors, auditor)
+}
+
+// SetAuditorsThreshold sets the minimum number of auditors that must sign a token request.
+// Zero means that all auditors must sign.
+func (p *PublicParams) SetAuditorsThreshold(threshold uint64) {
+	p.AuditorsQuorum = threshold
 }
 
 func (p *PublicParams) AddIssuer(id driver.Identity) {
 	p.IssuerIDs = append(p.IssuerIDs, id)
 }
 
+// AddAdministrator adds the passed identity to the administrators
+func (p *PublicParams) AddAdministrator(id driver.Identity) {
+	p.AdminIDs = append(p.AdminIDs, id)
+}
+
+// SetAdministratorsThreshold sets the minimum number of administrators that must sign an administrative action.
+// Zero means that all administrators must sign.
+func (p *PublicParams) SetAdministratorsThreshold(threshold uint64) {
+	p.AdministratorsQuorum = threshold
+}
+
+// AddComplianceAuthority adds the passed identity to the compliance authorities
+func (p *PublicParams) AddComplianceAuthority(id driver.Identity) {
+	p.ComplianceIDs = append(p.ComplianceIDs, id)
+}
+
+// ComplianceAuthorities returns the list of identities authorized to freeze, unfreeze, and claw back tokens
+func (p *PublicParams) ComplianceAuthorities() []driver.Identity {
+	return p.ComplianceIDs
+}
+
+// SetComplianceThreshold sets the minimum number of compliance authorities that must authorize a clawback.
+// Zero means that all compliance authorities must.
+func (p *PublicParams) SetComplianceThreshold(threshold uint64) {
+	p.ComplianceQuorum = threshold
+}
+
+// ComplianceThreshold returns the minimum number of compliance authorities that must authorize a clawback
+func (p *PublicParams) ComplianceThreshold() uint64 {
+	return common.ComplianceThreshold(len(p.ComplianceIDs), p.ComplianceQuorum)
+}
+
+// RevocationPublicKey returns the PEM encoding of the public key of the revocation authority
+func (p *PublicParams) RevocationPublicKey() []byte {
+	return p.RevocationPK
+}
+
+// RevocationEpochLength returns the number of blocks of an epoch of the revocation authority
+func (p *PublicParams) RevocationEpochLength() uint64 {
+	return p.RevocationEpoch
+}
+
+// SetRevocationPublicKey sets the PEM encoding of the public key of the revocation authority and the number of blocks of its epochs.
+// From then on, the signatures of idemix owners must carry a non-revocation proof.
+func (p *PublicParams) SetRevocationPublicKey(pk []byte, epoch uint64) {
+	p.RevocationPK = pk
+	p.RevocationEpoch = epoch
+}
+
+// AddTokenTypeIssuers binds the passed token type, or prefix of token types, to the passed issuers
+func (p *PublicParams) AddTokenTypeIssuers(tokenType token.Type, issuers ...driver.Identity) {
+	p.TypeIssuers = append(p.TypeIssuers, &driver.TokenTypeIssuers{
+		TokenType: tokenType,
+		Issuers:   issuers,
+	})
+}
+
+// SetSupplyCap sets the maximum circulating supply of the passed token type
+func (p *PublicParams) SetSupplyCap(tokenType token.Type, supplyCap uint64) {
+	if p.MaxSupply == nil {
+		p.MaxSupply = map[token.Type]uint64{}
+	}
+	p.MaxSupply[tokenType] = supplyCap
+}
+
+// SupplyCaps returns the maximum circulating supply of the token types that have one
+func (p *PublicParams) SupplyCaps() map[token.Type]uint64 {
+	return p.MaxSupply
+}
+
 func (p *PublicParams) ComputeHash() ([]byte, error) {
 	raw, err := p.Bytes()
 	if err != nil {
@@ -482,12 +762,73 @@ func (p *PublicParams) Validate() error {
 	if maxToken != p.MaxToken {
 		return errors.Errorf("invalid maxt token, [%d]!=[%d]", maxToken, p.MaxToken)
 	}
+	if err := common.ValidateTokenTypeIssuers(p.TypeIssuers); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if len(p.Auditor) == 0 && len(p.AdditionalAuditors) != 0 {
+		return errors.New("invalid public parameters: additional auditors set without an auditor")
+	}
+	if err := common.ValidateAuditors(p.Auditors(), p.AuditorsQuorum); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := common.ValidateComplianceAuthorities(p.ComplianceIDs, p.ComplianceQuorum); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := common.ValidateAdministrators(p.AdminIDs, p.AdministratorsQuorum); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if err := common.ValidateSupplyCaps(p.MaxSupply); err != nil {
+		return errors.Wrap(err, "invalid public parameters")
+	}
+	if len(p.RevocationPK) != 0 {
+		if err := p.validateRevocation(); err != nil {
+			return errors.Wrap(err, "invalid public parameters")
+		}
+	}
 	// if len(pp.Issuers) == 0 {
 	//	return errors.New("invalid public parameters: empty list of issuers")
 	// }
 	return nil
 }
 
+// validateRevocation checks the revocation public key and the epoch length.
+// The non-revocation proofs are supported only by the idemix issuers on the curves that do not use BBS signatures.
+func (p *PublicParams) validateRevocation() error {
+	if p.RevocationEpoch == 0 {
+		return errors.New("invalid revocation epoch, it must be at least one block")
+	}
+	block, _ := pem.Decode(p.RevocationPK)
+	if block == nil {
+		return errors.New("invalid revocation public key, failed to decode PEM")
+	}
+	pk, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "invalid revocation public key")
+	}
+	if _, ok := pk.(*ecdsa.PublicKey); !ok {
+		return errors.Errorf("invalid revocation public key, expected an ECDSA key, got [%T]", pk)
+	}
+	for _, ipk := range p.IdemixIssuerPublicKeys {
+		if ipk.Curve == mathlib.BLS12_381_BBS {
+			return errors.New("owner revocation is not supported by the idemix issuers on curve BLS12_381_BBS")
+		}
+	}
+	return nil
+}
+
+func toProtoIdentity(id driver.Identity) (*pp.Identity, error) {
+	return &pp.Identity{
+		Raw: id,
+	}, nil
+}
+
+func fromProtoIdentity(id *pp.Identity) (driver.Identity, error) {
+	if id == nil {
+		return nil, nil
+	}
+	return id.Raw, nil
+}
+
 func log2(x uint64) uint64 {
 	return 63 - uint64(bits.LeadingZeros64(x))
 }
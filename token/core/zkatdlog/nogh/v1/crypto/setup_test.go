@@ -13,6 +13,7 @@ import (
 
 	math3 "github.com/IBM/mathlib"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,6 +55,161 @@ func TestSerialization(t *testing.T) {
 
 }
 
+func TestSerializationWithTokenTypeIssuers(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	pp.AddIssuer([]byte("issuer"))
+	pp.AddTokenTypeIssuers("USD", []byte("usd issuer"))
+	pp.AddTokenTypeIssuers("EUR*")
+	assert.NoError(t, pp.Validate())
+
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, pp.TypeIssuers, pp2.TypeIssuers)
+	assert.Equal(t, []driver.Identity{[]byte("issuer"), []byte("usd issuer")}, pp2.Issuers())
+
+	pp2.AddTokenTypeIssuers("USD")
+	assert.Error(t, pp2.Validate())
+}
+
+func TestSerializationWithAuditors(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pp.AuditorsThreshold())
+	pp.AddAuditor([]byte("auditor1"))
+	pp.AddAuditor([]byte("auditor2"))
+	pp.AddAuditor([]byte("auditor3"))
+	pp.SetAuditorsThreshold(2)
+	assert.NoError(t, pp.Validate())
+
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Identity{[]byte("auditor1"), []byte("auditor2"), []byte("auditor3")}, pp2.Auditors())
+	assert.Equal(t, uint64(2), pp2.AuditorsThreshold())
+
+	pp2.SetAuditorsThreshold(0)
+	assert.Equal(t, uint64(3), pp2.AuditorsThreshold())
+	pp2.SetAuditorsThreshold(4)
+	assert.Error(t, pp2.Validate())
+}
+
+func TestSerializationWithSupplyCaps(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	assert.Empty(t, pp.SupplyCaps())
+	pp.SetSupplyCap("USD", 1000)
+	pp.SetSupplyCap("EUR", 500)
+	assert.NoError(t, pp.Validate())
+
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	ser2, err := pp.Serialize()
+	assert.NoError(t, err)
+	assert.Equal(t, ser, ser2)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, map[token.Type]uint64{"USD": 1000, "EUR": 500}, pp2.SupplyCaps())
+
+	pp2.SetSupplyCap("GOLD", 0)
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: invalid supply cap for token type [GOLD], must be greater than 0")
+}
+
+func TestSerializationWithComplianceAuthorities(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pp.ComplianceThreshold())
+	pp.AddComplianceAuthority([]byte("compliance1"))
+	pp.AddComplianceAuthority([]byte("compliance2"))
+	pp.AddComplianceAuthority([]byte("compliance3"))
+	pp.SetComplianceThreshold(2)
+	assert.NoError(t, pp.Validate())
+
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.Identity{[]byte("compliance1"), []byte("compliance2"), []byte("compliance3")}, pp2.ComplianceAuthorities())
+	assert.Equal(t, uint64(2), pp2.ComplianceThreshold())
+
+	pp2.SetComplianceThreshold(0)
+	assert.Equal(t, uint64(3), pp2.ComplianceThreshold())
+	pp2.SetComplianceThreshold(4)
+	assert.Error(t, pp2.Validate())
+}
+
+func TestSerializationWithRevocationPublicKey(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	revocationPK, err := os.ReadFile("./testdata/idemix/msp/RevocationPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	assert.Empty(t, pp.RevocationPublicKey())
+	pp.SetRevocationPublicKey(revocationPK, 100)
+	assert.NoError(t, pp.Validate())
+
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.Equal(t, revocationPK, pp2.RevocationPublicKey())
+	assert.Equal(t, uint64(100), pp2.RevocationEpochLength())
+
+	pp2.SetRevocationPublicKey(revocationPK, 0)
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: invalid revocation epoch, it must be at least one block")
+	pp2.SetRevocationPublicKey([]byte("ra"), 100)
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: invalid revocation public key, failed to decode PEM")
+	pp2.SetRevocationPublicKey(revocationPK, 100)
+	pp2.IdemixIssuerPublicKeys[0].Curve = math3.BLS12_381_BBS
+	assert.EqualError(t, pp2.Validate(), "invalid public parameters: owner revocation is not supported by the idemix issuers on curve BLS12_381_BBS")
+}
+
+func TestAggregatedRangeProofs(t *testing.T) {
+	issuerPK, err := os.ReadFile("./testdata/idemix/msp/IssuerPublicKey")
+	assert.NoError(t, err)
+	pp, err := Setup(32, issuerPK, math3.BN254)
+	assert.NoError(t, err)
+	assert.False(t, pp.AggregatedRangeProofs())
+
+	pp.EnableAggregatedRangeProofs()
+	assert.True(t, pp.AggregatedRangeProofs())
+	ser, err := pp.Serialize()
+	assert.NoError(t, err)
+	pp2, err := NewPublicParamsFromBytes(ser, DLogPublicParameters)
+	assert.NoError(t, err)
+	assert.True(t, pp2.AggregatedRangeProofs())
+	assert.NoError(t, pp2.Validate())
+
+	// the generators for aggregation extend those of the public parameters
+	generators := pp.RangeProofParams.AggregationGenerators(pp.Curve)
+	left, right, err := generators(4 * 32)
+	assert.NoError(t, err)
+	assert.Len(t, left, 4*32)
+	assert.Len(t, right, 4*32)
+	assert.Equal(t, pp.RangeProofParams.LeftGenerators, left[:32])
+	assert.Equal(t, pp.RangeProofParams.RightGenerators, right[:32])
+	assert.NoError(t, pp.GenerateRangeProofParameters(4*32))
+	assert.Equal(t, pp.RangeProofParams.LeftGenerators, left)
+	assert.Equal(t, pp.RangeProofParams.RightGenerators, right)
+
+	_, _, err = generators(16)
+	assert.Error(t, err)
+	_, _, err = generators(32 * 1024)
+	assert.Error(t, err)
+}
+
 func TestComputeMaxTokenValue(t *testing.T) {
 	pp := PublicParams{
 		RangeProofParams: &RangeProofParams{
@@ -282,8 +282,9 @@ func (t *Action) Validate() error {
 	return nil
 }
 
+// ExtraSigners returns the compliance authorities that authorize the clawback carried by the action, if any
 func (t *Action) ExtraSigners() []driver.Identity {
-	return nil
+	return driver.ClawbackAuthorizers(t.Metadata)
 }
 
 // Serialize marshal TransferAction
@@ -51,9 +51,14 @@ func NewVerifier(inputs, outputs []*math.G1, pp *v1.PublicParams) *Verifier {
 	v.TypeAndSum = NewTypeAndSumVerifier(pp.PedersenGenerators, inputs, outputs, math.Curves[pp.Curve])
 
 	// check if this is an ownership transfer
-	// if so, skip range proof, well-formedness proof is enough
+	// if so, skip range proof, well-formedness proof is enough.
+	// If required by the public parameters, the range proofs of the outputs are aggregated into a single proof,
+	// the verifier keeps accepting a proof for each output.
 	if len(inputs) != 1 || len(outputs) != 1 {
 		v.RangeCorrectness = rp.NewRangeCorrectnessVerifier(pp.PedersenGenerators[1:], pp.RangeProofParams.LeftGenerators, pp.RangeProofParams.RightGenerators, pp.RangeProofParams.P, pp.RangeProofParams.Q, pp.RangeProofParams.BitLength, pp.RangeProofParams.NumberOfRounds, math.Curves[pp.Curve])
+		if pp.AggregatedRangeProofs() {
+			v.RangeCorrectness.AggregationGenerators = pp.RangeProofParams.AggregationGenerators(pp.Curve)
+		}
 	}
 
 	return v
@@ -108,6 +113,9 @@ func NewProver(inputWitness, outputWitness []*token.TokenDataWitness, inputs, ou
 			coms[i].Sub(commitmentToType)
 		}
 		p.RangeCorrectness = rp.NewRangeCorrectnessProver(coms, values, blindingFactors, pp.PedersenGenerators[1:], pp.RangeProofParams.LeftGenerators, pp.RangeProofParams.RightGenerators, pp.RangeProofParams.P, pp.RangeProofParams.Q, pp.RangeProofParams.BitLength, pp.RangeProofParams.NumberOfRounds, math.Curves[pp.Curve])
+		if pp.AggregatedRangeProofs() {
+			p.RangeCorrectness.AggregationGenerators = pp.RangeProofParams.AggregationGenerators(pp.Curve)
+		}
 
 	}
 	return p, nil
@@ -82,6 +82,51 @@ var _ = Describe("Transfer", func() {
 				Expect(err.Error()).To(ContainSubstring("invalid range proof at index 0: invalid range proof"))
 			})
 		})
+		Context("public parameters require aggregated range proofs", func() {
+			var (
+				pp                 *v1.PublicParams
+				intw, outtw        []*token.TokenDataWitness
+				in, out            []*math.G1
+				aggregatedVerifier *transfer.Verifier
+			)
+			BeforeEach(func() {
+				var err error
+				pp, err = v1.Setup(32, nil, math.FP256BN_AMCL)
+				Expect(err).NotTo(HaveOccurred())
+				intw, outtw, in, out = prepareInputsForZKTransfer(pp)
+				// per-output range proofs
+				prover, err = transfer.NewProver(intw, outtw, in, out, pp)
+				Expect(err).NotTo(HaveOccurred())
+				verifier = transfer.NewVerifier(in, out, pp)
+
+				pp.EnableAggregatedRangeProofs()
+				Expect(pp.AggregatedRangeProofs()).To(BeTrue())
+				aggregatedVerifier = transfer.NewVerifier(in, out, pp)
+			})
+			It("aggregates the range proofs of the outputs", func() {
+				aggregatedProver, err := transfer.NewProver(intw, outtw, in, out, pp)
+				Expect(err).NotTo(HaveOccurred())
+				raw, err := aggregatedProver.Prove()
+				Expect(err).NotTo(HaveOccurred())
+				proof := &transfer.Proof{}
+				Expect(proof.Deserialize(raw)).To(Succeed())
+				Expect(proof.RangeCorrectness.Proofs).To(HaveLen(1))
+				Expect(aggregatedVerifier.Verify(raw)).To(Succeed())
+
+				// verifiers of previous versions do not accept aggregated range proofs
+				err = verifier.Verify(raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("aggregated range proofs are not supported"))
+			})
+			It("accepts a range proof for each output", func() {
+				raw, err := prover.Prove()
+				Expect(err).NotTo(HaveOccurred())
+				proof := &transfer.Proof{}
+				Expect(proof.Deserialize(raw)).To(Succeed())
+				Expect(proof.RangeCorrectness.Proofs).To(HaveLen(2))
+				Expect(aggregatedVerifier.Verify(raw)).To(Succeed())
+			})
+		})
 	})
 })
 
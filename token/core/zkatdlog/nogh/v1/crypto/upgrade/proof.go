@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package upgrade
+
+import (
+	"bytes"
+	"encoding/asn1"
+	errors2 "errors"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/core"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// Proof proves the ownership of the fabtoken tokens to upgrade to the issuer asked for the upgrade.
+// It carries, for each token, the signature of its owner on the challenge and the tokens, see MessageToSign.
+// The order of the signatures is the same as the order of the tokens.
+// The proof is checked off-chain only. On the ledger, the owners of the upgraded tokens sign the token request
+// as extra signers of the issue action, binding their consent to the outputs and the anchor of the request.
+type Proof struct {
+	Challenge  []byte
+	Signatures [][]byte
+}
+
+func (p *Proof) Serialize() ([]byte, error) {
+	return asn1.Marshal(*p)
+}
+
+func (p *Proof) Deserialize(raw []byte) error {
+	_, err := asn1.Unmarshal(raw, p)
+	return err
+}
+
+// Input is a token to upgrade, as it is stored on the ledger
+type Input struct {
+	ID    token.ID
+	Token []byte
+}
+
+// InputsFromLedgerTokens returns the inputs corresponding to the passed ledger tokens
+func InputsFromLedgerTokens(tokens []token.LedgerToken) []*Input {
+	inputs := make([]*Input, len(tokens))
+	for i, tok := range tokens {
+		inputs[i] = &Input{ID: tok.ID, Token: tok.Token}
+	}
+	return inputs
+}
+
+// MessageToSign returns the message the owners of the passed inputs sign to answer the passed challenge
+func MessageToSign(challenge driver.TokensUpgradeChallenge, inputs []*Input) ([]byte, error) {
+	hasher := utils.NewSHA256Hasher()
+	if err := errors2.Join(
+		hasher.AddInt(len(challenge)),
+		hasher.AddBytes(challenge),
+		hasher.AddInt(len(inputs)),
+	); err != nil {
+		return nil, errors.Wrap(err, "failed to hash challenge")
+	}
+	for i, input := range inputs {
+		if err := errors2.Join(
+			hasher.AddInt(len(input.ID.TxId)),
+			hasher.AddString(input.ID.TxId),
+			hasher.AddUInt64(input.ID.Index),
+			hasher.AddInt(len(input.Token)),
+			hasher.AddBytes(input.Token),
+		); err != nil {
+			return nil, errors.Wrapf(err, "failed to hash input [%d]", i)
+		}
+	}
+	return hasher.Digest(), nil
+}
+
+// SignerProvider returns the signer of an identity
+type SignerProvider interface {
+	GetSigner(identity driver.Identity) (driver.Signer, error)
+}
+
+// Prover generates upgrade proofs with the signers of the owners of the tokens to upgrade
+type Prover struct {
+	SignerProvider SignerProvider
+}
+
+func NewProver(signerProvider SignerProvider) *Prover {
+	return &Prover{SignerProvider: signerProvider}
+}
+
+// Prove returns the serialized proof of ownership of the passed inputs for the passed challenge
+func (p *Prover) Prove(challenge driver.TokensUpgradeChallenge, inputs []*Input) ([]byte, error) {
+	if len(challenge) == 0 {
+		return nil, errors.New("empty challenge")
+	}
+	if len(inputs) == 0 {
+		return nil, errors.New("no tokens to upgrade")
+	}
+	msg, err := MessageToSign(challenge, inputs)
+	if err != nil {
+		return nil, err
+	}
+	proof := &Proof{
+		Challenge:  challenge,
+		Signatures: make([][]byte, len(inputs)),
+	}
+	for i, input := range inputs {
+		owner, err := OwnerOf(input.Token)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid token at [%d]", i)
+		}
+		signer, err := p.SignerProvider.GetSigner(owner)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get signer for the owner of token [%s]", input.ID)
+		}
+		proof.Signatures[i], err = signer.Sign(msg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to sign for token [%s]", input.ID)
+		}
+	}
+	return proof.Serialize()
+}
+
+// VerifierProvider returns the verifier of the owner of a token
+type VerifierProvider interface {
+	GetOwnerVerifier(id driver.Identity) (driver.Verifier, error)
+}
+
+// Verifier checks upgrade proofs against the owners of the tokens to upgrade
+type Verifier struct {
+	VerifierProvider VerifierProvider
+}
+
+func NewVerifier(verifierProvider VerifierProvider) *Verifier {
+	return &Verifier{VerifierProvider: verifierProvider}
+}
+
+// Verify checks that the passed serialized proof answers the passed challenge and
+// carries a valid signature of the owner of each input.
+func (v *Verifier) Verify(challenge driver.TokensUpgradeChallenge, raw []byte, inputs []*Input) error {
+	if len(challenge) == 0 {
+		return errors.New("empty challenge")
+	}
+	if len(raw) == 0 {
+		return errors.New("empty upgrade proof")
+	}
+	if len(inputs) == 0 {
+		return errors.New("no tokens to upgrade")
+	}
+	proof := &Proof{}
+	if err := proof.Deserialize(raw); err != nil {
+		return errors.Wrap(err, "failed to deserialize upgrade proof")
+	}
+	if !bytes.Equal(challenge, proof.Challenge) {
+		return errors.New("upgrade proof does not answer the challenge")
+	}
+	if len(proof.Signatures) != len(inputs) {
+		return errors.Errorf("expected [%d] signatures, got [%d]", len(inputs), len(proof.Signatures))
+	}
+	msg, err := MessageToSign(challenge, inputs)
+	if err != nil {
+		return err
+	}
+	for i, input := range inputs {
+		owner, err := OwnerOf(input.Token)
+		if err != nil {
+			return errors.Wrapf(err, "invalid token at [%d]", i)
+		}
+		verifier, err := v.VerifierProvider.GetOwnerVerifier(owner)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get verifier for the owner of token [%s]", input.ID)
+		}
+		if err := verifier.Verify(msg, proof.Signatures[i]); err != nil {
+			return errors.Wrapf(err, "invalid signature for token [%s]", input.ID)
+		}
+	}
+	return nil
+}
+
+// OwnerOf returns the owner of the passed fabtoken token, as it is stored on the ledger
+func OwnerOf(raw []byte) (driver.Identity, error) {
+	output := &core.Output{}
+	if err := output.Deserialize(raw); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal fabtoken")
+	}
+	if len(output.Owner) == 0 {
+		return nil, errors.New("token without owner")
+	}
+	return output.Owner, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package upgrade
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/fabtoken/v1/core"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProveAndVerify(t *testing.T) {
+	inputs := []*Input{
+		fabtoken(t, "tx1", 0, "alice"),
+		fabtoken(t, "tx2", 1, "bob"),
+	}
+	challenge := []byte("challenge")
+	prover := NewProver(&keys{owners: []string{"alice", "bob"}})
+	verifier := NewVerifier(&keys{})
+
+	proof, err := prover.Prove(challenge, inputs)
+	require.NoError(t, err)
+	assert.NoError(t, verifier.Verify(challenge, proof, inputs))
+	// the challenge is required
+	assert.EqualError(t, verifier.Verify(nil, proof, inputs), "empty challenge")
+
+	// another challenge
+	assert.EqualError(t, verifier.Verify([]byte("another"), proof, inputs), "upgrade proof does not answer the challenge")
+	// other tokens
+	assert.Error(t, verifier.Verify(challenge, proof, []*Input{inputs[1], inputs[0]}))
+	assert.EqualError(t, verifier.Verify(challenge, proof, inputs[:1]), "expected [1] signatures, got [2]")
+	// no proof
+	assert.EqualError(t, verifier.Verify(challenge, nil, inputs), "empty upgrade proof")
+
+	// a token of someone else cannot be upgraded
+	_, err = NewProver(&keys{owners: []string{"alice"}}).Prove(challenge, inputs)
+	assert.Error(t, err)
+	forged := &Proof{Challenge: challenge, Signatures: [][]byte{sign("alice", nil), sign("alice", nil)}}
+	raw, err := forged.Serialize()
+	require.NoError(t, err)
+	assert.Error(t, verifier.Verify(challenge, raw, inputs))
+
+	// only fabtoken tokens can be upgraded
+	_, err = prover.Prove(challenge, []*Input{{ID: token.ID{TxId: "tx3"}, Token: []byte("invalid")}})
+	assert.Error(t, err)
+	_, err = prover.Prove(nil, inputs)
+	assert.EqualError(t, err, "empty challenge")
+}
+
+func TestMessageToSign(t *testing.T) {
+	a, err := MessageToSign([]byte("challenge"), []*Input{{ID: token.ID{TxId: "ab", Index: 1}, Token: []byte("c")}})
+	require.NoError(t, err)
+	b, err := MessageToSign([]byte("challenge"), []*Input{{ID: token.ID{TxId: "a", Index: 1}, Token: []byte("bc")}})
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func fabtoken(t *testing.T, txID string, index uint64, owner string) *Input {
+	raw, err := (&core.Output{Owner: []byte(owner), Type: "USD", Quantity: "0x10"}).Serialize()
+	require.NoError(t, err)
+	return &Input{ID: token.ID{TxId: txID, Index: index}, Token: raw}
+}
+
+func sign(owner string, msg []byte) []byte {
+	return append([]byte(owner+":"), msg...)
+}
+
+// keys signs on behalf of the listed owners, and verifies the signatures of any owner
+type keys struct {
+	owners []string
+}
+
+func (k *keys) GetSigner(identity driver.Identity) (driver.Signer, error) {
+	for _, owner := range k.owners {
+		if owner == string(identity) {
+			return &key{owner: owner}, nil
+		}
+	}
+	return nil, errors.Errorf("no signer for [%s]", identity)
+}
+
+func (k *keys) GetOwnerVerifier(identity driver.Identity) (driver.Verifier, error) {
+	return &key{owner: string(identity)}, nil
+}
+
+type key struct {
+	owner string
+}
+
+func (k *key) Sign(msg []byte) ([]byte, error) {
+	return sign(k.owner, msg), nil
+}
+
+func (k *key) Verify(msg, sigma []byte) error {
+	if !bytes.Equal(sign(k.owner, msg), sigma) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -37,12 +37,20 @@ func (d *base) PublicParametersFromBytes(params []byte) (driver.PublicParameters
 }
 
 func (d *base) DefaultValidator(pp driver.PublicParameters) (driver.Validator, error) {
+	return d.newValidator(pp, 0)
+}
+
+// newValidator returns a validator for the passed public parameters with a worker pool of the passed size,
+// see common.Validator.Parallelism
+func (d *base) newValidator(pp driver.PublicParameters, parallelism int) (*validator.Validator, error) {
 	deserializer, err := NewDeserializer(pp.(*v1.PublicParams))
 	if err != nil {
 		return nil, errors.Errorf("failed to create token service deserializer: %v", err)
 	}
 	logger := logging.DriverLoggerFromPP("token-sdk.driver.zkatdlog", pp.Identifier())
-	return validator.New(logger, pp.(*v1.PublicParams), deserializer), nil
+	v := validator.New(logger, pp.(*v1.PublicParams), deserializer)
+	v.Parallelism = parallelism
+	return v, nil
 }
 
 func (d *base) newWalletService(
@@ -7,21 +7,37 @@ SPDX-License-Identifier: Apache-2.0
 package driver
 
 import (
+	"sync"
+
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/deserializer"
 	idemix2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/idemix"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/multisig"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
 	htlc2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	timelock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	"github.com/pkg/errors"
 )
 
 // Deserializer deserializes verifiers associated with issuers, owners, and auditors
 type Deserializer struct {
 	*common.Deserializer
+	pp  *v1.PublicParams
+	des *deserializer.TypedVerifierDeserializerMultiplex
+
+	epochsLock sync.Mutex
+	epochs     map[uint64]*epochDeserializer
+}
+
+// epochDeserializer is the deserializer of an epoch, built for the revocation list with the given sequence number
+type epochDeserializer struct {
+	sequence uint64
+	des      *common.Deserializer
 }
 
 // NewDeserializer returns a deserializer
@@ -29,20 +45,76 @@ func NewDeserializer(pp *v1.PublicParams) (*Deserializer, error) {
 	if pp == nil {
 		return nil, errors.New("failed to get deserializer: nil public parameters")
 	}
+	des, err := newTypedVerifierDeserializer(pp, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Deserializer{
+		Deserializer: common.NewDeserializer(idemix2.IdentityType, des, des, des, des, des, des, des),
+		pp:           pp,
+		des:          des,
+		epochs:       map[uint64]*epochDeserializer{},
+	}, nil
+}
+
+// ForRevocationEpoch returns a deserializer whose owner verifiers require the signatures of the idemix identities,
+// including those nested in htlc, timelock, and multisig owners, to carry a non-revocation proof valid in the passed epoch,
+// disclosing a revocation handle that is not in the passed revocation list.
+// If the public parameters do not set a revocation public key, the deserializer itself is returned.
+func (d *Deserializer) ForRevocationEpoch(epoch uint64, revocationList *driver.RevocationList) (driver.Deserializer, error) {
+	if len(d.pp.RevocationPublicKey()) == 0 {
+		return d, nil
+	}
+	var sequence uint64
+	if revocationList != nil {
+		sequence = revocationList.Sequence
+	}
 
+	d.epochsLock.Lock()
+	defer d.epochsLock.Unlock()
+	if ed, ok := d.epochs[epoch]; ok && ed.sequence == sequence {
+		return ed.des, nil
+	}
+	owners, err := newTypedVerifierDeserializer(d.pp, func(idemixDes *idemix2.Deserializer) (common.VerifierDeserializer, error) {
+		return idemix2.NewNonRevocationDeserializer(idemixDes, d.pp.RevocationPublicKey(), epoch, revocationList)
+	})
+	if err != nil {
+		return nil, err
+	}
+	des := common.NewDeserializer(idemix2.IdentityType, d.des, owners, d.des, d.des, d.des, d.des, d.des)
+	// validation moves forward one epoch at a time, keep only the epochs next to the requested one
+	for e := range d.epochs {
+		if e+1 < epoch || e > epoch+1 {
+			delete(d.epochs, e)
+		}
+	}
+	d.epochs[epoch] = &epochDeserializer{sequence: sequence, des: des}
+	return des, nil
+}
+
+// newTypedVerifierDeserializer returns the deserializer of the identities supported by the passed public parameters.
+// If not nil, idemixVerifiers returns the deserializer of the verifiers of the idemix identities.
+func newTypedVerifierDeserializer(pp *v1.PublicParams, idemixVerifiers func(*idemix2.Deserializer) (common.VerifierDeserializer, error)) (*deserializer.TypedVerifierDeserializerMultiplex, error) {
 	des := deserializer.NewTypedVerifierDeserializerMultiplex()
 	for _, idemixIssuerPublicKey := range pp.IdemixIssuerPublicKeys {
 		idemixDes, err := idemix2.NewDeserializer(idemixIssuerPublicKey.PublicKey, idemixIssuerPublicKey.Curve)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed getting idemix deserializer for passed public params [%d]", idemixIssuerPublicKey.Curve)
 		}
-		des.AddTypedVerifierDeserializer(idemix2.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(idemixDes, idemixDes))
+		var verifierDes common.VerifierDeserializer = idemixDes
+		if idemixVerifiers != nil {
+			verifierDes, err = idemixVerifiers(idemixDes)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed getting idemix verifier deserializer for passed public params [%d]", idemixIssuerPublicKey.Curve)
+			}
+		}
+		des.AddTypedVerifierDeserializer(idemix2.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(verifierDes, idemixDes))
 	}
 	des.AddTypedVerifierDeserializer(x509.IdentityType, deserializer.NewTypedIdentityVerifierDeserializer(&x509.IdentityDeserializer{}, &x509.AuditMatcherDeserializer{}))
 	des.AddTypedVerifierDeserializer(htlc2.ScriptType, htlc.NewTypedIdentityDeserializer(des))
+	des.AddTypedVerifierDeserializer(timelock2.ScriptType, timelock.NewTypedIdentityDeserializer(des))
 	des.AddTypedVerifierDeserializer(multisig.Multisig, multisig.NewTypedIdentityDeserializer(des, des))
-
-	return &Deserializer{Deserializer: common.NewDeserializer(idemix2.IdentityType, des, des, des, des, des)}, nil
+	return des, nil
 }
 
 type TokenDeserializer struct{}
@@ -78,6 +150,7 @@ func NewEIDRHDeserializer() *EIDRHDeserializer {
 	d.AddDeserializer(idemix2.IdentityType, &idemix2.AuditInfoDeserializer{})
 	d.AddDeserializer(x509.IdentityType, &x509.AuditInfoDeserializer{})
 	d.AddDeserializer(htlc2.ScriptType, htlc.NewAuditDeserializer(&idemix2.AuditInfoDeserializer{}))
+	d.AddDeserializer(timelock2.ScriptType, timelock.NewAuditDeserializer(&idemix2.AuditInfoDeserializer{}))
 	d.AddDeserializer(multisig.Multisig, &multisig.AuditInfoDeserializer{})
 	return d
 }
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package driver
 
 import (
+	"context"
+
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
 	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/server/view"
@@ -21,6 +23,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/config"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx/multisig"
@@ -120,15 +123,19 @@ func (d *Driver) NewTokenService(tmsID driver.TMSID, publicParams []byte) (drive
 	deserializer := ws.Deserializer
 	ip := ws.IdentityProvider
 
+	revocationList := common.NewCachedRevocationList(func() ([]byte, error) {
+		return n.QueryRevocationList(context.Background(), tmsID.Namespace)
+	}, common.DefaultRevocationListRefreshInterval)
 	authorization := common.NewAuthorizationMultiplexer(
-		common.NewTMSAuthorization(logger, ppm.PublicParams(), ws),
+		common.NewTMSAuthorization(logger, ppm.PublicParams(), ws, revocationList),
 		htlc.NewScriptAuth(ws),
+		timelock.NewScriptAuth(ws),
 		multisig.NewEscrowAuth(ws),
 	)
 
 	metricsProvider := metrics.NewTMSProvider(tmsConfig.ID(), d.metricsProvider)
 	driverMetrics := v1.NewMetrics(metricsProvider)
-	tokensService, err := v1.NewTokensService(logger, ppm, deserializer)
+	tokensService, err := v1.NewTokensService(logger, ppm, deserializer, ip)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to initiliaze token service for [%s:%s]", tmsID.Network, tmsID.Namespace)
 	}
@@ -173,6 +180,9 @@ func (d *Driver) NewDefaultValidator(params driver.PublicParameters) (driver.Val
 	if !ok {
 		return nil, errors.Errorf("invalid public parameters type [%T]", params)
 	}
-
-	return d.DefaultValidator(pp)
+	parallelism, err := d.configService.ValidatorParallelism()
+	if err != nil {
+		return nil, err
+	}
+	return d.newValidator(pp, parallelism)
 }
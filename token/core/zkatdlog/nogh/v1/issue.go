@@ -175,7 +175,7 @@ func (s *IssueService) Issue(ctx context.Context, issuerIdentity driver.Identity
 		},
 		Inputs:       inputsMetadata,
 		Outputs:      outputsMetadata,
-		ExtraSigners: nil,
+		ExtraSigners: issueAction.ExtraSigners(),
 	}
 
 	return issueAction, meta, err
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/math"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/upgrade"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens/core/comm"
@@ -38,13 +39,15 @@ type TokensService struct {
 	Logger                  logging.Logger
 	PublicParametersManager common.PublicParametersManager[*crypto.PublicParams]
 	IdentityDeserializer    driver.Deserializer
+	UpgradeProver           *upgrade.Prover
+	UpgradeVerifier         *upgrade.Verifier
 
 	OutputTokenFormat               token.Format
 	SupportedTokenFormatList        []token.Format
 	UpgradeSupportedTokenFormatList []token.Format
 }
 
-func NewTokensService(logger logging.Logger, publicParametersManager common.PublicParametersManager[*crypto.PublicParams], identityDeserializer driver.Deserializer) (*TokensService, error) {
+func NewTokensService(logger logging.Logger, publicParametersManager common.PublicParametersManager[*crypto.PublicParams], identityDeserializer driver.Deserializer, signerProvider upgrade.SignerProvider) (*TokensService, error) {
 	// compute supported tokens
 	pp := publicParametersManager.PublicParams()
 	maxPrecision := pp.RangeProofParams.BitLength
@@ -86,6 +89,8 @@ func NewTokensService(logger logging.Logger, publicParametersManager common.Publ
 		TokensService:                   common.NewTokensService(),
 		PublicParametersManager:         publicParametersManager,
 		IdentityDeserializer:            identityDeserializer,
+		UpgradeProver:                   upgrade.NewProver(signerProvider),
+		UpgradeVerifier:                 upgrade.NewVerifier(identityDeserializer),
 		OutputTokenFormat:               outputTokenFormat,
 		SupportedTokenFormatList:        supportedTokenFormatList,
 		UpgradeSupportedTokenFormatList: upgradeSupportedTokenFormatList,
@@ -205,16 +210,40 @@ func (s *TokensService) DeserializeToken(outputFormat token.Format, outputRaw []
 		}, nil
 }
 
+// GenUpgradeProof proves the ownership of the passed tokens with a signature of each token owner on the challenge and the tokens, see upgrade.Proof
 func (s *TokensService) GenUpgradeProof(ch driver.TokensUpgradeChallenge, tokens []token.LedgerToken) ([]byte, error) {
-	// TODO: implement
-	return nil, nil
+	if err := s.checkUpgradeFormats(tokens); err != nil {
+		return nil, err
+	}
+	proof, err := s.UpgradeProver.Prove(ch, upgrade.InputsFromLedgerTokens(tokens))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate upgrade proof")
+	}
+	return proof, nil
 }
 
+// CheckUpgradeProof checks that the passed proof answers the challenge and carries a valid signature of the owner of each token.
+// If the challenge is empty, the proof is checked against the challenge it carries, as the validator does.
 func (s *TokensService) CheckUpgradeProof(ch driver.TokensUpgradeChallenge, proof driver.TokensUpgradeProof, tokens []token.LedgerToken) (bool, error) {
-	// TODO: implement
+	if err := s.checkUpgradeFormats(tokens); err != nil {
+		return false, err
+	}
+	if err := s.UpgradeVerifier.Verify(ch, proof, upgrade.InputsFromLedgerTokens(tokens)); err != nil {
+		s.Logger.Debugf("invalid upgrade proof: [%s]", err)
+		return false, nil
+	}
 	return true, nil
 }
 
+func (s *TokensService) checkUpgradeFormats(tokens []token.LedgerToken) error {
+	for _, tok := range tokens {
+		if !slices.Contains(s.UpgradeSupportedTokenFormatList, tok.Format) {
+			return errors.Errorf("upgrade of unsupported token format [%s] requested", tok.Format)
+		}
+	}
+	return nil
+}
+
 func (s *TokensService) deserializeTokenWithOutputTokenFormat(outputRaw []byte, metadataRaw []byte) (*token2.Token, *token2.Metadata, error) {
 	// get zkatdlog token
 	output, err := s.getOutput(outputRaw, false)
@@ -286,14 +315,7 @@ func (s *TokensService) ProcessTokensUpgradeRequest(utp *driver.TokenUpgradeRequ
 		return nil, nil, errors.New("nil token upgrade request")
 	}
 
-	// check that each token doesn't have a supported format
-	for _, tok := range utp.Tokens {
-		if !slices.Contains(s.UpgradeSupportedTokenFormatList, tok.Format) {
-			return nil, nil, errors.Errorf("upgrade of unsupported token format [%s] requested", tok.Format)
-		}
-	}
-
-	// check the upgrade proof
+	// check the upgrade proof, it also checks that each token doesn't have a supported format
 	ok, err := s.CheckUpgradeProof(utp.Challenge, utp.Proof, utp.Tokens)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to check upgrade proof")
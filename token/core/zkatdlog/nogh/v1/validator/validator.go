@@ -62,14 +62,16 @@ func New(
 		TransferUpgradeWitnessValidate,
 		TransferZKProofValidate,
 		TransferHTLCValidate,
+		TransferTimelockValidate,
 	}
 	transferValidators = append(transferValidators, extraValidators...)
 
 	issueValidators := []ValidateIssueFunc{
 		IssueValidate,
+		IssueUpgradeValidate,
 	}
 
-	return common.NewValidator[*v1.PublicParams, *token.Token, *transfer.Action, *issue.Action, driver.Deserializer](
+	v := common.NewValidator[*v1.PublicParams, *token.Token, *transfer.Action, *issue.Action, driver.Deserializer](
 		logger,
 		pp,
 		deserializer,
@@ -77,4 +79,11 @@ func New(
 		transferValidators,
 		issueValidators,
 	)
+	v.ClawbackValidators = []ValidateTransferFunc{
+		TransferActionValidate,
+		TransferClawbackValidate,
+		TransferUpgradeWitnessValidate,
+		TransferZKProofValidate,
+	}
+	return v
 }
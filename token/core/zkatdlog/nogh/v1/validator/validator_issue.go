@@ -7,9 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package validator
 
 import (
-	"bytes"
-
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/issue"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/upgrade"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/pkg/errors"
 )
@@ -25,27 +26,39 @@ func IssueValidate(ctx *Context) error {
 	if err != nil {
 		return errors.New("failed to verify issue")
 	}
-	if err := issue.NewVerifier(
-		commitments,
-		ctx.PP).Verify(action.GetProof()); err != nil {
+	proofVerifier := issue.NewVerifier(commitments, ctx.PP)
+	if ctx.Workers != nil {
+		proofVerifier.RangeCorrectness.Executor = ctx.Workers
+	}
+	if err := proofVerifier.Verify(action.GetProof()); err != nil {
 		return err
 	}
 
-	issuers := ctx.PP.IssuerIDs
-	if len(issuers) != 0 {
+	if len(ctx.PP.TypeIssuers) == 0 {
 		// Check the issuer is among those known
-		found := false
-		for _, issuer := range issuers {
-			if bytes.Equal(action.Issuer, issuer) {
-				found = true
-				break
-			}
-		}
-		if !found {
+		if err := common.IsAuthorizedIssuer(ctx.PP.IssuerIDs, nil, "", action.Issuer); err != nil {
 			return errors.Errorf("issuer [%s] is not in issuers", driver.Identity(action.Issuer).String())
 		}
+	} else {
+		// The type of the issued tokens must be disclosed, and the issuer must be authorized for it
+		if action.TypeOpening == nil {
+			return errors.New("issue action does not disclose the type of the issued tokens")
+		}
+		proof := &issue.Proof{}
+		if err := proof.Deserialize(action.GetProof()); err != nil {
+			return errors.Wrap(err, "failed to deserialize issue proof")
+		}
+		if err := action.TypeOpening.Verify(proof.SameType.CommitmentToType, ctx.PP.PedersenGenerators, math.Curves[ctx.PP.Curve]); err != nil {
+			return errors.Wrap(err, "failed to verify the type of the issued tokens")
+		}
+		if err := common.IsAuthorizedIssuer(ctx.PP.IssuerIDs, ctx.PP.TypeIssuers, action.TypeOpening.Type, action.Issuer); err != nil {
+			return errors.Wrapf(err, "issuer [%s] is not authorized", driver.Identity(action.Issuer).String())
+		}
 	}
 
+	if ctx.RevocationList.IsIssuerRevoked(driver.Identity(action.Issuer)) {
+		return errors.Errorf("issuer [%s] has been revoked", driver.Identity(action.Issuer).String())
+	}
 	verifier, err := ctx.Deserializer.GetIssuerVerifier(action.Issuer)
 	if err != nil {
 		return errors.Wrapf(err, "failed getting verifier for issuer [%s]", action.Issuer.String())
@@ -55,3 +68,31 @@ func IssueValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// IssueUpgradeValidate checks that the owners of the tokens upgraded by an issue action signed the token request.
+// The owners sign after the issue action is assembled, therefore their consent covers the outputs, the recipients,
+// and the anchor of the request. Their signatures follow the issuer's one, in the order of the inputs.
+// The existence of the upgraded tokens on the ledger is checked when their spending is translated.
+func IssueUpgradeValidate(ctx *Context) error {
+	if len(ctx.IssueAction.Inputs) == 0 {
+		return nil
+	}
+	des, err := ownerDeserializer(ctx)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting owner deserializer")
+	}
+	for i, input := range ctx.IssueAction.Inputs {
+		owner, err := upgrade.OwnerOf(input.Token)
+		if err != nil {
+			return errors.Wrapf(err, "invalid upgraded token [%s]", input.ID)
+		}
+		verifier, err := des.GetOwnerVerifier(owner)
+		if err != nil {
+			return errors.Wrapf(err, "failed getting verifier for the owner of upgraded token [%d]", i)
+		}
+		if _, err := ctx.SignatureProvider.HasBeenSignedBy(owner, verifier); err != nil {
+			return errors.Wrapf(err, "failed verifying signature of the owner of upgraded token [%s]", input.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validator
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// RevocationEpochDeserializer returns deserializers bound to an epoch of the revocation authority
+type RevocationEpochDeserializer interface {
+	// ForRevocationEpoch returns a deserializer whose owner verifiers require the signatures of the idemix identities
+	// to carry a non-revocation proof valid in the passed epoch, disclosing a revocation handle not in the passed revocation list
+	ForRevocationEpoch(epoch uint64, revocationList *driver.RevocationList) (driver.Deserializer, error)
+}
+
+// ownerDeserializer returns the deserializer to be used to verify the signatures of the owners.
+// If the public parameters set a revocation public key, the signatures of the idemix owners, also those nested in
+// htlc, timelock, and multisig owners, must carry a non-revocation proof valid in the epoch
+// determined by the height of the ledger the transaction is validated at,
+// and the owners revoked by the revocation list stored on the ledger are rejected.
+func ownerDeserializer(ctx *Context) (driver.Deserializer, error) {
+	if len(ctx.PP.RevocationPublicKey()) == 0 {
+		return ctx.Deserializer, nil
+	}
+	red, ok := ctx.Deserializer.(RevocationEpochDeserializer)
+	if !ok {
+		return nil, errors.New("the deserializer does not support non-revocation proofs")
+	}
+	if _, ok := ctx.Attributes[common.BlockHeight]; !ok {
+		return nil, errors.New("the block height is not available, cannot determine the revocation epoch")
+	}
+	height, err := common.GetBlockHeight(ctx.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	return red.ForRevocationEpoch(driver.RevocationEpoch(height, ctx.PP.RevocationEpochLength()), ctx.RevocationList)
+}
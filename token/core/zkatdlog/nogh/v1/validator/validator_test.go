@@ -8,6 +8,12 @@ package validator_test
 
 import (
 	"context"
+	ecdsa2 "crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"os"
 	"time"
 
@@ -15,6 +21,7 @@ import (
 	math "github.com/IBM/mathlib"
 	registry2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/registry"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	v1 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/audit"
 	issue2 "github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/issue"
@@ -32,6 +39,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/sig"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/storage/kvs"
 	ix509 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/x509"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/slices"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
@@ -136,10 +144,105 @@ var _ = Describe("validator", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 			It("succeeds", func() {
-				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), fakeLedger.GetStateStub, "1", raw)
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+		})
+
+		Context("Validator is called with a non-anonymous issue action and token type issuers", func() {
+			It("succeeds when the type is allowed", func() {
+				pp.AddTokenTypeIssuers("AB*")
+				_, ir, _ = prepareNonAnonymousIssueRequest(pp, auditor)
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			It("fails when the type is not allowed", func() {
+				pp.AddTokenTypeIssuers("XYZ")
+				_, ir, _ = prepareNonAnonymousIssueRequest(pp, auditor)
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("token type [ABC] is not allowed"))
+			})
+			It("fails when the issuer is not bound to the type", func() {
+				pp.AddTokenTypeIssuers("ABC", []byte("another issuer"))
+				_, ir, _ = prepareNonAnonymousIssueRequest(pp, auditor)
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("is not authorized to issue tokens of type [ABC]"))
+			})
+			It("fails when the type is not disclosed", func() {
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				pp.AddTokenTypeIssuers("ABC")
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("issue action does not disclose the type of the issued tokens"))
+			})
+		})
+
+		Context("Validator is called with a non-anonymous issue action and multiple auditors", func() {
+			var (
+				sigma2 []byte
+			)
+			BeforeEach(func() {
+				asigner2, _ := prepareECDSASigner()
+				araw2, err := asigner2.Serialize()
+				Expect(err).NotTo(HaveOccurred())
+				pp.AddAuditor(araw2)
+				raw, err := ir.MarshalToMessageToSign([]byte("1"))
+				Expect(err).NotTo(HaveOccurred())
+				sigma2, err = asigner2.Sign(raw)
+				Expect(err).NotTo(HaveOccurred())
+			})
+			It("succeeds when the threshold is met", func() {
+				pp.SetAuditorsThreshold(1)
+				ir.AuditorSignatures = append(ir.AuditorSignatures, nil)
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(actions)).To(Equal(1))
 			})
+			It("succeeds when all auditors sign", func() {
+				ir.AuditorSignatures = append(ir.AuditorSignatures, sigma2)
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			It("fails when the threshold is not met", func() {
+				ir.AuditorSignatures = append(ir.AuditorSignatures, nil)
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("insufficient number of auditor signatures, expected at least [2], got [1]"))
+			})
+			It("fails when an auditor signature is invalid", func() {
+				pp.SetAuditorsThreshold(1)
+				ir.AuditorSignatures = append(ir.AuditorSignatures, []byte("invalid signature"))
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to verify signature of auditor at [1]"))
+			})
+			It("fails when the auditor signatures are not one per auditor", func() {
+				raw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				_, _, err = engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid number of auditor signatures, expected [2], got [1]"))
+			})
 		})
 
 		Context("validator is called correctly with a transfer action", func() {
@@ -175,6 +278,134 @@ var _ = Describe("validator", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(actions)).To(Equal(1))
 			})
+			It("succeeds without a block height when the public parameters do not set a revocation public key", func() {
+				_, ok := driver.BlockHeightFromContext(context.TODO())
+				Expect(ok).To(BeFalse())
+				actions, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", raw)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(actions)).To(Equal(1))
+			})
+			Context("and the public parameters set a revocation public key", func() {
+				var (
+					ra      *idemix2.CRISigner
+					otherRA *idemix2.CRISigner
+				)
+				BeforeEach(func() {
+					var raPK []byte
+					ra, raPK = prepareCRISigner()
+					otherRA, _ = prepareCRISigner()
+					pp.SetRevocationPublicKey(raPK, 100)
+					deserializer, err := zkatdlog.NewDeserializer(pp)
+					Expect(err).NotTo(HaveOccurred())
+					engine = enginedlog.New(logging.MustGetLogger("validator"), pp, deserializer)
+				})
+				nonRevoked := func(ra *idemix2.CRISigner, epoch uint64) []byte {
+					cri, err := ra.SignCRI(epoch)
+					Expect(err).NotTo(HaveOccurred())
+					request := &driver.TokenRequest{
+						Transfers:         tr.Transfers,
+						AuditorSignatures: tr.AuditorSignatures,
+					}
+					msg, err := (&driver.TokenRequest{Transfers: tr.Transfers}).MarshalToMessageToSign([]byte("1"))
+					Expect(err).NotTo(HaveOccurred())
+					for i, sigma := range tr.Signatures {
+						prover, ok := sender.Signers[i].(idemix2.NonRevocationProver)
+						Expect(ok).To(BeTrue())
+						proof, rh, err := prover.ProveNonRevocation(msg, cri)
+						Expect(err).NotTo(HaveOccurred())
+						nrs := &driver.NonRevokedSignature{Signature: sigma, NonRevocationProof: proof, RevocationHandle: rh}
+						nrsRaw, err := nrs.Bytes()
+						Expect(err).NotTo(HaveOccurred())
+						request.Signatures = append(request.Signatures, nrsRaw)
+					}
+					raw, err := request.Bytes()
+					Expect(err).NotTo(HaveOccurred())
+					return raw
+				}
+				// revocationHandles returns the signatures of the passed request and the revocation handles they disclose
+				revocationHandles := func(raw []byte) (*driver.TokenRequest, []*driver.NonRevokedSignature, [][]byte) {
+					request := &driver.TokenRequest{}
+					Expect(request.FromBytes(raw)).To(Succeed())
+					var signatures []*driver.NonRevokedSignature
+					var rhs [][]byte
+					for _, sigma := range request.Signatures {
+						nrs := &driver.NonRevokedSignature{}
+						Expect(nrs.FromBytes(sigma)).To(Succeed())
+						signatures = append(signatures, nrs)
+						rhs = append(rhs, nrs.RevocationHandle)
+					}
+					return request, signatures, rhs
+				}
+				// the ledger is at height 1050, that is, in epoch 10
+				atHeight := driver.WithBlockHeight(context.TODO(), 1050)
+				It("succeeds when the owners attach a valid non-revocation proof", func() {
+					actions, _, err := engine.VerifyTokenRequestFromRaw(atHeight, getState, "1", nonRevoked(ra, 10))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(len(actions)).To(Equal(1))
+				})
+				It("succeeds when the non-revocation proof has been generated in the previous epoch", func() {
+					actions, _, err := engine.VerifyTokenRequestFromRaw(atHeight, getState, "1", nonRevoked(ra, 9))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(len(actions)).To(Equal(1))
+				})
+				It("fails when the owners do not attach a non-revocation proof", func() {
+					_, _, err := engine.VerifyTokenRequestFromRaw(atHeight, getState, "1", raw)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("missing non-revocation proof"))
+				})
+				It("fails when the non-revocation proof has expired", func() {
+					_, _, err := engine.VerifyTokenRequestFromRaw(atHeight, getState, "1", nonRevoked(ra, 8))
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("expired non-revocation proof"))
+				})
+				It("fails when the credential revocation information has not been signed with the revocation key", func() {
+					_, _, err := engine.VerifyTokenRequestFromRaw(atHeight, getState, "1", nonRevoked(otherRA, 10))
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("invalid credential revocation information"))
+				})
+				It("fails when the owner has been revoked by the revocation list on the ledger", func() {
+					raw := nonRevoked(ra, 10)
+					_, _, rhs := revocationHandles(raw)
+					list := &driver.RevocationList{Sequence: 1, OwnerRevocationHandles: rhs[:1]}
+					listRaw, err := list.Bytes()
+					Expect(err).NotTo(HaveOccurred())
+					revokedState := func(id token2.ID) ([]byte, error) {
+						if id == driver.RevocationListID() {
+							return listRaw, nil
+						}
+						return fakeLedger.GetState(id)
+					}
+					// the CRI is the same for all the owners, the revoked owner holds a valid one
+					_, _, err = engine.VerifyTokenRequestFromRaw(atHeight, revokedState, "1", raw)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("revoked owner, revocation handle"))
+				})
+				It("fails when the non-revocation proof does not disclose the claimed revocation handle", func() {
+					request, signatures, _ := revocationHandles(nonRevoked(ra, 10))
+					request.Signatures = nil
+					for _, nrs := range signatures {
+						nrs.RevocationHandle = []byte("another revocation handle")
+						sigma, err := nrs.Bytes()
+						Expect(err).NotTo(HaveOccurred())
+						request.Signatures = append(request.Signatures, sigma)
+					}
+					raw, err := request.Bytes()
+					Expect(err).NotTo(HaveOccurred())
+					_, _, err = engine.VerifyTokenRequestFromRaw(atHeight, getState, "1", raw)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("invalid non-revocation proof"))
+				})
+				It("succeeds when the ledger is at height zero", func() {
+					actions, _, err := engine.VerifyTokenRequestFromRaw(driver.WithBlockHeight(context.TODO(), 0), getState, "1", nonRevoked(ra, 0))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(len(actions)).To(Equal(1))
+				})
+				It("fails when the block height is not available", func() {
+					_, _, err := engine.VerifyTokenRequestFromRaw(context.TODO(), getState, "1", nonRevoked(ra, 10))
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("the block height is not available"))
+				})
+			})
 		})
 		Context("validator is called correctly with a redeem action", func() {
 			var (
@@ -269,6 +500,69 @@ var _ = Describe("validator", func() {
 				})
 			})
 		})
+		Context("validator is called with a batch of token requests", func() {
+			var requests []*driver.ValidationRequest
+			BeforeEach(func() {
+				issueRaw, err := ir.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				swapRaw, err := ar.Bytes()
+				Expect(err).NotTo(HaveOccurred())
+				inputs := map[string][]byte{}
+				for i, txID := range []string{"0", "1"} {
+					inputs[txID], err = inputsForTransfer[i].Serialize()
+					Expect(err).NotTo(HaveOccurred())
+				}
+				// each request is verified against its own ledger
+				swapLedger := func(id token2.ID) ([]byte, error) {
+					return inputs[id.TxId], nil
+				}
+				emptyLedger := func(id token2.ID) ([]byte, error) {
+					return nil, nil
+				}
+				requests = []*driver.ValidationRequest{
+					{GetState: emptyLedger, Anchor: "1", Raw: issueRaw},
+					{GetState: swapLedger, Anchor: "2", Raw: swapRaw},
+					{GetState: swapLedger, Anchor: "3", Raw: swapRaw},
+					{GetState: emptyLedger, Anchor: "4", Raw: nil},
+				}
+			})
+			It("returns a result for each request", func() {
+				engine.Parallelism = 2
+				results := engine.VerifyTokenRequestsFromRaw(context.TODO(), requests)
+				Expect(results).To(HaveLen(4))
+				Expect(results[0].Err).NotTo(HaveOccurred())
+				Expect(results[0].Actions).To(HaveLen(1))
+				Expect(results[1].Err).NotTo(HaveOccurred())
+				Expect(results[1].Actions).To(HaveLen(1))
+				// the swap request was signed for anchor [2]
+				Expect(results[2].Err).To(HaveOccurred())
+				Expect(results[3].Err).To(MatchError("empty token request"))
+			})
+		})
+	})
+	Describe("Timelock Scripts", func() {
+		var ctx *enginedlog.Context
+		BeforeEach(func() {
+			raw, err := json.Marshal(&timelock.Script{
+				Sender:      []byte("sender"),
+				Recipient:   []byte("recipient"),
+				BlockHeight: 10,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			owner, err := identity.WrapWithType(timelock.ScriptType, raw)
+			Expect(err).NotTo(HaveOccurred())
+			ctx = &enginedlog.Context{
+				TransferAction: &transfer.Action{Outputs: []*tokn.Token{{Owner: owner}}},
+				Attributes:     driver.ValidationAttributes{},
+			}
+		})
+		It("accepts a lock until a block height when the block height is available", func() {
+			ctx.Attributes[common.BlockHeight] = []byte("5")
+			Expect(enginedlog.TransferTimelockValidate(ctx)).To(Succeed())
+		})
+		It("rejects a lock until a block height when the block height is not available", func() {
+			Expect(enginedlog.TransferTimelockValidate(ctx)).To(MatchError("timelock script invalid: locked until block height [10], but the block height is not available"))
+		})
 	})
 })
 
@@ -278,6 +572,20 @@ func prepareECDSASigner() (*ecdsa.Signer, *ecdsa.Verifier) {
 	return signer, signer.Verifier
 }
 
+// prepareCRISigner returns the signer of the credential revocation information of a new revocation key,
+// and the PEM encoding of its public key
+func prepareCRISigner() (*idemix2.CRISigner, []byte) {
+	sk, err := ecdsa2.GenerateKey(elliptic.P384(), rand.Reader)
+	Expect(err).NotTo(HaveOccurred())
+	skRaw, err := x509.MarshalECPrivateKey(sk)
+	Expect(err).NotTo(HaveOccurred())
+	pkRaw, err := x509.MarshalPKIXPublicKey(&sk.PublicKey)
+	Expect(err).NotTo(HaveOccurred())
+	signer, err := idemix2.NewCRISigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: skRaw}), math.FP256BN_AMCL)
+	Expect(err).NotTo(HaveOccurred())
+	return signer, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkRaw})
+}
+
 func prepareNonAnonymousIssueRequest(pp *v1.PublicParams, auditor *audit.Auditor) (*issue2.Issuer, *driver.TokenRequest, *driver.TokenRequestMetadata) {
 	signer, err := ecdsa.NewECDSASigner()
 	Expect(err).NotTo(HaveOccurred())
@@ -568,5 +876,9 @@ func prepareTransfer(pp *v1.PublicParams, signer driver.SigningIdentity, auditor
 }
 
 func getState(id token2.ID) ([]byte, error) {
+	if id == driver.RevocationListID() {
+		// no revocation list is stored
+		return nil, nil
+	}
 	return fakeLedger.GetState(id)
 }
@@ -11,12 +11,15 @@ import (
 	"time"
 
 	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/zkatdlog/nogh/v1/crypto/transfer"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	htlc2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/htlc"
+	timelock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/htlc"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
@@ -34,6 +37,10 @@ func TransferSignatureValidate(ctx *Context) error {
 		return errors.Errorf("invalid number of token inputs, expected at least 1")
 	}
 
+	des, err := ownerDeserializer(ctx)
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting owner deserializer")
+	}
 	var inputToken []*token.Token
 	for i, in := range ctx.TransferAction.Inputs {
 		tok := in.Token
@@ -43,7 +50,7 @@ func TransferSignatureValidate(ctx *Context) error {
 
 		// check sender signature
 		ctx.Logger.Debugf("check sender [%d][%s]", i, driver.Identity(tok.Owner).UniqueID())
-		verifier, err := ctx.Deserializer.GetOwnerVerifier(tok.Owner)
+		verifier, err := des.GetOwnerVerifier(tok.Owner)
 		if err != nil {
 			return errors.Wrapf(err, "failed deserializing owner [%d][%v][%s]", i, in, driver.Identity(tok.Owner))
 		}
@@ -61,6 +68,26 @@ func TransferSignatureValidate(ctx *Context) error {
 	return nil
 }
 
+// TransferClawbackValidate checks that the transfer action is a clawback authorized by a quorum of the compliance authorities,
+// and that all its outputs go to the recovery owner.
+// It replaces TransferSignatureValidate, the owners of the inputs do not sign a clawback.
+// The ZK transfer proof is then checked by TransferZKProofValidate as for any other transfer.
+func TransferClawbackValidate(ctx *Context) error {
+	// recall that TransferActionValidate has been called before this function
+	clawback, key, err := common.VerifyClawback(ctx.PP, ctx.Deserializer, ctx.SignatureProvider, ctx.TransferAction)
+	if err != nil {
+		return err
+	}
+	for i, out := range ctx.TransferAction.Outputs {
+		if out.IsRedeem() || !clawback.RecoveryOwner.Equal(out.Owner) {
+			return errors.Errorf("invalid clawback: output at [%d] does not go to the recovery owner", i)
+		}
+	}
+	ctx.InputTokens = ctx.TransferAction.InputTokens()
+	ctx.CountMetadataKey(key)
+	return nil
+}
+
 func TransferUpgradeWitnessValidate(ctx *Context) error {
 	// recall that TransferActionValidate has been called before this function
 
@@ -99,10 +126,11 @@ func TransferZKProofValidate(ctx *Context) error {
 		in[i] = tok.Data
 	}
 
-	if err := transfer.NewVerifier(
-		in,
-		ctx.TransferAction.GetOutputCommitments(),
-		ctx.PP).Verify(ctx.TransferAction.GetProof()); err != nil {
+	verifier := transfer.NewVerifier(in, ctx.TransferAction.GetOutputCommitments(), ctx.PP)
+	if verifier.RangeCorrectness != nil && ctx.Workers != nil {
+		verifier.RangeCorrectness.Executor = ctx.Workers
+	}
+	if err := verifier.Verify(ctx.TransferAction.GetProof()); err != nil {
 		return err
 	}
 
@@ -173,3 +201,63 @@ func TransferHTLCValidate(ctx *Context) error {
 	}
 	return nil
 }
+
+// TransferTimelockValidate checks the validity of the timelock scripts, if any
+func TransferTimelockValidate(ctx *Context) error {
+	now := time.Now()
+
+	for _, in := range ctx.InputTokens {
+		owner, err := identity.UnmarshalTypedIdentity(in.Owner)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshal owner of input token")
+		}
+		if owner.Type != timelock.ScriptType {
+			continue
+		}
+		if len(ctx.InputTokens) != 1 || len(ctx.TransferAction.GetOutputs()) != 1 {
+			return errors.Errorf("invalid transfer action: a timelock script only transfers the ownership of a token")
+		}
+
+		out := ctx.TransferAction.GetOutputs()[0].(*token.Token)
+		if out.IsRedeem() {
+			return errors.Errorf("invalid transfer action: the output corresponding to a timelock spending should not be a redeem")
+		}
+
+		// check that owner field in output is correct and that the token is released
+		blockHeight, err := common.GetBlockHeight(ctx.Attributes)
+		if err != nil {
+			return err
+		}
+		if _, err := timelock2.VerifyOwner(in.Owner, out.Owner, now, blockHeight); err != nil {
+			return errors.Wrap(err, "failed to verify transfer from timelock script")
+		}
+	}
+
+	for _, o := range ctx.TransferAction.GetOutputs() {
+		out, ok := o.(*token.Token)
+		if !ok {
+			return errors.Errorf("invalid output")
+		}
+		if out.IsRedeem() {
+			continue
+		}
+		owner, err := identity.UnmarshalTypedIdentity(out.Owner)
+		if err != nil {
+			return err
+		}
+		if owner.Type == timelock.ScriptType {
+			script := &timelock.Script{}
+			if err := script.FromBytes(owner.Identity); err != nil {
+				return err
+			}
+			if err := script.Validate(now); err != nil {
+				return errors.WithMessagef(err, "timelock script invalid")
+			}
+			// the release of a token locked until a block height can be checked only if the network passes the height
+			if script.BlockHeight != 0 && !common.IsBlockHeightAvailable(ctx.Attributes) {
+				return errors.Errorf("timelock script invalid: locked until block height [%d], but the block height is not available", script.BlockHeight)
+			}
+		}
+	}
+	return nil
+}
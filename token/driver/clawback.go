@@ -0,0 +1,81 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// ClawbackMetadataPrefix is the prefix of the transfer action metadata key that turns a transfer action into a clawback
+const ClawbackMetadataPrefix = "clawback."
+
+// ClawbackMetadataKey returns the transfer action metadata key of a clawback whose first input is the passed token.
+// A token can be spent only once, therefore, the key is unique on the ledger.
+func ClawbackMetadataKey(firstInput *token.ID) string {
+	return ClawbackMetadataPrefix + firstInput.String()
+}
+
+// Clawback is carried by the metadata of a transfer action that spends tokens without the signature of their owners.
+// The clawback is authorized by a quorum of the compliance authorities listed in the public parameters,
+// see PublicParameters.ComplianceThreshold, and all its outputs go to the recovery owner.
+type Clawback struct {
+	// Authorizers are the compliance authorities that authorize the clawback.
+	// They sign the token request in this order, in place of the owners of the inputs.
+	Authorizers []Identity
+	// RecoveryOwner is the owner of all the outputs of the clawback
+	RecoveryOwner Identity
+}
+
+// Bytes returns the JSON encoding of the clawback
+func (c *Clawback) Bytes() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// FromBytes decodes the clawback from the passed JSON encoding
+func (c *Clawback) FromBytes(raw []byte) error {
+	*c = Clawback{}
+	if err := json.Unmarshal(raw, c); err != nil {
+		return errors.Wrap(err, "failed unmarshalling clawback")
+	}
+	return nil
+}
+
+// GetClawback returns the clawback carried by the passed transfer action metadata, and its metadata key.
+// It returns a nil clawback if the metadata does not carry any.
+func GetClawback(metadata map[string][]byte) (string, *Clawback, error) {
+	var key string
+	for k := range metadata {
+		if !strings.HasPrefix(k, ClawbackMetadataPrefix) {
+			continue
+		}
+		if len(key) != 0 {
+			return "", nil, errors.New("more than one clawback in the same action")
+		}
+		key = k
+	}
+	if len(key) == 0 {
+		return "", nil, nil
+	}
+	clawback := &Clawback{}
+	if err := clawback.FromBytes(metadata[key]); err != nil {
+		return "", nil, err
+	}
+	return key, clawback, nil
+}
+
+// ClawbackAuthorizers returns the authorizers of the clawback carried by the passed transfer action metadata, if any
+func ClawbackAuthorizers(metadata map[string][]byte) []Identity {
+	_, clawback, err := GetClawback(metadata)
+	if err != nil || clawback == nil {
+		return nil
+	}
+	return clawback.Authorizers
+}
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// FrozenTokenTxIDPrefix is the prefix of the reserved transaction identifiers under which the ledger keeps
+// the markers of the frozen tokens
+const FrozenTokenTxIDPrefix = "frozen."
+
+// FrozenTokenID returns the identifier under which the ledger keeps the marker of the passed token, if frozen.
+// The marker can be read like any other token with a GetStateFnc.
+func FrozenTokenID(id *token.ID) token.ID {
+	return token.ID{TxId: FrozenTokenTxIDPrefix + id.TxId, Index: id.Index}
+}
+
+// IsFrozenTokenTxID returns true if the passed transaction identifier is reserved to the markers of the frozen tokens
+func IsFrozenTokenTxID(txID string) bool {
+	return strings.HasPrefix(txID, FrozenTokenTxIDPrefix)
+}
+
+// IsTokenFrozen returns true if the passed ledger keeps a marker for the passed token
+func IsTokenFrozen(ledger Ledger, id *token.ID) (bool, error) {
+	raw, err := ledger.GetState(FrozenTokenID(id))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read the frozen marker of [%s]", id)
+	}
+	return len(raw) != 0, nil
+}
+
+// Freeze is the compliance action that freezes, or unfreezes, tokens stored on the ledger.
+// A frozen token stays on the ledger but cannot be spent.
+// It travels in the FreezeActions of a TokenRequest.
+type Freeze struct {
+	// TokenIDs are the identifiers of the tokens to freeze or unfreeze
+	TokenIDs []*token.ID
+	// Unfreeze is true if the tokens must be unfrozen
+	Unfreeze bool
+	// Signatures are the signatures of the compliance authorities, see PublicParameters.ComplianceAuthorities,
+	// on the message returned by MessageToSign
+	Signatures []*AdminSignature
+}
+
+// MessageToSign returns the message the compliance authorities sign to authorize the action.
+// The message is bound to the passed anchor, therefore, the action cannot be replayed in another transaction.
+func (f *Freeze) MessageToSign(anchor string) ([]byte, error) {
+	raw, err := json.Marshal(&Freeze{TokenIDs: f.TokenIDs, Unfreeze: f.Unfreeze})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshalling freeze action")
+	}
+	return append(raw, anchor...), nil
+}
+
+// Bytes returns the JSON encoding of the action
+func (f *Freeze) Bytes() ([]byte, error) {
+	return json.Marshal(f)
+}
+
+// FromBytes decodes the action from the passed JSON encoding
+func (f *Freeze) FromBytes(raw []byte) error {
+	*f = Freeze{}
+	if err := json.Unmarshal(raw, f); err != nil {
+		return errors.Wrap(err, "failed unmarshalling freeze action")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	freeze := &Freeze{TokenIDs: []*token.ID{{TxId: "tx1", Index: 1}}}
+	m1, err := freeze.MessageToSign("anchor1")
+	assert.NoError(t, err)
+	m2, err := freeze.MessageToSign("anchor2")
+	assert.NoError(t, err)
+	assert.NotEqual(t, m1, m2)
+
+	// the signatures are not part of the message to sign
+	freeze.Signatures = []*AdminSignature{{Signer: Identity("compliance"), Sigma: []byte("sigma")}}
+	m3, err := freeze.MessageToSign("anchor1")
+	assert.NoError(t, err)
+	assert.Equal(t, m1, m3)
+
+	raw, err := freeze.Bytes()
+	assert.NoError(t, err)
+	freeze2 := &Freeze{Unfreeze: true}
+	assert.NoError(t, freeze2.FromBytes(raw))
+	assert.Equal(t, freeze, freeze2)
+
+	id := FrozenTokenID(&token.ID{TxId: "tx1", Index: 1})
+	assert.Equal(t, token.ID{TxId: "frozen.tx1", Index: 1}, id)
+	assert.True(t, IsFrozenTokenTxID(id.TxId))
+	assert.False(t, IsFrozenTokenTxID("tx1"))
+}
+
+func TestGetClawback(t *testing.T) {
+	key := ClawbackMetadataKey(&token.ID{TxId: "tx1", Index: 1})
+	assert.Equal(t, "clawback.[tx1:1]", key)
+
+	clawback := &Clawback{Authorizers: []Identity{Identity("compliance")}, RecoveryOwner: Identity("recovery")}
+	raw, err := clawback.Bytes()
+	assert.NoError(t, err)
+
+	k, c, err := GetClawback(map[string][]byte{"other": []byte("value"), key: raw})
+	assert.NoError(t, err)
+	assert.Equal(t, key, k)
+	assert.Equal(t, clawback, c)
+	assert.Equal(t, clawback.Authorizers, ClawbackAuthorizers(map[string][]byte{key: raw}))
+
+	// no clawback
+	_, c, err = GetClawback(map[string][]byte{"other": []byte("value")})
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+	assert.Nil(t, ClawbackAuthorizers(nil))
+
+	// more than one clawback
+	_, _, err = GetClawback(map[string][]byte{key: raw, ClawbackMetadataKey(&token.ID{TxId: "tx2"}): raw})
+	assert.EqualError(t, err, "more than one clawback in the same action")
+}
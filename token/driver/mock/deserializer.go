@@ -8,6 +8,19 @@ import (
 )
 
 type Deserializer struct {
+	GetAdministratorVerifierStub        func(driver.Identity) (driver.Verifier, error)
+	getAdministratorVerifierMutex       sync.RWMutex
+	getAdministratorVerifierArgsForCall []struct {
+		arg1 driver.Identity
+	}
+	getAdministratorVerifierReturns struct {
+		result1 driver.Verifier
+		result2 error
+	}
+	getAdministratorVerifierReturnsOnCall map[int]struct {
+		result1 driver.Verifier
+		result2 error
+	}
 	GetAuditInfoStub        func(driver.Identity, driver.AuditInfoProvider) ([]byte, error)
 	getAuditInfoMutex       sync.RWMutex
 	getAuditInfoArgsForCall []struct {
@@ -49,6 +62,19 @@ type Deserializer struct {
 		result1 driver.Verifier
 		result2 error
 	}
+	GetComplianceVerifierStub        func(driver.Identity) (driver.Verifier, error)
+	getComplianceVerifierMutex       sync.RWMutex
+	getComplianceVerifierArgsForCall []struct {
+		arg1 driver.Identity
+	}
+	getComplianceVerifierReturns struct {
+		result1 driver.Verifier
+		result2 error
+	}
+	getComplianceVerifierReturnsOnCall map[int]struct {
+		result1 driver.Verifier
+		result2 error
+	}
 	GetIssuerVerifierStub        func(driver.Identity) (driver.Verifier, error)
 	getIssuerVerifierMutex       sync.RWMutex
 	getIssuerVerifierArgsForCall []struct {
@@ -104,6 +130,70 @@ type Deserializer struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *Deserializer) GetAdministratorVerifier(arg1 driver.Identity) (driver.Verifier, error) {
+	fake.getAdministratorVerifierMutex.Lock()
+	ret, specificReturn := fake.getAdministratorVerifierReturnsOnCall[len(fake.getAdministratorVerifierArgsForCall)]
+	fake.getAdministratorVerifierArgsForCall = append(fake.getAdministratorVerifierArgsForCall, struct {
+		arg1 driver.Identity
+	}{arg1})
+	stub := fake.GetAdministratorVerifierStub
+	fakeReturns := fake.getAdministratorVerifierReturns
+	fake.recordInvocation("GetAdministratorVerifier", []interface{}{arg1})
+	fake.getAdministratorVerifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Deserializer) GetAdministratorVerifierCallCount() int {
+	fake.getAdministratorVerifierMutex.RLock()
+	defer fake.getAdministratorVerifierMutex.RUnlock()
+	return len(fake.getAdministratorVerifierArgsForCall)
+}
+
+func (fake *Deserializer) GetAdministratorVerifierCalls(stub func(driver.Identity) (driver.Verifier, error)) {
+	fake.getAdministratorVerifierMutex.Lock()
+	defer fake.getAdministratorVerifierMutex.Unlock()
+	fake.GetAdministratorVerifierStub = stub
+}
+
+func (fake *Deserializer) GetAdministratorVerifierArgsForCall(i int) driver.Identity {
+	fake.getAdministratorVerifierMutex.RLock()
+	defer fake.getAdministratorVerifierMutex.RUnlock()
+	argsForCall := fake.getAdministratorVerifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Deserializer) GetAdministratorVerifierReturns(result1 driver.Verifier, result2 error) {
+	fake.getAdministratorVerifierMutex.Lock()
+	defer fake.getAdministratorVerifierMutex.Unlock()
+	fake.GetAdministratorVerifierStub = nil
+	fake.getAdministratorVerifierReturns = struct {
+		result1 driver.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Deserializer) GetAdministratorVerifierReturnsOnCall(i int, result1 driver.Verifier, result2 error) {
+	fake.getAdministratorVerifierMutex.Lock()
+	defer fake.getAdministratorVerifierMutex.Unlock()
+	fake.GetAdministratorVerifierStub = nil
+	if fake.getAdministratorVerifierReturnsOnCall == nil {
+		fake.getAdministratorVerifierReturnsOnCall = make(map[int]struct {
+			result1 driver.Verifier
+			result2 error
+		})
+	}
+	fake.getAdministratorVerifierReturnsOnCall[i] = struct {
+		result1 driver.Verifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Deserializer) GetAuditInfo(arg1 driver.Identity, arg2 driver.AuditInfoProvider) ([]byte, error) {
 	fake.getAuditInfoMutex.Lock()
 	ret, specificReturn := fake.getAuditInfoReturnsOnCall[len(fake.getAuditInfoArgsForCall)]
@@ -303,6 +393,70 @@ func (fake *Deserializer) GetAuditorVerifierReturnsOnCall(i int, result1 driver.
 	}{result1, result2}
 }
 
+func (fake *Deserializer) GetComplianceVerifier(arg1 driver.Identity) (driver.Verifier, error) {
+	fake.getComplianceVerifierMutex.Lock()
+	ret, specificReturn := fake.getComplianceVerifierReturnsOnCall[len(fake.getComplianceVerifierArgsForCall)]
+	fake.getComplianceVerifierArgsForCall = append(fake.getComplianceVerifierArgsForCall, struct {
+		arg1 driver.Identity
+	}{arg1})
+	stub := fake.GetComplianceVerifierStub
+	fakeReturns := fake.getComplianceVerifierReturns
+	fake.recordInvocation("GetComplianceVerifier", []interface{}{arg1})
+	fake.getComplianceVerifierMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Deserializer) GetComplianceVerifierCallCount() int {
+	fake.getComplianceVerifierMutex.RLock()
+	defer fake.getComplianceVerifierMutex.RUnlock()
+	return len(fake.getComplianceVerifierArgsForCall)
+}
+
+func (fake *Deserializer) GetComplianceVerifierCalls(stub func(driver.Identity) (driver.Verifier, error)) {
+	fake.getComplianceVerifierMutex.Lock()
+	defer fake.getComplianceVerifierMutex.Unlock()
+	fake.GetComplianceVerifierStub = stub
+}
+
+func (fake *Deserializer) GetComplianceVerifierArgsForCall(i int) driver.Identity {
+	fake.getComplianceVerifierMutex.RLock()
+	defer fake.getComplianceVerifierMutex.RUnlock()
+	argsForCall := fake.getComplianceVerifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Deserializer) GetComplianceVerifierReturns(result1 driver.Verifier, result2 error) {
+	fake.getComplianceVerifierMutex.Lock()
+	defer fake.getComplianceVerifierMutex.Unlock()
+	fake.GetComplianceVerifierStub = nil
+	fake.getComplianceVerifierReturns = struct {
+		result1 driver.Verifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Deserializer) GetComplianceVerifierReturnsOnCall(i int, result1 driver.Verifier, result2 error) {
+	fake.getComplianceVerifierMutex.Lock()
+	defer fake.getComplianceVerifierMutex.Unlock()
+	fake.GetComplianceVerifierStub = nil
+	if fake.getComplianceVerifierReturnsOnCall == nil {
+		fake.getComplianceVerifierReturnsOnCall = make(map[int]struct {
+			result1 driver.Verifier
+			result2 error
+		})
+	}
+	fake.getComplianceVerifierReturnsOnCall[i] = struct {
+		result1 driver.Verifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Deserializer) GetIssuerVerifier(arg1 driver.Identity) (driver.Verifier, error) {
 	fake.getIssuerVerifierMutex.Lock()
 	ret, specificReturn := fake.getIssuerVerifierReturnsOnCall[len(fake.getIssuerVerifierArgsForCall)]
@@ -569,8 +723,12 @@ func (fake *Deserializer) Invocations() map[string][][]interface{} {
 	defer fake.getAuditInfoMutex.RUnlock()
 	fake.getAuditInfoMatcherMutex.RLock()
 	defer fake.getAuditInfoMatcherMutex.RUnlock()
+	fake.getAdministratorVerifierMutex.RLock()
+	defer fake.getAdministratorVerifierMutex.RUnlock()
 	fake.getAuditorVerifierMutex.RLock()
 	defer fake.getAuditorVerifierMutex.RUnlock()
+	fake.getComplianceVerifierMutex.RLock()
+	defer fake.getComplianceVerifierMutex.RUnlock()
 	fake.getIssuerVerifierMutex.RLock()
 	defer fake.getIssuerVerifierMutex.RUnlock()
 	fake.getOwnerVerifierMutex.RLock()
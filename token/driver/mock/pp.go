@@ -5,9 +5,30 @@ import (
 	"sync"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 )
 
 type PublicParameters struct {
+	AdministratorsStub        func() []driver.Identity
+	administratorsMutex       sync.RWMutex
+	administratorsArgsForCall []struct {
+	}
+	administratorsReturns struct {
+		result1 []driver.Identity
+	}
+	administratorsReturnsOnCall map[int]struct {
+		result1 []driver.Identity
+	}
+	AdministratorsThresholdStub        func() uint64
+	administratorsThresholdMutex       sync.RWMutex
+	administratorsThresholdArgsForCall []struct {
+	}
+	administratorsThresholdReturns struct {
+		result1 uint64
+	}
+	administratorsThresholdReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	AuditorsStub        func() []driver.Identity
 	auditorsMutex       sync.RWMutex
 	auditorsArgsForCall []struct {
@@ -18,6 +39,16 @@ type PublicParameters struct {
 	auditorsReturnsOnCall map[int]struct {
 		result1 []driver.Identity
 	}
+	AuditorsThresholdStub        func() uint64
+	auditorsThresholdMutex       sync.RWMutex
+	auditorsThresholdArgsForCall []struct {
+	}
+	auditorsThresholdReturns struct {
+		result1 uint64
+	}
+	auditorsThresholdReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	BytesStub        func() ([]byte, error)
 	bytesMutex       sync.RWMutex
 	bytesArgsForCall []struct {
@@ -40,6 +71,26 @@ type PublicParameters struct {
 	certificationDriverReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ComplianceAuthoritiesStub        func() []driver.Identity
+	complianceAuthoritiesMutex       sync.RWMutex
+	complianceAuthoritiesArgsForCall []struct {
+	}
+	complianceAuthoritiesReturns struct {
+		result1 []driver.Identity
+	}
+	complianceAuthoritiesReturnsOnCall map[int]struct {
+		result1 []driver.Identity
+	}
+	ComplianceThresholdStub        func() uint64
+	complianceThresholdMutex       sync.RWMutex
+	complianceThresholdArgsForCall []struct {
+	}
+	complianceThresholdReturns struct {
+		result1 uint64
+	}
+	complianceThresholdReturnsOnCall map[int]struct {
+		result1 uint64
+	}
 	GraphHidingStub        func() bool
 	graphHidingMutex       sync.RWMutex
 	graphHidingArgsForCall []struct {
@@ -112,6 +163,16 @@ type PublicParameters struct {
 	stringReturnsOnCall map[int]struct {
 		result1 string
 	}
+	SupplyCapsStub        func() map[token.Type]uint64
+	supplyCapsMutex       sync.RWMutex
+	supplyCapsArgsForCall []struct {
+	}
+	supplyCapsReturns struct {
+		result1 map[token.Type]uint64
+	}
+	supplyCapsReturnsOnCall map[int]struct {
+		result1 map[token.Type]uint64
+	}
 	TokenDataHidingStub        func() bool
 	tokenDataHidingMutex       sync.RWMutex
 	tokenDataHidingArgsForCall []struct {
@@ -122,6 +183,16 @@ type PublicParameters struct {
 	tokenDataHidingReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	TokenTypeIssuersStub        func() []*driver.TokenTypeIssuers
+	tokenTypeIssuersMutex       sync.RWMutex
+	tokenTypeIssuersArgsForCall []struct {
+	}
+	tokenTypeIssuersReturns struct {
+		result1 []*driver.TokenTypeIssuers
+	}
+	tokenTypeIssuersReturnsOnCall map[int]struct {
+		result1 []*driver.TokenTypeIssuers
+	}
 	ValidateStub        func() error
 	validateMutex       sync.RWMutex
 	validateArgsForCall []struct {
@@ -146,6 +217,112 @@ type PublicParameters struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *PublicParameters) Administrators() []driver.Identity {
+	fake.administratorsMutex.Lock()
+	ret, specificReturn := fake.administratorsReturnsOnCall[len(fake.administratorsArgsForCall)]
+	fake.administratorsArgsForCall = append(fake.administratorsArgsForCall, struct {
+	}{})
+	stub := fake.AdministratorsStub
+	fakeReturns := fake.administratorsReturns
+	fake.recordInvocation("Administrators", []interface{}{})
+	fake.administratorsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) AdministratorsCallCount() int {
+	fake.administratorsMutex.RLock()
+	defer fake.administratorsMutex.RUnlock()
+	return len(fake.administratorsArgsForCall)
+}
+
+func (fake *PublicParameters) AdministratorsCalls(stub func() []driver.Identity) {
+	fake.administratorsMutex.Lock()
+	defer fake.administratorsMutex.Unlock()
+	fake.AdministratorsStub = stub
+}
+
+func (fake *PublicParameters) AdministratorsReturns(result1 []driver.Identity) {
+	fake.administratorsMutex.Lock()
+	defer fake.administratorsMutex.Unlock()
+	fake.AdministratorsStub = nil
+	fake.administratorsReturns = struct {
+		result1 []driver.Identity
+	}{result1}
+}
+
+func (fake *PublicParameters) AdministratorsReturnsOnCall(i int, result1 []driver.Identity) {
+	fake.administratorsMutex.Lock()
+	defer fake.administratorsMutex.Unlock()
+	fake.AdministratorsStub = nil
+	if fake.administratorsReturnsOnCall == nil {
+		fake.administratorsReturnsOnCall = make(map[int]struct {
+			result1 []driver.Identity
+		})
+	}
+	fake.administratorsReturnsOnCall[i] = struct {
+		result1 []driver.Identity
+	}{result1}
+}
+
+func (fake *PublicParameters) AdministratorsThreshold() uint64 {
+	fake.administratorsThresholdMutex.Lock()
+	ret, specificReturn := fake.administratorsThresholdReturnsOnCall[len(fake.administratorsThresholdArgsForCall)]
+	fake.administratorsThresholdArgsForCall = append(fake.administratorsThresholdArgsForCall, struct {
+	}{})
+	stub := fake.AdministratorsThresholdStub
+	fakeReturns := fake.administratorsThresholdReturns
+	fake.recordInvocation("AdministratorsThreshold", []interface{}{})
+	fake.administratorsThresholdMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) AdministratorsThresholdCallCount() int {
+	fake.administratorsThresholdMutex.RLock()
+	defer fake.administratorsThresholdMutex.RUnlock()
+	return len(fake.administratorsThresholdArgsForCall)
+}
+
+func (fake *PublicParameters) AdministratorsThresholdCalls(stub func() uint64) {
+	fake.administratorsThresholdMutex.Lock()
+	defer fake.administratorsThresholdMutex.Unlock()
+	fake.AdministratorsThresholdStub = stub
+}
+
+func (fake *PublicParameters) AdministratorsThresholdReturns(result1 uint64) {
+	fake.administratorsThresholdMutex.Lock()
+	defer fake.administratorsThresholdMutex.Unlock()
+	fake.AdministratorsThresholdStub = nil
+	fake.administratorsThresholdReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *PublicParameters) AdministratorsThresholdReturnsOnCall(i int, result1 uint64) {
+	fake.administratorsThresholdMutex.Lock()
+	defer fake.administratorsThresholdMutex.Unlock()
+	fake.AdministratorsThresholdStub = nil
+	if fake.administratorsThresholdReturnsOnCall == nil {
+		fake.administratorsThresholdReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.administratorsThresholdReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *PublicParameters) Auditors() []driver.Identity {
 	fake.auditorsMutex.Lock()
 	ret, specificReturn := fake.auditorsReturnsOnCall[len(fake.auditorsArgsForCall)]
@@ -165,6 +342,8 @@ func (fake *PublicParameters) Auditors() []driver.Identity {
 }
 
 func (fake *PublicParameters) AuditorsCallCount() int {
+	fake.administratorsMutex.RLock()
+	defer fake.administratorsMutex.RUnlock()
 	fake.auditorsMutex.RLock()
 	defer fake.auditorsMutex.RUnlock()
 	return len(fake.auditorsArgsForCall)
@@ -199,6 +378,59 @@ func (fake *PublicParameters) AuditorsReturnsOnCall(i int, result1 []driver.Iden
 	}{result1}
 }
 
+func (fake *PublicParameters) AuditorsThreshold() uint64 {
+	fake.auditorsThresholdMutex.Lock()
+	ret, specificReturn := fake.auditorsThresholdReturnsOnCall[len(fake.auditorsThresholdArgsForCall)]
+	fake.auditorsThresholdArgsForCall = append(fake.auditorsThresholdArgsForCall, struct {
+	}{})
+	stub := fake.AuditorsThresholdStub
+	fakeReturns := fake.auditorsThresholdReturns
+	fake.recordInvocation("AuditorsThreshold", []interface{}{})
+	fake.auditorsThresholdMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) AuditorsThresholdCallCount() int {
+	fake.auditorsThresholdMutex.RLock()
+	defer fake.auditorsThresholdMutex.RUnlock()
+	return len(fake.auditorsThresholdArgsForCall)
+}
+
+func (fake *PublicParameters) AuditorsThresholdCalls(stub func() uint64) {
+	fake.auditorsThresholdMutex.Lock()
+	defer fake.auditorsThresholdMutex.Unlock()
+	fake.AuditorsThresholdStub = stub
+}
+
+func (fake *PublicParameters) AuditorsThresholdReturns(result1 uint64) {
+	fake.auditorsThresholdMutex.Lock()
+	defer fake.auditorsThresholdMutex.Unlock()
+	fake.AuditorsThresholdStub = nil
+	fake.auditorsThresholdReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *PublicParameters) AuditorsThresholdReturnsOnCall(i int, result1 uint64) {
+	fake.auditorsThresholdMutex.Lock()
+	defer fake.auditorsThresholdMutex.Unlock()
+	fake.AuditorsThresholdStub = nil
+	if fake.auditorsThresholdReturnsOnCall == nil {
+		fake.auditorsThresholdReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.auditorsThresholdReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *PublicParameters) Bytes() ([]byte, error) {
 	fake.bytesMutex.Lock()
 	ret, specificReturn := fake.bytesReturnsOnCall[len(fake.bytesArgsForCall)]
@@ -308,6 +540,114 @@ func (fake *PublicParameters) CertificationDriverReturnsOnCall(i int, result1 st
 	}{result1}
 }
 
+func (fake *PublicParameters) ComplianceAuthorities() []driver.Identity {
+	fake.complianceAuthoritiesMutex.Lock()
+	ret, specificReturn := fake.complianceAuthoritiesReturnsOnCall[len(fake.complianceAuthoritiesArgsForCall)]
+	fake.complianceAuthoritiesArgsForCall = append(fake.complianceAuthoritiesArgsForCall, struct {
+	}{})
+	stub := fake.ComplianceAuthoritiesStub
+	fakeReturns := fake.complianceAuthoritiesReturns
+	fake.recordInvocation("ComplianceAuthorities", []interface{}{})
+	fake.complianceAuthoritiesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) ComplianceAuthoritiesCallCount() int {
+	fake.complianceAuthoritiesMutex.RLock()
+	defer fake.complianceAuthoritiesMutex.RUnlock()
+	fake.complianceThresholdMutex.RLock()
+	defer fake.complianceThresholdMutex.RUnlock()
+	return len(fake.complianceAuthoritiesArgsForCall)
+}
+
+func (fake *PublicParameters) ComplianceAuthoritiesCalls(stub func() []driver.Identity) {
+	fake.complianceAuthoritiesMutex.Lock()
+	defer fake.complianceAuthoritiesMutex.Unlock()
+	fake.ComplianceAuthoritiesStub = stub
+}
+
+func (fake *PublicParameters) ComplianceAuthoritiesReturns(result1 []driver.Identity) {
+	fake.complianceAuthoritiesMutex.Lock()
+	defer fake.complianceAuthoritiesMutex.Unlock()
+	fake.ComplianceAuthoritiesStub = nil
+	fake.complianceAuthoritiesReturns = struct {
+		result1 []driver.Identity
+	}{result1}
+}
+
+func (fake *PublicParameters) ComplianceAuthoritiesReturnsOnCall(i int, result1 []driver.Identity) {
+	fake.complianceAuthoritiesMutex.Lock()
+	defer fake.complianceAuthoritiesMutex.Unlock()
+	fake.ComplianceAuthoritiesStub = nil
+	if fake.complianceAuthoritiesReturnsOnCall == nil {
+		fake.complianceAuthoritiesReturnsOnCall = make(map[int]struct {
+			result1 []driver.Identity
+		})
+	}
+	fake.complianceAuthoritiesReturnsOnCall[i] = struct {
+		result1 []driver.Identity
+	}{result1}
+}
+
+func (fake *PublicParameters) ComplianceThreshold() uint64 {
+	fake.complianceThresholdMutex.Lock()
+	ret, specificReturn := fake.complianceThresholdReturnsOnCall[len(fake.complianceThresholdArgsForCall)]
+	fake.complianceThresholdArgsForCall = append(fake.complianceThresholdArgsForCall, struct {
+	}{})
+	stub := fake.ComplianceThresholdStub
+	fakeReturns := fake.complianceThresholdReturns
+	fake.recordInvocation("ComplianceThreshold", []interface{}{})
+	fake.complianceThresholdMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) ComplianceThresholdCallCount() int {
+	fake.complianceThresholdMutex.RLock()
+	defer fake.complianceThresholdMutex.RUnlock()
+	return len(fake.complianceThresholdArgsForCall)
+}
+
+func (fake *PublicParameters) ComplianceThresholdCalls(stub func() uint64) {
+	fake.complianceThresholdMutex.Lock()
+	defer fake.complianceThresholdMutex.Unlock()
+	fake.ComplianceThresholdStub = stub
+}
+
+func (fake *PublicParameters) ComplianceThresholdReturns(result1 uint64) {
+	fake.complianceThresholdMutex.Lock()
+	defer fake.complianceThresholdMutex.Unlock()
+	fake.ComplianceThresholdStub = nil
+	fake.complianceThresholdReturns = struct {
+		result1 uint64
+	}{result1}
+}
+
+func (fake *PublicParameters) ComplianceThresholdReturnsOnCall(i int, result1 uint64) {
+	fake.complianceThresholdMutex.Lock()
+	defer fake.complianceThresholdMutex.Unlock()
+	fake.ComplianceThresholdStub = nil
+	if fake.complianceThresholdReturnsOnCall == nil {
+		fake.complianceThresholdReturnsOnCall = make(map[int]struct {
+			result1 uint64
+		})
+	}
+	fake.complianceThresholdReturnsOnCall[i] = struct {
+		result1 uint64
+	}{result1}
+}
+
 func (fake *PublicParameters) GraphHiding() bool {
 	fake.graphHidingMutex.Lock()
 	ret, specificReturn := fake.graphHidingReturnsOnCall[len(fake.graphHidingArgsForCall)]
@@ -682,6 +1022,59 @@ func (fake *PublicParameters) StringReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *PublicParameters) SupplyCaps() map[token.Type]uint64 {
+	fake.supplyCapsMutex.Lock()
+	ret, specificReturn := fake.supplyCapsReturnsOnCall[len(fake.supplyCapsArgsForCall)]
+	fake.supplyCapsArgsForCall = append(fake.supplyCapsArgsForCall, struct {
+	}{})
+	stub := fake.SupplyCapsStub
+	fakeReturns := fake.supplyCapsReturns
+	fake.recordInvocation("SupplyCaps", []interface{}{})
+	fake.supplyCapsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) SupplyCapsCallCount() int {
+	fake.supplyCapsMutex.RLock()
+	defer fake.supplyCapsMutex.RUnlock()
+	return len(fake.supplyCapsArgsForCall)
+}
+
+func (fake *PublicParameters) SupplyCapsCalls(stub func() map[token.Type]uint64) {
+	fake.supplyCapsMutex.Lock()
+	defer fake.supplyCapsMutex.Unlock()
+	fake.SupplyCapsStub = stub
+}
+
+func (fake *PublicParameters) SupplyCapsReturns(result1 map[token.Type]uint64) {
+	fake.supplyCapsMutex.Lock()
+	defer fake.supplyCapsMutex.Unlock()
+	fake.SupplyCapsStub = nil
+	fake.supplyCapsReturns = struct {
+		result1 map[token.Type]uint64
+	}{result1}
+}
+
+func (fake *PublicParameters) SupplyCapsReturnsOnCall(i int, result1 map[token.Type]uint64) {
+	fake.supplyCapsMutex.Lock()
+	defer fake.supplyCapsMutex.Unlock()
+	fake.SupplyCapsStub = nil
+	if fake.supplyCapsReturnsOnCall == nil {
+		fake.supplyCapsReturnsOnCall = make(map[int]struct {
+			result1 map[token.Type]uint64
+		})
+	}
+	fake.supplyCapsReturnsOnCall[i] = struct {
+		result1 map[token.Type]uint64
+	}{result1}
+}
+
 func (fake *PublicParameters) TokenDataHiding() bool {
 	fake.tokenDataHidingMutex.Lock()
 	ret, specificReturn := fake.tokenDataHidingReturnsOnCall[len(fake.tokenDataHidingArgsForCall)]
@@ -735,6 +1128,59 @@ func (fake *PublicParameters) TokenDataHidingReturnsOnCall(i int, result1 bool)
 	}{result1}
 }
 
+func (fake *PublicParameters) TokenTypeIssuers() []*driver.TokenTypeIssuers {
+	fake.tokenTypeIssuersMutex.Lock()
+	ret, specificReturn := fake.tokenTypeIssuersReturnsOnCall[len(fake.tokenTypeIssuersArgsForCall)]
+	fake.tokenTypeIssuersArgsForCall = append(fake.tokenTypeIssuersArgsForCall, struct {
+	}{})
+	stub := fake.TokenTypeIssuersStub
+	fakeReturns := fake.tokenTypeIssuersReturns
+	fake.recordInvocation("TokenTypeIssuers", []interface{}{})
+	fake.tokenTypeIssuersMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *PublicParameters) TokenTypeIssuersCallCount() int {
+	fake.tokenTypeIssuersMutex.RLock()
+	defer fake.tokenTypeIssuersMutex.RUnlock()
+	return len(fake.tokenTypeIssuersArgsForCall)
+}
+
+func (fake *PublicParameters) TokenTypeIssuersCalls(stub func() []*driver.TokenTypeIssuers) {
+	fake.tokenTypeIssuersMutex.Lock()
+	defer fake.tokenTypeIssuersMutex.Unlock()
+	fake.TokenTypeIssuersStub = stub
+}
+
+func (fake *PublicParameters) TokenTypeIssuersReturns(result1 []*driver.TokenTypeIssuers) {
+	fake.tokenTypeIssuersMutex.Lock()
+	defer fake.tokenTypeIssuersMutex.Unlock()
+	fake.TokenTypeIssuersStub = nil
+	fake.tokenTypeIssuersReturns = struct {
+		result1 []*driver.TokenTypeIssuers
+	}{result1}
+}
+
+func (fake *PublicParameters) TokenTypeIssuersReturnsOnCall(i int, result1 []*driver.TokenTypeIssuers) {
+	fake.tokenTypeIssuersMutex.Lock()
+	defer fake.tokenTypeIssuersMutex.Unlock()
+	fake.TokenTypeIssuersStub = nil
+	if fake.tokenTypeIssuersReturnsOnCall == nil {
+		fake.tokenTypeIssuersReturnsOnCall = make(map[int]struct {
+			result1 []*driver.TokenTypeIssuers
+		})
+	}
+	fake.tokenTypeIssuersReturnsOnCall[i] = struct {
+		result1 []*driver.TokenTypeIssuers
+	}{result1}
+}
+
 func (fake *PublicParameters) Validate() error {
 	fake.validateMutex.Lock()
 	ret, specificReturn := fake.validateReturnsOnCall[len(fake.validateArgsForCall)]
@@ -846,10 +1292,18 @@ func (fake *PublicParameters) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.auditorsMutex.RLock()
 	defer fake.auditorsMutex.RUnlock()
+	fake.auditorsThresholdMutex.RLock()
+	defer fake.auditorsThresholdMutex.RUnlock()
 	fake.bytesMutex.RLock()
 	defer fake.bytesMutex.RUnlock()
 	fake.certificationDriverMutex.RLock()
 	defer fake.certificationDriverMutex.RUnlock()
+	fake.complianceAuthoritiesMutex.RLock()
+	defer fake.complianceAuthoritiesMutex.RUnlock()
+	fake.administratorsThresholdMutex.RLock()
+	defer fake.administratorsThresholdMutex.RUnlock()
+	fake.complianceThresholdMutex.RLock()
+	defer fake.complianceThresholdMutex.RUnlock()
 	fake.graphHidingMutex.RLock()
 	defer fake.graphHidingMutex.RUnlock()
 	fake.identifierMutex.RLock()
@@ -864,8 +1318,12 @@ func (fake *PublicParameters) Invocations() map[string][][]interface{} {
 	defer fake.serializeMutex.RUnlock()
 	fake.stringMutex.RLock()
 	defer fake.stringMutex.RUnlock()
+	fake.supplyCapsMutex.RLock()
+	defer fake.supplyCapsMutex.RUnlock()
 	fake.tokenDataHidingMutex.RLock()
 	defer fake.tokenDataHidingMutex.RUnlock()
+	fake.tokenTypeIssuersMutex.RLock()
+	defer fake.tokenTypeIssuersMutex.RUnlock()
 	fake.validateMutex.RLock()
 	defer fake.validateMutex.RUnlock()
 	fake.versionMutex.RLock()
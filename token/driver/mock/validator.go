@@ -6,7 +6,6 @@ import (
 	"sync"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
-	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 )
 
 type Validator struct {
@@ -23,24 +22,36 @@ type Validator struct {
 		result1 []interface{}
 		result2 error
 	}
-	VerifyTokenRequestFromRawStub        func(context.Context, func(id token.ID) ([]byte, error), string, []byte) ([]interface{}, map[string][]byte, error)
+	VerifyTokenRequestFromRawStub        func(context.Context, driver.GetStateFnc, string, []byte) ([]interface{}, driver.ValidationAttributes, error)
 	verifyTokenRequestFromRawMutex       sync.RWMutex
 	verifyTokenRequestFromRawArgsForCall []struct {
 		arg1 context.Context
-		arg2 func(id token.ID) ([]byte, error)
+		arg2 driver.GetStateFnc
 		arg3 string
 		arg4 []byte
 	}
 	verifyTokenRequestFromRawReturns struct {
 		result1 []interface{}
-		result2 map[string][]byte
+		result2 driver.ValidationAttributes
 		result3 error
 	}
 	verifyTokenRequestFromRawReturnsOnCall map[int]struct {
 		result1 []interface{}
-		result2 map[string][]byte
+		result2 driver.ValidationAttributes
 		result3 error
 	}
+	VerifyTokenRequestsFromRawStub        func(context.Context, []*driver.ValidationRequest) []*driver.ValidationResult
+	verifyTokenRequestsFromRawMutex       sync.RWMutex
+	verifyTokenRequestsFromRawArgsForCall []struct {
+		arg1 context.Context
+		arg2 []*driver.ValidationRequest
+	}
+	verifyTokenRequestsFromRawReturns struct {
+		result1 []*driver.ValidationResult
+	}
+	verifyTokenRequestsFromRawReturnsOnCall map[int]struct {
+		result1 []*driver.ValidationResult
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -114,7 +125,7 @@ func (fake *Validator) UnmarshalActionsReturnsOnCall(i int, result1 []interface{
 	}{result1, result2}
 }
 
-func (fake *Validator) VerifyTokenRequestFromRaw(arg1 context.Context, arg2 func(id token.ID) ([]byte, error), arg3 string, arg4 []byte) ([]interface{}, map[string][]byte, error) {
+func (fake *Validator) VerifyTokenRequestFromRaw(arg1 context.Context, arg2 driver.GetStateFnc, arg3 string, arg4 []byte) ([]interface{}, driver.ValidationAttributes, error) {
 	var arg4Copy []byte
 	if arg4 != nil {
 		arg4Copy = make([]byte, len(arg4))
@@ -124,7 +135,7 @@ func (fake *Validator) VerifyTokenRequestFromRaw(arg1 context.Context, arg2 func
 	ret, specificReturn := fake.verifyTokenRequestFromRawReturnsOnCall[len(fake.verifyTokenRequestFromRawArgsForCall)]
 	fake.verifyTokenRequestFromRawArgsForCall = append(fake.verifyTokenRequestFromRawArgsForCall, struct {
 		arg1 context.Context
-		arg2 func(id token.ID) ([]byte, error)
+		arg2 driver.GetStateFnc
 		arg3 string
 		arg4 []byte
 	}{arg1, arg2, arg3, arg4Copy})
@@ -147,48 +158,115 @@ func (fake *Validator) VerifyTokenRequestFromRawCallCount() int {
 	return len(fake.verifyTokenRequestFromRawArgsForCall)
 }
 
-func (fake *Validator) VerifyTokenRequestFromRawCalls(stub func(context.Context, func(id token.ID) ([]byte, error), string, []byte) ([]interface{}, map[string][]byte, error)) {
+func (fake *Validator) VerifyTokenRequestFromRawCalls(stub func(context.Context, driver.GetStateFnc, string, []byte) ([]interface{}, driver.ValidationAttributes, error)) {
 	fake.verifyTokenRequestFromRawMutex.Lock()
 	defer fake.verifyTokenRequestFromRawMutex.Unlock()
 	fake.VerifyTokenRequestFromRawStub = stub
 }
 
-func (fake *Validator) VerifyTokenRequestFromRawArgsForCall(i int) (context.Context, func(id token.ID) ([]byte, error), string, []byte) {
+func (fake *Validator) VerifyTokenRequestFromRawArgsForCall(i int) (context.Context, driver.GetStateFnc, string, []byte) {
 	fake.verifyTokenRequestFromRawMutex.RLock()
 	defer fake.verifyTokenRequestFromRawMutex.RUnlock()
 	argsForCall := fake.verifyTokenRequestFromRawArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
-func (fake *Validator) VerifyTokenRequestFromRawReturns(result1 []interface{}, result2 map[string][]byte, result3 error) {
+func (fake *Validator) VerifyTokenRequestFromRawReturns(result1 []interface{}, result2 driver.ValidationAttributes, result3 error) {
 	fake.verifyTokenRequestFromRawMutex.Lock()
 	defer fake.verifyTokenRequestFromRawMutex.Unlock()
 	fake.VerifyTokenRequestFromRawStub = nil
 	fake.verifyTokenRequestFromRawReturns = struct {
 		result1 []interface{}
-		result2 map[string][]byte
+		result2 driver.ValidationAttributes
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *Validator) VerifyTokenRequestFromRawReturnsOnCall(i int, result1 []interface{}, result2 map[string][]byte, result3 error) {
+func (fake *Validator) VerifyTokenRequestFromRawReturnsOnCall(i int, result1 []interface{}, result2 driver.ValidationAttributes, result3 error) {
 	fake.verifyTokenRequestFromRawMutex.Lock()
 	defer fake.verifyTokenRequestFromRawMutex.Unlock()
 	fake.VerifyTokenRequestFromRawStub = nil
 	if fake.verifyTokenRequestFromRawReturnsOnCall == nil {
 		fake.verifyTokenRequestFromRawReturnsOnCall = make(map[int]struct {
 			result1 []interface{}
-			result2 map[string][]byte
+			result2 driver.ValidationAttributes
 			result3 error
 		})
 	}
 	fake.verifyTokenRequestFromRawReturnsOnCall[i] = struct {
 		result1 []interface{}
-		result2 map[string][]byte
+		result2 driver.ValidationAttributes
 		result3 error
 	}{result1, result2, result3}
 }
 
+func (fake *Validator) VerifyTokenRequestsFromRaw(arg1 context.Context, arg2 []*driver.ValidationRequest) []*driver.ValidationResult {
+	var arg2Copy []*driver.ValidationRequest
+	if arg2 != nil {
+		arg2Copy = make([]*driver.ValidationRequest, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.verifyTokenRequestsFromRawMutex.Lock()
+	ret, specificReturn := fake.verifyTokenRequestsFromRawReturnsOnCall[len(fake.verifyTokenRequestsFromRawArgsForCall)]
+	fake.verifyTokenRequestsFromRawArgsForCall = append(fake.verifyTokenRequestsFromRawArgsForCall, struct {
+		arg1 context.Context
+		arg2 []*driver.ValidationRequest
+	}{arg1, arg2Copy})
+	stub := fake.VerifyTokenRequestsFromRawStub
+	fakeReturns := fake.verifyTokenRequestsFromRawReturns
+	fake.recordInvocation("VerifyTokenRequestsFromRaw", []interface{}{arg1, arg2Copy})
+	fake.verifyTokenRequestsFromRawMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Validator) VerifyTokenRequestsFromRawCallCount() int {
+	fake.verifyTokenRequestsFromRawMutex.RLock()
+	defer fake.verifyTokenRequestsFromRawMutex.RUnlock()
+	return len(fake.verifyTokenRequestsFromRawArgsForCall)
+}
+
+func (fake *Validator) VerifyTokenRequestsFromRawCalls(stub func(context.Context, []*driver.ValidationRequest) []*driver.ValidationResult) {
+	fake.verifyTokenRequestsFromRawMutex.Lock()
+	defer fake.verifyTokenRequestsFromRawMutex.Unlock()
+	fake.VerifyTokenRequestsFromRawStub = stub
+}
+
+func (fake *Validator) VerifyTokenRequestsFromRawArgsForCall(i int) (context.Context, []*driver.ValidationRequest) {
+	fake.verifyTokenRequestsFromRawMutex.RLock()
+	defer fake.verifyTokenRequestsFromRawMutex.RUnlock()
+	argsForCall := fake.verifyTokenRequestsFromRawArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Validator) VerifyTokenRequestsFromRawReturns(result1 []*driver.ValidationResult) {
+	fake.verifyTokenRequestsFromRawMutex.Lock()
+	defer fake.verifyTokenRequestsFromRawMutex.Unlock()
+	fake.VerifyTokenRequestsFromRawStub = nil
+	fake.verifyTokenRequestsFromRawReturns = struct {
+		result1 []*driver.ValidationResult
+	}{result1}
+}
+
+func (fake *Validator) VerifyTokenRequestsFromRawReturnsOnCall(i int, result1 []*driver.ValidationResult) {
+	fake.verifyTokenRequestsFromRawMutex.Lock()
+	defer fake.verifyTokenRequestsFromRawMutex.Unlock()
+	fake.VerifyTokenRequestsFromRawStub = nil
+	if fake.verifyTokenRequestsFromRawReturnsOnCall == nil {
+		fake.verifyTokenRequestsFromRawReturnsOnCall = make(map[int]struct {
+			result1 []*driver.ValidationResult
+		})
+	}
+	fake.verifyTokenRequestsFromRawReturnsOnCall[i] = struct {
+		result1 []*driver.ValidationResult
+	}{result1}
+}
+
 func (fake *Validator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -196,6 +274,8 @@ func (fake *Validator) Invocations() map[string][][]interface{} {
 	defer fake.unmarshalActionsMutex.RUnlock()
 	fake.verifyTokenRequestFromRawMutex.RLock()
 	defer fake.verifyTokenRequestFromRawMutex.RUnlock()
+	fake.verifyTokenRequestsFromRawMutex.RLock()
+	defer fake.verifyTokenRequestsFromRawMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// RevocationAuthorityPublicParameters is implemented by the public parameters of the drivers that support
+// the revocation of anonymous owner identities.
+// Epoch by epoch, the revocation authority signs the credential revocation information (CRI) with its revocation key.
+// The owners use it to attach a non-revocation proof to their signatures.
+// The CRI is the same for all the owners, therefore the proof discloses the revocation handle of the owner,
+// and the validators reject the owners whose revocation handle is in the revocation list stored on the ledger,
+// see RevocationList.OwnerRevocationHandles.
+// Epochs are measured in blocks, the epoch of a transaction is determined by the height of the ledger it is validated at.
+type RevocationAuthorityPublicParameters interface {
+	// RevocationPublicKey returns the PEM encoding of the public revocation key, nil if owner revocation is disabled
+	RevocationPublicKey() []byte
+	// RevocationEpochLength returns the number of blocks of an epoch of the revocation authority
+	RevocationEpochLength() uint64
+}
+
+// IsOwnerRevocationEnabled returns true if the passed public parameters require the signatures of the idemix owners
+// to carry a non-revocation proof. The validation of such signatures needs the height of the ledger,
+// see WithBlockHeight, therefore the networks that cannot provide it must reject these public parameters.
+func IsOwnerRevocationEnabled(pp any) bool {
+	rapp, ok := pp.(RevocationAuthorityPublicParameters)
+	return ok && len(rapp.RevocationPublicKey()) != 0
+}
+
+// RevocationEpoch returns the epoch of the revocation authority the passed ledger height falls in
+func RevocationEpoch(height uint64, length uint64) uint64 {
+	if length == 0 {
+		return 0
+	}
+	return height / length
+}
+
+// IsRevocationEpochValidIn returns true if a non-revocation proof generated for the passed epoch is still valid in the current epoch.
+// A proof is valid in the epoch it has been generated for and in the next one,
+// to tolerate the transactions signed at the end of an epoch.
+func IsRevocationEpochValidIn(proofEpoch, currentEpoch uint64) bool {
+	return proofEpoch == currentEpoch || proofEpoch+1 == currentEpoch
+}
+
+// NonRevokedSignature is the signature of an owner, accompanied by the proof that the owner has not been revoked
+type NonRevokedSignature struct {
+	// Signature is the signature of the owner
+	Signature []byte
+	// NonRevocationProof is the driver-specific non-revocation proof, generated by the owner on the same message
+	NonRevocationProof []byte
+	// RevocationHandle is the revocation handle of the owner, disclosed by the non-revocation proof.
+	// The validators check it against the revocation list stored on the ledger.
+	RevocationHandle []byte
+}
+
+// Bytes returns the JSON encoding of the signature
+func (s *NonRevokedSignature) Bytes() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromBytes decodes the signature from the passed JSON encoding
+func (s *NonRevokedSignature) FromBytes(raw []byte) error {
+	*s = NonRevokedSignature{}
+	if err := json.Unmarshal(raw, s); err != nil {
+		return errors.Wrap(err, "failed unmarshalling non-revoked signature")
+	}
+	if len(s.Signature) == 0 || len(s.NonRevocationProof) == 0 || len(s.RevocationHandle) == 0 {
+		return errors.New("invalid non-revoked signature, signature, non-revocation proof, or revocation handle missing")
+	}
+	return nil
+}
+
+// StripNonRevocationProof returns the owner signature carried by the passed signature.
+// If the passed signature does not carry a non-revocation proof, it is returned as it is.
+func StripNonRevocationProof(sigma []byte) []byte {
+	nrs := &NonRevokedSignature{}
+	if err := nrs.FromBytes(sigma); err != nil {
+		return sigma
+	}
+	return nrs.Signature
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevocationEpoch(t *testing.T) {
+	assert.Equal(t, uint64(2), RevocationEpoch(200, 100))
+	assert.Equal(t, uint64(2), RevocationEpoch(299, 100))
+	assert.Equal(t, uint64(3), RevocationEpoch(300, 100))
+	assert.Equal(t, uint64(0), RevocationEpoch(300, 0))
+
+	assert.True(t, IsRevocationEpochValidIn(2, 2))
+	assert.True(t, IsRevocationEpochValidIn(2, 3))
+	assert.False(t, IsRevocationEpochValidIn(2, 4))
+	assert.False(t, IsRevocationEpochValidIn(3, 2))
+}
+
+func TestNonRevokedSignature(t *testing.T) {
+	nrs := &NonRevokedSignature{
+		Signature:          []byte("sigma"),
+		NonRevocationProof: []byte("proof"),
+		RevocationHandle:   []byte("rh"),
+	}
+	raw, err := nrs.Bytes()
+	assert.NoError(t, err)
+	nrs2 := &NonRevokedSignature{}
+	assert.NoError(t, nrs2.FromBytes(raw))
+	assert.Equal(t, nrs, nrs2)
+	assert.Equal(t, []byte("sigma"), StripNonRevocationProof(raw))
+
+	// signatures without proof are returned as they are
+	assert.Equal(t, []byte("sigma"), StripNonRevocationProof([]byte("sigma")))
+	assert.Error(t, nrs2.FromBytes([]byte(`{"Signature":"c2lnbWE="}`)))
+}
+
+func TestIsOwnerRevoked(t *testing.T) {
+	var nilList *RevocationList
+	assert.False(t, nilList.IsOwnerRevoked([]byte("rh")))
+
+	list := &RevocationList{OwnerRevocationHandles: [][]byte{[]byte("rh1"), []byte("rh2")}}
+	assert.True(t, list.IsOwnerRevoked([]byte("rh2")))
+	assert.False(t, list.IsOwnerRevoked([]byte("rh3")))
+	assert.False(t, list.IsOwnerRevoked(nil))
+}
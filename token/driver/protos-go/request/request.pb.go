@@ -34,6 +34,10 @@ const (
 	ActionType_ISSUE ActionType = 0
 	// Token transfer action type
 	ActionType_TRANSFER ActionType = 1
+	// Administrative action type, such as the update of the revocation list
+	ActionType_ADMIN ActionType = 2
+	// Compliance action type, such as the freeze of tokens
+	ActionType_FREEZE ActionType = 3
 )
 
 // Enum value maps for ActionType.
@@ -41,10 +45,14 @@ var (
 	ActionType_name = map[int32]string{
 		0: "ISSUE",
 		1: "TRANSFER",
+		2: "ADMIN",
+		3: "FREEZE",
 	}
 	ActionType_value = map[string]int32{
 		"ISSUE":    0,
 		"TRANSFER": 1,
+		"ADMIN":    2,
+		"FREEZE":   3,
 	}
 )
 
@@ -160,7 +168,7 @@ func (x *AuditableIdentity) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+// Deprecated: Use AuditableIdentity.ProtoReflect.Descriptor instead.
 func (*AuditableIdentity) Descriptor() ([]byte, []int) {
 	return file_request_proto_rawDescGZIP(), []int{1}
 }
@@ -543,7 +551,6 @@ type ActionMetadata struct {
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Metadata:
-	//
 	//	*ActionMetadata_IssueMetadata
 	//	*ActionMetadata_TransferMetadata
 	Metadata isActionMetadata_Metadata `protobuf_oneof:"Metadata"`
@@ -796,6 +803,7 @@ type TokenRequest struct {
 	Actions           []*Action    `protobuf:"bytes,2,rep,name=actions,proto3" json:"actions,omitempty"`                                              // List of token actions to perform
 	Signatures        []*Signature `protobuf:"bytes,3,rep,name=signatures,proto3" json:"signatures,omitempty"`                                        // Signatures for the actions
 	AuditorSignatures []*Signature `protobuf:"bytes,4,rep,name=auditor_signatures,json=auditorSignatures,proto3" json:"auditor_signatures,omitempty"` // Additional signatures from auditors
+	PublicParamsHash  []byte       `protobuf:"bytes,5,opt,name=public_params_hash,json=publicParamsHash,proto3" json:"public_params_hash,omitempty"`  // Hash of the public parameters the actions have been prepared with
 }
 
 func (x *TokenRequest) Reset() {
@@ -858,6 +866,13 @@ func (x *TokenRequest) GetAuditorSignatures() []*Signature {
 	return nil
 }
 
+func (x *TokenRequest) GetPublicParamsHash() []byte {
+	if x != nil {
+		return x.PublicParamsHash
+	}
+	return nil
+}
+
 type TokenRequestWithMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1022,7 +1037,7 @@ var file_request_proto_rawDesc = []byte{
 	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22, 0x1d, 0x0a, 0x09, 0x53,
 	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22, 0xc7, 0x01, 0x0a, 0x0c, 0x54,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x72, 0x61, 0x77, 0x22, 0xf5, 0x01, 0x0a, 0x0c, 0x54,
 	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76,
 	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65,
 	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
@@ -1035,23 +1050,27 @@ var file_request_proto_rawDesc = []byte{
 	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
 	0x65, 0x52, 0x11, 0x61, 0x75, 0x64, 0x69, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x73, 0x22, 0xb6, 0x01, 0x0a, 0x18, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61,
-	0x6e, 0x63, 0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6e, 0x63,
-	0x68, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2a, 0x25, 0x0a,
-	0x0a, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x49,
-	0x53, 0x53, 0x55, 0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46,
-	0x45, 0x52, 0x10, 0x01, 0x42, 0x16, 0x5a, 0x14, 0x2e, 0x2e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x72, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x10, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x48, 0x61,
+	0x73, 0x68, 0x22, 0xb6, 0x01, 0x0a, 0x18, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6e, 0x63,
+	0x68, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6e, 0x63, 0x68, 0x6f,
+	0x72, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x38, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2a, 0x3c, 0x0a, 0x0a, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x53, 0x53,
+	0x55, 0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46, 0x45, 0x52,
+	0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x44, 0x4d, 0x49, 0x4e, 0x10, 0x02, 0x12, 0x0a, 0x0a,
+	0x06, 0x46, 0x52, 0x45, 0x45, 0x5a, 0x45, 0x10, 0x03, 0x42, 0x16, 0x5a, 0x14, 0x2e, 0x2e, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2d, 0x67, 0x6f, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1071,7 +1090,7 @@ var file_request_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_request_proto_goTypes = []interface{}{
 	(ActionType)(0),                  // 0: protos.ActionType
 	(*Identity)(nil),                 // 1: protos.Identity
-	(*AuditableIdentity)(nil),        // 2: protos.Identity
+	(*AuditableIdentity)(nil),        // 2: protos.AuditableIdentity
 	(*TokenID)(nil),                  // 3: protos.TokenID
 	(*TransferInputMetadata)(nil),    // 4: protos.TransferInputMetadata
 	(*OutputMetadata)(nil),           // 5: protos.OutputMetadata
@@ -1087,15 +1106,15 @@ var file_request_proto_goTypes = []interface{}{
 	nil,                              // 15: protos.TokenRequestMetadata.ApplicationEntry
 }
 var file_request_proto_depIdxs = []int32{
-	1,  // 0: protos.Identity.identity:type_name -> protos.Identity
+	1,  // 0: protos.AuditableIdentity.identity:type_name -> protos.Identity
 	3,  // 1: protos.TransferInputMetadata.token_id:type_name -> protos.TokenID
-	2,  // 2: protos.TransferInputMetadata.senders:type_name -> protos.Identity
-	2,  // 3: protos.OutputMetadata.receivers:type_name -> protos.Identity
+	2,  // 2: protos.TransferInputMetadata.senders:type_name -> protos.AuditableIdentity
+	2,  // 3: protos.OutputMetadata.receivers:type_name -> protos.AuditableIdentity
 	4,  // 4: protos.TransferMetadata.inputs:type_name -> protos.TransferInputMetadata
 	5,  // 5: protos.TransferMetadata.outputs:type_name -> protos.OutputMetadata
 	1,  // 6: protos.TransferMetadata.extra_signers:type_name -> protos.Identity
 	3,  // 7: protos.IssueInputMetadata.token_id:type_name -> protos.TokenID
-	2,  // 8: protos.IssueMetadata.issuer:type_name -> protos.Identity
+	2,  // 8: protos.IssueMetadata.issuer:type_name -> protos.AuditableIdentity
 	7,  // 9: protos.IssueMetadata.inputs:type_name -> protos.IssueInputMetadata
 	5,  // 10: protos.IssueMetadata.outputs:type_name -> protos.OutputMetadata
 	1,  // 11: protos.IssueMetadata.extra_signers:type_name -> protos.Identity
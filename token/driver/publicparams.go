@@ -6,6 +6,15 @@ SPDX-License-Identifier: Apache-2.0
 
 package driver
 
+import (
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+)
+
+// TokenTypeWildcard is the suffix that turns the token type of a TokenTypeIssuers entry into a prefix
+const TokenTypeWildcard = "*"
+
 // PPHash is used to model the hash of the raw public parameters.
 // This should avoid confusion between the bytes of the public params themselves and its hash.
 type PPHash []byte
@@ -50,8 +59,30 @@ type PublicParameters interface {
 	Bytes() ([]byte, error)
 	// Auditors returns the list of auditors.
 	Auditors() []Identity
+	// AuditorsThreshold returns the minimum number of auditors that must sign a token request.
+	// It is zero if there are no auditors.
+	AuditorsThreshold() uint64
 	// Issuers returns the list of issuers.
 	Issuers() []Identity
+	// Administrators returns the identities authorized to sign administrative actions,
+	// such as the updates of the revocation list of issuers and auditors.
+	Administrators() []Identity
+	// AdministratorsThreshold returns the minimum number of administrators that must sign an administrative action.
+	// It is zero if there are no administrators.
+	AdministratorsThreshold() uint64
+	// ComplianceAuthorities returns the identities authorized to freeze, unfreeze, and claw back tokens.
+	// If empty, no token can be frozen or clawed back.
+	ComplianceAuthorities() []Identity
+	// ComplianceThreshold returns the minimum number of compliance authorities that must authorize a clawback.
+	// It is zero if there are no compliance authorities.
+	ComplianceThreshold() uint64
+	// TokenTypeIssuers returns the token types, or prefixes of token types, that can be issued,
+	// each bound to the issuers authorized to issue it.
+	// If empty, any token type can be issued by any of the issuers.
+	TokenTypeIssuers() []*TokenTypeIssuers
+	// SupplyCaps returns the maximum circulating supply of the token types that have one.
+	// The token types not in the map have no supply cap.
+	SupplyCaps() map[token.Type]uint64
 	// Precision returns the precision used to represent the token value.
 	Precision() uint64
 	// String returns a readable version of the public parameters
@@ -73,3 +104,25 @@ type PublicParamsManager interface {
 	// PublicParamsHash returns the hash of the raw public parameters
 	PublicParamsHash() PPHash
 }
+
+// TokenTypeIssuers binds a token type, or a prefix of token types, to the issuers authorized to issue it.
+type TokenTypeIssuers struct {
+	// TokenType is a token type, or a prefix of token types if it ends with TokenTypeWildcard
+	TokenType token.Type
+	// Issuers is the list of identities authorized to issue tokens of the matching types.
+	// If empty, the general list of issuers applies.
+	Issuers []Identity
+}
+
+// IsPrefix returns true if this entry matches all the token types starting with a given prefix
+func (t *TokenTypeIssuers) IsPrefix() bool {
+	return strings.HasSuffix(string(t.TokenType), TokenTypeWildcard)
+}
+
+// Matches returns true if the passed token type is covered by this entry
+func (t *TokenTypeIssuers) Matches(tokenType token.Type) bool {
+	if t.IsPrefix() {
+		return strings.HasPrefix(string(tokenType), strings.TrimSuffix(string(t.TokenType), TokenTypeWildcard))
+	}
+	return t.TokenType == tokenType
+}
@@ -33,6 +33,17 @@ type TokenRequest struct {
 	Transfers         [][]byte
 	Signatures        [][]byte
 	AuditorSignatures [][]byte
+	// PublicParamsHash is the hash of the public parameters the actions have been prepared with.
+	// It is empty for requests prepared before public parameters epochs were introduced.
+	// It is part of the message to sign, so that the validator of a past epoch cannot be selected for the actions
+	// of a request without the consent of the signers.
+	PublicParamsHash PPHash `asn1:"omitempty"`
+	// AdminActions are the administrative actions, such as RevocationListUpdate.
+	// Each of them carries the signatures of the administrators, therefore, they are not part of the message to sign.
+	AdminActions [][]byte `asn1:"omitempty"`
+	// FreezeActions are the compliance actions that freeze or unfreeze tokens, see Freeze.
+	// Each of them carries the signatures of the compliance authorities, therefore, they are not part of the message to sign.
+	FreezeActions [][]byte `asn1:"omitempty"`
 }
 
 func (r *TokenRequest) Bytes() ([]byte, error) {
@@ -60,8 +71,11 @@ func (r *TokenRequest) ToProtos() (*request.TokenRequest, error) {
 		utils.ToActionSlice(request.ActionType_ISSUE, r.Issues),
 		utils.ToActionSlice(request.ActionType_TRANSFER, r.Transfers)...,
 	)
+	tr.Actions = append(tr.Actions, utils.ToActionSlice(request.ActionType_ADMIN, r.AdminActions)...)
+	tr.Actions = append(tr.Actions, utils.ToActionSlice(request.ActionType_FREEZE, r.FreezeActions)...)
 	tr.Signatures = utils.ToSignatureSlice(r.Signatures)
 	tr.AuditorSignatures = utils.ToSignatureSlice(r.AuditorSignatures)
+	tr.PublicParamsHash = r.PublicParamsHash
 	return tr, nil
 }
 
@@ -75,6 +89,10 @@ func (r *TokenRequest) FromProtos(tr *request.TokenRequest) error {
 			r.Issues = append(r.Issues, action.Raw)
 		case request.ActionType_TRANSFER:
 			r.Transfers = append(r.Transfers, action.Raw)
+		case request.ActionType_ADMIN:
+			r.AdminActions = append(r.AdminActions, action.Raw)
+		case request.ActionType_FREEZE:
+			r.FreezeActions = append(r.FreezeActions, action.Raw)
 		default:
 			return errors.Errorf("unknown action type [%s]", action.Type)
 		}
@@ -85,17 +103,20 @@ func (r *TokenRequest) FromProtos(tr *request.TokenRequest) error {
 		}
 		r.Signatures = append(r.Signatures, signature.Raw)
 	}
+	// auditor signatures are positional, one per auditor in the public parameters.
+	// An empty signature means that the corresponding auditor did not sign.
 	for _, signature := range tr.AuditorSignatures {
-		if signature == nil || len(signature.Raw) == 0 {
+		if signature == nil {
 			return errors.New("nil auditor signature found")
 		}
 		r.AuditorSignatures = append(r.AuditorSignatures, signature.Raw)
 	}
+	r.PublicParamsHash = tr.PublicParamsHash
 	return nil
 }
 
 func (r *TokenRequest) MarshalToMessageToSign(anchor []byte) ([]byte, error) {
-	bytes, err := asn1.Marshal(TokenRequest{Issues: r.Issues, Transfers: r.Transfers})
+	bytes, err := asn1.Marshal(TokenRequest{Issues: r.Issues, Transfers: r.Transfers, PublicParamsHash: r.PublicParamsHash})
 	if err != nil {
 		return nil, errors.Wrapf(err, "audit of tx [%s] failed: error marshal token request for signature", string(anchor))
 	}
@@ -22,6 +22,7 @@ func TestTokenRequestSerialization(t *testing.T) {
 		Transfers:         [][]byte{[]byte("transfer1")},
 		Signatures:        [][]byte{[]byte("signature1")},
 		AuditorSignatures: [][]byte{[]byte("auditor_signature1")},
+		FreezeActions:     [][]byte{[]byte("freeze1")},
 	}
 	raw, err := req.Bytes()
 	assert.NoError(t, err)
@@ -32,6 +33,28 @@ func TestTokenRequestSerialization(t *testing.T) {
 	assert.Equal(t, req, req2)
 }
 
+func TestTokenRequestMessageToSign(t *testing.T) {
+	req := &TokenRequest{
+		Issues:     [][]byte{[]byte("issue1")},
+		Transfers:  [][]byte{[]byte("transfer1")},
+		Signatures: [][]byte{[]byte("signature1")},
+	}
+	legacy, err := req.MarshalToMessageToSign([]byte("anchor"))
+	assert.NoError(t, err)
+
+	// the signatures are not part of the message
+	req.Signatures = nil
+	msg, err := req.MarshalToMessageToSign([]byte("anchor"))
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, msg)
+
+	// the hash of the public parameters is
+	req.PublicParamsHash = []byte("pp_hash")
+	msg, err = req.MarshalToMessageToSign([]byte("anchor"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, legacy, msg)
+}
+
 func TestTokenRequestMetadataSerialization(t *testing.T) {
 	reqMeta := &TokenRequestMetadata{
 		Issues: []*IssueMetadata{
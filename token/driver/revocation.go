@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// RevocationListTxID is the reserved transaction identifier with which the validators read the revocation list
+const RevocationListTxID = "revocation.list"
+
+// RevocationListID returns the identifier with which a validator reads, via its GetStateFnc, the revocation list.
+// The networks store the list under its own key, and not under the key of an output.
+func RevocationListID() token.ID {
+	return token.ID{TxId: RevocationListTxID, Index: 0}
+}
+
+// RevocationList lists the issuer and auditor identities, among those in the public parameters,
+// whose signatures are no longer accepted, and the revocation handles of the revoked anonymous owners.
+type RevocationList struct {
+	// Sequence is the sequence number of the list. Each update of the list must increment it by one.
+	Sequence uint64
+	// Issuers are the revoked issuer identities
+	Issuers []Identity
+	// Auditors are the revoked auditor identities
+	Auditors []Identity
+	// OwnerRevocationHandles are the revocation handles of the revoked owners.
+	// They are checked against the revocation handle disclosed by the non-revocation proofs, see NonRevokedSignature.
+	OwnerRevocationHandles [][]byte
+}
+
+// Bytes returns the JSON encoding of the list
+func (l *RevocationList) Bytes() ([]byte, error) {
+	return json.Marshal(l)
+}
+
+// FromBytes decodes the list from the passed JSON encoding.
+// An empty value corresponds to an empty list.
+func (l *RevocationList) FromBytes(raw []byte) error {
+	if len(raw) == 0 {
+		*l = RevocationList{}
+		return nil
+	}
+	if err := json.Unmarshal(raw, l); err != nil {
+		return errors.Wrap(err, "failed unmarshalling revocation list")
+	}
+	return nil
+}
+
+// IsIssuerRevoked returns true if the passed issuer identity has been revoked.
+// A nil list revokes nothing.
+func (l *RevocationList) IsIssuerRevoked(id Identity) bool {
+	return l != nil && containsIdentity(l.Issuers, id)
+}
+
+// IsAuditorRevoked returns true if the passed auditor identity has been revoked.
+// A nil list revokes nothing.
+func (l *RevocationList) IsAuditorRevoked(id Identity) bool {
+	return l != nil && containsIdentity(l.Auditors, id)
+}
+
+// IsOwnerRevoked returns true if the owner with the passed revocation handle has been revoked.
+// A nil list revokes nothing.
+func (l *RevocationList) IsOwnerRevoked(revocationHandle []byte) bool {
+	return l != nil && slices.ContainsFunc(l.OwnerRevocationHandles, func(rh []byte) bool {
+		return bytes.Equal(rh, revocationHandle)
+	})
+}
+
+// ReadRevocationList returns the revocation list stored on the passed ledger, an empty list if none is stored
+func ReadRevocationList(ledger Ledger) (*RevocationList, error) {
+	raw, err := ledger.GetState(RevocationListID())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the revocation list")
+	}
+	list := &RevocationList{}
+	if err := list.FromBytes(raw); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// AdminSignature is the signature of an administrator, see PublicParameters.Administrators, on an administrative action
+type AdminSignature struct {
+	// Signer is the identity of the administrator
+	Signer Identity
+	// Sigma is the signature
+	Sigma []byte
+}
+
+// RevocationListUpdate is the administrative action that replaces the revocation list stored on the ledger.
+// It travels in the AdminActions of a TokenRequest.
+type RevocationListUpdate struct {
+	// List is the new revocation list, as returned by RevocationList.Bytes
+	List []byte
+	// Signatures are the signatures of the administrators on the message returned by MessageToSign
+	Signatures []*AdminSignature
+}
+
+// revocationListUpdateToSign is the message the administrators sign to authorize a RevocationListUpdate
+type revocationListUpdateToSign struct {
+	Namespace        string
+	PublicParamsHash []byte
+	Sequence         uint64
+	List             []byte
+}
+
+// MessageToSign returns the message the administrators sign to authorize the update.
+// The message binds the new list, and its sequence number, to the namespace it is stored in and to
+// the hash of the public parameters the update is validated with, so that the update cannot be replayed elsewhere.
+func (u *RevocationListUpdate) MessageToSign(namespace string, ppHash PPHash) ([]byte, error) {
+	list := &RevocationList{}
+	if err := list.FromBytes(u.List); err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(&revocationListUpdateToSign{
+		Namespace:        namespace,
+		PublicParamsHash: ppHash,
+		Sequence:         list.Sequence,
+		List:             u.List,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal revocation list update to sign")
+	}
+	return raw, nil
+}
+
+// Bytes returns the JSON encoding of the update
+func (u *RevocationListUpdate) Bytes() ([]byte, error) {
+	return json.Marshal(u)
+}
+
+// FromBytes decodes the update from the passed JSON encoding
+func (u *RevocationListUpdate) FromBytes(raw []byte) error {
+	if err := json.Unmarshal(raw, u); err != nil {
+		return errors.Wrap(err, "failed unmarshalling revocation list update")
+	}
+	return nil
+}
+
+func containsIdentity(ids []Identity, id Identity) bool {
+	return slices.ContainsFunc(ids, id.Equal)
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package driver
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// SupplyCounterTxIDPrefix is the prefix of the reserved transaction identifiers
+// with which the validators read the circulating supply of the token types with a supply cap.
+const SupplyCounterTxIDPrefix = "supply."
+
+// SupplyCounterID returns the identifier with which a validator reads, via its GetStateFnc, the circulating supply of the passed token type.
+// The networks store the counter under its own key, and not under the key of an output.
+func SupplyCounterID(tokenType token.Type) token.ID {
+	return token.ID{TxId: SupplyCounterTxIDPrefix + string(tokenType), Index: 0}
+}
+
+// SupplyCounterType returns the token type whose supply counter is read with the passed identifier, if any
+func SupplyCounterType(id token.ID) (token.Type, bool) {
+	tokenType, ok := strings.CutPrefix(id.TxId, SupplyCounterTxIDPrefix)
+	if !ok || id.Index != 0 {
+		return "", false
+	}
+	return token.Type(tokenType), true
+}
+
+// IsSupplyCounterTxID returns true if the passed transaction identifier is reserved to the supply counters
+func IsSupplyCounterTxID(txID string) bool {
+	return strings.HasPrefix(txID, SupplyCounterTxIDPrefix)
+}
+
+// MarshalSupply encodes the passed circulating supply as stored on the ledger
+func MarshalSupply(supply uint64) []byte {
+	return []byte(strconv.FormatUint(supply, 10))
+}
+
+// UnmarshalSupply decodes a circulating supply as stored on the ledger.
+// An empty value corresponds to a zero supply.
+func UnmarshalSupply(raw []byte) (uint64, error) {
+	if len(raw) == 0 {
+		return 0, nil
+	}
+	supply, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid supply [%s]", string(raw))
+	}
+	return supply, nil
+}
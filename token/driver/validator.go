@@ -21,6 +21,40 @@ type ValidationAttributes = map[ValidationAttributeID][]byte
 // GetStateFnc models a function that returns the value for the given key from the ledger
 type GetStateFnc = func(id token.ID) ([]byte, error)
 
+type blockHeightKey struct{}
+
+// WithBlockHeight returns a copy of the passed context carrying the current height of the ledger
+// against which a token request is validated.
+func WithBlockHeight(ctx context.Context, height uint64) context.Context {
+	return context.WithValue(ctx, blockHeightKey{}, height)
+}
+
+// BlockHeightFromContext returns the ledger height carried by the passed context, if any
+func BlockHeightFromContext(ctx context.Context) (uint64, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	height, ok := ctx.Value(blockHeightKey{}).(uint64)
+	return height, ok
+}
+
+type namespaceKey struct{}
+
+// WithNamespace returns a copy of the passed context carrying the namespace
+// in which a token request is validated.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace carried by the passed context, if any
+func NamespaceFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	namespace, ok := ctx.Value(namespaceKey{}).(string)
+	return namespace, ok && len(namespace) != 0
+}
+
 // Ledger models a read-only ledger
 type Ledger interface {
 	// GetState returns the value for the given key
@@ -41,6 +75,26 @@ type ValidatorLedger interface {
 	GetState(id token.ID) ([]byte, error)
 }
 
+// ValidationRequest is a marshalled token request to be verified, as part of a batch, against its own ledger and anchor
+type ValidationRequest struct {
+	// GetState gives access to the ledger against which the token request is verified
+	GetState GetStateFnc
+	// Anchor is the anchor of the token request
+	Anchor string
+	// Raw is the marshalled token request
+	Raw []byte
+}
+
+// ValidationResult is the outcome of the verification of a ValidationRequest
+type ValidationResult struct {
+	// Actions are the actions contained in the token request, if the verification succeeded
+	Actions []interface{}
+	// Attributes contains information about the token request. The content of this map is driver-dependant
+	Attributes ValidationAttributes
+	// Err is the reason why the verification failed, if any
+	Err error
+}
+
 // Validator models a token request validator
 type Validator interface {
 	// UnmarshalActions returns the actions contained in the serialized token request
@@ -49,4 +103,8 @@ type Validator interface {
 	// The function returns additionally a map that contains information about the token request. The content of this map
 	// is driver-dependant
 	VerifyTokenRequestFromRaw(ctx context.Context, getState GetStateFnc, anchor string, raw []byte) ([]interface{}, ValidationAttributes, error)
+	// VerifyTokenRequestsFromRaw verifies the passed batch of token requests, possibly in parallel.
+	// Each request is verified independently of the others, as VerifyTokenRequestFromRaw does.
+	// The function returns a result for each request, in the same order.
+	VerifyTokenRequestsFromRaw(ctx context.Context, requests []*ValidationRequest) []*ValidationResult
 }
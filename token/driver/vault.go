@@ -8,6 +8,7 @@ package driver
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 )
@@ -101,6 +102,6 @@ type QueryEngine interface {
 	// WhoDeletedTokens returns info about who deleted the passed tokens.
 	// The bool array is an indicator used to tell if the token at a given position has been deleted or not
 	WhoDeletedTokens(inputs ...*token.ID) ([]string, []bool, error)
-	// Balance returns the sun of the amounts, with 64 bits of precision, of the tokens with type and EID equal to those passed as arguments.
-	Balance(id string, tokenType token.Type) (uint64, error)
+	// Balance returns the sum of the amounts, with arbitrary precision, of the tokens with type and EID equal to those passed as arguments.
+	Balance(id string, tokenType token.Type) (*big.Int, error)
 }
@@ -8,6 +8,7 @@ package driver
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 )
@@ -77,8 +78,8 @@ type OwnerWallet interface {
 	// ListTokensIterator returns an iterator of unspent tokens owned by this wallet filtered using the passed options.
 	ListTokensIterator(opts *ListTokensOptions) (UnspentTokensIterator, error)
 
-	// Balance returns the sun of the amounts, with 64 bits of precision, of the tokens with type and EID equal to those passed as arguments.
-	Balance(opts *ListTokensOptions) (uint64, error)
+	// Balance returns the sum of the amounts, with arbitrary precision, of the tokens with type and EID equal to those passed as arguments.
+	Balance(opts *ListTokensOptions) (*big.Int, error)
 
 	// EnrollmentID returns the enrollment ID of the owner wallet
 	EnrollmentID() string
@@ -231,6 +232,10 @@ type Deserializer interface {
 	GetIssuerVerifier(id Identity) (Verifier, error)
 	// GetAuditorVerifier returns the verifier associated to the passed auditor identity
 	GetAuditorVerifier(id Identity) (Verifier, error)
+	// GetAdministratorVerifier returns the verifier associated to the passed administrator identity
+	GetAdministratorVerifier(id Identity) (Verifier, error)
+	// GetComplianceVerifier returns the verifier associated to the passed compliance authority identity
+	GetComplianceVerifier(id Identity) (Verifier, error)
 	// Recipients returns the recipient identities from the given serialized representation
 	Recipients(raw Identity) ([]Identity, error)
 	// GetAuditInfoMatcher returns an identity matcher for the passed identity and audit data
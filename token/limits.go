@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ActionLimitsKey is the key, in the configuration of a TMS, of the limits on the actions of its token requests
+const ActionLimitsKey = "request.limits"
+
+var (
+	// ErrTooManyInputs is returned when an action would spend more tokens than the configured limit
+	ErrTooManyInputs = errors.New("too many inputs")
+	// ErrTooManyOutputs is returned when an action would create more tokens than the configured limit
+	ErrTooManyOutputs = errors.New("too many outputs")
+)
+
+// ActionLimits bounds the number of inputs and outputs of the transfer and redeem actions of a token request,
+// so that oversized transactions are rejected when the request is assembled rather than by the network.
+// A zero limit means no limit.
+type ActionLimits struct {
+	// MaxInputs is the maximum number of tokens an action can spend
+	MaxInputs int `yaml:"maxInputs,omitempty"`
+	// MaxOutputs is the maximum number of tokens an action can create, the change included
+	MaxOutputs int `yaml:"maxOutputs,omitempty"`
+}
+
+// Check returns ErrTooManyInputs, or ErrTooManyOutputs, if an action with the passed number of inputs and outputs exceeds the limits
+func (l *ActionLimits) Check(inputs, outputs int) error {
+	if l.MaxInputs > 0 && inputs > l.MaxInputs {
+		return errors.Wrapf(ErrTooManyInputs, "the action spends [%d] tokens, max [%d]", inputs, l.MaxInputs)
+	}
+	if l.MaxOutputs > 0 && outputs > l.MaxOutputs {
+		return errors.Wrapf(ErrTooManyOutputs, "the action creates [%d] tokens, max [%d]", outputs, l.MaxOutputs)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package token
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+)
+
+type limitsConfig struct {
+	driver.Configuration
+	limits *ActionLimits
+}
+
+func (c *limitsConfig) IsSet(key string) bool {
+	return key == ActionLimitsKey && c.limits != nil
+}
+
+func (c *limitsConfig) UnmarshalKey(key string, rawVal interface{}) error {
+	*rawVal.(*ActionLimits) = *c.limits
+	return nil
+}
+
+func TestActionLimits(t *testing.T) {
+	limits, err := (&Configuration{cm: &limitsConfig{}}).ActionLimits()
+	assert.NoError(t, err)
+	assert.NoError(t, limits.Check(1000, 1000))
+
+	limits, err = (&Configuration{cm: &limitsConfig{limits: &ActionLimits{MaxInputs: 3, MaxOutputs: 2}}}).ActionLimits()
+	assert.NoError(t, err)
+	assert.NoError(t, limits.Check(3, 2))
+	assert.ErrorIs(t, limits.Check(4, 2), ErrTooManyInputs)
+	assert.EqualError(t, limits.Check(4, 2), "the action spends [4] tokens, max [3]: too many inputs")
+	assert.ErrorIs(t, limits.Check(3, 3), ErrTooManyOutputs)
+
+	_, err = (&Configuration{cm: &limitsConfig{limits: &ActionLimits{MaxInputs: -1}}}).ActionLimits()
+	assert.EqualError(t, err, "invalid config for key [request.limits]: limits cannot be negative")
+}
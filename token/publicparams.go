@@ -8,6 +8,7 @@ package token
 
 import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 )
 
 type PPHash = driver.PPHash
@@ -57,6 +58,17 @@ func (c *PublicParameters) Auditors() []Identity {
 	return c.PublicParameters.Auditors()
 }
 
+// AuditorsThreshold returns the minimum number of auditors that must sign a token request
+func (c *PublicParameters) AuditorsThreshold() uint64 {
+	return c.PublicParameters.AuditorsThreshold()
+}
+
+// SupplyCap returns the maximum circulating supply of the passed token type, and true if the token type has a supply cap
+func (c *PublicParameters) SupplyCap(tokenType token2.Type) (uint64, bool) {
+	supplyCap, ok := c.PublicParameters.SupplyCaps()[tokenType]
+	return supplyCap, ok
+}
+
 // PublicParamsFetcher models the public parameters fetcher
 type PublicParamsFetcher interface {
 	// Fetch fetches the public parameters from the backend
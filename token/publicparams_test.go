@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/mock"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -120,6 +121,32 @@ func TestPublicParameters_Auditors(t *testing.T) {
 
 }
 
+func TestPublicParameters_AuditorsThreshold(t *testing.T) {
+	pp := &PublicParameters{
+		PublicParameters: &mock.PublicParameters{},
+	}
+
+	mockPP := pp.PublicParameters.(*mock.PublicParameters)
+	mockPP.AuditorsThresholdReturns(2)
+
+	assert.Equal(t, uint64(2), pp.AuditorsThreshold())
+}
+
+func TestPublicParameters_SupplyCap(t *testing.T) {
+	pp := &PublicParameters{
+		PublicParameters: &mock.PublicParameters{},
+	}
+
+	mockPP := pp.PublicParameters.(*mock.PublicParameters)
+	mockPP.SupplyCapsReturns(map[token2.Type]uint64{"USD": 100})
+
+	supplyCap, ok := pp.SupplyCap("USD")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), supplyCap)
+	_, ok = pp.SupplyCap("EUR")
+	assert.False(t, ok)
+}
+
 func TestPublicParametersManager_PublicParameters(t *testing.T) {
 	ppm := &PublicParametersManager{
 		ppm: &mock.PublicParamsManager{},
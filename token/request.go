@@ -223,6 +223,14 @@ func (r *Request) ID() string {
 // The action issues to the receiver a token of the passed type and quantity.
 // Additional options can be passed to customize the action.
 func (r *Request) Issue(ctx context.Context, wallet *IssuerWallet, receiver Identity, typ token.Type, q uint64, opts ...IssueOption) (*IssueAction, error) {
+	return r.IssueMany(ctx, wallet, typ, []uint64{q}, []Identity{receiver}, opts...)
+}
+
+// IssueMany appends a single issue action to the request. The action will be prepared using the provided issuer wallet.
+// The action issues tokens of the passed type to the receivers for the passed quantities.
+// In other words, receivers[0] will receive values[0], and so on.
+// Additional options can be passed to customize the action.
+func (r *Request) IssueMany(ctx context.Context, wallet *IssuerWallet, typ token.Type, values []uint64, receivers []Identity, opts ...IssueOption) (*IssueAction, error) {
 	span := trace.SpanFromContext(ctx)
 	span.AddEvent("start_issue")
 	defer span.AddEvent("end_issue")
@@ -232,16 +240,22 @@ func (r *Request) Issue(ctx context.Context, wallet *IssuerWallet, receiver Iden
 	if typ == "" {
 		return nil, errors.Errorf("type is empty")
 	}
-	if q == 0 {
-		return nil, errors.Errorf("q is zero")
+	if len(values) == 0 || len(values) != len(receivers) {
+		return nil, errors.Errorf("values and receivers must be non-empty and of the same length [%d:%d]", len(values), len(receivers))
 	}
 	maxTokenValue := r.TokenService.PublicParametersManager().PublicParameters().MaxTokenValue()
-	if q > maxTokenValue {
-		return nil, errors.Errorf("q is larger than max token value [%d]", maxTokenValue)
-	}
-
-	if receiver.IsNone() {
-		return nil, errors.Errorf("all recipients should be defined")
+	rawReceivers := make([][]byte, len(receivers))
+	for i, q := range values {
+		if q == 0 {
+			return nil, errors.Errorf("q is zero")
+		}
+		if q > maxTokenValue {
+			return nil, errors.Errorf("q is larger than max token value [%d]", maxTokenValue)
+		}
+		if receivers[i].IsNone() {
+			return nil, errors.Errorf("all recipients should be defined")
+		}
+		rawReceivers[i] = receivers[i]
 	}
 
 	id, err := wallet.GetIssuerIdentity(typ)
@@ -259,8 +273,8 @@ func (r *Request) Issue(ctx context.Context, wallet *IssuerWallet, receiver Iden
 		ctx,
 		id,
 		typ,
-		[]uint64{q},
-		[][]byte{receiver},
+		values,
+		rawReceivers,
 		&driver.IssueOptions{
 			Attributes: opt.Attributes,
 		},
@@ -274,6 +288,7 @@ func (r *Request) Issue(ctx context.Context, wallet *IssuerWallet, receiver Iden
 	if err != nil {
 		return nil, err
 	}
+	r.bindPublicParams()
 	r.Actions.Issues = append(r.Actions.Issues, actionRaw)
 	r.Metadata.Issues = append(r.Metadata.Issues, metaRaw)
 
@@ -329,6 +344,7 @@ func (r *Request) Transfer(ctx context.Context, wallet *OwnerWallet, typ token.T
 	if err != nil {
 		return nil, errors.Wrap(err, "failed serializing transfer action")
 	}
+	r.bindPublicParams()
 	r.Actions.Transfers = append(r.Actions.Transfers, raw)
 	r.Metadata.Transfers = append(r.Metadata.Transfers, transferMetadata)
 
@@ -380,6 +396,7 @@ func (r *Request) Redeem(ctx context.Context, wallet *OwnerWallet, typ token.Typ
 		return errors.Wrap(err, "failed serializing transfer action")
 	}
 
+	r.bindPublicParams()
 	r.Actions.Transfers = append(r.Actions.Transfers, raw)
 	r.Metadata.Transfers = append(r.Metadata.Transfers, transferMetadata)
 
@@ -431,12 +448,138 @@ func (r *Request) Upgrade(
 	if err != nil {
 		return nil, err
 	}
+	r.bindPublicParams()
 	r.Actions.Issues = append(r.Actions.Issues, raw)
 	r.Metadata.Issues = append(r.Metadata.Issues, meta)
 
 	return &IssueAction{a: action}, nil
 }
 
+// Clawback appends to the request a transfer action that spends the passed tokens without the signature of their owners,
+// and sends their total value to the passed recovery owner.
+// The clawback is authorized by the passed compliance authorities, that sign the request in place of the owners.
+// Their number must reach the compliance threshold of the public parameters.
+// The tokens must be available in the vault of this node, in the clear, as it happens for an auditor.
+func (r *Request) Clawback(ctx context.Context, tokenIDs []*token.ID, recoveryOwner Identity, authorities []Identity, opts ...TransferOption) (*TransferAction, error) {
+	if len(tokenIDs) == 0 {
+		return nil, errors.New("no token to claw back")
+	}
+	if recoveryOwner.IsNone() {
+		return nil, errors.New("no recovery owner passed")
+	}
+	if len(authorities) == 0 {
+		return nil, errors.New("no compliance authority passed")
+	}
+	opt, err := compileTransferOptions(opts...)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed compiling options [%v]", opts)
+	}
+
+	// the output carries the total value of the inputs
+	toks, err := r.TokenService.Vault().NewQueryEngine().ListAuditTokens(tokenIDs...)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed retrieving the tokens to claw back")
+	}
+	if len(toks) != len(tokenIDs) {
+		return nil, errors.Errorf("retrieved less tokens than those to claw back [%d][%d]", len(toks), len(tokenIDs))
+	}
+	precision := r.TokenService.PublicParametersManager().PublicParameters().Precision()
+	sum := token.NewZeroQuantity(precision)
+	for i, tok := range toks {
+		if tok == nil {
+			return nil, errors.Errorf("token [%s] not found", tokenIDs[i])
+		}
+		if tok.Type != toks[0].Type {
+			return nil, errors.Errorf("tokens of different types cannot be clawed back together [%s][%s]", tok.Type, toks[0].Type)
+		}
+		q, err := token.ToQuantity(tok.Quantity, precision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed converting quantity [%s]", tok.Quantity)
+		}
+		sum = sum.Add(q)
+	}
+	outputTokens := []*token.Token{{Owner: recoveryOwner, Type: toks[0].Type, Quantity: sum.Hex()}}
+
+	// bind the clawback to the action
+	clawback := &driver.Clawback{Authorizers: authorities, RecoveryOwner: recoveryOwner}
+	clawbackRaw, err := clawback.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing clawback")
+	}
+	attributes := map[interface{}]interface{}{}
+	for k, v := range opt.Attributes {
+		attributes[k] = v
+	}
+	attributes[TransferMetadataPrefix+driver.ClawbackMetadataKey(tokenIDs[0])] = clawbackRaw
+
+	r.TokenService.logger.Debugf("Prepare Clawback Action [id:%s,ins:%d]", r.Anchor, len(tokenIDs))
+
+	ts := r.TokenService.tms.TransferService()
+	transfer, transferMetadata, err := ts.Transfer(
+		ctx,
+		r.Anchor,
+		nil,
+		tokenIDs,
+		outputTokens,
+		&driver.TransferOptions{
+			Attributes: attributes,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating clawback action")
+	}
+	transferMetadata.ExtraSigners = authorities
+
+	// Append
+	raw, err := transfer.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed serializing clawback action")
+	}
+	r.bindPublicParams()
+	r.Actions.Transfers = append(r.Actions.Transfers, raw)
+	r.Metadata.Transfers = append(r.Metadata.Transfers, transferMetadata)
+
+	return &TransferAction{a: transfer}, nil
+}
+
+// Freeze appends to the request a freeze action on the passed tokens, signed by the passed compliance authorities.
+// If unfreeze is true, the tokens are unfrozen instead.
+// The signers of the compliance authorities must be available to this node.
+// Freeze actions cannot be mixed with issue and transfer actions in the same request.
+func (r *Request) Freeze(ids []*token.ID, unfreeze bool, authorities ...Identity) error {
+	if len(ids) == 0 {
+		return errors.New("no token to freeze")
+	}
+	if len(authorities) == 0 {
+		return errors.New("no compliance authority passed")
+	}
+	freeze := &driver.Freeze{TokenIDs: ids, Unfreeze: unfreeze}
+	msg, err := freeze.MessageToSign(r.Anchor)
+	if err != nil {
+		return errors.Wrap(err, "failed preparing freeze action")
+	}
+	for _, authority := range authorities {
+		signer, err := r.TokenService.SigService().GetSigner(authority)
+		if err != nil {
+			return errors.WithMessagef(err, "failed getting signer for compliance authority [%s]", authority)
+		}
+		sigma, err := signer.Sign(msg)
+		if err != nil {
+			return errors.WithMessagef(err, "failed signing freeze action with [%s]", authority)
+		}
+		freeze.Signatures = append(freeze.Signatures, &driver.AdminSignature{Signer: authority, Sigma: sigma})
+	}
+
+	// Append
+	raw, err := freeze.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "failed serializing freeze action")
+	}
+	r.Actions.FreezeActions = append(r.Actions.FreezeActions, raw)
+
+	return nil
+}
+
 // Outputs returns the sequence of outputs of the request supporting sequential and parallel aggregate operations.
 func (r *Request) Outputs() (*OutputStream, error) {
 	return r.outputs(false)
@@ -918,12 +1061,19 @@ func (r *Request) inputsAndOutputs(failOnMissing, verifyActions, noOutputForReci
 		if err != nil {
 			return nil, nil, err
 		}
-		inputs = append(inputs, extractedInputs...)
-
 		extractedOutputs, newCounter, err := r.extractTransferOutputs(i, counter, transferAction, transferMeta, failOnMissing, noOutputForRecipient)
 		if err != nil {
 			return nil, nil, err
 		}
+		if len(driver.ClawbackAuthorizers(transferAction.GetMetadata())) != 0 {
+			for _, input := range extractedInputs {
+				input.Clawback = true
+			}
+			for _, output := range extractedOutputs {
+				output.Clawback = true
+			}
+		}
+		inputs = append(inputs, extractedInputs...)
 		outputs = append(outputs, extractedOutputs...)
 		counter = newCounter
 	}
@@ -961,15 +1111,16 @@ func (r *Request) MarshalToAudit() ([]byte, error) {
 	if r.Actions == nil {
 		return nil, errors.Errorf("failed to marshal request in tx [%s] for audit", r.Anchor)
 	}
-	return r.Actions.MarshalToMessageToSign([]byte(r.Anchor))
+	return r.Actions.MarshalToMessageToSign([]byte(r.boundAnchor()))
 }
 
 // MarshalToSign marshals the request to a message suitable for signing.
+// If the request is bound to other requests, see Bind, the message covers the binding.
 func (r *Request) MarshalToSign() ([]byte, error) {
 	if r.Actions == nil {
 		return nil, errors.Errorf("failed to marshal request in tx [%s] for signing", r.Anchor)
 	}
-	return r.Actions.MarshalToMessageToSign([]byte(r.Anchor))
+	return r.Actions.MarshalToMessageToSign([]byte(r.boundAnchor()))
 }
 
 // RequestToBytes marshals the request's actions to bytes.
@@ -1025,6 +1176,25 @@ func (r *Request) AddAuditorSignature(sigma []byte) {
 	r.Actions.AuditorSignatures = append(r.Actions.AuditorSignatures, sigma)
 }
 
+// SetAuditorSignatures sets the auditor signatures on the request, one for each auditor in the public parameters
+// and in the same order. The passed signatures are indexed by the unique ID of the auditor's identity.
+// Auditors without a signature get an empty one.
+// It returns true if the number of signatures meets the auditors' threshold in the public parameters.
+func (r *Request) SetAuditorSignatures(sigmas map[string][]byte) bool {
+	pp := r.TokenService.PublicParametersManager().PublicParameters()
+	auditors := pp.Auditors()
+	signatures := make([][]byte, len(auditors))
+	signed := uint64(0)
+	for i, auditor := range auditors {
+		if sigma, ok := sigmas[auditor.UniqueID()]; ok && len(sigma) != 0 {
+			signatures[i] = sigma
+			signed++
+		}
+	}
+	r.Actions.AuditorSignatures = signatures
+	return signed >= pp.AuditorsThreshold()
+}
+
 func (r *Request) SetSignatures(sigmas map[string][]byte) bool {
 	signers := append(r.IssueSigners(), r.TransferSigners()...)
 	signatures := make([][]byte, len(signers))
@@ -1042,15 +1212,31 @@ func (r *Request) SetSignatures(sigmas map[string][]byte) bool {
 	return all
 }
 
+// TransferSigners returns the identities that must sign the transfer actions, in the order expected by the validators.
+// The owners of the inputs of a clawback do not sign, the compliance authorities authorizing it do.
 func (r *Request) TransferSigners() []Identity {
 	signers := make([]Identity, 0)
-	for _, transfer := range r.Transfers() {
-		signers = append(signers, transfer.Senders...)
+	for i, transfer := range r.Transfers() {
+		if !r.isClawback(i) {
+			signers = append(signers, transfer.Senders...)
+		}
 		signers = append(signers, transfer.ExtraSigners...)
 	}
 	return signers
 }
 
+// isClawback returns true if the transfer action at the passed index carries a clawback
+func (r *Request) isClawback(i int) bool {
+	if i >= len(r.Actions.Transfers) {
+		return false
+	}
+	action, err := r.TokenService.tms.TransferService().DeserializeTransferAction(r.Actions.Transfers[i])
+	if err != nil {
+		return false
+	}
+	return len(driver.ClawbackAuthorizers(action.GetMetadata())) != 0
+}
+
 func (r *Request) IssueSigners() []Identity {
 	signers := make([]Identity, 0)
 	for _, issue := range r.Issues() {
@@ -1258,10 +1444,20 @@ func (r *Request) GetMetadata() (*Metadata, error) {
 
 func (r *Request) AllApplicationMetadata() map[string][]byte { return r.Metadata.Application }
 
+// PublicParamsHash returns the hash of the public parameters the actions of this request have been prepared with.
+// If the request does not carry it, the hash of the current public parameters of the token service is returned.
 func (r *Request) PublicParamsHash() PPHash {
+	if r.Actions != nil && len(r.Actions.PublicParamsHash) != 0 {
+		return r.Actions.PublicParamsHash
+	}
 	return r.TokenService.PublicParametersManager().PublicParamsHash()
 }
 
+// bindPublicParams records in the request the hash of the public parameters its actions are prepared with
+func (r *Request) bindPublicParams() {
+	r.Actions.PublicParamsHash = r.TokenService.PublicParametersManager().PublicParamsHash()
+}
+
 func (r *Request) String() string { return r.Anchor }
 
 func (r *Request) parseInputIDs(inputs []*token.ID) ([]*token.ID, token.Quantity, token.Type, error) {
@@ -1309,6 +1505,15 @@ func (r *Request) prepareTransfer(redeem bool, wallet *OwnerWallet, tokenType to
 	var inputSum token.Quantity
 	var err error
 
+	limits, err := r.TokenService.Configuration().ActionLimits()
+	if err != nil {
+		return nil, nil, errors.WithMessagef(err, "failed getting action limits")
+	}
+	// fail before selecting the inputs if the outputs alone exceed the limits
+	if err := limits.Check(len(transferOpts.TokenIDs), len(values)); err != nil {
+		return nil, nil, err
+	}
+
 	transferOpts.TokenIDs = r.cleanupInputIDs(transferOpts.TokenIDs)
 
 	// if inputs have been passed, parse and certify them, if needed
@@ -1380,6 +1585,10 @@ func (r *Request) prepareTransfer(redeem bool, wallet *OwnerWallet, tokenType to
 		return nil, nil, errors.Errorf("the sum of the outputs is larger then the sum of the inputs [%s][%s]", inputSum.Decimal(), outputSum.Decimal())
 	}
 
+	if err := limits.Check(len(tokenIDs), len(outputTokens)); err != nil {
+		return nil, nil, err
+	}
+
 	if r.TokenService.PublicParametersManager().PublicParameters().GraphHiding() {
 		r.TokenService.logger.Debugf("graph hiding enabled, request certification")
 		// Check token certification
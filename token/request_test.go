@@ -23,6 +23,7 @@ func TestRequestSerialization(t *testing.T) {
 		Transfers:         [][]byte{[]byte("transfer1")},
 		Signatures:        [][]byte{[]byte("signature1")},
 		AuditorSignatures: [][]byte{[]byte("auditor_signature1")},
+		PublicParamsHash:  []byte("pp_hash"),
 	}
 	raw, err := r.Bytes()
 	assert.NoError(t, err)
@@ -34,6 +35,7 @@ func TestRequestSerialization(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, raw, raw2)
+	assert.Equal(t, r.PublicParamsHash(), r2.PublicParamsHash())
 
 	mRaw, err := r.MarshalToAudit()
 	assert.NoError(t, err)
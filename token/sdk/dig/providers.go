@@ -7,11 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package sdk
 
 import (
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view"
 	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db"
 	dbdriver "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/sql"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/unity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/config"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokendb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokenlockdb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokenlockdb/lease"
+	"github.com/pkg/errors"
 	"go.uber.org/dig"
 )
 
@@ -23,9 +32,72 @@ func NewDriverHolder(in struct {
 	return db.NewDriverHolder(in.ConfigProvider, in.Drivers...)
 }
 
+// migrationsConfigKey is the key of the schema migration options of the sql databases, see common.MigrationOpts
+const migrationsConfigKey = "token.db.migrations"
+
+func migrationOpts(cs driver2.ConfigService) (common.MigrationOpts, error) {
+	var opts common.MigrationOpts
+	if err := cs.UnmarshalKey(migrationsConfigKey, &opts); err != nil {
+		return common.MigrationOpts{}, errors.Wrapf(err, "failed unmarshalling [%s]", migrationsConfigKey)
+	}
+	return opts, nil
+}
+
+// tokenChangesRetentionConfigKey is the key of the duration the token changes are kept for.
+// A zero or negative duration keeps them forever.
+const tokenChangesRetentionConfigKey = "token.db.changes.retention"
+
+func newTokenNotifierManager(dh *db.DriverHolder, cs driver2.ConfigService) *tokendb.NotifierManager {
+	retention := tokendb.DefaultTokenChangesRetention
+	if cs.IsSet(tokenChangesRetentionConfigKey) {
+		retention = cs.GetDuration(tokenChangesRetentionConfigKey)
+	}
+	return tokendb.NewNotifierManagerWithRetention(dh, retention, "tokendb.persistence", "db.persistence")
+}
+
+func newSQLDriver(cs driver2.ConfigService) (dbdriver.NamedDriver, error) {
+	opts, err := migrationOpts(cs)
+	if err != nil {
+		return dbdriver.NamedDriver{}, err
+	}
+	return sql.NewDriverWithMigrations(opts), nil
+}
+
+func newUnityDriver(cs driver2.ConfigService) (dbdriver.NamedDriver, error) {
+	opts, err := migrationOpts(cs)
+	if err != nil {
+		return dbdriver.NamedDriver{}, err
+	}
+	return unity.NewUnityDriverWithMigrations(opts), nil
+}
+
 func newTokenDriverService(in struct {
 	dig.In
 	Drivers []core.NamedFactory[driver.Driver] `group:"token-drivers"`
 }) *core.TokenDriverService {
 	return core.NewTokenDriverService(in.Drivers)
 }
+
+// newTokenLockDBManager returns the token lock databases used by the sherdlock selector.
+// If replicas are configured, the locks are leases agreed by the replicas over FSC sessions, and no database is shared.
+func newTokenLockDBManager(in struct {
+	dig.In
+	DriverHolder     *db.DriverHolder
+	ConfigService    driver2.ConfigService
+	IdentityProvider driver2.IdentityProvider
+	ViewManager      *view.Manager
+	ViewRegistry     driver2.Registry
+}) (*tokenlockdb.Manager, error) {
+	cfg, err := config.New(in.ConfigService)
+	if err != nil {
+		return nil, err
+	}
+	if replicas := cfg.GetReplicas(); len(replicas) != 0 {
+		s, err := lease.NewFSCService(replicas, in.IdentityProvider, in.ViewManager, in.ViewRegistry)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to create lease service")
+		}
+		return s.Manager(), nil
+	}
+	return tokenlockdb.NewManager(in.DriverHolder, "tokenlockdb.persistence", "db.persistence"), nil
+}
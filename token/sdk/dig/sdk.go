@@ -17,6 +17,7 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/fabric/core"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/fabric/core/generic/committer"
 	fabricsdk "github.com/hyperledger-labs/fabric-smart-client/platform/fabric/sdk/dig"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/driver"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/kvs"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
@@ -33,11 +34,10 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/auditor"
 	_ "github.com/hyperledger-labs/fabric-token-sdk/token/services/certifier/dummy"
 	config2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/config"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/consolidation"
 	db2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db"
 	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/memory"
-	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/sql"
-	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/unity"
 	identity2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identitydb"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
@@ -48,7 +48,6 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/sherdlock"
 	selector "github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/simple"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokendb"
-	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokenlockdb"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
@@ -135,9 +134,7 @@ func (p *SDK) Install() error {
 		p.Container().Provide(func(dh *db2.DriverHolder) *tokendb.Manager {
 			return tokendb.NewManager(dh, "tokendb.persistence", "db.persistence")
 		}),
-		p.Container().Provide(func(dh *db2.DriverHolder) *tokendb.NotifierManager {
-			return tokendb.NewNotifierManager(dh, "tokendb.persistence", "db.persistence")
-		}),
+		p.Container().Provide(newTokenNotifierManager),
 		p.Container().Provide(digutils.Identity[*tokendb.Manager](), dig.As(new(tokens.DBProvider))),
 		p.Container().Provide(NewDriverHolder),
 		p.Container().Provide(func(dh *db2.DriverHolder) *auditdb.Manager {
@@ -147,9 +144,7 @@ func (p *SDK) Install() error {
 		p.Container().Provide(func(dh *db2.DriverHolder) *identitydb.Manager {
 			return identitydb.NewManager(dh, "identitydb.persistence", "db.persistence")
 		}),
-		p.Container().Provide(func(dh *db2.DriverHolder) *tokenlockdb.Manager {
-			return tokenlockdb.NewManager(dh, "tokenlockdb.persistence", "db.persistence")
-		}),
+		p.Container().Provide(newTokenLockDBManager),
 		p.Container().Provide(digutils.Identity[*kvs.KVS](), dig.As(new(identity2.Keystore))),
 		p.Container().Provide(identity.NewDBStorageProvider),
 		p.Container().Provide(digutils.Identity[*identity.DBStorageProvider](), dig.As(new(identity2.StorageProvider))),
@@ -168,8 +163,8 @@ func (p *SDK) Install() error {
 		p.Container().Provide(func(tracerProvider trace.TracerProvider) *tracing.TracerProvider {
 			return tracing.NewTracerProvider(tracerProvider)
 		}),
-		p.Container().Provide(unity.NewUnityDriver, dig.Group("token-db-drivers")),
-		p.Container().Provide(sql.NewDriver, dig.Group("token-db-drivers")),
+		p.Container().Provide(newUnityDriver, dig.Group("token-db-drivers")),
+		p.Container().Provide(newSQLDriver, dig.Group("token-db-drivers")),
 		p.Container().Provide(memory.NewDriver, dig.Group("token-db-drivers")),
 		p.Container().Provide(func(dbManager *tokendb.Manager, notifierManager *tokendb.NotifierManager, metricsProvider metrics.Provider) sherdlock.FetcherProvider {
 			return sherdlock.NewFetcherProvider(dbManager, notifierManager, metricsProvider, sherdlock.Mixed)
@@ -240,6 +235,9 @@ func (p *SDK) Start(ctx context.Context) error {
 	return errors2.Join(
 		p.Container().Invoke(registerNetworkDrivers),
 		p.Container().Invoke(connectNetworks),
+		p.Container().Invoke(func(configService *config2.Service, viewManager *view.Manager) error {
+			return startConsolidation(ctx, configService, viewManager)
+		}),
 	)
 }
 
@@ -266,6 +264,29 @@ func connectNetworks(configService *config2.Service, networkProvider *network.Pr
 	return nil
 }
 
+func startConsolidation(ctx context.Context, configService *config2.Service, viewManager *view.Manager) error {
+	configurations, err := configService.Configurations()
+	if err != nil {
+		return err
+	}
+	for _, tmsConfig := range configurations {
+		if !tmsConfig.IsSet(consolidation.ConfigurationKey) {
+			continue
+		}
+		policy := &consolidation.Policy{}
+		if err := tmsConfig.UnmarshalKey(consolidation.ConfigurationKey, policy); err != nil {
+			return errors.WithMessagef(err, "failed to load consolidation policy for tms [%s]", tmsConfig.ID())
+		}
+		s, err := consolidation.NewService(viewManager, tmsConfig.ID(), policy)
+		if err != nil {
+			return err
+		}
+		logger.Infof("start token consolidation for tms [%s] every [%s]", tmsConfig.ID(), policy.GetInterval())
+		s.Start(ctx)
+	}
+	return nil
+}
+
 func registerNetworkDrivers(in struct {
 	dig.In
 	NetworkProvider *network.Provider
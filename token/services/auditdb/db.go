@@ -80,6 +80,8 @@ const (
 	Transfer
 	// Redeem is the action type for redeeming tokens.
 	Redeem
+	// Clawback is the action type for moving tokens to a recovery owner on behalf of the compliance authorities.
+	Clawback
 )
 
 // MovementRecord is a record of a movement of assets.
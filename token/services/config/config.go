@@ -117,6 +117,22 @@ func (m *Service) Enabled() bool {
 	return m.enabled
 }
 
+// ValidatorParallelism returns the size of the worker pool of the validators, set with the key `token.validator.parallelism`.
+// Zero means the number of CPUs.
+func (m *Service) ValidatorParallelism() (int, error) {
+	if !m.cp.IsSet("token.validator.parallelism") {
+		return 0, nil
+	}
+	var parallelism int
+	if err := m.cp.UnmarshalKey("token.validator.parallelism", &parallelism); err != nil {
+		return 0, errors.Wrapf(err, "invalid config for key [token.validator.parallelism]")
+	}
+	if parallelism < 0 {
+		return 0, errors.Errorf("invalid config for key [token.validator.parallelism], must not be negative, got [%d]", parallelism)
+	}
+	return parallelism, nil
+}
+
 // LookupNamespace searches for a configuration configuration that matches the given network and channel, and
 // return its namespace.
 // If no matching configuration is found, an error is returned.
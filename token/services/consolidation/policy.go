@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consolidation
+
+import (
+	"math/big"
+	"slices"
+	"time"
+
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfigurationKey is the key of the consolidation policy in the configuration of a TMS
+	ConfigurationKey = "services.consolidation"
+
+	defaultMaxInputs = 16
+	defaultInterval  = 10 * time.Minute
+)
+
+// Policy tells which tokens the consolidation service merges, and when
+type Policy struct {
+	// Threshold is the number of tokens of a given type a wallet must hold before they are merged
+	Threshold int `yaml:"threshold"`
+	// MaxInputs is the maximum number of tokens merged by a single transaction
+	MaxInputs int `yaml:"maxInputs,omitempty"`
+	// Types are the token types to merge. If empty, all types are merged
+	Types []token2.Type `yaml:"types,omitempty"`
+	// Wallets are the identifiers of the owner wallets whose tokens are merged. If empty, all owner wallets are considered
+	Wallets []string `yaml:"wallets,omitempty"`
+	// Interval is the period between two consolidation rounds
+	Interval time.Duration `yaml:"interval,omitempty"`
+}
+
+// Validate returns an error if the policy cannot be enforced
+func (p *Policy) Validate() error {
+	if p.Threshold < 2 {
+		return errors.Errorf("invalid consolidation threshold [%d], at least two tokens are needed", p.Threshold)
+	}
+	if p.MaxInputs != 0 && p.MaxInputs < 2 {
+		return errors.Errorf("invalid consolidation max inputs [%d], at least two tokens are needed", p.MaxInputs)
+	}
+	if p.Interval < 0 {
+		return errors.Errorf("invalid consolidation interval [%s]", p.Interval)
+	}
+	return nil
+}
+
+func (p *Policy) GetMaxInputs() int {
+	if p.MaxInputs > 0 {
+		return p.MaxInputs
+	}
+	return defaultMaxInputs
+}
+
+func (p *Policy) GetInterval() time.Duration {
+	if p.Interval > 0 {
+		return p.Interval
+	}
+	return defaultInterval
+}
+
+func (p *Policy) accepts(typ token2.Type) bool {
+	return len(p.Types) == 0 || slices.Contains(p.Types, typ)
+}
+
+// Merge is a set of tokens of the same type to be merged into a single one
+type Merge struct {
+	Type     token2.Type
+	IDs      []*token2.ID
+	Quantity uint64
+}
+
+// Plan returns the merges, at most one per token type, the policy requires for the passed tokens of a wallet.
+// Each merge takes the smallest tokens, up to the maximum number of inputs and as long as their sum fits a single token.
+func (p *Policy) Plan(tokens []*token2.UnspentTokenInWallet, precision uint64, maxTokenValue uint64) ([]*Merge, error) {
+	type candidate struct {
+		id       *token2.ID
+		quantity *big.Int
+	}
+	byType := map[token2.Type][]*candidate{}
+	var types []token2.Type
+	for _, t := range tokens {
+		if !p.accepts(t.Type) {
+			continue
+		}
+		q, err := token2.ToQuantity(t.Quantity, precision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quantity for token [%s]", t.Id)
+		}
+		if _, ok := byType[t.Type]; !ok {
+			types = append(types, t.Type)
+		}
+		byType[t.Type] = append(byType[t.Type], &candidate{id: t.Id, quantity: q.ToBigInt()})
+	}
+
+	maxValue := new(big.Int).SetUint64(maxTokenValue)
+	var merges []*Merge
+	for _, typ := range types {
+		candidates := byType[typ]
+		if len(candidates) < p.Threshold {
+			continue
+		}
+		slices.SortStableFunc(candidates, func(a, b *candidate) int { return a.quantity.Cmp(b.quantity) })
+		sum := big.NewInt(0)
+		var ids []*token2.ID
+		for _, c := range candidates {
+			if len(ids) == p.GetMaxInputs() {
+				break
+			}
+			next := new(big.Int).Add(sum, c.quantity)
+			if next.Cmp(maxValue) > 0 {
+				break
+			}
+			sum = next
+			ids = append(ids, c.id)
+		}
+		if len(ids) < 2 {
+			continue
+		}
+		merges = append(merges, &Merge{Type: typ, IDs: ids, Quantity: sum.Uint64()})
+	}
+	return merges, nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consolidation
+
+import (
+	"fmt"
+	"testing"
+
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func unspent(typ token2.Type, quantities ...uint64) []*token2.UnspentTokenInWallet {
+	tokens := make([]*token2.UnspentTokenInWallet, len(quantities))
+	for i, q := range quantities {
+		tokens[i] = &token2.UnspentTokenInWallet{
+			Id:       &token2.ID{TxId: fmt.Sprintf("%s%d", typ, i)},
+			WalletID: "alice",
+			Type:     typ,
+			Quantity: fmt.Sprintf("0x%x", q),
+		}
+	}
+	return tokens
+}
+
+func TestPlan(t *testing.T) {
+	tokens := append(unspent("USD", 5, 1, 3, 2, 4), unspent("EUR", 1, 1)...)
+
+	// below the threshold, nothing to merge
+	merges, err := (&Policy{Threshold: 6}).Plan(tokens, 64, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, merges)
+
+	// the smallest tokens are merged first, up to the maximum number of inputs
+	merges, err = (&Policy{Threshold: 2, MaxInputs: 3}).Plan(tokens, 64, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Merge{
+		{Type: "USD", IDs: []*token2.ID{{TxId: "USD1"}, {TxId: "USD3"}, {TxId: "USD2"}}, Quantity: 6},
+		{Type: "EUR", IDs: []*token2.ID{{TxId: "EUR0"}, {TxId: "EUR1"}}, Quantity: 2},
+	}, merges)
+
+	// only the selected types are merged
+	merges, err = (&Policy{Threshold: 2, Types: []token2.Type{"EUR"}}).Plan(tokens, 64, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Merge{{Type: "EUR", IDs: []*token2.ID{{TxId: "EUR0"}, {TxId: "EUR1"}}, Quantity: 2}}, merges)
+
+	// the merged token cannot exceed the maximum token value
+	merges, err = (&Policy{Threshold: 2}).Plan(tokens, 64, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Merge{
+		{Type: "USD", IDs: []*token2.ID{{TxId: "USD1"}, {TxId: "USD3"}, {TxId: "USD2"}}, Quantity: 6},
+		{Type: "EUR", IDs: []*token2.ID{{TxId: "EUR0"}, {TxId: "EUR1"}}, Quantity: 2},
+	}, merges)
+	merges, err = (&Policy{Threshold: 2}).Plan(unspent("USD", 5, 4), 64, 7)
+	assert.NoError(t, err)
+	assert.Empty(t, merges)
+
+	_, err = (&Policy{Threshold: 2}).Plan(unspent("USD", 1, 1<<20), 16, 100)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Policy{Threshold: 10}).Validate())
+	assert.EqualError(t, (&Policy{Threshold: 1}).Validate(), "invalid consolidation threshold [1], at least two tokens are needed")
+	assert.EqualError(t, (&Policy{Threshold: 10, MaxInputs: 1}).Validate(), "invalid consolidation max inputs [1], at least two tokens are needed")
+
+	p := &Policy{Threshold: 10}
+	assert.Equal(t, defaultMaxInputs, p.GetMaxInputs())
+	assert.Equal(t, defaultInterval, p.GetInterval())
+}
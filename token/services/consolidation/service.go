@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consolidation
+
+import (
+	"context"
+	"time"
+
+	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/pkg/errors"
+)
+
+type ViewManager interface {
+	InitiateView(view view2.View, ctx context.Context) (interface{}, error)
+}
+
+// Service runs periodically the consolidation of the tokens of a TMS, see ConsolidateView
+type Service struct {
+	viewManager ViewManager
+	tmsID       token.TMSID
+	policy      *Policy
+}
+
+// NewService returns a consolidation service for the passed TMS and policy
+func NewService(viewManager ViewManager, tmsID token.TMSID, policy *Policy) (*Service, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, errors.WithMessagef(err, "invalid consolidation policy for [%s]", tmsID)
+	}
+	return &Service{viewManager: viewManager, tmsID: tmsID, policy: policy}, nil
+}
+
+// Start runs a consolidation round every policy interval until the passed context is done
+func (s *Service) Start(ctx context.Context) {
+	go s.run(ctx)
+}
+
+func (s *Service) run(ctx context.Context) {
+	ticker := time.NewTicker(s.policy.GetInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Consolidate(ctx)
+		}
+	}
+}
+
+// Consolidate runs a consolidation round and returns the identifiers of the committed transactions
+func (s *Service) Consolidate(ctx context.Context) []string {
+	logger.Debugf("consolidate tokens of [%s]...", s.tmsID)
+	res, err := s.viewManager.InitiateView(NewConsolidateView(s.tmsID, s.policy), ctx)
+	if err != nil {
+		// the tokens that could not be merged are picked again at the next round
+		logger.Errorf("failed to consolidate tokens of [%s]: [%s]", s.tmsID, err)
+	}
+	txIDs, _ := res.([]string)
+	return txIDs
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package consolidation
+
+import (
+	errors2 "errors"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokendb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// ApplicationMetadataKey is the application metadata key of the transactions built by the consolidation service.
+// Its value is the identifier of the wallet whose tokens are merged.
+const ApplicationMetadataKey = "consolidation"
+
+var logger = logging.MustGetLogger("token-sdk.consolidation")
+
+// IsConsolidation returns true if the passed application metadata belongs to a transaction built by the consolidation service
+func IsConsolidation(applicationMetadata map[string][]byte) bool {
+	_, ok := applicationMetadata[ApplicationMetadataKey]
+	return ok
+}
+
+type ConsolidateView struct {
+	tmsID  token.TMSID
+	policy *Policy
+}
+
+// NewConsolidateView returns a view that merges the tokens of the wallets of the passed TMS as required by the passed policy.
+// Each merge is a transfer of the wallet's tokens to a recipient identity of the same wallet that goes through
+// the endorsement, audit, and ordering of any other transfer.
+// The view returns the identifiers of the committed transactions.
+func NewConsolidateView(tmsID token.TMSID, policy *Policy) *ConsolidateView {
+	return &ConsolidateView{tmsID: tmsID, policy: policy}
+}
+
+func (v *ConsolidateView) Call(context view.Context) (interface{}, error) {
+	tms := token.GetManagementService(context, token.WithTMSID(v.tmsID))
+	if tms == nil {
+		return nil, errors.Errorf("no token management service for [%s]", v.tmsID)
+	}
+	db, err := tokendb.GetByTMSId(context, tms.ID())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get token db for [%s]", tms.ID())
+	}
+	pp := tms.PublicParametersManager().PublicParameters()
+	if pp == nil {
+		return nil, errors.Errorf("public parameters not set yet for TMS [%s]", tms.ID())
+	}
+	walletIDs := v.policy.Wallets
+	if len(walletIDs) == 0 {
+		if walletIDs, err = tms.WalletManager().OwnerWalletIDs(); err != nil {
+			return nil, err
+		}
+	}
+
+	var txIDs []string
+	var errs []error
+	for _, walletID := range walletIDs {
+		wallet := tms.WalletManager().OwnerWallet(walletID)
+		if wallet == nil {
+			errs = append(errs, errors.Errorf("owner wallet [%s] not found", walletID))
+			continue
+		}
+		tokens, err := spendableTokens(context, db, wallet.ID())
+		if err != nil {
+			errs = append(errs, errors.WithMessagef(err, "failed to get the tokens of wallet [%s]", walletID))
+			continue
+		}
+		merges, err := v.policy.Plan(tokens, pp.Precision(), pp.MaxTokenValue())
+		if err != nil {
+			errs = append(errs, errors.WithMessagef(err, "failed to plan the consolidation of wallet [%s]", walletID))
+			continue
+		}
+		for _, merge := range merges {
+			txID, err := v.merge(context, wallet, merge)
+			if err != nil {
+				errs = append(errs, errors.WithMessagef(err, "failed to merge [%d] tokens of type [%s] in wallet [%s]", len(merge.IDs), merge.Type, walletID))
+				continue
+			}
+			logger.Infof("merged [%d] tokens of type [%s] in wallet [%s] with transaction [%s]", len(merge.IDs), merge.Type, walletID, txID)
+			txIDs = append(txIDs, txID)
+		}
+	}
+	return txIDs, errors2.Join(errs...)
+}
+
+func (v *ConsolidateView) merge(context view.Context, wallet *token.OwnerWallet, merge *Merge) (string, error) {
+	recipient, err := wallet.GetRecipientIdentity()
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to get recipient identity")
+	}
+	tx, err := ttx.NewAnonymousTransaction(context, ttx.WithTMSID(v.tmsID))
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to create transaction")
+	}
+	tx.SetApplicationMetadata(ApplicationMetadataKey, []byte(wallet.ID()))
+	if err := tx.Transfer(wallet, merge.Type, []uint64{merge.Quantity}, []view.Identity{recipient}, token.WithTokenIDs(merge.IDs...)); err != nil {
+		return "", errors.WithMessagef(err, "failed to prepare transfer")
+	}
+	if _, err := context.RunView(ttx.NewCollectEndorsementsView(tx)); err != nil {
+		return "", errors.WithMessagef(err, "failed to collect endorsements for [%s]", tx.ID())
+	}
+	if _, err := context.RunView(ttx.NewOrderingAndFinalityView(tx)); err != nil {
+		return "", errors.WithMessagef(err, "failed to commit [%s]", tx.ID())
+	}
+	return tx.ID(), nil
+}
+
+func spendableTokens(context view.Context, db *tokendb.DB, walletID string) ([]*token2.UnspentTokenInWallet, error) {
+	it, err := db.SpendableTokensIteratorBy(context.Context(), walletID, "")
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var tokens []*token2.UnspentTokenInWallet
+	for {
+		t, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			return tokens, nil
+		}
+		tokens = append(tokens, t)
+	}
+}
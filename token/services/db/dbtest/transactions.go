@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sync"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	driver2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/test-go/testify/assert"
 )
 
@@ -30,6 +32,8 @@ var TokenTransactionDBCases = []struct {
 	{"Status", TStatus},
 	{"StoresTimestamp", TStoresTimestamp},
 	{"Movements", TMovements},
+	{"LargeAmounts", TLargeAmounts},
+	{"SumLargeAmounts", TSumLargeAmounts},
 	{"Transaction", TTransaction},
 	{"TokenRequest", TTokenRequest},
 	{"AllowsSameTxID", TAllowsSameTxID},
@@ -37,6 +41,8 @@ var TokenTransactionDBCases = []struct {
 	{"TransactionQueries", TTransactionQueries},
 	{"ValidationRecordQueries", TValidationRecordQueries},
 	{"TEndorserAcks", TEndorserAcks},
+	{"PendingTransactions", TPendingTransactions},
+	{"PaymentGroups", TPaymentGroups},
 }
 
 func TFailsIfRequestDoesNotExist(t *testing.T, db driver.TokenTransactionDB) {
@@ -228,6 +234,100 @@ func TMovements(t *testing.T, db driver.TokenTransactionDB) {
 	assert.Len(t, records, 1)
 }
 
+func TLargeAmounts(t *testing.T, db driver.TokenTransactionDB) {
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+	sum := new(big.Int).Mul(maxUint64, big.NewInt(3))
+
+	w, err := db.BeginAtomicWrite()
+	assert.NoError(t, err)
+	assert.NoError(t, w.AddTokenRequest("0", []byte{}, map[string][]byte{}, driver2.PPHash("tr")))
+	assert.NoError(t, w.AddTransaction(&driver.TransactionRecord{
+		TxID:         "0",
+		ActionType:   driver.Transfer,
+		SenderEID:    "bob",
+		RecipientEID: "alice",
+		TokenType:    "magic",
+		Amount:       sum,
+		Timestamp:    time.Now(),
+	}))
+	assert.NoError(t, w.AddMovement(&driver.MovementRecord{
+		TxID:         "0",
+		EnrollmentID: "alice",
+		TokenType:    "magic",
+		Amount:       sum,
+	}))
+	assert.NoError(t, w.AddMovement(&driver.MovementRecord{
+		TxID:         "0",
+		EnrollmentID: "bob",
+		TokenType:    "magic",
+		Amount:       new(big.Int).Neg(sum),
+	}))
+	// neither sent nor received
+	assert.NoError(t, w.AddMovement(&driver.MovementRecord{
+		TxID:         "0",
+		EnrollmentID: "carol",
+		TokenType:    "magic",
+		Amount:       big.NewInt(0),
+	}))
+	assert.NoError(t, w.Commit())
+
+	txs := getTransactions(t, db, driver.QueryTransactionsParams{})
+	assert.Len(t, txs, 1)
+	assert.Equal(t, sum, txs[0].Amount)
+
+	records, err := db.QueryMovements(driver.QueryMovementsParams{MovementDirection: driver.Received})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "alice", records[0].EnrollmentID)
+	assert.Equal(t, sum, records[0].Amount)
+
+	records, err = db.QueryMovements(driver.QueryMovementsParams{MovementDirection: driver.Sent})
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "bob", records[0].EnrollmentID)
+	assert.Equal(t, new(big.Int).Neg(sum), records[0].Amount)
+
+	records, err = db.QueryMovements(driver.QueryMovementsParams{MovementDirection: driver.All})
+	assert.NoError(t, err)
+	assert.Len(t, records, 3)
+}
+
+func TSumLargeAmounts(t *testing.T, db driver.TokenTransactionDB) {
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+	amount := big.NewInt(9e18)
+
+	w, err := db.BeginAtomicWrite()
+	assert.NoError(t, err)
+	for i, a := range []*big.Int{amount, amount, maxUint64} {
+		txID := fmt.Sprintf("tx%d", i)
+		assert.NoError(t, w.AddTokenRequest(txID, []byte{}, map[string][]byte{}, driver2.PPHash("tr")))
+		assert.NoError(t, w.AddTransaction(&driver.TransactionRecord{
+			TxID:         txID,
+			ActionType:   driver.Redeem,
+			SenderEID:    "alice",
+			RecipientEID: "",
+			TokenType:    "magic",
+			Amount:       a,
+			Timestamp:    time.Now(),
+		}))
+	}
+	assert.NoError(t, w.Commit())
+
+	// the sum exceeds the range of int64, and of uint64
+	expected := new(big.Int).Add(new(big.Int).Mul(amount, big.NewInt(2)), maxUint64)
+	sum, err := db.SumTransactionAmounts(driver.QueryTransactionsParams{
+		ActionTypes: []driver.ActionType{driver.Redeem},
+		TokenTypes:  []token2.Type{"magic"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sum)
+
+	// a single amount larger than int64 is returned as it is
+	sum, err = db.SumTransactionAmounts(driver.QueryTransactionsParams{IDs: []string{"tx2"}})
+	assert.NoError(t, err)
+	assert.Equal(t, maxUint64, sum)
+}
+
 func TTransaction(t *testing.T, db driver.TokenTransactionDB) {
 	var txs []*driver.TransactionRecord
 
@@ -773,6 +873,20 @@ func TTransactionQueries(t *testing.T, db driver.TokenTransactionDB) {
 			assert.Len(t, res, tc.expectedLen, fmt.Sprintf("params: %v", tc.params))
 		})
 	}
+
+	sum, err := db.SumTransactionAmounts(driver.QueryTransactionsParams{
+		ActionTypes: []driver.ActionType{driver.Redeem},
+		Statuses:    []driver.TxStatus{driver.Confirmed},
+		TokenTypes:  []token2.Type{"abc"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), sum)
+	sum, err = db.SumTransactionAmounts(driver.QueryTransactionsParams{
+		ActionTypes: []driver.ActionType{driver.Redeem},
+		TokenTypes:  []token2.Type{"magic"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), sum)
 }
 
 func getTransactions(t *testing.T, db driver.TokenTransactionDB, params driver.QueryTransactionsParams) []*driver.TransactionRecord {
@@ -909,6 +1023,81 @@ func TEndorserAcks(t *testing.T, db driver.TokenTransactionDB) {
 	}
 }
 
+func TPendingTransactions(t *testing.T, db driver.TokenTransactionDB) {
+	tx, err := db.GetPendingTransaction("1")
+	assert.NoError(t, err)
+	assert.Nil(t, tx)
+
+	assert.NoError(t, db.AddPendingTransaction("1", []byte("tx_1")))
+	assert.Error(t, db.AddPendingTransaction("1", []byte("tx_1")), "the same transaction cannot be added twice")
+	assert.Error(t, db.AddDetachedSignature("2", []byte("alice"), []byte("sigma")), "the transaction must be pending")
+
+	tx, err = db.GetPendingTransaction("1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("tx_1"), tx)
+	txIDs, err := db.GetPendingTransactionIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1"}, txIDs)
+
+	assert.NoError(t, db.AddDetachedSignature("1", []byte("alice"), []byte("sigma_alice")))
+	assert.NoError(t, db.AddDetachedSignature("1", []byte("bob"), []byte("sigma_bob")))
+	sigmas, err := db.GetDetachedSignatures("1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		token.Identity("alice").UniqueID(): []byte("sigma_alice"),
+		token.Identity("bob").UniqueID():   []byte("sigma_bob"),
+	}, sigmas)
+
+	assert.Error(t, db.ReplacePendingTransaction("2", []byte("tx_2")), "only pending transactions can be replaced")
+	assert.NoError(t, db.ReplacePendingTransaction("1", []byte("tx_1'")))
+	tx, err = db.GetPendingTransaction("1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("tx_1'"), tx)
+	sigmas, err = db.GetDetachedSignatures("1")
+	assert.NoError(t, err)
+	assert.Empty(t, sigmas)
+	assert.NoError(t, db.AddDetachedSignature("1", []byte("alice"), []byte("sigma_alice")))
+
+	assert.NoError(t, db.DeletePendingTransaction("1"))
+	tx, err = db.GetPendingTransaction("1")
+	assert.NoError(t, err)
+	assert.Nil(t, tx)
+	txIDs, err = db.GetPendingTransactionIDs()
+	assert.NoError(t, err)
+	assert.Empty(t, txIDs)
+	sigmas, err = db.GetDetachedSignatures("1")
+	assert.NoError(t, err)
+	assert.Empty(t, sigmas)
+}
+
+func TPaymentGroups(t *testing.T, db driver.TokenTransactionDB) {
+	g, err := db.GetPaymentGroup("g1")
+	assert.NoError(t, err)
+	assert.Nil(t, g)
+
+	assert.NoError(t, db.AddPaymentGroup("g1", 2))
+	assert.Error(t, db.AddPaymentGroup("g1", 2), "the same group cannot be added twice")
+	assert.Error(t, db.SetPaymentGroupStatus("g2", driver.Confirmed, ""), "the group must exist")
+
+	g, err = db.GetPaymentGroup("g1")
+	assert.NoError(t, err)
+	assert.Equal(t, "g1", g.GroupID)
+	assert.Equal(t, 2, g.Parts)
+	assert.Equal(t, driver.Pending, g.Status)
+	assert.Empty(t, g.TxIDs)
+
+	assert.NoError(t, db.AddPaymentGroupTransaction("g1", "tx_2", 1))
+	assert.NoError(t, db.AddPaymentGroupTransaction("g1", "tx_1", 0))
+	assert.Error(t, db.AddPaymentGroupTransaction("g1", "tx_3", 1), "a position cannot be taken twice")
+	assert.NoError(t, db.SetPaymentGroupStatus("g1", driver.Confirmed, "all parts confirmed"))
+
+	g, err = db.GetPaymentGroup("g1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx_1", "tx_2"}, g.TxIDs)
+	assert.Equal(t, driver.Confirmed, g.Status)
+	assert.Equal(t, "all parts confirmed", g.StatusMessage)
+}
+
 func createTestTransaction(t *testing.T, db driver.TokenTransactionDB, txID string) {
 	w, err := db.BeginAtomicWrite()
 	if err != nil {
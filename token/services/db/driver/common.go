@@ -27,6 +27,8 @@ const (
 	Transfer
 	// Redeem is the action type for redeeming tokens.
 	Redeem
+	// Clawback is the action type for moving tokens to a recovery owner on behalf of the compliance authorities.
+	Clawback
 )
 
 // SearchDirection defines the direction of a search.
@@ -225,6 +227,9 @@ type QueryTransactionsParams struct {
 	// Statuses is the list of transaction status to accept
 	// If empty, any status is accepted
 	Statuses []TxStatus
+	// TokenTypes is the list of token types to accept
+	// If empty, any token type is accepted
+	TokenTypes []token2.Type
 }
 
 // QueryValidationRecordsParams defines the parameters for querying validation records.
@@ -9,6 +9,7 @@ package driver
 import (
 	"context"
 	"errors"
+	"math/big"
 	"time"
 
 	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver"
@@ -34,6 +35,8 @@ type TokenRecord struct {
 	OwnerIdentity []byte
 	// OwnerWalletID is the identifier of the wallet that owns this token, it might be empty
 	OwnerWalletID string
+	// OwnerEnrollmentID is the enrollment ID of the owner of this token, if known. It might be empty
+	OwnerEnrollmentID string
 	// Ledger is the raw token as stored on the ledger
 	Ledger []byte
 	// LedgerFormat is the type of the raw token as stored on the ledger
@@ -53,6 +56,9 @@ type TokenRecord struct {
 	Auditor bool
 	// Issuer issued to mark this token as issued by this node
 	Issuer bool
+	// PublicParamsHash is the hash of the public parameters the token has been created with.
+	// It identifies the public parameters epoch the token comes from.
+	PublicParamsHash []byte
 }
 
 // TokenDetails provides details about an owned (spent or unspent) token
@@ -97,6 +103,8 @@ type QueryTokenDetailsParams struct {
 	Spendable SpendableFilter
 	// LedgerTokenFormats selects tokens whose output on the ledger has a format in the list
 	LedgerTokenFormats []token.Format
+	// ExcludeFrozen determines whether to exclude the tokens frozen by a compliance authority. It defaults to false.
+	ExcludeFrozen bool
 }
 
 type SpendableFilter int
@@ -134,6 +142,9 @@ type TokenDBTransaction interface {
 	// SetSpendableBySupportedTokenFormats sets the spendable flag to true for all the tokens having one of the passed token type.
 	// The spendable flag is set to false for the other tokens
 	SetSpendableBySupportedTokenFormats(ctx context.Context, formats []token.Format) error
+	// SetFrozen updates the frozen flag of the passed token.
+	// Frozen tokens are neither selected for spending nor counted in the balance.
+	SetFrozen(ctx context.Context, tokenID token.ID, frozen bool) error
 	// Commit commits this transaction
 	Commit() error
 	// Rollback rollbacks this transaction
@@ -161,6 +172,8 @@ type TokenDB interface {
 	ListUnspentTokensBy(walletID string, typ token.Type) (*token.UnspentTokens, error)
 	// ListUnspentTokens returns the list of all owned tokens
 	ListUnspentTokens() (*token.UnspentTokens, error)
+	// UnspentTokenIDsByEnrollmentID returns the identifiers of the unspent tokens whose owner has the passed enrollment ID
+	UnspentTokenIDsByEnrollmentID(ctx context.Context, enrollmentID string) ([]*token.ID, error)
 	// ListAuditTokens returns the audited tokens for the passed ids
 	ListAuditTokens(ids ...*token.ID) ([]*token.Token, error)
 	// ListHistoryIssuedTokens returns the list of all issued tokens
@@ -191,8 +204,8 @@ type TokenDB interface {
 	NewTokenDBTransaction() (TokenDBTransaction, error)
 	// QueryTokenDetails provides detailed information about tokens
 	QueryTokenDetails(params QueryTokenDetailsParams) ([]TokenDetails, error)
-	// Balance returns the sun of the amounts of the tokens with type and EID equal to those passed as arguments.
-	Balance(ownerEID string, typ token.Type) (uint64, error)
+	// Balance returns the sum of the amounts, with arbitrary precision, of the tokens with type and EID equal to those passed as arguments.
+	Balance(ownerEID string, typ token.Type) (*big.Int, error)
 	// SetSupportedTokenFormats sets the supported token formats
 	SetSupportedTokenFormats(formats []token.Format) error
 }
@@ -203,8 +216,50 @@ type TokenDBDriver interface {
 	Open(cp ConfigProvider, tmsID token2.TMSID) (TokenDB, error)
 }
 
+// TokenChangeOperation tells how a token changed
+type TokenChangeOperation int
+
+const (
+	// TokenInserted is recorded when a token is stored
+	TokenInserted TokenChangeOperation = iota + 1
+	// TokenDeleted is recorded when a token is spent, invalidated, or expires
+	TokenDeleted
+)
+
+// TokenChange is an entry of the token change log.
+// The change log is shared by all the replicas that use the same database.
+type TokenChange struct {
+	// Cursor is the position of the change in the log.
+	// Cursors are not consecutive, but no change becomes readable after a change with a greater cursor,
+	// therefore a consumer that stores the cursor of the last change it processed can resume from it without gaps.
+	Cursor uint64
+	// Operation tells whether the token was inserted or deleted
+	Operation TokenChangeOperation
+	// TokenID is the ID of the token
+	TokenID token.ID
+	// WalletID is the identifier of a wallet that owns the token, it might be empty.
+	// A token owned by multiple wallets has a change for each of them.
+	WalletID string
+	// Type is the type of token
+	Type token.Type
+	// Quantity is the number of units of Type carried in the token, encoded in base 16 with prefix ``0x''.
+	Quantity string
+	// SpentBy is the ID of the transaction that deleted the token, empty for insertions
+	SpentBy string
+}
+
+// ErrTokenChangesPruned is returned when reading the change log from a cursor that precedes pruned changes
+var ErrTokenChangesPruned = errors.New("token changes pruned")
+
 // TokenNotifier is the observable version of TokenDB
-type TokenNotifier driver2.Notifier
+type TokenNotifier interface {
+	driver2.Notifier
+	// TokenChanges returns, in order, at most limit changes recorded after the passed cursor.
+	// It returns ErrTokenChangesPruned if changes after the cursor have been pruned.
+	TokenChanges(after uint64, limit int) ([]TokenChange, error)
+	// PruneTokenChanges removes the changes recorded before the passed time
+	PruneTokenChanges(before time.Time) error
+}
 
 // TokenNotifierDriver is the interface for a token database driver
 type TokenNotifierDriver interface {
@@ -9,6 +9,8 @@ package driver
 import (
 	"context"
 	"errors"
+	"math/big"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
@@ -19,6 +21,8 @@ import (
 type TokenTransactionDB interface {
 	TransactionDB
 	TransactionEndorsementAckDB
+	PendingTransactionDB
+	PaymentGroupDB
 }
 
 type AtomicWrite interface {
@@ -65,6 +69,9 @@ type TransactionDB interface {
 	// QueryTransactions returns a list of transactions that match the given criteria
 	QueryTransactions(params QueryTransactionsParams) (TransactionIterator, error)
 
+	// SumTransactionAmounts returns the sum of the amounts of the transactions that match the given criteria
+	SumTransactionAmounts(params QueryTransactionsParams) (*big.Int, error)
+
 	// QueryMovements returns a list of movement records
 	QueryMovements(params QueryMovementsParams) ([]*MovementRecord, error)
 
@@ -87,6 +94,65 @@ type TransactionEndorsementAckDB interface {
 	GetTransactionEndorsementAcks(txID string) (map[string][]byte, error)
 }
 
+// PendingTransactionDB stores the transactions whose endorsement is paused waiting for detached signatures
+type PendingTransactionDB interface {
+	// AddPendingTransaction stores the passed serialized transaction under the passed transaction id
+	AddPendingTransaction(txID string, tx []byte) error
+
+	// GetPendingTransaction returns the pending transaction bound to the passed transaction id.
+	// It returns nil without error if the key is not found.
+	GetPendingTransaction(txID string) ([]byte, error)
+
+	// GetPendingTransactionIDs returns the ids of the pending transactions, oldest first
+	GetPendingTransactionIDs() ([]string, error)
+
+	// ReplacePendingTransaction atomically replaces the pending transaction bound to the passed transaction id
+	// and removes its detached signatures
+	ReplacePendingTransaction(txID string, tx []byte) error
+
+	// DeletePendingTransaction removes the pending transaction bound to the passed transaction id and its detached signatures
+	DeletePendingTransaction(txID string) error
+
+	// AddDetachedSignature records the detached signature of the passed signer on the given pending transaction
+	AddDetachedSignature(txID string, signer token.Identity, sigma []byte) error
+
+	// GetDetachedSignatures returns the detached signatures for the given pending transaction, indexed by the unique id of the signer
+	GetDetachedSignatures(txID string) (map[string][]byte, error)
+}
+
+// PaymentGroupRecord is a payment split into a chained sequence of transactions
+type PaymentGroupRecord struct {
+	// GroupID is the identifier of the payment group
+	GroupID string
+	// Parts is the number of transactions the payment is split into
+	Parts int
+	// TxIDs are the identifiers of the transactions of the group submitted so far, in order
+	TxIDs []string
+	// Status is the status of the whole group. It is Pending until all the transactions are confirmed,
+	// Confirmed afterwards, and Deleted if one of them fails
+	Status TxStatus
+	// StatusMessage is the user-friendly description of the status
+	StatusMessage string
+	// Timestamp is the time the group was stored
+	Timestamp time.Time
+}
+
+// PaymentGroupDB tracks the payments split into a chained sequence of transactions
+type PaymentGroupDB interface {
+	// AddPaymentGroup stores a new payment group, whose status is Pending, made of the passed number of transactions
+	AddPaymentGroup(groupID string, parts int) error
+
+	// AddPaymentGroupTransaction binds the passed transaction to the given payment group, at the given position
+	AddPaymentGroupTransaction(groupID string, txID string, index int) error
+
+	// SetPaymentGroupStatus sets the status of the given payment group
+	SetPaymentGroupStatus(groupID string, status TxStatus, message string) error
+
+	// GetPaymentGroup returns the payment group with the passed identifier.
+	// It returns nil without error if the key is not found.
+	GetPaymentGroup(groupID string) (*PaymentGroupRecord, error)
+}
+
 // TTXDBDriver is the interface for a token transaction db driver
 type TTXDBDriver interface {
 	// Open opens a token transaction database
@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	"github.com/pkg/errors"
+)
+
+// Amounts are stored as canonical decimal strings, with a leading minus for negative values,
+// because BIGINT cannot represent the whole unsigned 64-bit range of the token quantities,
+// and the sqlite numeric types lose precision above it.
+// The queries must not compare them as numbers: the database would either compare them as strings,
+// or cast them to a numeric type that overflows, or rounds, above 64 bits. Use the conditions below instead.
+
+// parseAmount parses an amount column value
+func parseAmount(s string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid amount [%s]", s)
+	}
+	return v, nil
+}
+
+// amountIsNegative returns the condition that selects the rows whose amount, in the passed column, is negative
+func amountIsNegative(column string) common.Condition {
+	return common.ConstCondition(fmt.Sprintf("%s LIKE '-%%'", column))
+}
+
+// amountIsPositive returns the condition that selects the rows whose amount, in the passed column, is positive.
+// A canonical decimal string is zero only if it is "0".
+func amountIsPositive(column string) common.Condition {
+	return common.ConstCondition(fmt.Sprintf("%s NOT LIKE '-%%' AND %s != '0'", column, column))
+}
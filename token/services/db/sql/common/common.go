@@ -26,6 +26,10 @@ type NewDBOpts struct {
 	DataSource   string
 	TablePrefix  string
 	CreateSchema bool
+	// Driver is the sql driver of the database, the schema migrations depend on it
+	Driver common.SQLDriverType
+	// Migrations tells how the pending schema migrations are handled when the schema is created
+	Migrations MigrationOpts
 }
 
 func NewDBOptsFromOpts(o Opts) NewDBOpts {
@@ -33,6 +37,7 @@ func NewDBOptsFromOpts(o Opts) NewDBOpts {
 		DataSource:   o.DataSource,
 		TablePrefix:  o.TablePrefix,
 		CreateSchema: !o.SkipCreateTable,
+		Driver:       o.Driver,
 	}
 }
 
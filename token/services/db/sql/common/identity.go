@@ -63,16 +63,15 @@ func NewCachedIdentityDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci common.Inte
 	return NewIdentityDB(
 		readDB,
 		writeDB,
-		opts.TablePrefix,
-		opts.CreateSchema,
+		opts,
 		secondcache.NewTyped[bool](1000),
 		secondcache.NewTyped[[]byte](1000),
 		ci,
 	)
 }
 
-func NewIdentityDB(readDB, writeDB *sql.DB, tablePrefix string, createSchema bool, signerInfoCache cache[bool], auditInfoCache cache[[]byte], ci common.Interpreter) (*IdentityDB, error) {
-	tables, err := GetTableNames(tablePrefix)
+func NewIdentityDB(readDB, writeDB *sql.DB, opts NewDBOpts, signerInfoCache cache[bool], auditInfoCache cache[[]byte], ci common.Interpreter) (*IdentityDB, error) {
+	tables, err := GetTableNames(opts.TablePrefix)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get table names")
 	}
@@ -89,7 +88,10 @@ func NewIdentityDB(readDB, writeDB *sql.DB, tablePrefix string, createSchema boo
 		auditInfoCache,
 		ci,
 	)
-	if createSchema {
+	if opts.CreateSchema {
+		if err = migrate(writeDB, opts, identityFamily); err != nil {
+			return nil, err
+		}
 		if err = common.InitSchema(writeDB, []string{identityDB.GetSchema()}...); err != nil {
 			return nil, err
 		}
@@ -19,15 +19,22 @@ type tableNames struct {
 	Requests               string
 	Validations            string
 	TransactionEndorseAck  string
+	PendingTransactions    string
+	DetachedSignatures     string
+	PaymentGroups          string
+	PaymentGroupTxs        string
 	Certifications         string
 	Tokens                 string
 	Ownership              string
+	TokenChanges           string
+	TokenChangesPruned     string
 	PublicParams           string
 	Wallets                string
 	IdentityConfigurations string
 	IdentityInfo           string
 	Signers                string
 	TokenLocks             string
+	SchemaVersions         string
 }
 
 func GetTableNames(prefix string) (tableNames, error) {
@@ -40,10 +47,16 @@ func GetTableNames(prefix string) (tableNames, error) {
 		Movements:              nc.MustGetTableName("movements"),
 		Transactions:           nc.MustGetTableName("transactions"),
 		TransactionEndorseAck:  nc.MustGetTableName("transaction_endorsements"),
+		PendingTransactions:    nc.MustGetTableName("pending_transactions"),
+		DetachedSignatures:     nc.MustGetTableName("detached_signatures"),
+		PaymentGroups:          nc.MustGetTableName("payment_groups"),
+		PaymentGroupTxs:        nc.MustGetTableName("payment_group_transactions"),
 		Requests:               nc.MustGetTableName("requests"),
 		Validations:            nc.MustGetTableName("request_validations"),
 		Tokens:                 nc.MustGetTableName("tokens"),
 		Ownership:              nc.MustGetTableName("token_ownership"),
+		TokenChanges:           nc.MustGetTableName("token_changes"),
+		TokenChangesPruned:     nc.MustGetTableName("token_changes_pruned"),
 		Certifications:         nc.MustGetTableName("token_certifications"),
 		TokenLocks:             nc.MustGetTableName("token_locks"),
 		PublicParams:           nc.MustGetTableName("public_params"),
@@ -51,5 +64,6 @@ func GetTableNames(prefix string) (tableNames, error) {
 		IdentityConfigurations: nc.MustGetTableName("identity_configurations"),
 		IdentityInfo:           nc.MustGetTableName("identity_information"),
 		Signers:                nc.MustGetTableName("identity_signers"),
+		SchemaVersions:         nc.MustGetTableName("schema_versions"),
 	}, nil
 }
@@ -24,15 +24,22 @@ func TestGetTableNames(t *testing.T) {
 		Requests:               "requests",
 		Validations:            "request_validations",
 		TransactionEndorseAck:  "transaction_endorsements",
+		PendingTransactions:    "pending_transactions",
+		DetachedSignatures:     "detached_signatures",
+		PaymentGroups:          "payment_groups",
+		PaymentGroupTxs:        "payment_group_transactions",
 		Certifications:         "token_certifications",
 		Tokens:                 "tokens",
 		Ownership:              "token_ownership",
+		TokenChanges:           "token_changes",
+		TokenChangesPruned:     "token_changes_pruned",
 		PublicParams:           "public_params",
 		Wallets:                "wallets",
 		IdentityConfigurations: "identity_configurations",
 		IdentityInfo:           "identity_information",
 		Signers:                "identity_signers",
 		TokenLocks:             "token_locks",
+		SchemaVersions:         "schema_versions",
 	}, names)
 
 	names, err = GetTableNames("valid_prefix")
@@ -0,0 +1,333 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	sql2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	"github.com/pkg/errors"
+)
+
+// ErrPendingMigrations is returned, in dry-run mode, when a database has schema migrations to apply
+var ErrPendingMigrations = errors.New("pending schema migrations")
+
+// MigrationOpts tells how the pending schema migrations are handled when a database is opened
+type MigrationOpts struct {
+	// DryRun logs the pending migrations without applying them.
+	// A database with pending migrations then fails to open with ErrPendingMigrations.
+	DryRun bool `yaml:"dryRun,omitempty"`
+}
+
+// WithMigrations returns a constructor that opens the databases with the passed migration options
+func WithMigrations[T any](newDB NewDBFunc[T], migrations MigrationOpts) NewDBFunc[T] {
+	return func(readDB, writeDB *sql.DB, opts NewDBOpts) (T, error) {
+		opts.Migrations = migrations
+		return newDB(readDB, writeDB, opts)
+	}
+}
+
+// migration is an up-migration of the tables of a family to the next schema version
+type migration struct {
+	Description string
+	// Up returns the statements that bring the tables to this version.
+	// The statements depend on the current schema: the tables created by this version of the SDK,
+	// or not created yet, need none.
+	Up func(s *schema, tables tableNames) ([]string, error)
+}
+
+// family is a set of tables that are created and migrated together.
+// Its version is the number of migrations applied, recorded in the schema versions table.
+type family struct {
+	Name       string
+	Migrations []migration
+}
+
+var (
+	tokensFamily = family{
+		Name: "tokens",
+		Migrations: []migration{
+			addColumn(func(t tableNames) string { return t.Tokens }, "ledger_type", "TEXT DEFAULT ''"),
+			addColumn(func(t tableNames) string { return t.Tokens }, "owner_enrollment_id", "TEXT NOT NULL DEFAULT ''"),
+			addColumn(func(t tableNames) string { return t.Tokens }, "frozen", "BOOL NOT NULL DEFAULT false"),
+			addColumn(func(t tableNames) string { return t.Tokens }, "pp_hash", "BYTEA"),
+			amountAsText(func(t tableNames) []string { return []string{t.Tokens} }),
+		},
+	}
+	transactionsFamily = family{
+		Name: "transactions",
+		Migrations: []migration{
+			amountAsText(func(t tableNames) []string { return []string{t.Transactions, t.Movements} }),
+		},
+	}
+	identityFamily   = family{Name: "identity", Migrations: []migration{baseline()}}
+	walletFamily     = family{Name: "wallet", Migrations: []migration{baseline()}}
+	tokenLocksFamily = family{Name: "token_locks", Migrations: []migration{baseline()}}
+)
+
+// baseline is the first migration of the families whose tables have not changed since the versioning was introduced.
+// Their tables, if any, already have the current schema, therefore it executes nothing, and only records version 1,
+// from which the later migrations of the family start.
+func baseline() migration {
+	return migration{
+		Description: "baseline schema",
+		Up: func(*schema, tableNames) ([]string, error) {
+			return nil, nil
+		},
+	}
+}
+
+// addColumn adds a column to a table created before the column was introduced
+func addColumn(table func(tableNames) string, column, definition string) migration {
+	return migration{
+		Description: fmt.Sprintf("add column [%s]", column),
+		Up: func(s *schema, tables tableNames) ([]string, error) {
+			columns, err := s.columns(table(tables))
+			if err != nil {
+				return nil, err
+			}
+			if columns == nil {
+				return nil, nil
+			}
+			if _, ok := columns[column]; ok {
+				return nil, nil
+			}
+			return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table(tables), column, definition)}, nil
+		},
+	}
+}
+
+// amountAsText converts to TEXT the amount column of tables created with BIGINT, see parseAmount.
+// Postgres and sqlite support renaming, adding, and dropping a column, while sqlite cannot alter its type.
+func amountAsText(tablesOf func(tableNames) []string) migration {
+	return migration{
+		Description: "store amounts as decimal strings",
+		Up: func(s *schema, tables tableNames) ([]string, error) {
+			var statements []string
+			for _, table := range tablesOf(tables) {
+				columns, err := s.columns(table)
+				if err != nil {
+					return nil, err
+				}
+				if typ, ok := columns["amount"]; !ok || typ == "TEXT" {
+					continue
+				}
+				statements = append(statements,
+					fmt.Sprintf("ALTER TABLE %s RENAME COLUMN amount TO amount_bigint", table),
+					fmt.Sprintf("ALTER TABLE %s ADD COLUMN amount TEXT NOT NULL DEFAULT '0'", table),
+					fmt.Sprintf("UPDATE %s SET amount = CAST(amount_bigint AS TEXT)", table),
+					fmt.Sprintf("ALTER TABLE %s DROP COLUMN amount_bigint", table),
+				)
+			}
+			return statements, nil
+		},
+	}
+}
+
+type querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// schema inspects the tables of a database
+type schema struct {
+	q      querier
+	driver common.SQLDriverType
+}
+
+// columns returns the upper-case types of the columns of the passed table, indexed by name, or nil if the table does not exist
+func (s *schema) columns(table string) (map[string]string, error) {
+	var query string
+	switch s.driver {
+	case sql2.SQLite:
+		query = "SELECT name, type FROM pragma_table_info($1)"
+	case sql2.Postgres:
+		query = "SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1"
+	default:
+		return nil, errors.Errorf("schema inspection not supported for driver [%s]", s.driver)
+	}
+	logger.Debug(query, table)
+	rows, err := s.q.Query(query, table)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed querying the columns of [%s]", table)
+	}
+	defer Close(rows)
+
+	var columns map[string]string
+	for rows.Next() {
+		var name, typ string
+		if err := rows.Scan(&name, &typ); err != nil {
+			return nil, errors.Wrapf(err, "failed scanning the columns of [%s]", table)
+		}
+		if columns == nil {
+			columns = map[string]string{}
+		}
+		columns[strings.ToLower(name)] = strings.ToUpper(typ)
+	}
+	return columns, rows.Err()
+}
+
+// pendingMigration is a migration that has not been applied yet, with the statements it would execute on the current schema
+type pendingMigration struct {
+	Family      string
+	Version     int
+	Description string
+	Statements  []string
+}
+
+// pendingMigrations returns the migrations of the passed family that have not been applied to the database yet.
+// The statements of each migration are computed against the current schema, and therefore do not account for the previous pending migrations.
+func pendingMigrations(db *sql.DB, opts NewDBOpts, f family) ([]pendingMigration, error) {
+	tables, err := GetTableNames(opts.TablePrefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get table names")
+	}
+	s := &schema{q: db, driver: opts.Driver}
+	version, err := schemaVersion(s, tables.SchemaVersions, f.Name)
+	if err != nil {
+		return nil, err
+	}
+	var pending []pendingMigration
+	for i := version; i < len(f.Migrations); i++ {
+		statements, err := f.Migrations[i].Up(s, tables)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed planning migration [%s:%d]", f.Name, i+1)
+		}
+		pending = append(pending, pendingMigration{
+			Family:      f.Name,
+			Version:     i + 1,
+			Description: f.Migrations[i].Description,
+			Statements:  statements,
+		})
+	}
+	return pending, nil
+}
+
+func schemaVersion(s *schema, versionsTable, familyName string) (int, error) {
+	columns, err := s.columns(versionsTable)
+	if err != nil {
+		return 0, err
+	}
+	if columns == nil {
+		return 0, nil
+	}
+	var version int
+	query := fmt.Sprintf("SELECT version FROM %s WHERE family = $1", versionsTable)
+	logger.Debug(query, familyName)
+	if err := s.q.QueryRow(query, familyName).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, errors.Wrapf(err, "failed reading the schema version of [%s]", familyName)
+	}
+	return version, nil
+}
+
+// migrate brings the tables of the passed family to the latest schema version, before they are created if missing.
+// In dry-run mode, it logs the pending migrations and returns ErrPendingMigrations if any of them has statements to execute.
+func migrate(db *sql.DB, opts NewDBOpts, f family) error {
+	if opts.Migrations.DryRun {
+		return dryRun(db, opts, f)
+	}
+	tables, err := GetTableNames(opts.TablePrefix)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get table names")
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "failed starting a transaction")
+	}
+	if err := migrateTx(tx, opts.Driver, tables, f); err != nil {
+		if err2 := tx.Rollback(); err2 != nil {
+			logger.Errorf("failed rolling back the migrations of [%s]: [%s]", f.Name, err2)
+		}
+		return errors.WithMessagef(err, "failed migrating [%s] tables", f.Name)
+	}
+	return tx.Commit()
+}
+
+func migrateTx(tx *sql.Tx, driver common.SQLDriverType, tables tableNames, f family) error {
+	now := time.Now().UTC()
+	for _, stmt := range []struct {
+		query string
+		args  []any
+	}{
+		{query: fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			family TEXT NOT NULL PRIMARY KEY,
+			version INT NOT NULL,
+			migrated_at TIMESTAMP NOT NULL
+		)`, tables.SchemaVersions)},
+		{
+			query: fmt.Sprintf("INSERT INTO %s (family, version, migrated_at) VALUES ($1, 0, $2) ON CONFLICT DO NOTHING", tables.SchemaVersions),
+			args:  []any{f.Name, now},
+		},
+		{
+			// locks the version of the family until the migrations are committed, for the replicas sharing the database
+			query: fmt.Sprintf("UPDATE %s SET version = version WHERE family = $1", tables.SchemaVersions),
+			args:  []any{f.Name},
+		},
+	} {
+		logger.Debug(stmt.query, stmt.args)
+		if _, err := tx.Exec(stmt.query, stmt.args...); err != nil {
+			return errors.Wrapf(err, "failed executing [%s]", stmt.query)
+		}
+	}
+
+	s := &schema{q: tx, driver: driver}
+	version, err := schemaVersion(s, tables.SchemaVersions, f.Name)
+	if err != nil {
+		return err
+	}
+	if version >= len(f.Migrations) {
+		return nil
+	}
+	for i := version; i < len(f.Migrations); i++ {
+		statements, err := f.Migrations[i].Up(s, tables)
+		if err != nil {
+			return errors.WithMessagef(err, "failed planning migration [%s:%d]", f.Name, i+1)
+		}
+		if len(statements) > 0 {
+			logger.Infof("migrating [%s] tables to version [%d]: %s", f.Name, i+1, f.Migrations[i].Description)
+		}
+		for _, statement := range statements {
+			logger.Debug(statement)
+			if _, err := tx.Exec(statement); err != nil {
+				return errors.Wrapf(err, "failed executing [%s]", statement)
+			}
+		}
+	}
+	query := fmt.Sprintf("UPDATE %s SET version = $1, migrated_at = $2 WHERE family = $3", tables.SchemaVersions)
+	logger.Debug(query, len(f.Migrations), now, f.Name)
+	if _, err := tx.Exec(query, len(f.Migrations), now, f.Name); err != nil {
+		return errors.Wrapf(err, "failed updating the schema version of [%s]", f.Name)
+	}
+	return nil
+}
+
+func dryRun(db *sql.DB, opts NewDBOpts, f family) error {
+	pending, err := pendingMigrations(db, opts, f)
+	if err != nil {
+		return err
+	}
+	var descriptions []string
+	for _, m := range pending {
+		if len(m.Statements) == 0 {
+			continue
+		}
+		logger.Infof("dry run: migration [%s:%d] of [%s] would execute: %s", m.Family, m.Version, opts.TablePrefix, strings.Join(m.Statements, "; "))
+		descriptions = append(descriptions, fmt.Sprintf("%s:%d (%s)", m.Family, m.Version, m.Description))
+	}
+	if len(descriptions) > 0 {
+		return errors.Wrapf(ErrPendingMigrations, "[%s]", strings.Join(descriptions, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"path"
+	"testing"
+
+	sql2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/sqlite"
+	"github.com/pkg/errors"
+	"github.com/test-go/testify/assert"
+)
+
+// legacyTokensSchema is the tokens table of a database created before the schema was versioned
+const legacyTokensSchema = `CREATE TABLE %s (
+	tx_id TEXT NOT NULL,
+	idx INT NOT NULL,
+	amount BIGINT NOT NULL,
+	token_type TEXT NOT NULL,
+	quantity TEXT NOT NULL,
+	issuer_raw BYTEA,
+	owner_raw BYTEA NOT NULL,
+	owner_type TEXT NOT NULL,
+	owner_identity BYTEA NOT NULL,
+	owner_wallet_id TEXT,
+	ledger BYTEA NOT NULL,
+	ledger_metadata BYTEA NOT NULL,
+	stored_at TIMESTAMP NOT NULL,
+	is_deleted BOOL NOT NULL DEFAULT false,
+	spent_by TEXT NOT NULL DEFAULT '',
+	spent_at TIMESTAMP,
+	owner BOOL NOT NULL DEFAULT false,
+	auditor BOOL NOT NULL DEFAULT false,
+	issuer BOOL NOT NULL DEFAULT false,
+	spendable BOOL NOT NULL DEFAULT true,
+	PRIMARY KEY (tx_id, idx)
+)`
+
+func openSqlite(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", path.Join(t.TempDir(), "db.sqlite")))
+	assert.NoError(t, err)
+	t.Cleanup(func() { Close(db) })
+	return db
+}
+
+func TestMigrations(t *testing.T) {
+	db := openSqlite(t)
+	tables, err := GetTableNames("test")
+	assert.NoError(t, err)
+	_, err = db.Exec(fmt.Sprintf(legacyTokensSchema, tables.Tokens))
+	assert.NoError(t, err)
+	_, err = db.Exec(fmt.Sprintf("INSERT INTO %s (tx_id, idx, amount, token_type, quantity, owner_raw, owner_type, owner_identity, owner_wallet_id, ledger, ledger_metadata, stored_at, owner) VALUES ('tx1', 0, 42, 'TST', '0x2a', x'01', 'idemix', x'01', 'wallet', x'01', x'01', CURRENT_TIMESTAMP, true)", tables.Tokens))
+	assert.NoError(t, err)
+	_, err = db.Exec(fmt.Sprintf("INSERT INTO %s (tx_id, idx, wallet_id) VALUES ('tx1', 0, 'wallet')", tables.Ownership))
+	assert.Error(t, err, "the ownership table does not exist yet")
+
+	opts := NewDBOpts{TablePrefix: "test", CreateSchema: true, Driver: sql2.SQLite}
+	ci := NewTokenInterpreter(sqlite.NewInterpreter())
+
+	// the dry run reports the pending migrations and leaves the database untouched
+	dryRunOpts := opts
+	dryRunOpts.Migrations = MigrationOpts{DryRun: true}
+	_, err = NewTokenDB(db, db, dryRunOpts, ci)
+	assert.True(t, errors.Is(err, ErrPendingMigrations))
+	pending, err := pendingMigrations(db, opts, tokensFamily)
+	assert.NoError(t, err)
+	assert.Len(t, pending, len(tokensFamily.Migrations))
+	assert.Equal(t, []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN ledger_type TEXT DEFAULT ''", tables.Tokens)}, pending[0].Statements)
+	columns, err := (&schema{q: db, driver: sql2.SQLite}).columns(tables.Tokens)
+	assert.NoError(t, err)
+	assert.Equal(t, "BIGINT", columns["amount"])
+	assert.NotContains(t, columns, "frozen")
+
+	// the migrations bring the legacy table to the current schema
+	tokenDB, err := NewTokenDB(db, db, opts, ci)
+	assert.NoError(t, err)
+	columns, err = (&schema{q: db, driver: sql2.SQLite}).columns(tables.Tokens)
+	assert.NoError(t, err)
+	assert.Equal(t, "TEXT", columns["amount"])
+	for _, column := range []string{"ledger_type", "owner_enrollment_id", "frozen", "pp_hash"} {
+		assert.Contains(t, columns, column)
+	}
+	assert.NotContains(t, columns, "amount_bigint")
+	version, err := schemaVersion(&schema{q: db, driver: sql2.SQLite}, tables.SchemaVersions, tokensFamily.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, len(tokensFamily.Migrations), version)
+
+	_, err = db.Exec(fmt.Sprintf("INSERT INTO %s (tx_id, idx, wallet_id) VALUES ('tx1', 0, 'wallet')", tables.Ownership))
+	assert.NoError(t, err)
+	balance, err := tokenDB.Balance("wallet", "TST")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), balance)
+
+	// nothing is pending anymore
+	pending, err = pendingMigrations(db, opts, tokensFamily)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+	_, err = NewTokenDB(db, db, dryRunOpts, ci)
+	assert.NoError(t, err)
+}
+
+func TestMigrationsFreshDatabase(t *testing.T) {
+	db := openSqlite(t)
+	opts := NewDBOpts{TablePrefix: "test", CreateSchema: true, Driver: sql2.SQLite}
+	tables, err := GetTableNames(opts.TablePrefix)
+	assert.NoError(t, err)
+
+	// a database created by the current version has nothing to migrate, even in dry-run mode
+	dryRunOpts := opts
+	dryRunOpts.Migrations = MigrationOpts{DryRun: true}
+	_, err = NewTransactionDB(db, db, dryRunOpts, NewTokenInterpreter(sqlite.NewInterpreter()))
+	assert.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = NewTokenDB(db, db, opts, NewTokenInterpreter(sqlite.NewInterpreter()))
+		assert.NoError(t, err)
+		_, err = NewTransactionDB(db, db, opts, NewTokenInterpreter(sqlite.NewInterpreter()))
+		assert.NoError(t, err)
+		_, err = NewWalletDB(db, db, opts)
+		assert.NoError(t, err)
+		_, err = NewTokenLockDB(db, db, opts)
+		assert.NoError(t, err)
+		_, err = NewCachedIdentityDB(db, db, opts, sqlite.NewInterpreter())
+		assert.NoError(t, err)
+	}
+
+	s := &schema{q: db, driver: sql2.SQLite}
+	for _, f := range []family{tokensFamily, transactionsFamily, walletFamily, tokenLocksFamily, identityFamily} {
+		version, err := schemaVersion(s, tables.SchemaVersions, f.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, len(f.Migrations), version, "version of [%s]", f.Name)
+	}
+	var families int
+	assert.NoError(t, db.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", tables.SchemaVersions)).Scan(&families))
+	assert.Equal(t, 5, families)
+}
+
+func TestMigrationsBaseline(t *testing.T) {
+	db := openSqlite(t)
+	opts := NewDBOpts{TablePrefix: "test", CreateSchema: true, Driver: sql2.SQLite}
+	tables, err := GetTableNames(opts.TablePrefix)
+	assert.NoError(t, err)
+
+	// a wallet database created before the versioning
+	_, err = NewWalletDB(db, db, opts)
+	assert.NoError(t, err)
+	_, err = db.Exec(fmt.Sprintf("DROP TABLE %s", tables.SchemaVersions))
+	assert.NoError(t, err)
+
+	// the baseline executes nothing, therefore the dry run does not block the database
+	pending, err := pendingMigrations(db, opts, walletFamily)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Empty(t, pending[0].Statements)
+	dryRunOpts := opts
+	dryRunOpts.Migrations = MigrationOpts{DryRun: true}
+	_, err = NewWalletDB(db, db, dryRunOpts)
+	assert.NoError(t, err)
+
+	// and records version 1
+	_, err = NewWalletDB(db, db, opts)
+	assert.NoError(t, err)
+	version, err := schemaVersion(&schema{q: db, driver: sql2.SQLite}, tables.SchemaVersions, walletFamily.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, version)
+}
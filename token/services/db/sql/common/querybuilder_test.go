@@ -209,7 +209,7 @@ func TestMovementConditions(t *testing.T) {
 				TokenTypes:        []token.Type{"XYZ"},
 				MovementDirection: driver.Sent,
 			},
-			expectedSql:  "WHERE (enrollment_id = $1 AND token_type = $2 AND status != 3 AND amount < 0) ORDER BY stored_at DESC",
+			expectedSql:  "WHERE (enrollment_id = $1 AND token_type = $2 AND status != 3 AND amount LIKE '-%') ORDER BY stored_at DESC",
 			expectedArgs: []interface{}{"alice", "XYZ"},
 		},
 		{
@@ -220,7 +220,7 @@ func TestMovementConditions(t *testing.T) {
 				MovementDirection: driver.Received,
 				NumRecords:        2,
 			},
-			expectedSql:  "WHERE (status = $1 AND amount > 0) ORDER BY stored_at DESC LIMIT 2",
+			expectedSql:  "WHERE (status = $1 AND amount NOT LIKE '-%' AND amount != '0') ORDER BY stored_at DESC LIMIT 2",
 			expectedArgs: []interface{}{driver.Pending},
 		},
 	}
@@ -88,6 +88,9 @@ func (c *tokenInterpreter) HasTokenDetails(params driver.QueryTokenDetailsParams
 	} else if params.Spendable == driver.SpendableOnly {
 		conds = append(conds, common.ConstCondition("spendable = true"))
 	}
+	if params.ExcludeFrozen {
+		conds = append(conds, common.ConstCondition("frozen = false"))
+	}
 	if len(params.LedgerTokenFormats) > 0 {
 		conds = append(conds, c.HasTokenFormats("ledger_type", params.LedgerTokenFormats...))
 	}
@@ -105,10 +108,11 @@ func (c *tokenInterpreter) HasMovementsParams(params driver.QueryMovementsParams
 		conds = append(conds, common.ConstCondition(fmt.Sprintf("status != %d", driver.Deleted)))
 	}
 
+	// the sign of the amount tells the direction
 	if params.MovementDirection == driver.Sent {
-		conds = append(conds, common.ConstCondition("amount < 0"))
+		conds = append(conds, amountIsNegative("amount"))
 	} else if params.MovementDirection == driver.Received {
-		conds = append(conds, common.ConstCondition("amount > 0"))
+		conds = append(conds, amountIsPositive("amount"))
 	}
 	return c.And(conds...)
 }
@@ -149,6 +153,9 @@ func (c *tokenInterpreter) HasTransactionParams(params driver.QueryTransactionsP
 	if len(params.Statuses) > 0 {
 		conds = append(conds, c.InInts("status", common.ToInts(params.Statuses)))
 	}
+	if len(params.TokenTypes) > 0 {
+		conds = append(conds, c.HasTokenTypes("token_type", params.TokenTypes...))
+	}
 
 	// See QueryTransactionsParams for expected behavior. If only one of sender or
 	// recipient is set, we return all transactions. If both are set, we do an OR.
@@ -9,6 +9,8 @@ package common
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/big"
 	"sync"
 	"testing"
 	"time"
@@ -131,6 +133,9 @@ var TokensCases = []struct {
 	{"Certification", TCertification},
 	{"QueryTokenDetails", TQueryTokenDetails},
 	{"TTokenTypes", TTokenTypes},
+	{"FrozenTokens", TFrozenTokens},
+	{"LargeAmounts", TLargeAmounts},
+	{"TokenChanges", TTokenChanges},
 }
 
 func TTransaction(t *testing.T, db TestTokenDB) {
@@ -945,7 +950,7 @@ func TQueryTokenDetails(t *testing.T, db TestTokenDB) {
 	assertEqual(t, tx1, res[0])
 	balance, err := db.Balance("alice", "TST1")
 	assert.NoError(t, err)
-	assert.Equal(t, res[0].Amount, balance)
+	assert.Equal(t, new(big.Int).SetUint64(res[0].Amount), balance)
 
 	// alice TST
 	res, err = db.QueryTokenDetails(driver.QueryTokenDetailsParams{WalletID: "alice", TokenType: TST})
@@ -954,7 +959,7 @@ func TQueryTokenDetails(t *testing.T, db TestTokenDB) {
 	assertEqual(t, tx2, res[0])
 	balance, err = db.Balance("alice", TST)
 	assert.NoError(t, err)
-	assert.Equal(t, res[0].Amount, balance)
+	assert.Equal(t, new(big.Int).SetUint64(res[0].Amount), balance)
 
 	// bob TST
 	res, err = db.QueryTokenDetails(driver.QueryTokenDetailsParams{WalletID: "bob", TokenType: TST})
@@ -963,7 +968,7 @@ func TQueryTokenDetails(t *testing.T, db TestTokenDB) {
 	assertEqual(t, tx21, res[0])
 	balance, err = db.Balance("bob", TST)
 	assert.NoError(t, err)
-	assert.Equal(t, res[0].Amount, balance)
+	assert.Equal(t, new(big.Int).SetUint64(res[0].Amount), balance)
 
 	// spent
 	assert.NoError(t, db.DeleteTokens("delby", &token.ID{TxId: "tx2", Index: 1}))
@@ -1090,6 +1095,67 @@ func TTokenTypes(t *testing.T, db TestTokenDB) {
 	consumeSpendableTokensIterator(t, it, "TST1", 1)
 }
 
+func TFrozenTokens(t *testing.T, db TestTokenDB) {
+	tx, err := db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	for i, eid := range []string{"alice", "alice", "bob"} {
+		assert.NoError(t, tx.StoreToken(context.TODO(), driver.TokenRecord{
+			TxID:              "tx1",
+			Index:             uint64(i),
+			IssuerRaw:         []byte{},
+			OwnerRaw:          []byte{1, 2, 3},
+			OwnerType:         "idemix",
+			OwnerIdentity:     []byte{},
+			OwnerWalletID:     "wallet",
+			OwnerEnrollmentID: eid,
+			Ledger:            []byte("ledger"),
+			LedgerFormat:      "CLEAR",
+			LedgerMetadata:    []byte{},
+			Quantity:          "0x02",
+			Type:              TST,
+			Amount:            2,
+			Owner:             true,
+		}, []string{"wallet"}))
+	}
+	assert.NoError(t, tx.Commit())
+
+	ids, err := db.UnspentTokenIDsByEnrollmentID(context.TODO(), "alice")
+	assert.NoError(t, err)
+	assert2.ElementsMatch(t, []*token.ID{{TxId: "tx1", Index: 0}, {TxId: "tx1", Index: 1}}, ids)
+
+	// freeze the tokens of alice
+	tx, err = db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	for _, id := range ids {
+		assert.NoError(t, tx.SetFrozen(context.TODO(), *id, true))
+	}
+	assert.NoError(t, tx.Commit())
+
+	it, err := db.SpendableTokensIteratorBy(context.TODO(), "wallet", TST)
+	assert.NoError(t, err)
+	consumeSpendableTokensIterator(t, it, TST, 1)
+	balance, err := db.Balance("wallet", TST)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), balance)
+	// frozen tokens are still unspent
+	ids, err = db.UnspentTokenIDsByEnrollmentID(context.TODO(), "alice")
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+
+	// unfreeze one of them
+	tx, err = db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.SetFrozen(context.TODO(), token.ID{TxId: "tx1", Index: 0}, false))
+	assert.NoError(t, tx.Commit())
+
+	it, err = db.SpendableTokensIteratorBy(context.TODO(), "wallet", TST)
+	assert.NoError(t, err)
+	consumeSpendableTokensIterator(t, it, TST, 2)
+	balance, err = db.Balance("wallet", TST)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(4), balance)
+}
+
 func consumeSpendableTokensIterator(t *testing.T, it driver3.SpendableTokensIterator, tokenType token.Type, count int) {
 	defer it.Close()
 	for i := 0; i < count; i++ {
@@ -1108,3 +1174,130 @@ func assertEqual(t *testing.T, r driver.TokenRecord, d driver.TokenDetails) {
 	assert.Equal(t, r.Amount, d.Amount)
 	assert.Equal(t, r.OwnerType, d.OwnerType)
 }
+
+func TLargeAmounts(t *testing.T, db TestTokenDB) {
+	tx, err := db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, tx.StoreToken(context.TODO(), driver.TokenRecord{
+			TxID:              "tx1",
+			Index:             uint64(i),
+			IssuerRaw:         []byte{},
+			OwnerRaw:          []byte{1, 2, 3},
+			OwnerType:         "idemix",
+			OwnerIdentity:     []byte{},
+			OwnerWalletID:     "wallet",
+			OwnerEnrollmentID: "alice",
+			Ledger:            []byte("ledger"),
+			LedgerFormat:      "CLEAR",
+			LedgerMetadata:    []byte{},
+			Quantity:          "0xffffffffffffffff",
+			Type:              TST,
+			Amount:            math.MaxUint64,
+			Owner:             true,
+		}, []string{"wallet"}))
+	}
+	assert.NoError(t, tx.Commit())
+
+	res, err := db.QueryTokenDetails(driver.QueryTokenDetailsParams{WalletID: "wallet"})
+	assert.NoError(t, err)
+	assert.Len(t, res, 3)
+	for _, td := range res {
+		assert.Equal(t, uint64(math.MaxUint64), td.Amount)
+	}
+	balance, err := db.Balance("wallet", TST)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Mul(new(big.Int).SetUint64(math.MaxUint64), big.NewInt(3)), balance)
+}
+
+func TTokenChanges(t *testing.T, db TestTokenDB) {
+	tokenDB := db.(*TokenDB)
+	record := func(index uint64, walletID string) driver.TokenRecord {
+		return driver.TokenRecord{
+			TxID:           "tx1",
+			Index:          index,
+			OwnerRaw:       []byte{1, 2, 3},
+			OwnerType:      "idemix",
+			OwnerIdentity:  []byte{},
+			OwnerWalletID:  walletID,
+			Ledger:         []byte("ledger"),
+			LedgerMetadata: []byte{},
+			Quantity:       "0x02",
+			Type:           TST,
+			Amount:         2,
+			Owner:          true,
+		}
+	}
+
+	tx, err := db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.StoreToken(context.TODO(), record(0, "alice"), []string{"alice"}))
+	assert.NoError(t, tx.StoreToken(context.TODO(), record(1, ""), []string{"alice", "bob"}))
+	assert.NoError(t, tx.StoreToken(context.TODO(), record(2, "bob"), nil))
+	assert.NoError(t, tx.Commit())
+
+	// rolled back changes are not recorded and do not leave gaps
+	tx, err = db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.StoreToken(context.TODO(), record(3, "alice"), nil))
+	assert.NoError(t, tx.Rollback())
+
+	assert.NoError(t, db.DeleteTokens("tx2", &token.ID{TxId: "tx1", Index: 1}, &token.ID{TxId: "tx1", Index: 3}))
+	tx, err = db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Delete(context.TODO(), token.ID{TxId: "tx1", Index: 2}, "tx3"))
+	// deleting again records nothing
+	assert.NoError(t, tx.Delete(context.TODO(), token.ID{TxId: "tx1", Index: 2}, "tx4"))
+	assert.NoError(t, tx.Commit())
+	assert.NoError(t, db.DeleteTokens("tx5", &token.ID{TxId: "tx1", Index: 1}))
+
+	changes, err := tokenChanges(tokenDB.readDB, tokenDB.table, tokenDB.cursors, 0, 100)
+	assert.NoError(t, err)
+	expected := []driver.TokenChange{
+		{Operation: driver.TokenInserted, TokenID: token.ID{TxId: "tx1", Index: 0}, WalletID: "alice"},
+		{Operation: driver.TokenInserted, TokenID: token.ID{TxId: "tx1", Index: 1}, WalletID: "alice"},
+		{Operation: driver.TokenInserted, TokenID: token.ID{TxId: "tx1", Index: 1}, WalletID: "bob"},
+		{Operation: driver.TokenInserted, TokenID: token.ID{TxId: "tx1", Index: 2}, WalletID: "bob"},
+		{Operation: driver.TokenDeleted, TokenID: token.ID{TxId: "tx1", Index: 1}, WalletID: "alice", SpentBy: "tx2"},
+		{Operation: driver.TokenDeleted, TokenID: token.ID{TxId: "tx1", Index: 1}, WalletID: "bob", SpentBy: "tx2"},
+		{Operation: driver.TokenDeleted, TokenID: token.ID{TxId: "tx1", Index: 2}, WalletID: "bob", SpentBy: "tx3"},
+	}
+	assert.Len(t, changes, len(expected))
+	for i := range expected {
+		expected[i].Cursor = uint64(i + 1)
+		expected[i].Type = TST
+		expected[i].Quantity = "0x02"
+	}
+	assert.Equal(t, expected, changes)
+
+	// a consumer resumes from the cursor of the last change it processed
+	changes, err = tokenChanges(tokenDB.readDB, tokenDB.table, tokenDB.cursors, 4, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[4:6], changes)
+	changes, err = tokenChanges(tokenDB.readDB, tokenDB.table, tokenDB.cursors, 7, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// pruning removes the old changes, and the consumers that did not process them cannot resume
+	notifier := &TokenNotifier{readDB: tokenDB.readDB, writeDB: tokenDB.writeDB, tables: tokenDB.table, cursors: tokenDB.cursors}
+	assert.NoError(t, notifier.PruneTokenChanges(time.Now().Add(-time.Hour)))
+	changes, err = notifier.TokenChanges(0, 100)
+	assert.NoError(t, err)
+	assert.Len(t, changes, len(expected))
+	assert.NoError(t, notifier.PruneTokenChanges(time.Now().Add(time.Hour)))
+	_, err = notifier.TokenChanges(6, 100)
+	assert2.ErrorIs(t, err, driver.ErrTokenChangesPruned)
+	changes, err = notifier.TokenChanges(7, 100)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// cursors keep growing after pruning
+	tx, err = db.NewTokenDBTransaction()
+	assert.NoError(t, err)
+	assert.NoError(t, tx.StoreToken(context.TODO(), record(4, "alice"), nil))
+	assert.NoError(t, tx.Commit())
+	changes, err = notifier.TokenChanges(7, 100)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert2.Greater(t, changes[0].Cursor, uint64(7))
+}
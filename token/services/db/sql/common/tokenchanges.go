@@ -0,0 +1,387 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver"
+	sql2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// The token change log records the insertions and deletions of tokens in the same transaction that writes the tokens.
+// A reader delivers the changes in cursor order, therefore a change must never commit after a visible change with a greater cursor.
+// The cursors are assigned without locks shared by the writers, see changeCursors.
+
+// maxChangesPerTx is the number of changes a single transaction can record, see postgresCursors
+const maxChangesPerTx = 1 << 20
+
+// changeCursors assigns the cursors of the token changes and tells which changes can be delivered
+type changeCursors interface {
+	// Next returns the expression of the cursor of the change with the passed ordinal in the writing transaction
+	Next(ordinal int) string
+	// Final returns the condition on the seq column that holds for the changes that are committed,
+	// and that no change committed later can precede
+	Final() string
+}
+
+func newChangeCursors(d common.SQLDriverType, tables tokenTables) changeCursors {
+	if d == sql2.Postgres {
+		return &postgresCursors{}
+	}
+	return &serialCursors{tables: tables}
+}
+
+// serialCursors assign consecutive cursors, for the databases that run one writing transaction at a time, like sqlite
+type serialCursors struct {
+	tables tokenTables
+}
+
+func (c *serialCursors) Next(int) string {
+	return fmt.Sprintf("(SELECT MAX(seq) + 1 FROM (SELECT MAX(seq) AS seq FROM %s UNION ALL SELECT seq FROM %s WHERE id = 0) AS last)", c.tables.Changes, c.tables.ChangesPruned)
+}
+
+func (c *serialCursors) Final() string {
+	return ""
+}
+
+// postgresCursors take the upper bits of the cursor from the id of the writing transaction and the lower bits from the ordinal of the change in it.
+// The transactions with an id smaller than the minimum id of the running transactions are finished,
+// therefore their changes can be delivered, and the running and later transactions record greater cursors.
+// A long-running transaction holds back the delivery of the changes of the younger ones until it ends.
+type postgresCursors struct{}
+
+func (c *postgresCursors) Next(ordinal int) string {
+	return fmt.Sprintf("((pg_current_xact_id()::text::bigint << 20) | %d)", ordinal)
+}
+
+func (c *postgresCursors) Final() string {
+	return "seq < (pg_snapshot_xmin(pg_current_snapshot())::text::bigint << 20)"
+}
+
+// tokenChangesSchema returns the schema of the token change log
+func tokenChangesSchema(tables tokenTables) string {
+	return fmt.Sprintf(`
+		-- Token Changes
+		CREATE TABLE IF NOT EXISTS %s (
+			seq BIGINT PRIMARY KEY,
+			operation INT NOT NULL,
+			tx_id TEXT NOT NULL,
+			idx INT NOT NULL,
+			wallet_id TEXT NOT NULL DEFAULT '',
+			token_type TEXT NOT NULL,
+			quantity TEXT NOT NULL,
+			spent_by TEXT NOT NULL DEFAULT '',
+			recorded_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS recorded_at_%s ON %s ( recorded_at );
+
+		-- Token Changes Pruned, the greatest cursor of the changes removed by the retention policy
+		CREATE TABLE IF NOT EXISTS %s (
+			id INT PRIMARY KEY,
+			seq BIGINT NOT NULL
+		);
+		INSERT INTO %s (id, seq) VALUES (0, 0) ON CONFLICT DO NOTHING;
+		`,
+		tables.Changes,
+		tables.Changes, tables.Changes,
+		tables.ChangesPruned,
+		tables.ChangesPruned,
+	)
+}
+
+// recordTokenChanges appends the passed changes to the change log.
+// The ordinal counts the changes recorded so far by the transaction.
+func recordTokenChanges(tx *sql.Tx, tables *tokenTables, cursors changeCursors, ordinal *int, changes []driver.TokenChange) error {
+	now := time.Now().UTC()
+	for _, c := range changes {
+		if *ordinal >= maxChangesPerTx {
+			return errors.Errorf("too many token changes in a single transaction, the maximum is [%d]", maxChangesPerTx)
+		}
+		query := fmt.Sprintf("INSERT INTO %s (seq, operation, tx_id, idx, wallet_id, token_type, quantity, spent_by, recorded_at) VALUES (%s, $1, $2, $3, $4, $5, $6, $7, $8)", tables.Changes, cursors.Next(*ordinal))
+		logger.Debug(query, c.Operation, c.TokenID.TxId, c.TokenID.Index, c.WalletID, c.Type, c.Quantity, c.SpentBy, now)
+		if _, err := tx.Exec(query, c.Operation, c.TokenID.TxId, c.TokenID.Index, c.WalletID, c.Type, c.Quantity, c.SpentBy, now); err != nil {
+			return errors.Wrapf(err, "failed recording the change of [%s]", c.TokenID)
+		}
+		*ordinal++
+	}
+	return nil
+}
+
+// insertionChanges returns the changes that record the insertion of a token, one for each of its wallets
+func insertionChanges(tr driver.TokenRecord, owners []string) []driver.TokenChange {
+	wallets := walletsOf(append([]string{tr.OwnerWalletID}, owners...))
+	changes := make([]driver.TokenChange, len(wallets))
+	for i, walletID := range wallets {
+		changes[i] = driver.TokenChange{
+			Operation: driver.TokenInserted,
+			TokenID:   token.ID{TxId: tr.TxID, Index: tr.Index},
+			WalletID:  walletID,
+			Type:      tr.Type,
+			Quantity:  tr.Quantity,
+		}
+	}
+	return changes
+}
+
+// deletionChanges returns the changes that record the deletion of the passed tokens, one for each of their wallets.
+// The tokens that do not exist or are already deleted have no changes.
+func deletionChanges(tx *sql.Tx, tables *tokenTables, ci TokenInterpreter, deletedBy string, ids ...*token.ID) ([]driver.TokenChange, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	cond := ci.HasTokens(common.JoinCol(tables.Tokens, "tx_id"), common.JoinCol(tables.Tokens, "idx"), ids...)
+	offset := 1
+	where := cond.ToString(&offset)
+	query, err := NewSelect(
+		fmt.Sprintf("%s.tx_id, %s.idx, owner_wallet_id, token_type, quantity, %s.wallet_id", tables.Tokens, tables.Tokens, tables.Ownership),
+	).From(tables.Tokens, joinOnTokenID(tables.Tokens, tables.Ownership)).Where(fmt.Sprintf("%s AND is_deleted = false", where)).Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed building query")
+	}
+	logger.Debug(query, cond.Params())
+	rows, err := tx.Query(query, cond.Params()...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed querying the tokens to delete")
+	}
+	defer Close(rows)
+
+	type deleted struct {
+		typ      token.Type
+		quantity string
+		wallets  []string
+	}
+	tokens := map[token.ID]*deleted{}
+	for rows.Next() {
+		var id token.ID
+		var typ token.Type
+		var quantity string
+		var ownerWalletID, walletID *string
+		if err := rows.Scan(&id.TxId, &id.Index, &ownerWalletID, &typ, &quantity, &walletID); err != nil {
+			return nil, errors.Wrapf(err, "failed scanning the tokens to delete")
+		}
+		d, ok := tokens[id]
+		if !ok {
+			d = &deleted{typ: typ, quantity: quantity}
+			tokens[id] = d
+		}
+		for _, w := range []*string{ownerWalletID, walletID} {
+			if w != nil {
+				d.wallets = append(d.wallets, *w)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed querying the tokens to delete")
+	}
+
+	var changes []driver.TokenChange
+	for _, id := range ids {
+		d, ok := tokens[*id]
+		if !ok {
+			continue
+		}
+		delete(tokens, *id)
+		for _, walletID := range walletsOf(d.wallets) {
+			changes = append(changes, driver.TokenChange{
+				Operation: driver.TokenDeleted,
+				TokenID:   *id,
+				WalletID:  walletID,
+				Type:      d.typ,
+				Quantity:  d.quantity,
+				SpentBy:   deletedBy,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// walletsOf returns the distinct non-empty wallet IDs, in order of appearance, or a single empty wallet ID if there are none
+func walletsOf(walletIDs []string) []string {
+	var wallets []string
+	for _, w := range walletIDs {
+		if len(w) > 0 && !slices.Contains(wallets, w) {
+			wallets = append(wallets, w)
+		}
+	}
+	if len(wallets) == 0 {
+		return []string{""}
+	}
+	return wallets
+}
+
+// TokenNotifier delivers the notifications of the token tables and reads the token change log.
+// It fans out the notifications to all the subscribers, sharing a single subscription to the underlying notifier.
+type TokenNotifier struct {
+	notifier driver2.Notifier
+	readDB   *sql.DB
+	writeDB  *sql.DB
+	tables   tokenTables
+	cursors  changeCursors
+
+	subscribeMutex sync.Mutex
+	subscribed     bool
+	mutex          sync.RWMutex
+	callbacks      []driver2.TriggerCallback
+}
+
+func NewTokenNotifier(readDB, writeDB *sql.DB, opts NewDBOpts, notifier driver2.Notifier) (*TokenNotifier, error) {
+	tables, err := GetTableNames(opts.TablePrefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get table names")
+	}
+	t := tokenTables{Changes: tables.TokenChanges, ChangesPruned: tables.TokenChangesPruned}
+	return &TokenNotifier{
+		notifier: notifier,
+		readDB:   readDB,
+		writeDB:  writeDB,
+		tables:   t,
+		cursors:  newChangeCursors(opts.Driver, t),
+	}, nil
+}
+
+// Subscribe registers the passed callback.
+// The notifier subscribes to the underlying notifier with the first callback, and again with the next one if that fails.
+func (n *TokenNotifier) Subscribe(callback driver2.TriggerCallback) error {
+	n.subscribeMutex.Lock()
+	defer n.subscribeMutex.Unlock()
+	if !n.subscribed {
+		if err := n.notifier.Subscribe(n.dispatch); err != nil {
+			return errors.Wrapf(err, "failed subscribing to the token notifications")
+		}
+		n.subscribed = true
+	}
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.callbacks = append(n.callbacks, callback)
+	return nil
+}
+
+// UnsubscribeAll removes the registered callbacks and the subscription to the underlying notifier
+func (n *TokenNotifier) UnsubscribeAll() error {
+	n.subscribeMutex.Lock()
+	defer n.subscribeMutex.Unlock()
+	n.mutex.Lock()
+	n.callbacks = nil
+	n.mutex.Unlock()
+	if !n.subscribed {
+		return nil
+	}
+	if err := n.notifier.UnsubscribeAll(); err != nil {
+		return errors.Wrapf(err, "failed unsubscribing from the token notifications")
+	}
+	n.subscribed = false
+	return nil
+}
+
+func (n *TokenNotifier) dispatch(operation driver2.Operation, payload map[driver2.ColumnKey]string) {
+	n.mutex.RLock()
+	defer n.mutex.RUnlock()
+	for _, callback := range n.callbacks {
+		callback(operation, payload)
+	}
+}
+
+// TokenChanges returns, in order, at most limit changes recorded after the passed cursor.
+// It returns driver.ErrTokenChangesPruned if changes after the cursor have been pruned.
+func (n *TokenNotifier) TokenChanges(after uint64, limit int) ([]driver.TokenChange, error) {
+	return tokenChanges(n.readDB, n.tables, n.cursors, after, limit)
+}
+
+// PruneTokenChanges removes the final changes recorded before the passed time
+func (n *TokenNotifier) PruneTokenChanges(before time.Time) (err error) {
+	tx, err := n.writeDB.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "failed starting a db transaction")
+	}
+	defer func() {
+		if err != nil {
+			if err1 := tx.Rollback(); err1 != nil {
+				logger.Errorf("error rolling back: %s", err1.Error())
+			}
+		}
+	}()
+
+	where := "recorded_at < $1"
+	if final := n.cursors.Final(); len(final) > 0 {
+		where = fmt.Sprintf("%s AND %s", where, final)
+	}
+	query := fmt.Sprintf("SELECT MAX(seq) FROM %s WHERE %s", n.tables.Changes, where)
+	logger.Debug(query, before)
+	var last *int64
+	if err = tx.QueryRow(query, before.UTC()).Scan(&last); err != nil {
+		return errors.Wrapf(err, "failed querying the token changes to prune")
+	}
+	if last == nil {
+		return tx.Commit()
+	}
+	for _, query := range []string{
+		fmt.Sprintf("DELETE FROM %s WHERE seq <= $1", n.tables.Changes),
+		fmt.Sprintf("UPDATE %s SET seq = $1 WHERE id = 0 AND seq < $1", n.tables.ChangesPruned),
+	} {
+		logger.Debug(query, *last)
+		if _, err = tx.Exec(query, *last); err != nil {
+			return errors.Wrapf(err, "failed pruning the token changes up to [%d]", *last)
+		}
+	}
+	return tx.Commit()
+}
+
+// tokenChanges reads the changes and the cursor of the last pruned change with a single statement, hence from the same snapshot
+func tokenChanges(db *sql.DB, tables tokenTables, cursors changeCursors, after uint64, limit int) ([]driver.TokenChange, error) {
+	on := "c.seq > $1"
+	if final := cursors.Final(); len(final) > 0 {
+		on = fmt.Sprintf("%s AND c.%s", on, final)
+	}
+	query := fmt.Sprintf("SELECT p.seq, c.seq, c.operation, c.tx_id, c.idx, c.wallet_id, c.token_type, c.quantity, c.spent_by "+
+		"FROM %s AS p LEFT JOIN %s AS c ON %s WHERE p.id = 0 ORDER BY c.seq LIMIT %d", tables.ChangesPruned, tables.Changes, on, limit)
+	logger.Debug(query, after)
+	rows, err := db.Query(query, after)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed querying the token changes")
+	}
+	defer Close(rows)
+
+	var changes []driver.TokenChange
+	var pruned uint64
+	for rows.Next() {
+		var seq *uint64
+		var operation *driver.TokenChangeOperation
+		var txID, walletID, tokenType, quantity, spentBy *string
+		var idx *uint64
+		if err := rows.Scan(&pruned, &seq, &operation, &txID, &idx, &walletID, &tokenType, &quantity, &spentBy); err != nil {
+			return nil, errors.Wrapf(err, "failed scanning the token changes")
+		}
+		if seq == nil {
+			continue
+		}
+		changes = append(changes, driver.TokenChange{
+			Cursor:    *seq,
+			Operation: *operation,
+			TokenID:   token.ID{TxId: *txID, Index: *idx},
+			WalletID:  *walletID,
+			Type:      token.Type(*tokenType),
+			Quantity:  *quantity,
+			SpentBy:   *spentBy,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed querying the token changes")
+	}
+	if after < pruned {
+		return nil, errors.Wrapf(driver.ErrTokenChangesPruned, "the changes up to [%d] have been pruned, cannot resume from [%d]", pruned, after)
+	}
+	return changes, nil
+}
@@ -55,6 +55,9 @@ func NewTokenLockDB(readDB, writeDB *sql.DB, opts NewDBOpts) (*TokenLockDB, erro
 		},
 	)
 	if opts.CreateSchema {
+		if err = migrate(writeDB, opts, tokenLocksFamily); err != nil {
+			return nil, err
+		}
 		if err = common.InitSchema(writeDB, []string{tokenLockDB.GetSchema()}...); err != nil {
 			return nil, err
 		}
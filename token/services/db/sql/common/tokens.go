@@ -12,8 +12,10 @@ import (
 	"encoding/base64"
 	errors2 "errors"
 	"fmt"
+	"math/big"
 	"runtime/debug"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -33,6 +35,8 @@ type tokenTables struct {
 	Ownership      string
 	PublicParams   string
 	Certifications string
+	Changes        string
+	ChangesPruned  string
 }
 
 func NewTokenDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci TokenInterpreter) (driver.TokenDB, error) {
@@ -46,8 +50,14 @@ func NewTokenDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci TokenInterpreter) (d
 		Ownership:      tables.Ownership,
 		PublicParams:   tables.PublicParams,
 		Certifications: tables.Certifications,
+		Changes:        tables.TokenChanges,
+		ChangesPruned:  tables.TokenChangesPruned,
 	}, ci)
+	tokenDB.cursors = newChangeCursors(opts.Driver, tokenDB.table)
 	if opts.CreateSchema {
+		if err = migrate(writeDB, opts, tokensFamily); err != nil {
+			return nil, err
+		}
 		if err = common.InitSchema(writeDB, tokenDB.GetSchema()); err != nil {
 			return nil, err
 		}
@@ -60,6 +70,7 @@ type TokenDB struct {
 	writeDB *sql.DB
 	table   tokenTables
 	ci      TokenInterpreter
+	cursors changeCursors
 
 	sttMutex              sync.RWMutex
 	supportedTokenFormats []token.Format
@@ -71,6 +82,7 @@ func newTokenDB(readDB, writeDB *sql.DB, tables tokenTables, ci TokenInterpreter
 		writeDB: writeDB,
 		table:   tables,
 		ci:      ci,
+		cursors: &serialCursors{tables: tables},
 	}
 }
 
@@ -92,11 +104,32 @@ func (db *TokenDB) StoreToken(tr driver.TokenRecord, owners []string) (err error
 }
 
 // DeleteTokens deletes multiple tokens at the same time (when spent, invalid or expired)
-func (db *TokenDB) DeleteTokens(deletedBy string, ids ...*token.ID) error {
+func (db *TokenDB) DeleteTokens(deletedBy string, ids ...*token.ID) (err error) {
 	logger.Debugf("delete tokens [%s][%v]", deletedBy, ids)
 	if len(ids) == 0 {
 		return nil
 	}
+	tx, err := db.writeDB.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "failed starting a db transaction")
+	}
+	defer func() {
+		if err != nil {
+			if err1 := tx.Rollback(); err1 != nil {
+				logger.Errorf("error rolling back: %s", err1.Error())
+			}
+		}
+	}()
+
+	changes, err := deletionChanges(tx, &db.table, db.ci, deletedBy, ids...)
+	if err != nil {
+		return err
+	}
+	var ordinal int
+	if err = recordTokenChanges(tx, &db.table, db.cursors, &ordinal, changes); err != nil {
+		return err
+	}
+
 	cond := db.ci.HasTokens("tx_id", "idx", ids...)
 	args := append([]any{true, deletedBy, time.Now().UTC()}, cond.Params()...)
 	offset := 4
@@ -108,10 +141,10 @@ func (db *TokenDB) DeleteTokens(deletedBy string, ids ...*token.ID) error {
 	}
 	// query := fmt.Sprintf("UPDATE %s SET is_deleted = true, spent_by = $1, spent_at = $2 WHERE %s", db.table.Tokens, where)
 	logger.Debug(query, args)
-	if _, err := db.writeDB.Exec(query, args...); err != nil {
+	if _, err = tx.Exec(query, args...); err != nil {
 		return errors.Wrapf(err, "error setting tokens to deleted [%v]", ids)
 	}
-	return nil
+	return tx.Commit()
 }
 
 // IsMine just checks if the token is in the local storage and not deleted
@@ -173,6 +206,7 @@ func (db *TokenDB) SpendableTokensIteratorBy(ctx context.Context, walletID strin
 		TokenType:          typ,
 		Spendable:          driver.SpendableOnly,
 		LedgerTokenFormats: db.getSupportedTokenFormats(),
+		ExcludeFrozen:      true,
 	}, ""))
 
 	query, err := NewSelect("tx_id, idx, token_type, quantity, owner_wallet_id").From(db.table.Tokens).Where(where).Compile()
@@ -241,35 +275,52 @@ func (db *TokenDB) UnsupportedTokensIteratorBy(ctx context.Context, walletID str
 	return &LedgerTokensIterator{txs: rows}, nil
 }
 
-// Balance returns the sun of the amounts, with 64 bits of precision, of the tokens with type and EID equal to those passed as arguments.
-func (db *TokenDB) Balance(walletID string, typ token.Type) (uint64, error) {
+// Balance returns the sum of the amounts, with arbitrary precision, of the tokens with type and EID equal to those passed as arguments.
+// Frozen tokens are not counted.
+func (db *TokenDB) Balance(walletID string, typ token.Type) (*big.Int, error) {
 	return db.balance(driver.QueryTokenDetailsParams{
-		WalletID:  walletID,
-		TokenType: typ,
+		WalletID:      walletID,
+		TokenType:     typ,
+		ExcludeFrozen: true,
 	})
 }
 
-func (db *TokenDB) balance(opts driver.QueryTokenDetailsParams) (uint64, error) {
+// balance sums the amounts in go, as the amounts are stored as decimal strings to support the full 64-bit range
+// and the sum can exceed it.
+// This loads the amount of every matching token, instead of a single row. We accept it because the NUMERIC type
+// of postgres could sum them in the database, but sqlite has no arbitrary-precision type, and a single code path
+// keeps the two backends consistent. The wallets with many small tokens pay for it.
+func (db *TokenDB) balance(opts driver.QueryTokenDetailsParams) (*big.Int, error) {
 	where, args := common.Where(db.ci.HasTokenDetails(opts, db.table.Tokens))
 	join := joinOnTokenID(db.table.Tokens, db.table.Ownership)
-	query, err := NewSelect("SUM(amount)").From(db.table.Tokens, join).Where(where).Compile()
+	query, err := NewSelect("amount").From(db.table.Tokens, join).Where(where).Compile()
 	if err != nil {
-		return 0, errors.Wrapf(err, "failed to compile query")
+		return nil, errors.Wrapf(err, "failed to compile query")
 	}
 
 	logger.Debug(query, args)
-	row := db.readDB.QueryRow(query, args...)
-	var sum *uint64
-	if err := row.Scan(&sum); err != nil {
-		if errors.HasCause(err, sql.ErrNoRows) {
-			return 0, nil
+	rows, err := db.readDB.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error querying db")
+	}
+	defer Close(rows)
+
+	sum := big.NewInt(0)
+	for rows.Next() {
+		var amount string
+		if err := rows.Scan(&amount); err != nil {
+			return nil, errors.Wrapf(err, "error scanning amount")
 		}
-		return 0, errors.Wrapf(err, "error querying db")
+		v, err := parseAmount(amount)
+		if err != nil {
+			return nil, err
+		}
+		sum.Add(sum, v)
 	}
-	if sum == nil {
-		return 0, nil
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "error querying db")
 	}
-	return *sum, nil
+	return sum, nil
 }
 
 // ListUnspentTokensBy returns the list of unspent tokens, filtered by owner and token type
@@ -318,6 +369,38 @@ func (db *TokenDB) ListUnspentTokens() (*token.UnspentTokens, error) {
 	}
 }
 
+// UnspentTokenIDsByEnrollmentID returns the identifiers of the unspent tokens whose owner has the passed enrollment ID.
+// The enrollment ID of the owner is recorded when the token is stored, if known.
+func (db *TokenDB) UnspentTokenIDsByEnrollmentID(ctx context.Context, enrollmentID string) ([]*token.ID, error) {
+	span := trace.SpanFromContext(ctx)
+	where, args := common.Where(db.ci.And(
+		db.ci.Cmp("owner_enrollment_id", "=", enrollmentID),
+		common.ConstCondition("is_deleted = false"),
+	))
+	query, err := NewSelect("tx_id, idx").From(db.table.Tokens).Where(where).Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, args)
+	span.AddEvent("start_query", tracing.WithAttributes(tracing.String(QueryLabel, query)))
+	rows, err := db.readDB.Query(query, args...)
+	span.AddEvent("end_query")
+	if err != nil {
+		return nil, errors.Wrapf(err, "error querying db")
+	}
+	defer Close(rows)
+
+	var ids []*token.ID
+	for rows.Next() {
+		id := &token.ID{}
+		if err := rows.Scan(&id.TxId, &id.Index); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 // ListAuditTokens returns the audited tokens associated to the passed ids
 func (db *TokenDB) ListAuditTokens(ids ...*token.ID) ([]*token.Token, error) {
 	if len(ids) == 0 {
@@ -926,7 +1009,7 @@ func (db *TokenDB) GetSchema() string {
 		CREATE TABLE IF NOT EXISTS %s (
 			tx_id TEXT NOT NULL,
 			idx INT NOT NULL,
-			amount BIGINT NOT NULL,
+			amount TEXT NOT NULL,
 			token_type TEXT NOT NULL,
 			quantity TEXT NOT NULL,
 			issuer_raw BYTEA,
@@ -934,6 +1017,7 @@ func (db *TokenDB) GetSchema() string {
 			owner_type TEXT NOT NULL,
 			owner_identity BYTEA NOT NULL,
 			owner_wallet_id TEXT, 
+			owner_enrollment_id TEXT NOT NULL DEFAULT '',
 			ledger BYTEA NOT NULL,
             ledger_type TEXT DEFAULT '',
 			ledger_metadata BYTEA NOT NULL,
@@ -945,6 +1029,8 @@ func (db *TokenDB) GetSchema() string {
 			auditor BOOL NOT NULL DEFAULT false,
 			issuer BOOL NOT NULL DEFAULT false,
 			spendable BOOL NOT NULL DEFAULT true,
+			frozen BOOL NOT NULL DEFAULT false,
+			pp_hash BYTEA,
 			PRIMARY KEY (tx_id, idx)
 		);
 		CREATE INDEX IF NOT EXISTS idx_spent_%s ON %s ( is_deleted, owner );
@@ -983,7 +1069,7 @@ func (db *TokenDB) GetSchema() string {
 		db.table.Ownership, db.table.Tokens,
 		db.table.PublicParams, db.table.PublicParams, db.table.PublicParams,
 		db.table.Certifications, db.table.Tokens,
-	)
+	) + tokenChangesSchema(db.table)
 }
 
 func (db *TokenDB) Close() {
@@ -998,7 +1084,7 @@ func (db *TokenDB) NewTokenDBTransaction() (driver.TokenDBTransaction, error) {
 	if err != nil {
 		return nil, errors.Errorf("failed starting a db transaction")
 	}
-	return &TokenTransaction{ci: db.ci, table: &db.table, tx: tx}, nil
+	return &TokenTransaction{ci: db.ci, table: &db.table, cursors: db.cursors, tx: tx}, nil
 }
 
 func (db *TokenDB) SetSupportedTokenFormats(formats []token.Format) error {
@@ -1064,9 +1150,12 @@ func (db *TokenDB) unspendableTokenFormats(ctx context.Context, walletID string,
 }
 
 type TokenTransaction struct {
-	table *tokenTables
-	ci    TokenInterpreter
-	tx    *sql.Tx
+	table   *tokenTables
+	ci      TokenInterpreter
+	cursors changeCursors
+	tx      *sql.Tx
+	// changes counts the token changes recorded by the transaction
+	changes int
 }
 
 func (t *TokenTransaction) GetToken(ctx context.Context, tokenID token.ID, includeDeleted bool) (*token.Token, []string, error) {
@@ -1131,6 +1220,16 @@ func (t *TokenTransaction) Delete(ctx context.Context, tokenID token.ID, deleted
 	span := trace.SpanFromContext(ctx)
 	// logger.Debugf("delete token [%s:%d:%s]", txID, index, deletedBy)
 	// We don't delete audit tokens, and we keep the 'ownership' relation.
+	changes, err := deletionChanges(t.tx, t.table, t.ci, deletedBy, &tokenID)
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := recordTokenChanges(t.tx, t.table, t.cursors, &t.changes, changes); err != nil {
+		span.RecordError(err)
+		return err
+	}
+
 	now := time.Now().UTC()
 	query, err := NewUpdate(t.table.Tokens).Set("is_deleted, spent_by, spent_at").Where("tx_id, idx").Compile()
 	if err != nil {
@@ -1157,7 +1256,7 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 	// Store token
 	now := time.Now().UTC()
 	query, err := NewInsertInto(t.table.Tokens).Rows(
-		"tx_id, idx, issuer_raw, owner_raw, owner_type, owner_identity, owner_wallet_id, ledger, ledger_type, ledger_metadata, token_type, quantity, amount, stored_at, owner, auditor, issuer").Compile()
+		"tx_id, idx, issuer_raw, owner_raw, owner_type, owner_identity, owner_wallet_id, owner_enrollment_id, ledger, ledger_type, ledger_metadata, token_type, quantity, amount, stored_at, owner, auditor, issuer, pp_hash").Compile()
 	if err != nil {
 		return errors.Wrapf(err, "failed building insert")
 	}
@@ -1169,6 +1268,7 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 		tr.OwnerType,
 		len(tr.OwnerIdentity),
 		tr.OwnerWalletID,
+		tr.OwnerEnrollmentID,
 		len(tr.Ledger),
 		tr.LedgerFormat,
 		len(tr.LedgerMetadata),
@@ -1178,7 +1278,8 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 		now,
 		tr.Owner,
 		tr.Auditor,
-		tr.Issuer)
+		tr.Issuer,
+		len(tr.PublicParamsHash))
 	span.AddEvent("query", tracing.WithAttributes(tracing.String(QueryLabel, query)))
 	if _, err := t.tx.Exec(query,
 		tr.TxID,
@@ -1188,16 +1289,18 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 		tr.OwnerType,
 		tr.OwnerIdentity,
 		tr.OwnerWalletID,
+		tr.OwnerEnrollmentID,
 		tr.Ledger,
 		tr.LedgerFormat,
 		tr.LedgerMetadata,
 		tr.Type,
 		tr.Quantity,
-		tr.Amount,
+		strconv.FormatUint(tr.Amount, 10),
 		now,
 		tr.Owner,
 		tr.Auditor,
-		tr.Issuer); err != nil {
+		tr.Issuer,
+		tr.PublicParamsHash); err != nil {
 		logger.Errorf("error storing token [%s] in table [%s]: [%s][%s]", tr.TxID, t.table.Tokens, err, string(debug.Stack()))
 		return errors.Wrapf(err, "error storing token [%s] in table [%s]", tr.TxID, t.table.Tokens)
 	}
@@ -1216,6 +1319,10 @@ func (t *TokenTransaction) StoreToken(ctx context.Context, tr driver.TokenRecord
 		}
 	}
 
+	// Record the insertion in the change log
+	if err := recordTokenChanges(t.tx, t.table, t.cursors, &t.changes, insertionChanges(tr, owners)); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -1233,6 +1340,19 @@ func (t *TokenTransaction) SetSpendable(ctx context.Context, tokenID token.ID, s
 
 }
 
+func (t *TokenTransaction) SetFrozen(ctx context.Context, tokenID token.ID, frozen bool) error {
+	span := trace.SpanFromContext(ctx)
+	query := fmt.Sprintf("UPDATE %s SET frozen = $1 WHERE tx_id = $2 AND idx = $3;", t.table.Tokens)
+	logger.Debug(query, frozen, tokenID.TxId, tokenID.Index)
+	span.AddEvent("query", tracing.WithAttributes(tracing.String(QueryLabel, query)))
+	if _, err := t.tx.Exec(query, frozen, tokenID.TxId, tokenID.Index); err != nil {
+		span.RecordError(err)
+		return errors.Wrapf(err, "error setting frozen flag to [%v] for [%s]", frozen, tokenID)
+	}
+	span.AddEvent("end_query")
+	return nil
+}
+
 func (t *TokenTransaction) SetSpendableBySupportedTokenFormats(ctx context.Context, formats []token.Format) error {
 	span := trace.SpanFromContext(ctx)
 
@@ -7,13 +7,21 @@ SPDX-License-Identifier: Apache-2.0
 package common_test
 
 import (
+	sql2 "database/sql"
 	"fmt"
+	"math"
+	"math/big"
 	"path"
+	"strings"
 	"testing"
 
+	driver3 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/sql"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/sqlite"
+	"github.com/pkg/errors"
+	"github.com/test-go/testify/assert"
 )
 
 //
@@ -106,6 +114,55 @@ func TestTokensSqliteMemory(t *testing.T) {
 	// }
 }
 
+func TestTokensSqliteAmountMigration(t *testing.T) {
+	dataSource := fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", path.Join(t.TempDir(), "db.sqlite"))
+	db, err := sql.OpenSqlite(common.Opts{DataSource: dataSource, TablePrefix: "migration", MaxOpenConns: 10}, func(readDB, writeDB *sql2.DB, opts common.NewDBOpts) (driver.TokenDB, error) {
+		// create the tables as previous versions did, with a BIGINT amount, and store a token
+		legacy, err := sqlite.NewTokenDB(readDB, writeDB, common.NewDBOpts{TablePrefix: opts.TablePrefix})
+		if err != nil {
+			return nil, err
+		}
+		schema := strings.Replace(legacy.(*common.TokenDB).GetSchema(), "amount TEXT NOT NULL", "amount BIGINT NOT NULL", 1)
+		if _, err := writeDB.Exec(schema); err != nil {
+			return nil, err
+		}
+		if err := legacy.(*common.TokenDB).StoreToken(tokenRecord(0, 42), []string{"wallet"}); err != nil {
+			return nil, err
+		}
+		return sqlite.NewTokenDB(readDB, writeDB, opts)
+	})
+	assert.NoError(t, err)
+	defer db.(*common.TokenDB).Close()
+
+	balance, err := db.Balance("wallet", "TST")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), balance)
+
+	assert.NoError(t, db.(*common.TokenDB).StoreToken(tokenRecord(1, math.MaxUint64), []string{"wallet"}))
+	balance, err = db.Balance("wallet", "TST")
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Add(new(big.Int).SetUint64(math.MaxUint64), big.NewInt(42)), balance)
+}
+
+func tokenRecord(index uint64, amount uint64) driver.TokenRecord {
+	return driver.TokenRecord{
+		TxID:              "tx1",
+		Index:             index,
+		OwnerRaw:          []byte{1, 2, 3},
+		OwnerType:         "idemix",
+		OwnerIdentity:     []byte{},
+		OwnerWalletID:     "wallet",
+		OwnerEnrollmentID: "alice",
+		Ledger:            []byte("ledger"),
+		LedgerFormat:      "CLEAR",
+		LedgerMetadata:    []byte{},
+		Quantity:          fmt.Sprintf("0x%x", amount),
+		Type:              "TST",
+		Amount:            amount,
+		Owner:             true,
+	}
+}
+
 func TestTokensPostgres(t *testing.T) {
 	terminate, pgConnStr := common.StartPostgresContainer(t)
 	defer terminate()
@@ -135,3 +192,48 @@ func TestTokensPostgres(t *testing.T) {
 	//	})
 	// }
 }
+
+type failingNotifier struct {
+	failures      int
+	subscriptions int
+	callbacks     []driver3.TriggerCallback
+}
+
+func (n *failingNotifier) Subscribe(callback driver3.TriggerCallback) error {
+	if n.failures > 0 {
+		n.failures--
+		return errors.New("connection refused")
+	}
+	n.subscriptions++
+	n.callbacks = append(n.callbacks, callback)
+	return nil
+}
+
+func (n *failingNotifier) UnsubscribeAll() error {
+	n.callbacks = nil
+	return nil
+}
+
+func TestTokenNotifierSubscribe(t *testing.T) {
+	underlying := &failingNotifier{failures: 1}
+	notifier, err := common.NewTokenNotifier(nil, nil, common.NewDBOpts{TablePrefix: "test"}, underlying)
+	assert.NoError(t, err)
+
+	// a failed subscription is retried by the next one
+	var calls int
+	callback := func(driver3.Operation, map[driver3.ColumnKey]string) { calls++ }
+	assert.Error(t, notifier.Subscribe(callback))
+	assert.NoError(t, notifier.Subscribe(callback))
+	assert.NoError(t, notifier.Subscribe(callback))
+	assert.Equal(t, 1, underlying.subscriptions)
+	underlying.callbacks[0](driver3.Insert, nil)
+	assert.Equal(t, 2, calls)
+
+	// unsubscribing removes the underlying subscription, and the next callback subscribes again
+	assert.NoError(t, notifier.UnsubscribeAll())
+	assert.Empty(t, underlying.callbacks)
+	assert.NoError(t, notifier.Subscribe(callback))
+	assert.Equal(t, 2, underlying.subscriptions)
+	underlying.callbacks[0](driver3.Insert, nil)
+	assert.Equal(t, 3, calls)
+}
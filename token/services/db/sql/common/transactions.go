@@ -31,6 +31,10 @@ type transactionTables struct {
 	Requests              string
 	Validations           string
 	TransactionEndorseAck string
+	PendingTransactions   string
+	DetachedSignatures    string
+	PaymentGroups         string
+	PaymentGroupTxs       string
 }
 
 type TransactionDB struct {
@@ -54,6 +58,8 @@ func NewAuditTransactionDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci TokenInte
 		DataSource:   opts.DataSource,
 		TablePrefix:  opts.TablePrefix + "_aud",
 		CreateSchema: opts.CreateSchema,
+		Driver:       opts.Driver,
+		Migrations:   opts.Migrations,
 	}, ci)
 }
 
@@ -68,8 +74,15 @@ func NewTransactionDB(readDB, writeDB *sql.DB, opts NewDBOpts, ci TokenInterpret
 		Requests:              tables.Requests,
 		Validations:           tables.Validations,
 		TransactionEndorseAck: tables.TransactionEndorseAck,
+		PendingTransactions:   tables.PendingTransactions,
+		DetachedSignatures:    tables.DetachedSignatures,
+		PaymentGroups:         tables.PaymentGroups,
+		PaymentGroupTxs:       tables.PaymentGroupTxs,
 	}, ci)
 	if opts.CreateSchema {
+		if err = migrate(writeDB, opts, transactionsFamily); err != nil {
+			return nil, err
+		}
 		if err = common.InitSchema(writeDB, []string{transactionsDB.GetSchema()}...); err != nil {
 			return nil, err
 		}
@@ -115,7 +128,7 @@ func (db *TransactionDB) QueryMovements(params driver.QueryMovementsParams) (res
 	// Loop through rows, using Scan to assign column data to struct fields.
 	for rows.Next() {
 		var r driver.MovementRecord
-		var amount int64
+		var amount string
 		var status int
 		err = rows.Scan(
 			&r.TxID,
@@ -127,7 +140,9 @@ func (db *TransactionDB) QueryMovements(params driver.QueryMovementsParams) (res
 		if err != nil {
 			return res, err
 		}
-		r.Amount = big.NewInt(amount)
+		if r.Amount, err = parseAmount(amount); err != nil {
+			return res, err
+		}
 		r.Status = driver.TxStatus(status)
 		logger.Debugf("movement [%s:%s:%d]", r.TxID, r.Status, r.Amount)
 
@@ -159,6 +174,40 @@ func (db *TransactionDB) QueryTransactions(params driver.QueryTransactionsParams
 	return &TransactionIterator{txs: rows}, nil
 }
 
+func (db *TransactionDB) SumTransactionAmounts(params driver.QueryTransactionsParams) (*big.Int, error) {
+	conditions, args := common.Where(db.ci.HasTransactionParams(params, db.table.Transactions))
+	query, err := NewSelect("amount").
+		From(db.table.Transactions, joinOnTxID(db.table.Transactions, db.table.Requests)).Where(conditions).Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, args)
+	rows, err := db.readDB.Query(query, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error querying db")
+	}
+	defer Close(rows)
+
+	// the amounts are summed here, and not with SQL, because they can exceed the range of the numeric types of the database,
+	// at the cost of loading every matching row, see TokenDB.balance
+	sum := big.NewInt(0)
+	for rows.Next() {
+		var amount string
+		if err := rows.Scan(&amount); err != nil {
+			return nil, errors.Wrapf(err, "error scanning amount")
+		}
+		v, err := parseAmount(amount)
+		if err != nil {
+			return nil, err
+		}
+		sum.Add(sum, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "error querying db")
+	}
+	return sum, nil
+}
+
 func (db *TransactionDB) GetStatus(txID string) (driver.TxStatus, string, error) {
 	var status driver.TxStatus
 	var statusMessage string
@@ -264,6 +313,248 @@ func (db *TransactionDB) GetTransactionEndorsementAcks(txID string) (map[string]
 	return acks, nil
 }
 
+func (db *TransactionDB) AddPendingTransaction(txID string, tx []byte) error {
+	logger.Debugf("adding pending transaction [%s]", txID)
+
+	query, err := NewInsertInto(db.table.PendingTransactions).Rows("tx_id, tx, stored_at").Compile()
+	if err != nil {
+		return errors.Wrapf(err, "error compiling query")
+	}
+	now := time.Now().UTC()
+	logger.Debug(query, txID, fmt.Sprintf("(%d bytes)", len(tx)), now)
+	if _, err = db.writeDB.Exec(query, txID, tx, now); err != nil {
+		return ttxDBError(err)
+	}
+	return nil
+}
+
+func (db *TransactionDB) GetPendingTransaction(txID string) ([]byte, error) {
+	query, err := NewSelect("tx").From(db.table.PendingTransactions).Where("tx_id=$1").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, txID)
+
+	var tx []byte
+	if err := db.readDB.QueryRow(query, txID).Scan(&tx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error querying db")
+	}
+	return tx, nil
+}
+
+func (db *TransactionDB) GetPendingTransactionIDs() ([]string, error) {
+	query, err := NewSelect("tx_id").From(db.table.PendingTransactions).OrderBy("stored_at ASC").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query)
+
+	rows, err := db.readDB.Query(query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer Close(rows)
+	var txIDs []string
+	for rows.Next() {
+		var txID string
+		if err := rows.Scan(&txID); err != nil {
+			return nil, errors.Wrapf(err, "error querying db")
+		}
+		txIDs = append(txIDs, txID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return txIDs, nil
+}
+
+func (db *TransactionDB) ReplacePendingTransaction(txID string, raw []byte) error {
+	logger.Debugf("replacing pending transaction [%s]", txID)
+
+	tx, err := db.writeDB.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "failed starting a db transaction")
+	}
+	query, err := NewDeleteFrom(db.table.DetachedSignatures).Where("tx_id = $1").Compile()
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrapf(err, "error compiling query")
+	}
+	logger.Debug(query, txID)
+	if _, err := tx.Exec(query, txID); err != nil {
+		_ = tx.Rollback()
+		return errors.Wrapf(err, "failed deleting detached signatures of [%s]", txID)
+	}
+	query, err = NewUpdate(db.table.PendingTransactions).Set("tx, stored_at").Where("tx_id").Compile()
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrapf(err, "error compiling query")
+	}
+	now := time.Now().UTC()
+	logger.Debug(query, fmt.Sprintf("(%d bytes)", len(raw)), now, txID)
+	res, err := tx.Exec(query, raw, now, txID)
+	if err != nil {
+		_ = tx.Rollback()
+		return errors.Wrapf(err, "failed replacing pending transaction [%s]", txID)
+	}
+	if n, err := res.RowsAffected(); err != nil || n != 1 {
+		_ = tx.Rollback()
+		return errors.Errorf("pending transaction [%s] not found", txID)
+	}
+	return tx.Commit()
+}
+
+func (db *TransactionDB) DeletePendingTransaction(txID string) error {
+	logger.Debugf("deleting pending transaction [%s]", txID)
+
+	tx, err := db.writeDB.Begin()
+	if err != nil {
+		return errors.Wrapf(err, "failed starting a db transaction")
+	}
+	for _, table := range []string{db.table.DetachedSignatures, db.table.PendingTransactions} {
+		query, err := NewDeleteFrom(table).Where("tx_id = $1").Compile()
+		if err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "error compiling query")
+		}
+		logger.Debug(query, txID)
+		if _, err := tx.Exec(query, txID); err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "failed deleting pending transaction [%s]", txID)
+		}
+	}
+	return tx.Commit()
+}
+
+func (db *TransactionDB) AddDetachedSignature(txID string, signer token.Identity, sigma []byte) error {
+	logger.Debugf("adding detached signature for pending transaction [%s]", txID)
+
+	query, err := NewInsertInto(db.table.DetachedSignatures).Rows("id, tx_id, signer, sigma, stored_at").Compile()
+	if err != nil {
+		return errors.Wrapf(err, "error compiling query")
+	}
+	now := time.Now().UTC()
+	logger.Debug(query, txID, fmt.Sprintf("(%d bytes)", len(signer)), fmt.Sprintf("(%d bytes)", len(sigma)), now)
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return errors.Wrapf(err, "error generating uuid")
+	}
+	if _, err = db.writeDB.Exec(query, id, txID, signer, sigma, now); err != nil {
+		return ttxDBError(err)
+	}
+	return nil
+}
+
+func (db *TransactionDB) GetDetachedSignatures(txID string) (map[string][]byte, error) {
+	query, err := NewSelect("signer, sigma").From(db.table.DetachedSignatures).Where("tx_id=$1").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed compiling query")
+	}
+	logger.Debug(query, txID)
+
+	rows, err := db.readDB.Query(query, txID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer Close(rows)
+	sigmas := make(map[string][]byte)
+	for rows.Next() {
+		var signer []byte
+		var sigma []byte
+		if err := rows.Scan(&signer, &sigma); err != nil {
+			return nil, errors.Wrapf(err, "error querying db")
+		}
+		sigmas[token.Identity(signer).UniqueID()] = sigma
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return sigmas, nil
+}
+
+func (db *TransactionDB) AddPaymentGroup(groupID string, parts int) error {
+	logger.Debugf("adding payment group [%s] of [%d] transactions", groupID, parts)
+
+	query, err := NewInsertInto(db.table.PaymentGroups).Rows("group_id, parts, status, status_message, stored_at").Compile()
+	if err != nil {
+		return errors.Wrapf(err, "error compiling query")
+	}
+	now := time.Now().UTC()
+	logger.Debug(query, groupID, parts, driver.Pending, now)
+	if _, err = db.writeDB.Exec(query, groupID, parts, driver.Pending, "", now); err != nil {
+		return ttxDBError(err)
+	}
+	return nil
+}
+
+func (db *TransactionDB) AddPaymentGroupTransaction(groupID string, txID string, index int) error {
+	logger.Debugf("adding transaction [%s] to payment group [%s] at [%d]", txID, groupID, index)
+
+	query, err := NewInsertInto(db.table.PaymentGroupTxs).Rows("group_id, idx, tx_id").Compile()
+	if err != nil {
+		return errors.Wrapf(err, "error compiling query")
+	}
+	logger.Debug(query, groupID, index, txID)
+	if _, err = db.writeDB.Exec(query, groupID, index, txID); err != nil {
+		return ttxDBError(err)
+	}
+	return nil
+}
+
+func (db *TransactionDB) SetPaymentGroupStatus(groupID string, status driver.TxStatus, message string) error {
+	query := fmt.Sprintf("UPDATE %s SET status = $1, status_message = $2 WHERE group_id = $3;", db.table.PaymentGroups)
+	logger.Debug(query, status, message, groupID)
+	res, err := db.writeDB.Exec(query, status, message, groupID)
+	if err != nil {
+		return errors.Wrapf(err, "error updating payment group [%s]", groupID)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.Errorf("payment group [%s] not found", groupID)
+	}
+	return nil
+}
+
+func (db *TransactionDB) GetPaymentGroup(groupID string) (*driver.PaymentGroupRecord, error) {
+	query, err := NewSelect("parts, status, status_message, stored_at").From(db.table.PaymentGroups).Where("group_id=$1").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, groupID)
+
+	r := &driver.PaymentGroupRecord{GroupID: groupID}
+	if err := db.readDB.QueryRow(query, groupID).Scan(&r.Parts, &r.Status, &r.StatusMessage, &r.Timestamp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "error querying db")
+	}
+
+	query, err = NewSelect("tx_id").From(db.table.PaymentGroupTxs).Where("group_id=$1").OrderBy("idx").Compile()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile query")
+	}
+	logger.Debug(query, groupID)
+	rows, err := db.readDB.Query(query, groupID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query")
+	}
+	defer Close(rows)
+	for rows.Next() {
+		var txID string
+		if err := rows.Scan(&txID); err != nil {
+			return nil, errors.Wrapf(err, "error querying db")
+		}
+		r.TxIDs = append(r.TxIDs, txID)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 func (db *TransactionDB) Close() error {
 	logger.Info("closing database")
 	if db.readDB != db.writeDB {
@@ -317,7 +608,7 @@ func (db *TransactionDB) GetSchema() string {
 			sender_eid TEXT NOT NULL,
 			recipient_eid TEXT NOT NULL,
 			token_type TEXT NOT NULL,
-			amount BIGINT NOT NULL,
+			amount TEXT NOT NULL,
 			stored_at TIMESTAMP NOT NULL
 		);
 		CREATE INDEX IF NOT EXISTS idx_tx_id_%s ON %s ( tx_id );
@@ -328,7 +619,7 @@ func (db *TransactionDB) GetSchema() string {
 			tx_id TEXT NOT NULL REFERENCES %s,
 			enrollment_id TEXT NOT NULL,
 			token_type TEXT NOT NULL,
-			amount BIGINT NOT NULL,
+			amount TEXT NOT NULL,
 			stored_at TIMESTAMP NOT NULL
 		);
 		CREATE INDEX IF NOT EXISTS idx_tx_id_%s ON %s ( tx_id );
@@ -349,12 +640,50 @@ func (db *TransactionDB) GetSchema() string {
 			stored_at TIMESTAMP NOT NULL
 		);
 		CREATE INDEX IF NOT EXISTS idx_tx_id_%s ON %s ( tx_id );
+
+		-- pending transactions
+		CREATE TABLE IF NOT EXISTS %s (
+			tx_id TEXT NOT NULL PRIMARY KEY,
+			tx BYTEA NOT NULL,
+			stored_at TIMESTAMP NOT NULL
+		);
+
+		-- detached signatures
+		CREATE TABLE IF NOT EXISTS %s (
+			id CHAR(36) NOT NULL PRIMARY KEY,
+			tx_id TEXT NOT NULL REFERENCES %s,
+			signer BYTEA NOT NULL,
+			sigma BYTEA NOT NULL,
+			stored_at TIMESTAMP NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_tx_id_%s ON %s ( tx_id );
+
+		-- payment groups
+		CREATE TABLE IF NOT EXISTS %s (
+			group_id TEXT NOT NULL PRIMARY KEY,
+			parts INT NOT NULL,
+			status INT NOT NULL,
+			status_message TEXT NOT NULL,
+			stored_at TIMESTAMP NOT NULL
+		);
+
+		-- payment group transactions
+		CREATE TABLE IF NOT EXISTS %s (
+			group_id TEXT NOT NULL REFERENCES %s,
+			idx INT NOT NULL,
+			tx_id TEXT NOT NULL,
+			PRIMARY KEY (group_id, idx)
+		);
 		`,
 		db.table.Requests,
 		db.table.Transactions, db.table.Requests, db.table.Transactions, db.table.Transactions,
 		db.table.Movements, db.table.Requests, db.table.Movements, db.table.Movements,
 		db.table.Validations, db.table.Requests,
 		db.table.TransactionEndorseAck, db.table.TransactionEndorseAck, db.table.TransactionEndorseAck,
+		db.table.PendingTransactions,
+		db.table.DetachedSignatures, db.table.PendingTransactions, db.table.DetachedSignatures, db.table.DetachedSignatures,
+		db.table.PaymentGroups,
+		db.table.PaymentGroupTxs, db.table.PaymentGroups,
 	)
 }
 
@@ -384,7 +713,7 @@ func (t *TransactionIterator) Next() (*driver.TransactionRecord, error) {
 		return nil, nil
 	}
 	var actionType int
-	var amount int64
+	var amount string
 	var status int
 	var metadata []byte
 	// tx_id, action_type, sender_eid, recipient_eid, token_type, amount, status, stored_at
@@ -399,16 +728,21 @@ func (t *TransactionIterator) Next() (*driver.TransactionRecord, error) {
 		&metadata,
 		&r.Timestamp,
 	)
+	if err != nil {
+		return &r, err
+	}
 	if err := unmarshal(metadata, &r.ApplicationMetadata); err != nil {
 		logger.Errorf("error unmarshaling application metadata: %v", metadata)
 		return &r, errors.New("error umarshaling application metadata")
 	}
 
 	r.ActionType = driver.ActionType(actionType)
-	r.Amount = big.NewInt(amount)
+	if r.Amount, err = parseAmount(amount); err != nil {
+		return &r, err
+	}
 	r.Status = driver.TxStatus(status)
 
-	return &r, err
+	return &r, nil
 }
 
 type ValidationRecordsIterator struct {
@@ -535,10 +869,10 @@ func (w *AtomicWrite) AddTransaction(r *driver.TransactionRecord) error {
 	if w.txn == nil {
 		return errors.New("no db transaction in progress")
 	}
-	if !r.Amount.IsInt64() {
-		return errors.New("the database driver does not support larger values than int64")
+	if r.Amount == nil {
+		return errors.New("transaction amount not set")
 	}
-	amount := r.Amount.Int64()
+	amount := r.Amount.String()
 	actionType := int(r.ActionType)
 	id, err := uuid.GenerateUUID()
 	if err != nil {
@@ -580,14 +914,14 @@ func (w *AtomicWrite) AddTokenRequest(txID string, tr []byte, applicationMetadat
 }
 
 func (w *AtomicWrite) AddMovement(r *driver.MovementRecord) error {
-	logger.Debugf("adding movement record [%s:%s:%s:%d:%s]", r.TxID, r.EnrollmentID, r.TokenType, r.Amount.Int64(), r.Status)
+	logger.Debugf("adding movement record [%s:%s:%s:%s:%s]", r.TxID, r.EnrollmentID, r.TokenType, r.Amount, r.Status)
 	if w.txn == nil {
 		return errors.New("no db transaction in progress")
 	}
-	if !r.Amount.IsInt64() {
-		return errors.New("the database driver does not support larger values than int64")
+	if r.Amount == nil {
+		return errors.New("movement amount not set")
 	}
-	amount := r.Amount.Int64()
+	amount := r.Amount.String()
 
 	id, err := uuid.GenerateUUID()
 	if err != nil {
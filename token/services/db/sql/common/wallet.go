@@ -43,6 +43,9 @@ func NewWalletDB(readDB, writeDB *sql.DB, opts NewDBOpts) (driver.WalletDB, erro
 
 	walletDB := newWalletDB(readDB, writeDB, walletTables{Wallets: tables.Wallets})
 	if opts.CreateSchema {
+		if err = migrate(writeDB, opts, walletFamily); err != nil {
+			return nil, err
+		}
 		if err = common.InitSchema(writeDB, []string{walletDB.GetSchema()}...); err != nil {
 			return nil, errors.Wrapf(err, "failed to create schema")
 		}
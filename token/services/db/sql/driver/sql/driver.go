@@ -31,36 +31,41 @@ type Driver struct {
 }
 
 func NewDriver() driver.NamedDriver {
+	return NewDriverWithMigrations(common2.MigrationOpts{})
+}
+
+// NewDriverWithMigrations returns a sql driver whose databases handle the pending schema migrations as the passed options say
+func NewDriverWithMigrations(m common2.MigrationOpts) driver.NamedDriver {
 	return driver.NamedDriver{
 		Name: sql.SQLPersistence,
 		Driver: &Driver{
 			TokenLockCache: lazy.NewProviderWithKeyMapper(key, Combine(Openers[driver.TokenLockDB]{
-				sql.SQLite:   newSqliteOpener(sqlite2.NewTokenLockDB),
-				sql.Postgres: newPostgresOpener(postgres.NewTokenLockDB),
+				sql.SQLite:   newSqliteOpener(common2.WithMigrations(sqlite2.NewTokenLockDB, m)),
+				sql.Postgres: newPostgresOpener(common2.WithMigrations(postgres.NewTokenLockDB, m)),
 			})),
 			WalletCache: lazy.NewProviderWithKeyMapper(key, Combine(Openers[driver.WalletDB]{
-				sql.SQLite:   newSqliteOpener(sqlite2.NewWalletDB),
-				sql.Postgres: newPostgresOpener(postgres.NewWalletDB),
+				sql.SQLite:   newSqliteOpener(common2.WithMigrations(sqlite2.NewWalletDB, m)),
+				sql.Postgres: newPostgresOpener(common2.WithMigrations(postgres.NewWalletDB, m)),
 			})),
 			IdentityCache: lazy.NewProviderWithKeyMapper(key, Combine(Openers[driver.IdentityDB]{
-				sql.SQLite:   newSqliteOpener(sqlite2.NewIdentityDB),
-				sql.Postgres: newPostgresOpener(postgres.NewIdentityDB),
+				sql.SQLite:   newSqliteOpener(common2.WithMigrations(sqlite2.NewIdentityDB, m)),
+				sql.Postgres: newPostgresOpener(common2.WithMigrations(postgres.NewIdentityDB, m)),
 			})),
 			TokenCache: lazy.NewProviderWithKeyMapper(key, Combine(Openers[driver.TokenDB]{
-				sql.SQLite:   newSqliteOpener(sqlite2.NewTokenDB),
-				sql.Postgres: newPostgresOpener(postgres.NewTokenDB),
+				sql.SQLite:   newSqliteOpener(common2.WithMigrations(sqlite2.NewTokenDB, m)),
+				sql.Postgres: newPostgresOpener(common2.WithMigrations(postgres.NewTokenDB, m)),
 			})),
 			TokenNotifierCache: lazy.NewProviderWithKeyMapper(key, Combine(Openers[driver.TokenNotifier]{
-				sql.SQLite:   newSqliteOpener(sqlite2.NewTokenNotifier),
-				sql.Postgres: newPostgresOpener(postgres.NewTokenNotifier),
+				sql.SQLite:   newSqliteOpener(common2.WithMigrations(sqlite2.NewTokenNotifier, m)),
+				sql.Postgres: newPostgresOpener(common2.WithMigrations(postgres.NewTokenNotifier, m)),
 			})),
 			AuditTxCache: lazy.NewProviderWithKeyMapper(key, Combine(Openers[driver.AuditTransactionDB]{
-				sql.SQLite:   newSqliteOpener(sqlite2.NewAuditTransactionDB),
-				sql.Postgres: newPostgresOpener(postgres.NewAuditTransactionDB),
+				sql.SQLite:   newSqliteOpener(common2.WithMigrations(sqlite2.NewAuditTransactionDB, m)),
+				sql.Postgres: newPostgresOpener(common2.WithMigrations(postgres.NewAuditTransactionDB, m)),
 			})),
 			OwnerTxCache: lazy.NewProviderWithKeyMapper(key, Combine(Openers[driver.TokenTransactionDB]{
-				sql.SQLite:   newSqliteOpener(sqlite2.NewTransactionDB),
-				sql.Postgres: newPostgresOpener(postgres.NewTransactionDB),
+				sql.SQLite:   newSqliteOpener(common2.WithMigrations(sqlite2.NewTransactionDB, m)),
+				sql.Postgres: newPostgresOpener(common2.WithMigrations(postgres.NewTransactionDB, m)),
 			})),
 		},
 	}
@@ -90,6 +95,7 @@ func OpenPostgres[T any](opts common2.Opts, newDB common2.NewDBFunc[T]) (T, erro
 	if err != nil {
 		return utils.Zero[T](), err
 	}
+	opts.Driver = sql.Postgres
 	return newDB(readWriteDB, readWriteDB, common2.NewDBOptsFromOpts(opts))
 }
 
@@ -104,6 +110,7 @@ func OpenSqlite[T any](opts common2.Opts, newDB common2.NewDBFunc[T]) (T, error)
 	if err != nil {
 		return utils.Zero[T](), err
 	}
+	opts.Driver = sql.SQLite
 	return newDB(readDB, writeDB, common2.NewDBOptsFromOpts(opts))
 }
 
@@ -34,6 +34,11 @@ type Driver struct {
 }
 
 func NewUnityDriver() driver.NamedDriver {
+	return NewUnityDriverWithMigrations(common.MigrationOpts{})
+}
+
+// NewUnityDriverWithMigrations returns a unity driver whose databases handle the pending schema migrations as the passed options say
+func NewUnityDriverWithMigrations(m common.MigrationOpts) driver.NamedDriver {
 	var postgresDBCache lazy.Provider[common.Opts, *rwDBs] = lazy.NewProviderWithKeyMapper(key, func(opts common.Opts) (*rwDBs, error) {
 		db, err := postgres2.OpenDB(opts.DataSource, opts.MaxOpenConns, opts.MaxIdleConns, opts.MaxIdleTime)
 		return &rwDBs{readDB: db, writeDB: db}, err
@@ -47,32 +52,32 @@ func NewUnityDriver() driver.NamedDriver {
 		Driver: &Driver{
 			Driver: &sql3.Driver{
 				TokenLockCache: lazy.NewProviderWithKeyMapper(key, sql3.Combine(sql3.Openers[driver.TokenLockDB]{
-					sql.SQLite:   newOpener(sqliteDBCache, sqlite2.NewTokenLockDB),
-					sql.Postgres: newOpener(postgresDBCache, postgres.NewTokenLockDB),
+					sql.SQLite:   newOpener(sqliteDBCache, common.WithMigrations(sqlite2.NewTokenLockDB, m)),
+					sql.Postgres: newOpener(postgresDBCache, common.WithMigrations(postgres.NewTokenLockDB, m)),
 				})),
 				WalletCache: lazy.NewProviderWithKeyMapper(key, sql3.Combine(sql3.Openers[driver.WalletDB]{
-					sql.SQLite:   newOpener(sqliteDBCache, sqlite2.NewWalletDB),
-					sql.Postgres: newOpener(postgresDBCache, postgres.NewWalletDB),
+					sql.SQLite:   newOpener(sqliteDBCache, common.WithMigrations(sqlite2.NewWalletDB, m)),
+					sql.Postgres: newOpener(postgresDBCache, common.WithMigrations(postgres.NewWalletDB, m)),
 				})),
 				IdentityCache: lazy.NewProviderWithKeyMapper(key, sql3.Combine(sql3.Openers[driver.IdentityDB]{
-					sql.SQLite:   newOpener(sqliteDBCache, sqlite2.NewIdentityDB),
-					sql.Postgres: newOpener(postgresDBCache, postgres.NewIdentityDB),
+					sql.SQLite:   newOpener(sqliteDBCache, common.WithMigrations(sqlite2.NewIdentityDB, m)),
+					sql.Postgres: newOpener(postgresDBCache, common.WithMigrations(postgres.NewIdentityDB, m)),
 				})),
 				TokenCache: lazy.NewProviderWithKeyMapper(key, sql3.Combine(sql3.Openers[driver.TokenDB]{
-					sql.SQLite:   newOpener(sqliteDBCache, sqlite2.NewTokenDB),
-					sql.Postgres: newOpener(postgresDBCache, postgres.NewTokenDB),
+					sql.SQLite:   newOpener(sqliteDBCache, common.WithMigrations(sqlite2.NewTokenDB, m)),
+					sql.Postgres: newOpener(postgresDBCache, common.WithMigrations(postgres.NewTokenDB, m)),
 				})),
 				TokenNotifierCache: lazy.NewProviderWithKeyMapper(key, sql3.Combine(sql3.Openers[driver.TokenNotifier]{
-					sql.SQLite:   newOpener(sqliteDBCache, sqlite2.NewTokenNotifier),
-					sql.Postgres: newOpener(postgresDBCache, postgres.NewTokenNotifier),
+					sql.SQLite:   newOpener(sqliteDBCache, common.WithMigrations(sqlite2.NewTokenNotifier, m)),
+					sql.Postgres: newOpener(postgresDBCache, common.WithMigrations(postgres.NewTokenNotifier, m)),
 				})),
 				AuditTxCache: lazy.NewProviderWithKeyMapper(key, sql3.Combine(sql3.Openers[driver.AuditTransactionDB]{
-					sql.SQLite:   newOpener(sqliteDBCache, sqlite2.NewAuditTransactionDB),
-					sql.Postgres: newOpener(postgresDBCache, postgres.NewAuditTransactionDB),
+					sql.SQLite:   newOpener(sqliteDBCache, common.WithMigrations(sqlite2.NewAuditTransactionDB, m)),
+					sql.Postgres: newOpener(postgresDBCache, common.WithMigrations(postgres.NewAuditTransactionDB, m)),
 				})),
 				OwnerTxCache: lazy.NewProviderWithKeyMapper(key, sql3.Combine(sql3.Openers[driver.TokenTransactionDB]{
-					sql.SQLite:   newOpener(sqliteDBCache, sqlite2.NewTransactionDB),
-					sql.Postgres: newOpener(postgresDBCache, postgres.NewTransactionDB),
+					sql.SQLite:   newOpener(sqliteDBCache, common.WithMigrations(sqlite2.NewTransactionDB, m)),
+					sql.Postgres: newOpener(postgresDBCache, common.WithMigrations(postgres.NewTransactionDB, m)),
 				})),
 			},
 		},
@@ -20,11 +20,9 @@ func NewTokenDB(readDB, writeDB *sql.DB, opts common.NewDBOpts) (driver.TokenDB,
 	return common.NewTokenDB(readDB, writeDB, opts, common.NewTokenInterpreter(postgres.NewInterpreter()))
 }
 
-type TokenNotifier struct {
-	*postgres.Notifier
-}
-
-func NewTokenNotifier(_, writeDB *sql.DB, opts common.NewDBOpts) (driver.TokenNotifier, error) {
+// NewTokenNotifier returns a notifier that delivers the insertions, updates, and deletions of tokens
+// done by all the replicas that share the database
+func NewTokenNotifier(readDB, writeDB *sql.DB, opts common.NewDBOpts) (driver.TokenNotifier, error) {
 	tables, err := common.GetTableNames(opts.TablePrefix)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get table names")
@@ -35,5 +33,5 @@ func NewTokenNotifier(_, writeDB *sql.DB, opts common.NewDBOpts) (driver.TokenNo
 			return nil, err
 		}
 	}
-	return notifier, nil
+	return common.NewTokenNotifier(readDB, writeDB, opts, notifier)
 }
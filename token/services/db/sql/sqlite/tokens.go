@@ -19,6 +19,8 @@ func NewTokenDB(readDB, writeDB *sql.DB, opts common.NewDBOpts) (driver.TokenDB,
 	return common.NewTokenDB(readDB, writeDB, opts, common.NewTokenInterpreter(sqlite.NewInterpreter()))
 }
 
-func NewTokenNotifier(*sql.DB, *sql.DB, common.NewDBOpts) (driver.TokenNotifier, error) {
-	return notifier.NewNotifier(), nil
+// NewTokenNotifier returns a notifier that does not deliver notifications, as sqlite has no triggers across processes.
+// The token change log is still available to poll.
+func NewTokenNotifier(readDB, writeDB *sql.DB, opts common.NewDBOpts) (driver.TokenNotifier, error) {
+	return common.NewTokenNotifier(readDB, writeDB, opts, notifier.NewNotifier())
 }
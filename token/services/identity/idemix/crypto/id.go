@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package crypto
 
 import (
+	"bytes"
+
+	idemix "github.com/IBM/idemix/bccsp/schemes/dlog/crypto"
 	bccsp "github.com/IBM/idemix/bccsp/types"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/proto"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
@@ -21,6 +24,11 @@ const (
 
 var logger = logging.MustGetLogger("token-sdk.services.identity.idemix")
 
+// nonRevocationRHIndex is the index the non-revocation proofs pass to the idemix library as revocation handle index.
+// The proofs disclose the revocation handle attribute, at RHIndex. With AlgNoRevocation, the library uses this index
+// only to select the randomness of a hidden attribute, therefore it points to the OU attribute, that stays hidden.
+const nonRevocationRHIndex = 0
+
 type Identity struct {
 	NymPublicKey bccsp.Key
 	Idemix       *Deserializer
@@ -107,9 +115,89 @@ func (id *Identity) verifyProof() error {
 	return err
 }
 
+// VerifyNonRevocationProof checks that the passed proof is an idemix signature of the passed message, generated by the owner
+// of this identity, that it discloses the passed revocation handle, and that the credential revocation information (CRI)
+// it carries has been signed with the passed revocation public key.
+// It returns the epoch of the CRI.
+func (id *Identity) VerifyNonRevocationProof(msg []byte, proof []byte, revocationHandle []byte, revocationPK bccsp.Key) (uint64, error) {
+	if len(revocationHandle) == 0 {
+		return 0, errors.New("invalid non-revocation proof, revocation handle missing")
+	}
+	sig := &idemix.Signature{}
+	if err := proto.Unmarshal(proof, sig); err != nil {
+		return 0, errors.Wrap(err, "failed to unmarshal non-revocation proof")
+	}
+	if sig.Nym == nil || sig.NonRevocationProof == nil || sig.Epoch < 0 {
+		return 0, errors.New("invalid non-revocation proof, nym or credential revocation information missing")
+	}
+	nym, err := id.NymPublicKey.Bytes()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to serialize nym")
+	}
+	if !bytes.Equal(append(append([]byte{}, sig.Nym.X...), sig.Nym.Y...), nym) {
+		return 0, errors.New("invalid non-revocation proof, it has been generated for another nym")
+	}
+
+	valid, err := id.Idemix.Csp.Verify(
+		id.Idemix.IssuerPublicKey,
+		proof,
+		msg,
+		&bccsp.IdemixSignerOpts{
+			RevocationPublicKey: revocationPK,
+			Attributes: []bccsp.IdemixAttribute{
+				{Type: bccsp.IdemixHiddenAttribute},
+				{Type: bccsp.IdemixHiddenAttribute},
+				{Type: bccsp.IdemixHiddenAttribute},
+				{Type: bccsp.IdemixBytesAttribute, Value: revocationHandle},
+			},
+			RhIndex:          nonRevocationRHIndex,
+			EidIndex:         EIDIndex,
+			Epoch:            int(sig.Epoch),
+			VerificationType: bccsp.ExpectStandard,
+		},
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid non-revocation proof")
+	}
+	if !valid {
+		return 0, errors.New("invalid non-revocation proof")
+	}
+
+	// the idemix verifier does not check the signature of the revocation authority on the CRI, check it here
+	cri, err := proto.Marshal(&idemix.CredentialRevocationInformation{
+		Epoch:         sig.Epoch,
+		EpochPk:       sig.RevocationEpochPk,
+		EpochPkSig:    sig.RevocationPkSig,
+		RevocationAlg: sig.NonRevocationProof.RevocationAlg,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to marshal credential revocation information")
+	}
+	valid, err = id.Idemix.Csp.Verify(
+		revocationPK,
+		cri,
+		nil,
+		&bccsp.IdemixCRISignerOpts{
+			Epoch:               int(sig.Epoch),
+			RevocationAlgorithm: bccsp.RevocationAlgorithm(sig.NonRevocationProof.RevocationAlg),
+		},
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid credential revocation information")
+	}
+	if !valid {
+		return 0, errors.New("invalid credential revocation information")
+	}
+	return uint64(sig.Epoch), nil
+}
+
 type SigningIdentity struct {
 	*Identity `json:"-"`
 	CSP       bccsp.BCCSP `json:"-"`
+	// Credential is the idemix credential of the owner of this identity, it is needed to prove non-revocation
+	Credential []byte `json:"-"`
+	// RevocationHandle is the revocation handle of the credential, the non-revocation proof discloses it
+	RevocationHandle []byte `json:"-"`
 
 	EnrollmentId string
 	NymKeySKI    []byte
@@ -142,6 +230,46 @@ func (id *SigningIdentity) Sign(msg []byte) ([]byte, error) {
 	return sig, nil
 }
 
+// ProveNonRevocation returns an idemix signature of the passed message that carries the passed
+// credential revocation information (CRI) and discloses the revocation handle of the credential,
+// see Identity.VerifyNonRevocationProof. It returns the signature and the disclosed revocation handle.
+func (id *SigningIdentity) ProveNonRevocation(msg []byte, cri []byte) ([]byte, []byte, error) {
+	if len(id.Credential) == 0 || len(id.RevocationHandle) == 0 {
+		return nil, nil, errors.New("the credential or its revocation handle is not available, cannot prove non-revocation")
+	}
+	nymKey, err := id.CSP.GetKey(id.NymKeySKI)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot find nym secret key")
+	}
+	userKey, err := id.CSP.GetKey(id.UserKeySKI)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to retrieve user key with ski [%s]", id.UserKeySKI)
+	}
+	proof, err := id.Idemix.Csp.Sign(
+		userKey,
+		msg,
+		&bccsp.IdemixSignerOpts{
+			Credential: id.Credential,
+			Nym:        nymKey,
+			IssuerPK:   id.Idemix.IssuerPublicKey,
+			Attributes: []bccsp.IdemixAttribute{
+				{Type: bccsp.IdemixHiddenAttribute},
+				{Type: bccsp.IdemixHiddenAttribute},
+				{Type: bccsp.IdemixHiddenAttribute},
+				{Type: bccsp.IdemixBytesAttribute, Value: id.RevocationHandle},
+			},
+			RhIndex:  nonRevocationRHIndex,
+			EidIndex: EIDIndex,
+			CRI:      cri,
+			SigType:  bccsp.Standard,
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, id.RevocationHandle, nil
+}
+
 type NymSignatureVerifier struct {
 	CSP   bccsp.BCCSP
 	IPK   bccsp.Key
@@ -240,11 +240,13 @@ func (p *KeyManager) Identity(auditInfo []byte) (driver.Identity, []byte, error)
 		return nil, nil, errors.WithMessage(err, "failed to create identity")
 	}
 	sID := &crypto2.SigningIdentity{
-		CSP:          p.Csp,
-		Identity:     id,
-		NymKeySKI:    nymPublicKey.SKI(),
-		UserKeySKI:   p.userKeySKI,
-		EnrollmentId: enrollmentID,
+		CSP:              p.Csp,
+		Identity:         id,
+		Credential:       p.conf.Signer.Cred,
+		RevocationHandle: []byte(p.conf.Signer.RevocationHandle),
+		NymKeySKI:        nymPublicKey.SKI(),
+		UserKeySKI:       p.userKeySKI,
+		EnrollmentId:     enrollmentID,
 	}
 	raw, err := sID.Serialize()
 	if err != nil {
@@ -342,11 +344,13 @@ func (p *KeyManager) DeserializeSigningIdentity(raw []byte) (driver.SigningIdent
 	}
 
 	si := &crypto2.SigningIdentity{
-		CSP:          p.Csp,
-		Identity:     id.Identity,
-		UserKeySKI:   p.userKeySKI,
-		NymKeySKI:    id.NymPublicKey.SKI(),
-		EnrollmentId: p.conf.Signer.EnrollmentId,
+		CSP:              p.Csp,
+		Identity:         id.Identity,
+		Credential:       p.conf.Signer.Cred,
+		RevocationHandle: []byte(p.conf.Signer.RevocationHandle),
+		UserKeySKI:       p.userKeySKI,
+		NymKeySKI:        id.NymPublicKey.SKI(),
+		EnrollmentId:     p.conf.Signer.EnrollmentId,
 	}
 
 	// the only way to verify if this signing identity correspond to this key manager
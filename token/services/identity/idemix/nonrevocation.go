@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemix
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	csp "github.com/IBM/idemix/bccsp/types"
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	crypto2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/idemix/crypto"
+	"github.com/pkg/errors"
+)
+
+// NonRevocationProver is implemented by the signers of the idemix identities
+type NonRevocationProver interface {
+	// ProveNonRevocation returns an idemix signature of the passed message that carries the passed
+	// credential revocation information (CRI) and discloses the revocation handle of the signer.
+	// It returns the signature and the disclosed revocation handle.
+	ProveNonRevocation(msg []byte, cri []byte) ([]byte, []byte, error)
+}
+
+// NonRevocationDeserializer deserializes the verifiers of the idemix identities whose signatures
+// must carry a non-revocation proof valid in a given epoch, see driver.NonRevokedSignature.
+type NonRevocationDeserializer struct {
+	*Deserializer
+	revocationPK   csp.Key
+	epoch          uint64
+	revocationList *driver.RevocationList
+}
+
+// NewNonRevocationDeserializer returns a NonRevocationDeserializer for the passed deserializer,
+// the PEM encoding of the public key of the revocation authority, the current epoch,
+// and the revocation list stored on the ledger.
+func NewNonRevocationDeserializer(deserializer *Deserializer, revocationPK []byte, epoch uint64, revocationList *driver.RevocationList) (*NonRevocationDeserializer, error) {
+	pk, err := deserializer.Csp.KeyImport(revocationPK, &csp.IdemixRevocationPublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to import revocation public key")
+	}
+	return &NonRevocationDeserializer{Deserializer: deserializer, revocationPK: pk, epoch: epoch, revocationList: revocationList}, nil
+}
+
+func (d *NonRevocationDeserializer) DeserializeVerifier(raw driver.Identity) (driver.Verifier, error) {
+	identity, err := d.Deserialize(raw, true)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to deserialize identity")
+	}
+	return &NonRevocationVerifier{
+		Identity:       identity.Identity,
+		RevocationPK:   d.revocationPK,
+		Epoch:          d.epoch,
+		RevocationList: d.revocationList,
+	}, nil
+}
+
+// NonRevocationVerifier verifies the signatures of an idemix identity that carry a non-revocation proof
+type NonRevocationVerifier struct {
+	Identity       *crypto2.Identity
+	RevocationPK   csp.Key
+	Epoch          uint64
+	RevocationList *driver.RevocationList
+}
+
+// Verify checks that the non-revocation proof has been generated for the passed message by the owner of the identity,
+// with a credential revocation information valid in the current epoch, see driver.IsRevocationEpochValidIn,
+// that the revocation handle it discloses is not in the revocation list, and then verifies the signature of the owner.
+func (v *NonRevocationVerifier) Verify(message, sigma []byte) error {
+	nrs := &driver.NonRevokedSignature{}
+	if err := nrs.FromBytes(sigma); err != nil {
+		return errors.WithMessagef(err, "missing non-revocation proof")
+	}
+	epoch, err := v.Identity.VerifyNonRevocationProof(message, nrs.NonRevocationProof, nrs.RevocationHandle, v.RevocationPK)
+	if err != nil {
+		return err
+	}
+	if v.RevocationList.IsOwnerRevoked(nrs.RevocationHandle) {
+		return errors.Errorf("revoked owner, revocation handle [%s]", nrs.RevocationHandle)
+	}
+	if !driver.IsRevocationEpochValidIn(epoch, v.Epoch) {
+		return errors.Errorf("expired non-revocation proof, epoch [%d], current epoch [%d]", epoch, v.Epoch)
+	}
+	return v.Identity.Verify(message, nrs.Signature)
+}
+
+// CRISigner signs the credential revocation information (CRI) of the epochs.
+// It is used by the revocation authority.
+type CRISigner struct {
+	csp csp.BCCSP
+	key csp.Key
+}
+
+// NewCRISigner returns a CRISigner for the passed PEM encoding of the long-term revocation key,
+// as generated by idemixgen, and the passed curve of the idemix issuer.
+func NewCRISigner(revocationKey []byte, curveID math.CurveID) (*CRISigner, error) {
+	block, _ := pem.Decode(revocationKey)
+	if block == nil {
+		return nil, errors.New("failed to decode revocation key")
+	}
+	sk, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse revocation key")
+	}
+	cryptoProvider, err := crypto2.NewBCCSPWithDummyKeyStore(curveID, curveID == math.BLS12_381_BBS)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to instantiate crypto provider for curve [%d]", curveID)
+	}
+	key, err := cryptoProvider.KeyImport(sk.D.Bytes(), &csp.IdemixRevocationKeyImportOpts{Temporary: true})
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to import revocation key")
+	}
+	return &CRISigner{csp: cryptoProvider, key: key}, nil
+}
+
+// SignCRI returns the CRI of the passed epoch.
+// The idemix library supports only the algorithm without revocation (AlgNoRevocation), whose CRI does not depend
+// on the revoked revocation handles and is the same for all the owners. Therefore, the non-revocation proofs disclose
+// the revocation handle, and the owners are revoked by adding their revocation handle to the revocation list stored
+// on the ledger, see driver.RevocationList.OwnerRevocationHandles.
+func (s *CRISigner) SignCRI(epoch uint64) ([]byte, error) {
+	return s.csp.Sign(s.key, nil, &csp.IdemixCRISignerOpts{Epoch: int(epoch), RevocationAlgorithm: csp.AlgNoRevocation})
+}
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemix
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/IBM/idemix/bccsp/types"
+	math "github.com/IBM/mathlib"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	crypto2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/idemix/crypto"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/sig"
+	kvs2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/storage/kvs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonRevocationProof(t *testing.T) {
+	// prepare
+	kvs, err := kvs2.NewInMemory()
+	assert.NoError(t, err)
+	sigService := sig.NewService(sig.NewMultiplexDeserializer(), kvs2.NewIdentityDB(kvs, token.TMSID{Network: "pineapple"}))
+	config, err := crypto2.NewConfig("./testdata/fp256bn_amcl/idemix")
+	assert.NoError(t, err)
+	keyStore, err := crypto2.NewKeyStore(math.FP256BN_AMCL, kvs)
+	assert.NoError(t, err)
+	cryptoProvider, err := crypto2.NewBCCSP(keyStore, math.FP256BN_AMCL, false)
+	assert.NoError(t, err)
+	keyManager, err := NewKeyManager(config, sigService, types.EidNymRhNym, cryptoProvider)
+	assert.NoError(t, err)
+	des, err := NewDeserializer(keyManager.Ipk, math.FP256BN_AMCL)
+	assert.NoError(t, err)
+
+	// the revocation key
+	sk, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	assert.NoError(t, err)
+	skRaw, err := x509.MarshalECPrivateKey(sk)
+	assert.NoError(t, err)
+	pkRaw, err := x509.MarshalPKIXPublicKey(&sk.PublicKey)
+	assert.NoError(t, err)
+	criSigner, err := NewCRISigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: skRaw}), math.FP256BN_AMCL)
+	assert.NoError(t, err)
+	revocationPK := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkRaw})
+	_, err = NewCRISigner([]byte("invalid"), math.FP256BN_AMCL)
+	assert.EqualError(t, err, "failed to decode revocation key")
+
+	// two identities of the same credential
+	id, _, err := keyManager.Identity(nil)
+	assert.NoError(t, err)
+	signer, err := keyManager.DeserializeSigningIdentity(id)
+	assert.NoError(t, err)
+	id2, _, err := keyManager.Identity(nil)
+	assert.NoError(t, err)
+	signer2, err := keyManager.DeserializeSigningIdentity(id2)
+	assert.NoError(t, err)
+
+	cri, err := criSigner.SignCRI(10)
+	assert.NoError(t, err)
+	msg := []byte("hello world")
+	prove := func(signer driver.SigningIdentity, msg []byte, cri []byte) *driver.NonRevokedSignature {
+		sigma, err := signer.Sign(msg)
+		assert.NoError(t, err)
+		proof, rh, err := signer.(NonRevocationProver).ProveNonRevocation(msg, cri)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, rh)
+		return &driver.NonRevokedSignature{Signature: sigma, NonRevocationProof: proof, RevocationHandle: rh}
+	}
+	encode := func(nrs *driver.NonRevokedSignature) []byte {
+		raw, err := nrs.Bytes()
+		assert.NoError(t, err)
+		return raw
+	}
+	sign := func(signer driver.SigningIdentity, msg []byte, cri []byte) []byte {
+		return encode(prove(signer, msg, cri))
+	}
+
+	nrd, err := NewNonRevocationDeserializer(des, revocationPK, 11, nil)
+	assert.NoError(t, err)
+	verifier, err := nrd.DeserializeVerifier(id)
+	assert.NoError(t, err)
+
+	// valid
+	assert.NoError(t, verifier.Verify(msg, sign(signer, msg, cri)))
+
+	// the signature does not carry a proof
+	sigma, err := signer.Sign(msg)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, verifier.Verify(msg, sigma), "missing non-revocation proof")
+
+	// the proof has been generated for another message
+	assert.ErrorContains(t, verifier.Verify(msg, sign(signer, []byte("another message"), cri)), "invalid non-revocation proof")
+
+	// the proof has been generated by another identity
+	assert.ErrorContains(t, verifier.Verify(msg, sign(signer2, msg, cri)), "invalid non-revocation proof, it has been generated for another nym")
+
+	// the proof does not disclose the claimed revocation handle
+	nrs := prove(signer, msg, cri)
+	nrs.RevocationHandle = []byte("another handle")
+	assert.ErrorContains(t, verifier.Verify(msg, encode(nrs)), "invalid non-revocation proof")
+
+	// the owner has been revoked, the CRI does not help
+	nrs = prove(signer, msg, cri)
+	nrd, err = NewNonRevocationDeserializer(des, revocationPK, 11, &driver.RevocationList{
+		OwnerRevocationHandles: [][]byte{[]byte("another handle"), nrs.RevocationHandle},
+	})
+	assert.NoError(t, err)
+	revokedVerifier, err := nrd.DeserializeVerifier(id)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, revokedVerifier.Verify(msg, encode(nrs)), "revoked owner, revocation handle")
+
+	// the proof has expired
+	nrd, err = NewNonRevocationDeserializer(des, revocationPK, 12, nil)
+	assert.NoError(t, err)
+	verifier, err = nrd.DeserializeVerifier(id)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, verifier.Verify(msg, sign(signer, msg, cri)), "expired non-revocation proof, epoch [10], current epoch [12]")
+}
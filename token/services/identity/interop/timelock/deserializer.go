@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common/encoding/json"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	driver2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
+	"github.com/pkg/errors"
+)
+
+type deserializer interface {
+	DeserializeVerifier(id driver.Identity) (driver.Verifier, error)
+	MatchIdentity(id driver.Identity, ai []byte) error
+}
+
+type TypedIdentityDeserializer struct {
+	deserializer deserializer
+}
+
+func NewTypedIdentityDeserializer(deserializer deserializer) *TypedIdentityDeserializer {
+	return &TypedIdentityDeserializer{deserializer: deserializer}
+}
+
+func (t *TypedIdentityDeserializer) DeserializeVerifier(typ identity.Type, raw []byte) (driver.Verifier, error) {
+	if typ != timelock.ScriptType {
+		return nil, errors.Errorf("cannot deserializer type [%s], expected [%s]", typ, timelock.ScriptType)
+	}
+
+	script := &timelock.Script{}
+	err := json.Unmarshal(raw, script)
+	if err != nil {
+		return nil, errors.Errorf("failed to unmarshal TypedIdentity as a timelock script")
+	}
+	v := &timelock.Verifier{}
+	v.Recipient, err = t.deserializer.DeserializeVerifier(script.Recipient)
+	if err != nil {
+		return nil, errors.Errorf("failed to unmarshal the identity of the recipient in the timelock script")
+	}
+	v.Deadline = script.Deadline
+	return v, nil
+}
+
+func (t *TypedIdentityDeserializer) Recipients(id driver.Identity, typ identity.Type, raw []byte) ([]driver.Identity, error) {
+	if typ != timelock.ScriptType {
+		return nil, errors.New("unknown identity type")
+	}
+
+	script := &timelock.Script{}
+	err := json.Unmarshal(raw, script)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal timelock script")
+	}
+	return []driver.Identity{script.Recipient}, nil
+}
+
+func (t *TypedIdentityDeserializer) GetAuditInfo(id driver.Identity, typ identity.Type, raw []byte, p driver.AuditInfoProvider) ([]byte, error) {
+	if typ != timelock.ScriptType {
+		return nil, errors.Errorf("invalid type, got [%s], expected [%s]", typ, timelock.ScriptType)
+	}
+	script := &timelock.Script{}
+	var err error
+	err = json.Unmarshal(raw, script)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal timelock script")
+	}
+
+	auditInfo := &ScriptInfo{}
+	auditInfo.Sender, err = p.GetAuditInfo(script.Sender)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting audit info for timelock script [%s]", id.String())
+	}
+	auditInfo.Recipient, err = p.GetAuditInfo(script.Recipient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting audit info for script [%s]", id.String())
+	}
+
+	auditInfoRaw, err := json.Marshal(auditInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed marshaling audit info for script")
+	}
+	return auditInfoRaw, nil
+}
+
+func (t *TypedIdentityDeserializer) GetAuditInfoMatcher(owner driver.Identity, auditInfo []byte) (driver.Matcher, error) {
+	return &AuditInfoMatcher{
+		auditInfo:    auditInfo,
+		deserializer: t.deserializer,
+	}, nil
+}
+
+type AuditDeserializer struct {
+	AuditInfoDeserializer driver2.AuditInfoDeserializer
+}
+
+func NewAuditDeserializer(auditInfoDeserializer driver2.AuditInfoDeserializer) *AuditDeserializer {
+	return &AuditDeserializer{AuditInfoDeserializer: auditInfoDeserializer}
+}
+
+func (a *AuditDeserializer) DeserializeAuditInfo(bytes []byte) (driver2.AuditInfo, error) {
+	si := &ScriptInfo{}
+	err := json.Unmarshal(bytes, si)
+	if err != nil || (len(si.Sender) == 0 && len(si.Recipient) == 0) {
+		return nil, errors.Errorf("ivalid audit info, failed unmarshal [%s][%d][%d]", string(bytes), len(si.Sender), len(si.Recipient))
+	}
+	if len(si.Recipient) == 0 {
+		return nil, errors.Errorf("no recipient defined")
+	}
+	ai, err := a.AuditInfoDeserializer.DeserializeAuditInfo(si.Recipient)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed unamrshalling audit info [%s]", bytes)
+	}
+	return ai, nil
+}
+
+type AuditInfoMatcher struct {
+	auditInfo    []byte
+	deserializer deserializer
+}
+
+func (a *AuditInfoMatcher) Match(id []byte) error {
+	scriptInf := &ScriptInfo{}
+	if err := json.Unmarshal(a.auditInfo, scriptInf); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal script info")
+	}
+	scriptSender, scriptRecipient, err := GetScriptSenderAndRecipient(id)
+	if err != nil {
+		return errors.Wrap(err, "failed getting script sender and recipient")
+	}
+	err = a.deserializer.MatchIdentity(scriptSender, scriptInf.Sender)
+	if err != nil {
+		return errors.Wrapf(err, "failed matching sender identity [%s]", scriptSender.String())
+	}
+	err = a.deserializer.MatchIdentity(scriptRecipient, scriptInf.Recipient)
+	if err != nil {
+		return errors.Wrapf(err, "failed matching recipient identity [%s]", scriptRecipient.String())
+	}
+	return nil
+}
+
+// GetScriptSenderAndRecipient returns the script's sender and recipient according to the type of the given owner
+func GetScriptSenderAndRecipient(id []byte) (sender, recipient driver.Identity, err error) {
+	script := &timelock.Script{}
+	err = json.Unmarshal(id, script)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal timelock script")
+	}
+	return script.Sender, script.Recipient, nil
+}
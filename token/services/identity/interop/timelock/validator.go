@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
+	"github.com/pkg/errors"
+)
+
+// VerifyOwner validates the release of a time-locked token.
+// The output must be owned by the recipient in the script, and the script must be released at the passed time and block height.
+// A zero block height means that the block height is not known.
+func VerifyOwner(senderRawOwner []byte, outRawOwner []byte, now time.Time, blockHeight uint64) (*timelock.Script, error) {
+	sender, err := identity.UnmarshalTypedIdentity(senderRawOwner)
+	if err != nil {
+		return nil, err
+	}
+	if sender.Type != timelock.ScriptType {
+		return nil, errors.Errorf("invalid identity type, expected [%s], got [%s]", timelock.ScriptType, sender.Type)
+	}
+	script := &timelock.Script{}
+	if err := json.Unmarshal(sender.Identity, script); err != nil {
+		return nil, err
+	}
+	if err := script.Released(now, blockHeight); err != nil {
+		return nil, err
+	}
+	if !script.Recipient.Equal(outRawOwner) {
+		return nil, errors.New("owner of output token does not correspond to recipient in timelock script")
+	}
+	return script, nil
+}
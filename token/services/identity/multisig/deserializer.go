@@ -96,7 +96,10 @@ func (d *TypedIdentityDeserializer) DeserializeVerifier(typ identity.Type, id []
 	if err != nil {
 		return nil, errors.New("failed to unmarshal multisig identity")
 	}
-	verifier := &Verifier{}
+	if err := multisigIdentity.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid multisig identity")
+	}
+	verifier := &Verifier{Threshold: multisigIdentity.Threshold}
 	verifier.Verifiers = make([]driver.Verifier, len(multisigIdentity.Identities))
 	for k, i := range multisigIdentity.Identities {
 		verifier.Verifiers[k], err = d.VerifierDeserializer.DeserializeVerifier(i)
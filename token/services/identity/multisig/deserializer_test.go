@@ -51,6 +51,25 @@ func TestDeserializeVerifier_InvalidIdentity(t *testing.T) {
 	assert.Nil(t, verifier)
 }
 
+func TestDeserializeVerifier_Threshold(t *testing.T) {
+	verifierDES := &mockVerifierDES{}
+	deserializer := NewTypedIdentityDeserializer(verifierDES, nil)
+	id := &MultiIdentity{Identities: []token.Identity{[]byte("valid_multisig_identity"), []byte("valid_multisig_identity")}, Threshold: 1}
+	idRaw, err := id.Bytes()
+	require.NoError(t, err)
+
+	verifier, err := deserializer.DeserializeVerifier(Multisig, idRaw)
+	require.NoError(t, err)
+	assert.Equal(t, 1, verifier.(*Verifier).Threshold)
+
+	id.Threshold = 3
+	idRaw, err = id.Bytes()
+	require.NoError(t, err)
+	verifier, err = deserializer.DeserializeVerifier(Multisig, idRaw)
+	require.Error(t, err)
+	assert.Nil(t, verifier)
+}
+
 func TestGetOwnerAuditInfo_Success(t *testing.T) {
 	verifierDES := &mockVerifierDES{}
 	auditInfoMatcher := &mockAuditInfoMatcher{}
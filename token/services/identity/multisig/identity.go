@@ -20,8 +20,18 @@ import (
 // It is used to identify a multisig identity in a typed identity (identity.TypedIdentity).
 const Multisig = "ms"
 
+// Policy tells how many of the identities wrapped in a multisig identity must sign to spend the tokens it owns
+type Policy struct {
+	// Threshold is the minimum number of signatures required, m in an m-of-n multisig identity.
+	// Zero means that all the identities must sign.
+	Threshold int
+}
+
 type MultiIdentity struct {
 	Identities []token.Identity
+	// Threshold is the minimum number of signatures required. Zero means that all the identities must sign.
+	// It is omitted from the encoding when zero, so that n-of-n identities keep their original encoding.
+	Threshold int `asn1:"optional"`
 }
 
 func (m *MultiIdentity) Serialize() ([]byte, error) {
@@ -37,12 +47,50 @@ func (m *MultiIdentity) Bytes() ([]byte, error) {
 	return asn1.Marshal(*m)
 }
 
-// WrapIdentities wraps the given identities into a multisig identity
+// RequiredSignatures returns the number of signatures needed to spend the tokens owned by the multisig identity
+func (m *MultiIdentity) RequiredSignatures() int {
+	if m.Threshold > 0 {
+		return m.Threshold
+	}
+	return len(m.Identities)
+}
+
+// Validate returns an error if the threshold cannot be met by the wrapped identities
+func (m *MultiIdentity) Validate() error {
+	if len(m.Identities) == 0 {
+		return errors.New("no identities provided")
+	}
+	if m.Threshold < 0 || m.Threshold > len(m.Identities) {
+		return errors.Errorf("invalid threshold [%d] for [%d] identities", m.Threshold, len(m.Identities))
+	}
+	return nil
+}
+
+// WrapIdentities wraps the given identities into a multisig identity that requires the signatures of all of them
 func WrapIdentities(ids ...token.Identity) (token.Identity, error) {
-	if len(ids) == 0 {
-		return nil, errors.New("no identities provided")
+	return WrapIdentitiesWithPolicy(Policy{}, ids...)
+}
+
+// WrapIdentitiesWithPolicy wraps the given identities into a multisig identity whose signature policy is the passed one.
+// A threshold policy requires the wrapped identities to be distinct, otherwise a single signer could count more than once.
+func WrapIdentitiesWithPolicy(policy Policy, ids ...token.Identity) (token.Identity, error) {
+	mi := &MultiIdentity{Identities: ids, Threshold: policy.Threshold}
+	if err := mi.Validate(); err != nil {
+		return nil, err
+	}
+	if mi.Threshold == len(ids) {
+		// n-of-n, use the original encoding
+		mi.Threshold = 0
+	}
+	if mi.Threshold != 0 {
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			if seen[id.UniqueID()] {
+				return nil, errors.Errorf("identity [%s] appears more than once", id)
+			}
+			seen[id.UniqueID()] = true
+		}
 	}
-	mi := &MultiIdentity{Identities: ids}
 	raw, err := mi.Bytes()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed marshalling multi identity")
@@ -57,6 +105,16 @@ func WrapIdentities(ids ...token.Identity) (token.Identity, error) {
 // Unwrap returns the identities wrapped in the given multisig identity
 // It returns the identities and a boolean indicating whether the given identity is a multisig identity
 func Unwrap(raw []byte) (bool, []token.Identity, error) {
+	ok, mi, err := UnwrapMultiIdentity(raw)
+	if !ok || err != nil {
+		return ok, nil, err
+	}
+	return true, mi.Identities, nil
+}
+
+// UnwrapMultiIdentity returns the multisig identity, with its threshold, wrapped in the given typed identity.
+// It returns the multisig identity and a boolean indicating whether the given identity is a multisig identity
+func UnwrapMultiIdentity(raw []byte) (bool, *MultiIdentity, error) {
 	ti, err := identity.UnmarshalTypedIdentity(raw)
 	if err != nil {
 		return false, nil, errors.Wrap(err, "failed unmarshalling typed identity")
@@ -69,7 +127,7 @@ func Unwrap(raw []byte) (bool, []token.Identity, error) {
 	if err != nil {
 		return false, nil, errors.Wrap(err, "failed unmarshalling multi identity")
 	}
-	return true, mi.Identities, nil
+	return true, mi, nil
 }
 
 // InfoMatcher matches a multisig identity to its own audit info.
@@ -41,6 +41,37 @@ func TestWrapIdentities(t *testing.T) {
 	assert.Equal(t, identities, unwrapped)
 }
 
+func TestWrapIdentitiesWithPolicy(t *testing.T) {
+	identities := identities(t, "id1", "id2", "id3")
+	wrapped, err := WrapIdentitiesWithPolicy(Policy{Threshold: 2}, identities...)
+	assert.NoError(t, err)
+
+	isMultisig, mi, err := UnwrapMultiIdentity(wrapped)
+	assert.NoError(t, err)
+	assert.True(t, isMultisig)
+	assert.Equal(t, identities, mi.Identities)
+	assert.Equal(t, 2, mi.Threshold)
+	assert.Equal(t, 2, mi.RequiredSignatures())
+
+	// n-of-n keeps the original encoding
+	wrapped, err = WrapIdentitiesWithPolicy(Policy{Threshold: 3}, identities...)
+	assert.NoError(t, err)
+	legacy, err := WrapIdentities(identities...)
+	assert.NoError(t, err)
+	assert.Equal(t, legacy, wrapped)
+	_, mi, err = UnwrapMultiIdentity(wrapped)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mi.Threshold)
+	assert.Equal(t, 3, mi.RequiredSignatures())
+
+	_, err = WrapIdentitiesWithPolicy(Policy{Threshold: 4}, identities...)
+	assert.EqualError(t, err, "invalid threshold [4] for [3] identities")
+	_, err = WrapIdentitiesWithPolicy(Policy{Threshold: -1}, identities...)
+	assert.Error(t, err)
+	_, err = WrapIdentitiesWithPolicy(Policy{Threshold: 1}, identities[0], identities[0])
+	assert.Error(t, err)
+}
+
 func TestUnwrap_InvalidIdentity(t *testing.T) {
 	invalidIdentity := []byte("invalid")
 	isMultisig, unwrapped, err := Unwrap(invalidIdentity)
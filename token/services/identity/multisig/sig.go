@@ -18,8 +18,11 @@ import (
 // MultiSignature represents a multi-signature
 // It is a sequence of signatures from different identities on the same message.
 // The order of the signatures is the same as the order of the identities.
+// If Signers is set, the multi-signature carries only the signatures of the identities whose bit is set, in order.
 type MultiSignature struct {
 	Signatures [][]byte
+	// Signers is a bitmap over the identities, bit k is set if the k-th identity signed
+	Signers asn1.BitString `asn1:"optional"`
 }
 
 func (m *MultiSignature) Bytes() ([]byte, error) {
@@ -49,11 +52,41 @@ func JoinSignatures(identities []token.Identity, sigmas map[string][]byte) ([]by
 	return sig.Bytes()
 }
 
+// JoinThresholdSignatures joins the signatures of the first threshold identities that signed into a single signature
+// that carries the bitmap of the signers.
+// If the threshold is zero, or equal to the number of identities, all the signatures are required, see JoinSignatures.
+func JoinThresholdSignatures(identities []token.Identity, threshold int, sigmas map[string][]byte) ([]byte, error) {
+	if threshold <= 0 || threshold >= len(identities) {
+		return JoinSignatures(identities, sigmas)
+	}
+	sig := &MultiSignature{
+		Signatures: make([][]byte, 0, threshold),
+		Signers:    asn1.BitString{Bytes: make([]byte, (len(identities)+7)/8), BitLength: len(identities)},
+	}
+	for k, identity := range identities {
+		if len(sig.Signatures) == threshold {
+			break
+		}
+		sigma, ok := sigmas[identity.UniqueID()]
+		if !ok {
+			continue
+		}
+		sig.Signers.Bytes[k/8] |= 0x80 >> uint(k%8)
+		sig.Signatures = append(sig.Signatures, sigma)
+	}
+	if len(sig.Signatures) < threshold {
+		return nil, errors.Errorf("expected at least [%d] signatures, got [%d]", threshold, len(sig.Signatures))
+	}
+	return sig.Bytes()
+}
+
 // Verifier is a multi-signature verifier that verifies a multi-signature.
 // It is composed of a list of verifiers, one for each identity that signed the message.
 // The order of the verifiers is the same as the order of the identities.
 type Verifier struct {
 	Verifiers []driver.Verifier
+	// Threshold is the minimum number of valid signatures. Zero means that all the identities must sign.
+	Threshold int
 }
 
 func (v *Verifier) Verify(msg, raw []byte) error {
@@ -62,6 +95,9 @@ func (v *Verifier) Verify(msg, raw []byte) error {
 	if err != nil {
 		return errors.Wrapf(err, "failed to unmarshal multisig [%s]", hash.Hashable(raw))
 	}
+	if sig.Signers.BitLength != 0 {
+		return v.verifyThreshold(msg, sig)
+	}
 	if len(v.Verifiers) != len(sig.Signatures) {
 		return errors.Errorf("invalid multisig: expect [%d] signatures, but received [%d]", len(v.Verifiers), len(sig.Signatures))
 	}
@@ -72,3 +108,33 @@ func (v *Verifier) Verify(msg, raw []byte) error {
 	}
 	return nil
 }
+
+func (v *Verifier) verifyThreshold(msg []byte, sig *MultiSignature) error {
+	if sig.Signers.BitLength != len(v.Verifiers) {
+		return errors.Errorf("invalid multisig: expect a bitmap of [%d] signers, but received [%d]", len(v.Verifiers), sig.Signers.BitLength)
+	}
+	required := v.Threshold
+	if required <= 0 {
+		required = len(v.Verifiers)
+	}
+	signed := 0
+	for k, ver := range v.Verifiers {
+		if sig.Signers.At(k) == 0 {
+			continue
+		}
+		if signed == len(sig.Signatures) {
+			return errors.Errorf("invalid multisig: more signers than the [%d] signatures", len(sig.Signatures))
+		}
+		if err := ver.Verify(msg, sig.Signatures[signed]); err != nil {
+			return errors.Errorf("invalid multisig: signature of identity at index [%d] does not verify", k)
+		}
+		signed++
+	}
+	if signed != len(sig.Signatures) {
+		return errors.Errorf("invalid multisig: [%d] signers but [%d] signatures", signed, len(sig.Signatures))
+	}
+	if signed < required {
+		return errors.Errorf("invalid multisig: expect at least [%d] signatures, but received [%d]", required, signed)
+	}
+	return nil
+}
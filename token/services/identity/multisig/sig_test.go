@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package multisig
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdSignatures(t *testing.T) {
+	ids := identities(t, "id1", "id2", "id3")
+	verifier := &Verifier{
+		Verifiers: []driver.Verifier{
+			&expectedSigVerifier{sigma: []byte("sig1")},
+			&expectedSigVerifier{sigma: []byte("sig2")},
+			&expectedSigVerifier{sigma: []byte("sig3")},
+		},
+		Threshold: 2,
+	}
+
+	// any two signatures are enough
+	sigma, err := JoinThresholdSignatures(ids, 2, map[string][]byte{
+		ids[0].UniqueID(): []byte("sig1"),
+		ids[2].UniqueID(): []byte("sig3"),
+	})
+	require.NoError(t, err)
+	assert.NoError(t, verifier.Verify([]byte("msg"), sigma))
+	sig := &MultiSignature{}
+	require.NoError(t, sig.FromBytes(sigma))
+	assert.Equal(t, [][]byte{[]byte("sig1"), []byte("sig3")}, sig.Signatures)
+	assert.Equal(t, 1, sig.Signers.At(0))
+	assert.Equal(t, 0, sig.Signers.At(1))
+	assert.Equal(t, 1, sig.Signers.At(2))
+
+	// extra signatures are dropped
+	sigma, err = JoinThresholdSignatures(ids, 2, map[string][]byte{
+		ids[0].UniqueID(): []byte("sig1"),
+		ids[1].UniqueID(): []byte("sig2"),
+		ids[2].UniqueID(): []byte("sig3"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, sig.FromBytes(sigma))
+	assert.Len(t, sig.Signatures, 2)
+	assert.NoError(t, verifier.Verify([]byte("msg"), sigma))
+
+	// one signature is not enough
+	_, err = JoinThresholdSignatures(ids, 2, map[string][]byte{ids[1].UniqueID(): []byte("sig2")})
+	assert.EqualError(t, err, "expected at least [2] signatures, got [1]")
+
+	// the legacy encoding still requires all the signatures
+	sigma, err = JoinSignatures(ids, map[string][]byte{
+		ids[0].UniqueID(): []byte("sig1"),
+		ids[1].UniqueID(): []byte("sig2"),
+		ids[2].UniqueID(): []byte("sig3"),
+	})
+	require.NoError(t, err)
+	assert.NoError(t, verifier.Verify([]byte("msg"), sigma))
+}
+
+func TestThresholdSignatures_Invalid(t *testing.T) {
+	verifier := &Verifier{
+		Verifiers: []driver.Verifier{
+			&expectedSigVerifier{sigma: []byte("sig1")},
+			&expectedSigVerifier{sigma: []byte("sig2")},
+			&expectedSigVerifier{sigma: []byte("sig3")},
+		},
+		Threshold: 2,
+	}
+	verify := func(sig *MultiSignature) error {
+		raw, err := sig.Bytes()
+		require.NoError(t, err)
+		return verifier.Verify([]byte("msg"), raw)
+	}
+
+	// not enough signers
+	err := verify(&MultiSignature{Signatures: [][]byte{[]byte("sig1")}, Signers: bitmap(0x80, 3)})
+	assert.EqualError(t, err, "invalid multisig: expect at least [2] signatures, but received [1]")
+	// the same signer cannot count twice
+	err = verify(&MultiSignature{Signatures: [][]byte{[]byte("sig1"), []byte("sig1")}, Signers: bitmap(0x80, 3)})
+	assert.EqualError(t, err, "invalid multisig: [1] signers but [2] signatures")
+	// more signers than signatures
+	err = verify(&MultiSignature{Signatures: [][]byte{[]byte("sig1")}, Signers: bitmap(0xc0, 3)})
+	assert.EqualError(t, err, "invalid multisig: more signers than the [1] signatures")
+	// a signature that does not match its signer
+	err = verify(&MultiSignature{Signatures: [][]byte{[]byte("sig1"), []byte("sig3")}, Signers: bitmap(0xc0, 3)})
+	assert.EqualError(t, err, "invalid multisig: signature of identity at index [1] does not verify")
+	// a bitmap of the wrong size
+	err = verify(&MultiSignature{Signatures: [][]byte{[]byte("sig1"), []byte("sig2")}, Signers: bitmap(0xc0, 4)})
+	assert.EqualError(t, err, "invalid multisig: expect a bitmap of [3] signers, but received [4]")
+
+	// an n-of-n verifier requires all the signers, even with a bitmap
+	verifier.Threshold = 0
+	err = verify(&MultiSignature{Signatures: [][]byte{[]byte("sig1"), []byte("sig2")}, Signers: bitmap(0xc0, 3)})
+	assert.EqualError(t, err, "invalid multisig: expect at least [3] signatures, but received [2]")
+	err = verify(&MultiSignature{Signatures: [][]byte{[]byte("sig1"), []byte("sig2"), []byte("sig3")}, Signers: bitmap(0xe0, 3)})
+	assert.NoError(t, err)
+}
+
+func bitmap(bits byte, n int) asn1.BitString {
+	return asn1.BitString{Bytes: []byte{bits}, BitLength: n}
+}
+
+type expectedSigVerifier struct {
+	sigma []byte
+}
+
+func (v *expectedSigVerifier) Verify(message, sigma []byte) error {
+	if !bytes.Equal(v.sigma, sigma) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ package wallet
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
@@ -20,7 +21,7 @@ type TokenVault interface {
 	IsPending(id *token.ID) (bool, error)
 	UnspentTokensIteratorBy(ctx context.Context, id string, tokenType token.Type) (driver.UnspentTokensIterator, error)
 	ListHistoryIssuedTokens() (*token.IssuedTokens, error)
-	Balance(id string, tokenType token.Type) (uint64, error)
+	Balance(id string, tokenType token.Type) (*big.Int, error)
 }
 
 type WalletsConfiguration interface {
@@ -8,6 +8,7 @@ package wallet
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
@@ -19,7 +20,7 @@ import (
 
 type OwnerTokenVault interface {
 	UnspentTokensIteratorBy(ctx context.Context, id string, tokenType token.Type) (driver.UnspentTokensIterator, error)
-	Balance(id string, tokenType token.Type) (uint64, error)
+	Balance(id string, tokenType token.Type) (*big.Int, error)
 }
 
 type AuditorWallet struct {
@@ -256,10 +257,10 @@ func (w *LongTermOwnerWallet) ListTokens(opts *driver.ListTokensOptions) (*token
 	return unspentTokens, nil
 }
 
-func (w *LongTermOwnerWallet) Balance(opts *driver.ListTokensOptions) (uint64, error) {
+func (w *LongTermOwnerWallet) Balance(opts *driver.ListTokensOptions) (*big.Int, error) {
 	balance, err := w.TokenVault.Balance(w.WalletID, opts.TokenType)
 	if err != nil {
-		return 0, errors.Wrap(err, "token selection failed")
+		return nil, errors.Wrap(err, "token selection failed")
 	}
 	return balance, nil
 }
@@ -79,6 +79,8 @@ type Binder interface {
 type Transaction struct {
 	*ttx.Transaction
 	Binder Binder
+	// ServiceProvider is used to get the credential revocation information of the owners of the script, if needed
+	ServiceProvider token.ServiceProvider
 }
 
 // NewTransaction returns a new token transaction customized with the passed opts that will be signed by the passed signer
@@ -88,8 +90,9 @@ func NewTransaction(sp view.Context, signer view.Identity, opts ...ttx.TxOption)
 		return nil, err
 	}
 	return &Transaction{
-		Transaction: tx,
-		Binder:      view2.GetEndpointService(sp),
+		Transaction:     tx,
+		Binder:          view2.GetEndpointService(sp),
+		ServiceProvider: sp,
 	}, nil
 }
 
@@ -100,8 +103,9 @@ func NewAnonymousTransaction(sp view.Context, opts ...ttx.TxOption) (*Transactio
 		return nil, err
 	}
 	return &Transaction{
-		Transaction: tx,
-		Binder:      view2.GetEndpointService(sp),
+		Transaction:     tx,
+		Binder:          view2.GetEndpointService(sp),
+		ServiceProvider: sp,
 	}, nil
 }
 
@@ -112,8 +116,9 @@ func NewTransactionFromBytes(ctx view.Context, network, channel string, raw []by
 		return nil, err
 	}
 	return &Transaction{
-		Transaction: tx,
-		Binder:      view2.GetEndpointService(ctx),
+		Transaction:     tx,
+		Binder:          view2.GetEndpointService(ctx),
+		ServiceProvider: ctx,
 	}, nil
 }
 
@@ -226,7 +231,7 @@ func (t *Transaction) Reclaim(wallet *token.OwnerWallet, tok *token2.UnspentToke
 	logger.Debugf("registering signer for reclaim...")
 	if err := sigService.RegisterSigner(
 		tok.Owner,
-		signer,
+		ttx.NewNonRevocationSigner(t.ServiceProvider, t.TokenService(), script.Sender, signer),
 		verifier,
 	); err != nil {
 		return err
@@ -285,7 +290,7 @@ func (t *Transaction) Claim(wallet *token.OwnerWallet, tok *token2.UnspentToken,
 	if err := sigService.RegisterSigner(
 		tok.Owner,
 		&ClaimSigner{
-			Recipient: recipientSigner,
+			Recipient: ttx.NewNonRevocationSigner(t.ServiceProvider, t.TokenService(), script.Recipient, recipientSigner),
 			Preimage:  preImage,
 		},
 		&ClaimVerifier{
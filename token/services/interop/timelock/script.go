@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	token3 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	senderWalletPrefix    = "timelock.sender"
+	recipientWalletPrefix = "timelock.recipient"
+)
+
+// Script contains the details of a time-locked token.
+// The token can be spent only by the recipient, and only once the deadline, if any, has elapsed
+// and the ledger has reached the block height, if any.
+type Script struct {
+	Sender      view.Identity
+	Recipient   view.Identity
+	Deadline    time.Time
+	BlockHeight uint64
+}
+
+// Validate performs the following checks:
+// - The sender must be set
+// - The recipient must be set
+// - At least one of the deadline and the block height must be set
+// - The deadline, if set, must be after the passed time reference
+func (s *Script) Validate(timeReference time.Time) error {
+	if s.Sender.IsNone() {
+		return errors.New("sender not set")
+	}
+	if s.Recipient.IsNone() {
+		return errors.New("recipient not set")
+	}
+	if s.Deadline.IsZero() && s.BlockHeight == 0 {
+		return errors.New("neither a deadline nor a block height is set")
+	}
+	if !s.Deadline.IsZero() && s.Deadline.Before(timeReference) {
+		return errors.New("deadline has already passed")
+	}
+	return nil
+}
+
+// Released checks that the token locked by this script can be spent at the passed time and block height.
+// A zero block height means that the block height is not known, in which case a script with a block height is never released.
+func (s *Script) Released(now time.Time, blockHeight uint64) error {
+	if !s.Deadline.IsZero() && now.Before(s.Deadline) {
+		return errors.Errorf("token locked until [%s]", s.Deadline)
+	}
+	if s.BlockHeight != 0 {
+		if blockHeight == 0 {
+			return errors.Errorf("token locked until block height [%d], the current block height is not available", s.BlockHeight)
+		}
+		if blockHeight < s.BlockHeight {
+			return errors.Errorf("token locked until block height [%d], the current block height is [%d]", s.BlockHeight, blockHeight)
+		}
+	}
+	return nil
+}
+
+func (s *Script) FromBytes(raw []byte) error {
+	return json.Unmarshal(raw, s)
+}
+
+// ScriptAuth implements the Authorization interface for this script
+type ScriptAuth struct {
+	WalletService driver.WalletService
+}
+
+func NewScriptAuth(walletService driver.WalletService) *ScriptAuth {
+	return &ScriptAuth{WalletService: walletService}
+}
+
+// AmIAnAuditor returns false for script ownership
+func (s *ScriptAuth) AmIAnAuditor() bool {
+	return false
+}
+
+// IsMine returns true if either the sender or the recipient is in one of the owner wallets.
+// It returns an empty wallet id.
+func (s *ScriptAuth) IsMine(tok *token3.Token) (string, []string, bool) {
+	owner, err := identity.UnmarshalTypedIdentity(tok.Owner)
+	if err != nil {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, failed unmarshalling [%s]", view.Identity(tok.Owner), tok.Type, tok.Quantity, err)
+		return "", nil, false
+	}
+	if owner.Type != ScriptType {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, owner type is [%s] instead of [%s]", view.Identity(tok.Owner), tok.Type, tok.Quantity, owner.Type, ScriptType)
+		return "", nil, false
+	}
+	script := &Script{}
+	if err := json.Unmarshal(owner.Identity, script); err != nil {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, failed unmarshalling [%s]", view.Identity(tok.Owner), tok.Type, tok.Quantity, err)
+		return "", nil, false
+	}
+	if script.Sender.IsNone() || script.Recipient.IsNone() {
+		logger.Debugf("Is Mine [%s,%s,%s]? No, invalid content [%v]", view.Identity(tok.Owner), tok.Type, tok.Quantity, script)
+		return "", nil, false
+	}
+
+	var ids []string
+	// I'm either the sender
+	if wallet, err := s.WalletService.OwnerWallet(script.Sender); err == nil {
+		logger.Debugf("Is Mine [%s,%s,%s] as a sender? Yes", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+		ids = append(ids, senderWallet(wallet))
+	}
+
+	// or the recipient
+	if wallet, err := s.WalletService.OwnerWallet(script.Recipient); err == nil {
+		logger.Debugf("Is Mine [%s,%s,%s] as a recipient? Yes", view.Identity(tok.Owner), tok.Type, tok.Quantity)
+		ids = append(ids, recipientWallet(wallet))
+	}
+
+	return "", ids, len(ids) != 0
+}
+
+func (s *ScriptAuth) Issued(issuer driver.Identity, tok *token3.Token) bool {
+	return false
+}
+
+func (s *ScriptAuth) OwnerType(raw []byte) (string, []byte, error) {
+	owner, err := identity.UnmarshalTypedIdentity(raw)
+	if err != nil {
+		return "", nil, err
+	}
+	return owner.Type, owner.Identity, nil
+}
+
+// IsLockedWallet returns true if the passed wallet identifier refers to the time-locked tokens of a wallet.
+// Such tokens must not be selected for ordinary transfers.
+func IsLockedWallet(walletID string) bool {
+	return strings.HasPrefix(walletID, senderWalletPrefix) || strings.HasPrefix(walletID, recipientWalletPrefix)
+}
+
+type ownerWallet interface {
+	ID() string
+}
+
+func senderWallet(w ownerWallet) string {
+	return senderWalletPrefix + w.ID()
+}
+
+func recipientWallet(w ownerWallet) string {
+	return recipientWalletPrefix + w.ID()
+}
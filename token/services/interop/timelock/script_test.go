@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptValidate(t *testing.T) {
+	now := time.Now()
+	script := &Script{Sender: []byte("alice"), Recipient: []byte("bob")}
+	assert.EqualError(t, script.Validate(now), "neither a deadline nor a block height is set")
+
+	script.Deadline = now.Add(-time.Minute)
+	assert.EqualError(t, script.Validate(now), "deadline has already passed")
+	script.Deadline = now.Add(time.Minute)
+	assert.NoError(t, script.Validate(now))
+
+	script.Deadline = time.Time{}
+	script.BlockHeight = 10
+	assert.NoError(t, script.Validate(now))
+
+	assert.EqualError(t, (&Script{Recipient: []byte("bob"), BlockHeight: 10}).Validate(now), "sender not set")
+	assert.EqualError(t, (&Script{Sender: []byte("alice"), BlockHeight: 10}).Validate(now), "recipient not set")
+}
+
+func TestScriptReleased(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(time.Hour)
+
+	script := &Script{Deadline: deadline}
+	assert.Error(t, script.Released(now, 0))
+	assert.NoError(t, script.Released(deadline, 0))
+
+	script = &Script{BlockHeight: 10}
+	assert.EqualError(t, script.Released(now, 0), "token locked until block height [10], the current block height is not available")
+	assert.EqualError(t, script.Released(now, 9), "token locked until block height [10], the current block height is [9]")
+	assert.NoError(t, script.Released(now, 10))
+
+	// both conditions must hold
+	script = &Script{Deadline: deadline, BlockHeight: 10}
+	assert.Error(t, script.Released(now, 10))
+	assert.Error(t, script.Released(deadline, 9))
+	assert.NoError(t, script.Released(deadline.Add(time.Second), 11))
+}
+
+func TestIsLockedWallet(t *testing.T) {
+	assert.True(t, IsLockedWallet("timelock.senderalice"))
+	assert.True(t, IsLockedWallet("timelock.recipientalice"))
+	assert.False(t, IsLockedWallet("alice"))
+	assert.False(t, IsLockedWallet("htlc.recipientalice"))
+}
@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/pkg/errors"
+)
+
+// Verifier checks if a time-locked token can be released.
+// The block height condition, if any, is checked by the validator that knows the height of the ledger.
+type Verifier struct {
+	Recipient driver.Verifier
+	Deadline  time.Time
+}
+
+// Verify verifies the release signature of the recipient, once the deadline has elapsed
+func (v *Verifier) Verify(msg []byte, sigma []byte) error {
+	if !v.Deadline.IsZero() && time.Now().Before(v.Deadline) {
+		return errors.Errorf("token locked until [%s]", v.Deadline)
+	}
+	if err := v.Recipient.Verify(msg, sigma); err != nil {
+		return errors.WithMessagef(err, "failed verifying timelock release signature")
+	}
+	return nil
+}
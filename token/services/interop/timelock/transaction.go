@@ -0,0 +1,226 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"encoding/json"
+	"time"
+
+	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	ScriptType = "timelock" // timelock script
+)
+
+// WithBlockHeight sets the block height the ledger must reach before the locked token can be released
+func WithBlockHeight(height uint64) token.TransferOption {
+	return func(o *token.TransferOptions) error {
+		if o.Attributes == nil {
+			o.Attributes = map[interface{}]interface{}{}
+		}
+		o.Attributes["timelock.blockHeight"] = height
+		return nil
+	}
+}
+
+func compileTransferOptions(opts ...token.TransferOption) (*token.TransferOptions, error) {
+	txOptions := &token.TransferOptions{}
+	for _, opt := range opts {
+		if err := opt(txOptions); err != nil {
+			return nil, err
+		}
+	}
+	return txOptions, nil
+}
+
+type Binder interface {
+	Bind(longTerm view.Identity, ephemeral view.Identity) error
+}
+
+// Transaction holds a ttx transaction
+type Transaction struct {
+	*ttx.Transaction
+	Binder Binder
+	// ServiceProvider is used to get the credential revocation information of the owners of the script, if needed
+	ServiceProvider token.ServiceProvider
+}
+
+// NewTransaction returns a new token transaction customized with the passed opts that will be signed by the passed signer
+func NewTransaction(sp view.Context, signer view.Identity, opts ...ttx.TxOption) (*Transaction, error) {
+	tx, err := ttx.NewTransaction(sp, signer, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{
+		Transaction:     tx,
+		Binder:          view2.GetEndpointService(sp),
+		ServiceProvider: sp,
+	}, nil
+}
+
+// NewAnonymousTransaction returns a new anonymous token transaction customized with the passed opts
+func NewAnonymousTransaction(sp view.Context, opts ...ttx.TxOption) (*Transaction, error) {
+	tx, err := ttx.NewAnonymousTransaction(sp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{
+		Transaction:     tx,
+		Binder:          view2.GetEndpointService(sp),
+		ServiceProvider: sp,
+	}, nil
+}
+
+// NewTransactionFromBytes returns a new transaction from the passed bytes
+func NewTransactionFromBytes(ctx view.Context, network, channel string, raw []byte) (*Transaction, error) {
+	tx, err := ttx.NewTransactionFromBytes(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{
+		Transaction:     tx,
+		Binder:          view2.GetEndpointService(ctx),
+		ServiceProvider: ctx,
+	}, nil
+}
+
+// Lock appends a lock action to the token request of the transaction.
+// The locked token can be released by the recipient once the passed deadline has elapsed.
+// A zero deadline is allowed only if a block height is passed with WithBlockHeight, in which case
+// the token can be released once the ledger has reached that height.
+// A block height is rejected on the networks that do not provide the height of the ledger.
+func (t *Transaction) Lock(wallet *token.OwnerWallet, sender view.Identity, typ token2.Type, value uint64, recipient view.Identity, deadline time.Time, opts ...token.TransferOption) error {
+	options, err := compileTransferOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if recipient.IsNone() {
+		return errors.Errorf("must specify a recipient")
+	}
+
+	if sender == nil {
+		sender, err = wallet.GetRecipientIdentity()
+		if err != nil {
+			return errors.WithMessagef(err, "failed getting sender identity")
+		}
+	}
+
+	var blockHeight uint64
+	if options.Attributes != nil {
+		boxed, ok := options.Attributes["timelock.blockHeight"]
+		if ok {
+			blockHeight, ok = boxed.(uint64)
+			if !ok {
+				return errors.Errorf("expected timelock.blockHeight attribute to be uint64, got [%T]", boxed)
+			}
+		}
+	}
+	script := &Script{
+		Sender:      sender,
+		Recipient:   recipient,
+		Deadline:    deadline,
+		BlockHeight: blockHeight,
+	}
+	if err := script.Validate(time.Now()); err != nil {
+		return errors.WithMessagef(err, "invalid timelock script")
+	}
+	if blockHeight != 0 {
+		// the validator can check the block height only on the networks that provide it
+		tms := t.TokenService()
+		ledger, err := network.GetInstance(t.ServiceProvider, tms.Network(), tms.Channel()).Ledger()
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get ledger for [%s]", tms.ID())
+		}
+		if _, err := ledger.Height(); err != nil {
+			return errors.WithMessagef(err, "invalid timelock script, block height locks are not supported by [%s]", tms.ID())
+		}
+	}
+	scriptID, err := scriptAsIdentity(script)
+	if err != nil {
+		return err
+	}
+	_, err = t.TokenRequest.Transfer(
+		t.Transaction.Context,
+		wallet,
+		typ,
+		[]uint64{value},
+		[]view.Identity{scriptID},
+		opts...,
+	)
+	return err
+}
+
+// Release appends a release (transfer) action to the token request of the transaction.
+// The passed time-locked token is transferred to the recipient of the script.
+func (t *Transaction) Release(wallet *token.OwnerWallet, tok *token2.UnspentToken) error {
+	q, err := token2.ToQuantity(tok.Quantity, t.TokenRequest.TokenService.PublicParametersManager().PublicParameters().Precision())
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert quantity [%s]", tok.Quantity)
+	}
+	owner, err := identity.UnmarshalTypedIdentity(tok.Owner)
+	if err != nil {
+		return err
+	}
+	if owner.Type != ScriptType {
+		return errors.New("invalid owner type, expected timelock script")
+	}
+	script := &Script{}
+	if err := json.Unmarshal(owner.Identity, script); err != nil {
+		return errors.New("failed to unmarshal TypedIdentity as a timelock script")
+	}
+	if !script.Deadline.IsZero() && time.Now().Before(script.Deadline) {
+		return errors.Errorf("token locked until [%s]", script.Deadline)
+	}
+
+	// Register the signer for the release
+	logger.Debugf("registering signer for release...")
+	sigService := t.TokenService().SigService()
+	recipientSigner, err := sigService.GetSigner(script.Recipient)
+	if err != nil {
+		return err
+	}
+	recipientVerifier, err := sigService.OwnerVerifier(script.Recipient)
+	if err != nil {
+		return err
+	}
+	if err := sigService.RegisterSigner(
+		tok.Owner,
+		ttx.NewNonRevocationSigner(t.ServiceProvider, t.TokenService(), script.Recipient, recipientSigner),
+		&Verifier{
+			Recipient: recipientVerifier,
+			Deadline:  script.Deadline,
+		},
+	); err != nil {
+		return err
+	}
+
+	if err := t.Binder.Bind(script.Recipient, tok.Owner); err != nil {
+		return err
+	}
+
+	return t.Transfer(wallet, tok.Type, []uint64{q.ToBigInt().Uint64()}, []view.Identity{script.Recipient}, token.WithTokenIDs(tok.Id))
+}
+
+func scriptAsIdentity(script *Script) (view.Identity, error) {
+	rawScript, err := json.Marshal(script)
+	if err != nil {
+		return nil, err
+	}
+	ro := &identity.TypedIdentity{
+		Type:     ScriptType,
+		Identity: rawScript,
+	}
+	return ro.Bytes()
+}
@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package timelock
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+)
+
+// SelectFunction is the prototype of a function to select pairs (token,script)
+type SelectFunction = func(*token.UnspentToken, *Script) (bool, error)
+
+// SelectAll selects all time-locked tokens
+func SelectAll(tok *token.UnspentToken, script *Script) (bool, error) {
+	return true, nil
+}
+
+// SelectLocked selects the time-locked tokens whose deadline has not elapsed yet
+func SelectLocked(tok *token.UnspentToken, script *Script) (bool, error) {
+	now := time.Now()
+	logger.Debugf("[%v]>[%v], sender [%s], recipient [%s]?", script.Deadline, now, script.Sender.UniqueID(), script.Recipient.UniqueID())
+	return !script.Deadline.IsZero() && script.Deadline.After(now), nil
+}
+
+// SelectReleasable selects the time-locked tokens whose deadline has elapsed.
+// The block height condition, if any, is checked by the validator at release time.
+func SelectReleasable(tok *token.UnspentToken, script *Script) (bool, error) {
+	now := time.Now()
+	logger.Debugf("[%v]<=[%v], sender [%s], recipient [%s]?", script.Deadline, now, script.Sender.UniqueID(), script.Recipient.UniqueID())
+	return script.Deadline.IsZero() || !script.Deadline.After(now), nil
+}
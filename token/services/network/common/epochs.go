@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	"github.com/pkg/errors"
+)
+
+// EpochReader gives access to the public parameters epochs recorded in a namespace
+type EpochReader interface {
+	// ReadCurrentPublicParamsEpoch returns the number of the current public parameters epoch
+	ReadCurrentPublicParamsEpoch() (uint64, error)
+	// ReadPublicParamsEpoch returns the epoch of the public parameters with the passed hash, nil if not found
+	ReadPublicParamsEpoch(ppHash []byte) (*translator.PublicParamsEpoch, error)
+}
+
+// EpochValidators selects the validator of a token request depending on the public parameters the request has been
+// prepared with, as recorded in the request itself.
+// A request prepared with the public parameters of a past epoch is accepted as long as no more than GraceEpochs
+// epochs have started since then.
+type EpochValidators[V any] struct {
+	// GraceEpochs is the number of past epochs whose public parameters are still accepted
+	GraceEpochs uint64
+	// NewValidator returns a validator for the passed raw public parameters
+	NewValidator func(ppRaw []byte) (V, error)
+
+	lock       sync.RWMutex
+	validators map[string]V
+}
+
+func NewEpochValidators[V any](graceEpochs uint64, newValidator func(ppRaw []byte) (V, error)) *EpochValidators[V] {
+	return &EpochValidators[V]{
+		GraceEpochs:  graceEpochs,
+		NewValidator: newValidator,
+		validators:   map[string]V{},
+	}
+}
+
+// Select returns the validator for the passed marshalled token request.
+// The current validator, bound to the public parameters with hash currentHash, is returned if the request carries
+// that same hash, or no hash at all.
+// The current validator is returned also when the request cannot be unmarshalled, it is up to it to reject the request.
+// Falling back to the current validator is safe because the hash is part of the message signed by the owners,
+// the issuers, and the auditors: a request of an expired epoch whose hash has been removed, or replaced,
+// no longer carries valid signatures, and the current validator rejects it.
+// Requests prepared before public parameters epochs were introduced carry no hash and are verified, as before,
+// against the current public parameters only.
+func (e *EpochValidators[V]) Select(reader EpochReader, current V, currentHash []byte, raw []byte) (V, error) {
+	var zero V
+	tr := &driver.TokenRequest{}
+	if err := tr.FromBytes(raw); err != nil || len(tr.PublicParamsHash) == 0 || bytes.Equal(tr.PublicParamsHash, currentHash) {
+		return current, nil
+	}
+	ppHash := hex.EncodeToString(tr.PublicParamsHash)
+
+	epoch, err := reader.ReadPublicParamsEpoch(tr.PublicParamsHash)
+	if err != nil {
+		return zero, errors.WithMessagef(err, "failed to read epoch of public parameters [%s]", ppHash)
+	}
+	if epoch == nil {
+		return zero, errors.Errorf("token request prepared with unknown public parameters [%s]", ppHash)
+	}
+	currentEpoch, err := reader.ReadCurrentPublicParamsEpoch()
+	if err != nil {
+		return zero, errors.WithMessagef(err, "failed to read current public parameters epoch")
+	}
+	if currentEpoch > epoch.Number && currentEpoch-epoch.Number > e.GraceEpochs {
+		return zero, errors.Errorf(
+			"token request prepared with expired public parameters [%s] of epoch [%d], current epoch is [%d], grace window is [%d] epochs",
+			ppHash, epoch.Number, currentEpoch, e.GraceEpochs,
+		)
+	}
+
+	e.lock.RLock()
+	v, ok := e.validators[ppHash]
+	e.lock.RUnlock()
+	if ok {
+		return v, nil
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if v, ok := e.validators[ppHash]; ok {
+		return v, nil
+	}
+	v, err = e.NewValidator(epoch.PublicParams)
+	if err != nil {
+		return zero, errors.WithMessagef(err, "failed to instantiate validator for public parameters [%s] of epoch [%d]", ppHash, epoch.Number)
+	}
+	e.validators[ppHash] = v
+	return v, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type epochReader struct {
+	current uint64
+	epochs  map[string]*translator.PublicParamsEpoch
+}
+
+func (e *epochReader) ReadCurrentPublicParamsEpoch() (uint64, error) {
+	return e.current, nil
+}
+
+func (e *epochReader) ReadPublicParamsEpoch(ppHash []byte) (*translator.PublicParamsEpoch, error) {
+	return e.epochs[string(ppHash)], nil
+}
+
+func TestEpochValidators(t *testing.T) {
+	reader := &epochReader{
+		current: 3,
+		epochs: map[string]*translator.PublicParamsEpoch{
+			"h1": {Number: 1, PublicParams: []byte("pp_1")},
+			"h2": {Number: 2, PublicParams: []byte("pp_2")},
+			"h3": {Number: 3, PublicParams: []byte("pp_3")},
+		},
+	}
+	instantiated := 0
+	ev := NewEpochValidators(1, func(ppRaw []byte) (string, error) {
+		instantiated++
+		return string(ppRaw), nil
+	})
+	request := func(ppHash string) []byte {
+		raw, err := (&driver.TokenRequest{PublicParamsHash: []byte(ppHash)}).Bytes()
+		assert.NoError(t, err)
+		return raw
+	}
+
+	// current public parameters, no hash, or garbage
+	for _, raw := range [][]byte{request("h3"), request(""), []byte("garbage")} {
+		v, err := ev.Select(reader, "current", []byte("h3"), raw)
+		assert.NoError(t, err)
+		assert.Equal(t, "current", v)
+	}
+
+	// within the grace window
+	v, err := ev.Select(reader, "current", []byte("h3"), request("h2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "pp_2", v)
+	v, err = ev.Select(reader, "current", []byte("h3"), request("h2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "pp_2", v)
+	assert.Equal(t, 1, instantiated)
+
+	// expired
+	_, err = ev.Select(reader, "current", []byte("h3"), request("h1"))
+	assert.ErrorContains(t, err, "expired public parameters")
+
+	// unknown
+	_, err = ev.Select(reader, "current", []byte("h3"), request("h4"))
+	assert.ErrorContains(t, err, "unknown public parameters")
+}
+
+// signedValidator accepts a token request if its signature is the message to sign, as the signatures of the
+// owners, issuers, and auditors over a request would be
+type signedValidator struct{}
+
+func (signedValidator) Verify(raw []byte) error {
+	tr := &driver.TokenRequest{}
+	if err := tr.FromBytes(raw); err != nil {
+		return err
+	}
+	msg, err := tr.MarshalToMessageToSign([]byte("anchor"))
+	if err != nil {
+		return err
+	}
+	if len(tr.Signatures) != 1 || !bytes.Equal(tr.Signatures[0], msg) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func TestEpochValidatorsFallback(t *testing.T) {
+	reader := &epochReader{
+		current: 3,
+		epochs: map[string]*translator.PublicParamsEpoch{
+			"h1": {Number: 1, PublicParams: []byte("pp_1")},
+			"h3": {Number: 3, PublicParams: []byte("pp_3")},
+		},
+	}
+	ev := NewEpochValidators(1, func(ppRaw []byte) (signedValidator, error) {
+		return signedValidator{}, nil
+	})
+	signed := func(ppHash string) *driver.TokenRequest {
+		tr := &driver.TokenRequest{Issues: [][]byte{[]byte("issue")}, PublicParamsHash: []byte(ppHash)}
+		msg, err := tr.MarshalToMessageToSign([]byte("anchor"))
+		assert.NoError(t, err)
+		tr.Signatures = [][]byte{msg}
+		return tr
+	}
+	bytesOf := func(tr *driver.TokenRequest) []byte {
+		raw, err := tr.Bytes()
+		assert.NoError(t, err)
+		return raw
+	}
+
+	// a request of an expired epoch is rejected
+	tr := signed("h1")
+	_, err := ev.Select(reader, signedValidator{}, []byte("h3"), bytesOf(tr))
+	assert.ErrorContains(t, err, "expired public parameters [6831] of epoch [1], current epoch is [3]")
+
+	// without its hash, or with the current one, the same request goes to the current validator,
+	// which rejects it because the hash is signed
+	for _, ppHash := range []string{"", "h3"} {
+		tr.PublicParamsHash = []byte(ppHash)
+		v, err := ev.Select(reader, signedValidator{}, []byte("h3"), bytesOf(tr))
+		assert.NoError(t, err)
+		assert.EqualError(t, v.Verify(bytesOf(tr)), "invalid signature")
+	}
+
+	// a request prepared without a hash goes to the current validator, which accepts it
+	tr = signed("")
+	v, err := ev.Select(reader, signedValidator{}, []byte("h3"), bytesOf(tr))
+	assert.NoError(t, err)
+	assert.NoError(t, v.Verify(bytesOf(tr)))
+}
@@ -0,0 +1,230 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replay
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.services.network.common.replay")
+
+// Request is a token request to replay
+type Request struct {
+	// Anchor is the anchor, the transaction id, the token request is bound to
+	Anchor string `json:"anchor"`
+	// Raw is the serialized token request
+	Raw []byte `json:"request"`
+	// BlockHeight is the height of the ledger the token request was committed at. Zero means unknown
+	BlockHeight uint64 `json:"height,omitempty"`
+}
+
+// Result is the outcome of the replay of a token request
+type Result struct {
+	// Anchor is the anchor of the replayed token request
+	Anchor string
+	// Err is the reason why the token request has been rejected, if any
+	Err error
+}
+
+// Token is an unspent output stored on the ledger
+type Token struct {
+	// ID is the identifier of the output
+	ID token.ID `json:"id"`
+	// Value is the output as stored on the ledger
+	Value []byte `json:"value"`
+}
+
+// Replayer replays a chain of token requests against an in-memory ledger.
+// Each token request is first verified with the validator, against the state left by the token requests that precede it,
+// then it is written to the ledger by the translator, as the token chaincode does.
+// A rejected token request leaves the ledger untouched.
+type Replayer struct {
+	Validator     driver.Validator
+	KeyTranslator translator.KeyTranslator
+	Ledger        *Ledger
+	// Namespace is the namespace the token requests are replayed in, if known
+	Namespace string
+
+	// outputs are the identifiers of all the outputs created so far, spent or not
+	outputs []token.ID
+}
+
+// New returns a new Replayer whose ledger contains only the passed public parameters
+func New(ppRaw []byte, validator driver.Validator) (*Replayer, error) {
+	r := &Replayer{
+		Validator:     validator,
+		KeyTranslator: &keys.Translator{},
+		Ledger:        NewLedger(),
+	}
+	rws := r.Ledger.newRWSet()
+	w := translator.New("", translator.NewRWSetWrapper(rws, "", ""), r.KeyTranslator)
+	if err := w.Write(&setupAction{raw: ppRaw}); err != nil {
+		return nil, errors.Wrap(err, "failed to write public parameters")
+	}
+	rws.commit()
+	return r, nil
+}
+
+// ReplayAll replays the passed token requests in order and returns the outcome of each of them
+func (r *Replayer) ReplayAll(ctx context.Context, requests []*Request) []*Result {
+	results := make([]*Result, len(requests))
+	for i, request := range requests {
+		results[i] = &Result{Anchor: request.Anchor, Err: r.Replay(ctx, request)}
+	}
+	return results
+}
+
+// Replay verifies the passed token request and, if valid, applies it to the ledger
+func (r *Replayer) Replay(ctx context.Context, request *Request) error {
+	if request.BlockHeight != 0 {
+		ctx = driver.WithBlockHeight(ctx, request.BlockHeight)
+	}
+	if len(r.Namespace) != 0 {
+		ctx = driver.WithNamespace(ctx, r.Namespace)
+	}
+	actions, attributes, err := r.Validator.VerifyTokenRequestFromRaw(ctx, r.getState, request.Anchor, request.Raw)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to verify token request [%s]", request.Anchor)
+	}
+
+	rws := r.Ledger.newRWSet()
+	w := translator.New(request.Anchor, translator.NewRWSetWrapper(rws, "", request.Anchor), r.KeyTranslator)
+	numOutputs := 0
+	for _, action := range actions {
+		if err := w.Write(action); err != nil {
+			return errors.WithMessagef(err, "failed to write token action of [%s]", request.Anchor)
+		}
+		if a, ok := action.(interface{ NumOutputs() int }); ok {
+			numOutputs += a.NumOutputs()
+		}
+	}
+	if err := w.AddPublicParamsDependency(); err != nil {
+		return errors.WithMessagef(err, "failed to add public params dependency for [%s]", request.Anchor)
+	}
+	if _, err := w.CommitTokenRequest(attributes[common.TokenRequestToSign], true); err != nil {
+		return errors.WithMessagef(err, "failed to write token request [%s]", request.Anchor)
+	}
+	rws.commit()
+	logger.Debugf("token request [%s] applied, [%d] outputs created", request.Anchor, numOutputs)
+
+	for i := 0; i < numOutputs; i++ {
+		r.outputs = append(r.outputs, token.ID{TxId: request.Anchor, Index: uint64(i)})
+	}
+	return nil
+}
+
+// Unspent returns the outputs that are on the ledger, sorted by transaction id and index
+func (r *Replayer) Unspent() ([]*Token, error) {
+	var res []*Token
+	for _, id := range r.outputs {
+		value, err := r.getState(id)
+		if err != nil {
+			return nil, err
+		}
+		if len(value) == 0 {
+			// spent or redeemed
+			continue
+		}
+		res = append(res, &Token{ID: id, Value: value})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].ID.TxId != res[j].ID.TxId {
+			return res[i].ID.TxId < res[j].ID.TxId
+		}
+		return res[i].ID.Index < res[j].ID.Index
+	})
+	return res, nil
+}
+
+func (r *Replayer) getState(id token.ID) ([]byte, error) {
+	key, err := translator.StateKey(r.KeyTranslator, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed getting token key for [%v]", id)
+	}
+	return r.Ledger.GetState("", key)
+}
+
+// Ledger is an in-memory key-value store
+type Ledger struct {
+	lock   sync.RWMutex
+	states map[string][]byte
+}
+
+// NewLedger returns a new empty Ledger
+func NewLedger() *Ledger {
+	return &Ledger{states: map[string][]byte{}}
+}
+
+// GetState returns the value bound to the passed key in the passed namespace, nil if the key does not exist
+func (l *Ledger) GetState(namespace string, key string) ([]byte, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.states[ledgerKey(namespace, key)], nil
+}
+
+func (l *Ledger) newRWSet() *rwSet {
+	return &rwSet{ledger: l, writes: map[string][]byte{}}
+}
+
+func (l *Ledger) apply(writes map[string][]byte) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	for key, value := range writes {
+		if len(value) == 0 {
+			delete(l.states, key)
+			continue
+		}
+		l.states[key] = value
+	}
+}
+
+func ledgerKey(namespace string, key string) string {
+	return namespace + "\x00" + key
+}
+
+// rwSet buffers the writes of a transaction until it is committed.
+// As in Fabric, reads return the committed state, they do not see the writes of the same transaction.
+type rwSet struct {
+	ledger *Ledger
+	writes map[string][]byte
+}
+
+func (r *rwSet) SetState(namespace string, key string, value []byte) error {
+	r.writes[ledgerKey(namespace, key)] = value
+	return nil
+}
+
+func (r *rwSet) GetState(namespace string, key string) ([]byte, error) {
+	return r.ledger.GetState(namespace, key)
+}
+
+func (r *rwSet) DeleteState(namespace string, key string) error {
+	r.writes[ledgerKey(namespace, key)] = nil
+	return nil
+}
+
+func (r *rwSet) commit() {
+	r.ledger.apply(r.writes)
+}
+
+type setupAction struct {
+	raw []byte
+}
+
+func (s *setupAction) GetSetupParameters() ([]byte, error) {
+	return s.raw, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package replay
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver/mock"
+	mock2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	issue := &mock2.IssueAction{}
+	issue.NumOutputsReturns(2)
+	issue.GetSerializedOutputsReturns([][]byte{[]byte("o1"), []byte("o2")}, nil)
+
+	transfer := &mock2.TransferAction{}
+	transfer.NumOutputsReturns(1)
+	transfer.SerializeOutputAtReturns([]byte("o3"), nil)
+	transfer.GetInputsReturns([]*token.ID{{TxId: "tx1", Index: 0}})
+	transfer.GetSerializedInputsReturns([][]byte{[]byte("o1")}, nil)
+
+	validator := &mock.Validator{}
+	validator.VerifyTokenRequestFromRawStub = func(ctx context.Context, getState driver.GetStateFnc, anchor string, raw []byte) ([]interface{}, driver.ValidationAttributes, error) {
+		switch string(raw) {
+		case "issue":
+			return []interface{}{issue}, driver.ValidationAttributes{}, nil
+		case "transfer":
+			// the input must be on the ledger when the transfer is verified
+			value, err := getState(token.ID{TxId: "tx1", Index: 0})
+			if err != nil || len(value) == 0 {
+				return nil, nil, errors.New("input not found")
+			}
+			return []interface{}{transfer}, driver.ValidationAttributes{}, nil
+		default:
+			return nil, nil, errors.New("invalid token request")
+		}
+	}
+
+	r, err := New([]byte("pp"), validator)
+	assert.NoError(t, err)
+	results := r.ReplayAll(context.Background(), []*Request{
+		{Anchor: "tx1", Raw: []byte("issue")},
+		{Anchor: "tx2", Raw: []byte("transfer")},
+		// rejected by the validator
+		{Anchor: "tx3", Raw: []byte("garbage")},
+		// double spending, rejected by the translator
+		{Anchor: "tx4", Raw: []byte("transfer")},
+		// the anchor has been already used
+		{Anchor: "tx1", Raw: []byte("issue")},
+	})
+	assert.Len(t, results, 5)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.ErrorContains(t, results[2].Err, "failed to verify token request [tx3]: invalid token request")
+	assert.ErrorContains(t, results[3].Err, "failed to verify token request [tx4]: input not found")
+	assert.ErrorContains(t, results[4].Err, "failed to write token request [tx1]")
+
+	unspent, err := r.Unspent()
+	assert.NoError(t, err)
+	assert.Equal(t, []*Token{
+		{ID: token.ID{TxId: "tx1", Index: 1}, Value: []byte("o2")},
+		{ID: token.ID{TxId: "tx2", Index: 0}, Value: []byte("o3")},
+	}, unspent)
+}
+
+func TestReplayDoubleSpending(t *testing.T) {
+	issue := &mock2.IssueAction{}
+	issue.NumOutputsReturns(1)
+	issue.GetSerializedOutputsReturns([][]byte{[]byte("o1")}, nil)
+
+	transfer := &mock2.TransferAction{}
+	transfer.NumOutputsReturns(1)
+	transfer.SerializeOutputAtReturns([]byte("o2"), nil)
+	transfer.GetInputsReturns([]*token.ID{{TxId: "tx1", Index: 0}})
+	transfer.GetSerializedInputsReturns([][]byte{[]byte("o1")}, nil)
+
+	validator := &mock.Validator{}
+	validator.VerifyTokenRequestFromRawStub = func(ctx context.Context, getState driver.GetStateFnc, anchor string, raw []byte) ([]interface{}, driver.ValidationAttributes, error) {
+		if string(raw) == "issue" {
+			return []interface{}{issue}, driver.ValidationAttributes{}, nil
+		}
+		// a validator that does not look at the ledger
+		return []interface{}{transfer}, driver.ValidationAttributes{}, nil
+	}
+
+	r, err := New([]byte("pp"), validator)
+	assert.NoError(t, err)
+	assert.NoError(t, r.Replay(context.Background(), &Request{Anchor: "tx1", Raw: []byte("issue")}))
+	assert.NoError(t, r.Replay(context.Background(), &Request{Anchor: "tx2", Raw: []byte("transfer")}))
+	err = r.Replay(context.Background(), &Request{Anchor: "tx3", Raw: []byte("transfer")})
+	assert.ErrorContains(t, err, "input must exist")
+
+	unspent, err := r.Unspent()
+	assert.NoError(t, err)
+	assert.Equal(t, []*Token{{ID: token.ID{TxId: "tx2", Index: 0}, Value: []byte("o2")}}, unspent)
+
+	// the block height is passed to the validator
+	assert.NoError(t, r.Replay(context.Background(), &Request{Anchor: "tx4", Raw: []byte("issue"), BlockHeight: 42}))
+	ctx, _, _, _ := validator.VerifyTokenRequestFromRawArgsForCall(3)
+	height, ok := driver.BlockHeightFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), height)
+}
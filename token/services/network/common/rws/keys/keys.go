@@ -26,10 +26,14 @@ const (
 	OutputSNKeyPrefix            = "osn"
 	TokenSetupKeyPrefix          = "se"
 	TokenSetupHashKeyPrefix      = "seh"
+	TokenSetupEpochKeyPrefix     = "see"
+	TokenSetupEpochParamsPrefix  = "sep"
 	TokenRequestKeyPrefix        = "tr"
 	InputSerialNumberPrefix      = "sn"
 	IssueActionMetadataPrefix    = "iam"
 	TransferActionMetadataPrefix = "tam"
+	SupplyKeyPrefix              = "sup"
+	RevocationListKeyPrefix      = "rvl"
 )
 
 type Translator struct {
@@ -47,6 +51,14 @@ func (t *Translator) CreateSetupHashKey() (translator.Key, error) {
 	return createCompositeKey(TokenSetupHashKeyPrefix, nil)
 }
 
+func (t *Translator) CreateSetupEpochKey() (translator.Key, error) {
+	return createCompositeKey(TokenSetupEpochKeyPrefix, nil)
+}
+
+func (t *Translator) CreateSetupEpochParamsKey(ppHash string) (translator.Key, error) {
+	return createCompositeKey(TokenSetupEpochParamsPrefix, []string{ppHash})
+}
+
 func (t *Translator) CreateOutputSNKey(id string, index uint64, output []byte) (translator.Key, error) {
 	hf := sha256.New()
 	hf.Write([]byte(OutputSNKeyPrefix))
@@ -62,6 +74,14 @@ func (t *Translator) CreateOutputKey(id string, index uint64) (translator.Key, e
 	return createCompositeKey(id, []string{strconv.FormatUint(index, 10)})
 }
 
+func (t *Translator) CreateSupplyKey(tokenType string) (translator.Key, error) {
+	return createCompositeKey(SupplyKeyPrefix, []string{tokenType})
+}
+
+func (t *Translator) CreateRevocationListKey() (translator.Key, error) {
+	return createCompositeKey(RevocationListKeyPrefix, nil)
+}
+
 func (t *Translator) GetTransferMetadataSubKey(k string) (translator.Key, error) {
 	prefix, components, err := splitCompositeKey(k)
 	if err != nil {
@@ -12,6 +12,26 @@ type SetupAction interface {
 	GetSetupParameters() ([]byte, error)
 }
 
+// SupplyAction carries the new circulating supply of the token types whose supply has been changed by a token request
+type SupplyAction interface {
+	// GetSupplyCounters returns the new circulating supply of each token type, encoded as stored on the ledger
+	GetSupplyCounters() map[token.Type][]byte
+}
+
+// RevocationAction carries the new revocation list of issuers and auditors, see driver.RevocationList
+type RevocationAction interface {
+	// GetRevocationList returns the new revocation list, encoded as stored on the ledger
+	GetRevocationList() []byte
+}
+
+// FreezeAction carries the tokens frozen, or unfrozen, by a compliance authority, see driver.Freeze
+type FreezeAction interface {
+	// GetFrozenTokens returns the identifiers of the tokens to freeze, or unfreeze
+	GetFrozenTokens() []*token.ID
+	// IsUnfreeze returns true if the tokens must be unfrozen
+	IsUnfreeze() bool
+}
+
 //go:generate counterfeiter -o mock/issue_action.go -fake-name IssueAction . IssueAction
 
 type IssueAction interface {
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package translator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// PublicParamsEpoch is a version of the public parameters recorded in the namespace.
+// A new epoch starts each time the setup action installs public parameters that differ from the current ones.
+type PublicParamsEpoch struct {
+	// Number is the sequence number of the epoch, starting from 1
+	Number uint64
+	// PublicParams are the raw public parameters of the epoch
+	PublicParams []byte
+}
+
+// ReadCurrentPublicParamsEpoch returns the number of the current public parameters epoch.
+// It returns zero if no epoch has been recorded yet.
+func (t *Translator) ReadCurrentPublicParamsEpoch() (uint64, error) {
+	key, err := t.KeyTranslator.CreateSetupEpochKey()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to create setup epoch key")
+	}
+	raw, err := t.RWSet.GetState(key)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to get current setup epoch")
+	}
+	if len(raw) == 0 {
+		return 0, nil
+	}
+	epoch, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid setup epoch [%s]", string(raw))
+	}
+	return epoch, nil
+}
+
+// ReadPublicParamsEpoch returns the epoch of the public parameters with the passed hash.
+// It returns nil if the public parameters have never been installed in the namespace.
+func (t *Translator) ReadPublicParamsEpoch(ppHash []byte) (*PublicParamsEpoch, error) {
+	key, err := t.KeyTranslator.CreateSetupEpochParamsKey(hex.EncodeToString(ppHash))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create setup epoch params key")
+	}
+	raw, err := t.RWSet.GetState(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get setup epoch for [%s]", hex.EncodeToString(ppHash))
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	epoch := &PublicParamsEpoch{}
+	if err := json.Unmarshal(raw, epoch); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal setup epoch for [%s]", hex.EncodeToString(ppHash))
+	}
+	return epoch, nil
+}
+
+// commitPublicParamsEpoch starts a new epoch for the passed public parameters, unless they are already the
+// public parameters of the current epoch.
+// If no epoch has been recorded yet, the public parameters installed before epochs were introduced, if any,
+// are recorded first as the bootstrap epoch, so that the requests prepared with them are still accepted
+// within the grace window.
+func (t *Translator) commitPublicParamsEpoch(previous []byte, raw []byte, digest []byte) error {
+	current, err := t.ReadCurrentPublicParamsEpoch()
+	if err != nil {
+		return err
+	}
+	if current == 0 && len(previous) != 0 && !bytes.Equal(previous, raw) {
+		previousDigest := sha256.Sum256(previous)
+		if err := t.writePublicParamsEpoch(&PublicParamsEpoch{Number: 1, PublicParams: previous}, previousDigest[:]); err != nil {
+			return err
+		}
+		logger.Debugf("public parameters [%s] installed before epochs recorded at bootstrap epoch [1]", hex.EncodeToString(previousDigest[:]))
+		current = 1
+	}
+	epoch, err := t.ReadPublicParamsEpoch(digest)
+	if err != nil {
+		return err
+	}
+	if epoch != nil && epoch.Number == current {
+		logger.Debugf("public parameters [%s] already installed at epoch [%d]", hex.EncodeToString(digest), current)
+		return nil
+	}
+
+	epoch = &PublicParamsEpoch{Number: current + 1, PublicParams: raw}
+	if err := t.writePublicParamsEpoch(epoch, digest); err != nil {
+		return err
+	}
+	logger.Debugf("public parameters [%s] installed at epoch [%d]", hex.EncodeToString(digest), epoch.Number)
+	return nil
+}
+
+// writePublicParamsEpoch records the passed epoch for the public parameters with the passed digest, and makes it the current one
+func (t *Translator) writePublicParamsEpoch(epoch *PublicParamsEpoch, digest []byte) error {
+	epochRaw, err := json.Marshal(epoch)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal setup epoch [%d]", epoch.Number)
+	}
+	paramsKey, err := t.KeyTranslator.CreateSetupEpochParamsKey(hex.EncodeToString(digest))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create setup epoch params key")
+	}
+	if err := t.RWSet.SetState(paramsKey, epochRaw); err != nil {
+		return errors.Wrapf(err, "failed to write setup epoch [%d]", epoch.Number)
+	}
+	epochKey, err := t.KeyTranslator.CreateSetupEpochKey()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create setup epoch key")
+	}
+	if err := t.RWSet.SetState(epochKey, []byte(strconv.FormatUint(epoch.Number, 10))); err != nil {
+		return errors.Wrapf(err, "failed to write current setup epoch [%d]", epoch.Number)
+	}
+	return nil
+}
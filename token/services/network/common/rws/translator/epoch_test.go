@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package translator_test
+
+import (
+	"crypto/sha256"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type setupAction []byte
+
+func (s setupAction) GetSetupParameters() ([]byte, error) {
+	return s, nil
+}
+
+var _ = Describe("Public parameters epochs", func() {
+	var (
+		state  map[string][]byte
+		writer func(txID string) *translator.Translator
+	)
+
+	BeforeEach(func() {
+		state = map[string][]byte{}
+		fakeRWSet := &mock.RWSet{}
+		fakeRWSet.GetStateStub = func(_ string, key string) ([]byte, error) {
+			return state[key], nil
+		}
+		fakeRWSet.SetStateStub = func(_ string, key string, value []byte) error {
+			state[key] = value
+			return nil
+		}
+		writer = func(txID string) *translator.Translator {
+			return translator.New(txID, translator.NewRWSetWrapper(fakeRWSet, tokenNameSpace, txID), &keys.Translator{})
+		}
+	})
+
+	digest := func(raw []byte) []byte {
+		h := sha256.Sum256(raw)
+		return h[:]
+	}
+
+	It("starts a new epoch each time the public parameters change", func() {
+		current, err := writer("0").ReadCurrentPublicParamsEpoch()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(current).To(Equal(uint64(0)))
+
+		Expect(writer("1").Write(setupAction("pp_1"))).To(Succeed())
+		Expect(writer("2").Write(setupAction("pp_1"))).To(Succeed())
+		Expect(writer("3").Write(setupAction("pp_2"))).To(Succeed())
+
+		current, err = writer("4").ReadCurrentPublicParamsEpoch()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(current).To(Equal(uint64(2)))
+
+		epoch, err := writer("4").ReadPublicParamsEpoch(digest([]byte("pp_1")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(epoch).To(Equal(&translator.PublicParamsEpoch{Number: 1, PublicParams: []byte("pp_1")}))
+		epoch, err = writer("4").ReadPublicParamsEpoch(digest([]byte("pp_2")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(epoch).To(Equal(&translator.PublicParamsEpoch{Number: 2, PublicParams: []byte("pp_2")}))
+		epoch, err = writer("4").ReadPublicParamsEpoch(digest([]byte("pp_3")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(epoch).To(BeNil())
+
+		// going back to old public parameters starts a new epoch
+		Expect(writer("5").Write(setupAction("pp_1"))).To(Succeed())
+		epoch, err = writer("6").ReadPublicParamsEpoch(digest([]byte("pp_1")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(epoch.Number).To(Equal(uint64(3)))
+	})
+
+	It("records the public parameters installed before epochs as the bootstrap epoch", func() {
+		setupKey, err := (&keys.Translator{}).CreateSetupKey()
+		Expect(err).NotTo(HaveOccurred())
+		state[setupKey] = []byte("pp_0")
+
+		Expect(writer("1").Write(setupAction("pp_1"))).To(Succeed())
+
+		current, err := writer("2").ReadCurrentPublicParamsEpoch()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(current).To(Equal(uint64(2)))
+		epoch, err := writer("2").ReadPublicParamsEpoch(digest([]byte("pp_0")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(epoch).To(Equal(&translator.PublicParamsEpoch{Number: 1, PublicParams: []byte("pp_0")}))
+		epoch, err = writer("2").ReadPublicParamsEpoch(digest([]byte("pp_1")))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(epoch).To(Equal(&translator.PublicParamsEpoch{Number: 2, PublicParams: []byte("pp_1")}))
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package translator
+
+import (
+	"github.com/pkg/errors"
+)
+
+// MultiTranslator writes the token requests of several namespaces into the same RWSet.
+// Each namespace gets its own Translator, therefore the token requests of all namespaces
+// end up in a single transaction that either commits all of them or none.
+type MultiTranslator struct {
+	TxID        string
+	namespaces  []string
+	translators map[string]*Translator
+}
+
+// NewMulti returns a new MultiTranslator for the passed namespaces, that must be non-empty and distinct.
+func NewMulti(txID string, rws RWSet, keyTranslator KeyTranslator, namespaces ...string) (*MultiTranslator, error) {
+	if len(namespaces) == 0 {
+		return nil, errors.New("no namespace specified")
+	}
+	translators := make(map[string]*Translator, len(namespaces))
+	for _, namespace := range namespaces {
+		if len(namespace) == 0 {
+			return nil, errors.New("empty namespace")
+		}
+		if _, ok := translators[namespace]; ok {
+			return nil, errors.Errorf("namespace [%s] specified more than once", namespace)
+		}
+		translators[namespace] = New(txID, NewRWSetWrapper(rws, namespace, txID), keyTranslator)
+	}
+	return &MultiTranslator{
+		TxID:        txID,
+		namespaces:  namespaces,
+		translators: translators,
+	}, nil
+}
+
+// Namespaces returns the namespaces this translator writes to, in the order they were passed at creation time
+func (m *MultiTranslator) Namespaces() []string {
+	return m.namespaces
+}
+
+// Translator returns the translator bound to the passed namespace
+func (m *MultiTranslator) Translator(namespace string) (*Translator, error) {
+	t, ok := m.translators[namespace]
+	if !ok {
+		return nil, errors.Errorf("namespace [%s] not found in transaction [%s]", namespace, m.TxID)
+	}
+	return t, nil
+}
+
+// Write writes the passed action into the passed namespace
+func (m *MultiTranslator) Write(namespace string, action any) error {
+	t, err := m.Translator(namespace)
+	if err != nil {
+		return err
+	}
+	return t.Write(action)
+}
+
+// CommitTokenRequest commits the passed token request into the passed namespace
+func (m *MultiTranslator) CommitTokenRequest(namespace string, raw []byte, storeHash bool) ([]byte, error) {
+	t, err := m.Translator(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return t.CommitTokenRequest(raw, storeHash)
+}
+
+// AddPublicParamsDependency adds a dependency on the public parameters of each namespace
+func (m *MultiTranslator) AddPublicParamsDependency() error {
+	for _, namespace := range m.namespaces {
+		if err := m.translators[namespace].AddPublicParamsDependency(); err != nil {
+			return errors.WithMessagef(err, "failed to add public params dependency for namespace [%s]", namespace)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package translator_test
+
+import (
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator/mock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MultiTranslator", func() {
+	var (
+		fakeRWSet     *mock.RWSet
+		keyTranslator translator.KeyTranslator
+	)
+
+	BeforeEach(func() {
+		fakeRWSet = &mock.RWSet{}
+		keyTranslator = &keys.Translator{}
+		fakeRWSet.GetStateReturns(nil, nil)
+		fakeRWSet.SetStateReturns(nil)
+	})
+
+	When("the namespaces are not valid", func() {
+		It("fails", func() {
+			_, err := translator.NewMulti("0", fakeRWSet, keyTranslator)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("no namespace specified"))
+
+			_, err = translator.NewMulti("0", fakeRWSet, keyTranslator, "ns1", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("empty namespace"))
+
+			_, err = translator.NewMulti("0", fakeRWSet, keyTranslator, "ns1", "ns2", "ns1")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("namespace [ns1] specified more than once"))
+		})
+	})
+
+	When("the namespaces are valid", func() {
+		var writer *translator.MultiTranslator
+
+		BeforeEach(func() {
+			var err error
+			writer, err = translator.NewMulti("0", fakeRWSet, keyTranslator, "ns1", "ns2")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("writes each token request into its own namespace", func() {
+			setupKey, err := keyTranslator.CreateSetupHashKey()
+			Expect(err).NotTo(HaveOccurred())
+			fakeRWSet.GetStateStub = func(namespace string, key string) ([]byte, error) {
+				if key == setupKey {
+					return []byte("pp-hash"), nil
+				}
+				return nil, nil
+			}
+			Expect(writer.Namespaces()).To(Equal([]string{"ns1", "ns2"}))
+
+			fakeissue := &mock.IssueAction{}
+			fakeissue.GetSerializedOutputsReturns([][]byte{[]byte("output-1")}, nil)
+			fakeissue.NumOutputsReturns(1)
+			Expect(writer.Write("ns2", fakeissue)).To(Succeed())
+			Expect(writer.AddPublicParamsDependency()).To(Succeed())
+			_, err = writer.CommitTokenRequest("ns1", []byte("request-1"), false)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = writer.CommitTokenRequest("ns2", []byte("request-2"), false)
+			Expect(err).NotTo(HaveOccurred())
+
+			requestKey, err := keyTranslator.CreateTokenRequestKey("0")
+			Expect(err).NotTo(HaveOccurred())
+			written := map[string]map[string][]byte{}
+			for i := 0; i < fakeRWSet.SetStateCallCount(); i++ {
+				ns, key, value := fakeRWSet.SetStateArgsForCall(i)
+				if written[ns] == nil {
+					written[ns] = map[string][]byte{}
+				}
+				written[ns][key] = value
+			}
+			outputKey, err := keyTranslator.CreateOutputKey("0", 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(written["ns1"]).NotTo(HaveKey(outputKey))
+			Expect(written["ns2"]).To(HaveKeyWithValue(outputKey, []byte("output-1")))
+			Expect(written["ns1"]).To(HaveKeyWithValue(requestKey, []byte("request-1")))
+			Expect(written["ns2"]).To(HaveKeyWithValue(requestKey, []byte("request-2")))
+
+			// the public parameters dependency is added for each namespace
+			var readNamespaces []string
+			for i := 0; i < fakeRWSet.GetStateCallCount(); i++ {
+				ns, key := fakeRWSet.GetStateArgsForCall(i)
+				if key == setupKey {
+					readNamespaces = append(readNamespaces, ns)
+				}
+			}
+			Expect(readNamespaces).To(Equal([]string{"ns1", "ns2"}))
+		})
+
+		It("rejects unknown namespaces", func() {
+			err := writer.Write("ns3", &mock.IssueAction{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("namespace [ns3] not found in transaction [0]"))
+			_, err = writer.CommitTokenRequest("ns3", []byte("request"), false)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -11,6 +11,8 @@ import (
 
 	"github.com/gobuffalo/packr/v2/file/resolver/encoding/hex"
 	"github.com/hyperledger-labs/fabric-smart-client/pkg/utils/errors"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 )
 
 type (
@@ -30,8 +32,16 @@ type KeyTranslator interface {
 	CreateSetupKey() (Key, error)
 	// CreateSetupHashKey creates the key for the hashed public parameters
 	CreateSetupHashKey() (Key, error)
+	// CreateSetupEpochKey creates the key for the number of the current public parameters epoch
+	CreateSetupEpochKey() (Key, error)
+	// CreateSetupEpochParamsKey creates the key for the epoch of the public parameters with the passed hex-encoded hash
+	CreateSetupEpochParamsKey(ppHash string) (Key, error)
 	// CreateOutputKey creates the key for an output
 	CreateOutputKey(id string, index uint64) (Key, error)
+	// CreateSupplyKey creates the key for the circulating supply of the passed token type
+	CreateSupplyKey(tokenType string) (Key, error)
+	// CreateRevocationListKey creates the key for the revocation list of issuers and auditors
+	CreateRevocationListKey() (Key, error)
 	// CreateOutputSNKey creates the key for the serial number of an output
 	CreateOutputSNKey(id string, index uint64, output []byte) (Key, error)
 	// CreateInputSNKey creates the key for the serial number of an input
@@ -46,6 +56,19 @@ type KeyTranslator interface {
 	TransferActionMetadataKeyPrefix() (Key, error)
 }
 
+// StateKey returns the key under which the state the validator reads with the passed id is stored.
+// The reserved ids of the supply counters, see driver.SupplyCounterID, and of the revocation list, see driver.RevocationListID,
+// are mapped to their own keys, all the other ids to the key of the matching output.
+func StateKey(kt KeyTranslator, id token.ID) (Key, error) {
+	if tokenType, ok := driver.SupplyCounterType(id); ok {
+		return kt.CreateSupplyKey(string(tokenType))
+	}
+	if id == driver.RevocationListID() {
+		return kt.CreateRevocationListKey()
+	}
+	return kt.CreateOutputKey(id.TxId, id.Index)
+}
+
 // RWSet interface, used to read from, and write to, a rwset.
 type RWSet interface {
 	SetState(namespace string, key string, value []byte) error
@@ -201,6 +224,38 @@ func (h *HashedKeyTranslator) CreateTransferActionMetadataKey(key Key) (Key, err
 	return h.hash(8, k)
 }
 
+func (h *HashedKeyTranslator) CreateSetupEpochKey() (Key, error) {
+	k, err := h.KT.CreateSetupEpochKey()
+	if err != nil {
+		return "", err
+	}
+	return h.hash(9, k)
+}
+
+func (h *HashedKeyTranslator) CreateSetupEpochParamsKey(ppHash string) (Key, error) {
+	k, err := h.KT.CreateSetupEpochParamsKey(ppHash)
+	if err != nil {
+		return "", err
+	}
+	return h.hash(10, k)
+}
+
+func (h *HashedKeyTranslator) CreateSupplyKey(tokenType string) (Key, error) {
+	k, err := h.KT.CreateSupplyKey(tokenType)
+	if err != nil {
+		return "", err
+	}
+	return h.hash(11, k)
+}
+
+func (h *HashedKeyTranslator) CreateRevocationListKey() (Key, error) {
+	k, err := h.KT.CreateRevocationListKey()
+	if err != nil {
+		return "", err
+	}
+	return h.hash(12, k)
+}
+
 func (h *HashedKeyTranslator) TransferActionMetadataKeyPrefix() (Key, error) {
 	// TODO:
 	return "", nil
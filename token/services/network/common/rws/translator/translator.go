@@ -8,7 +8,9 @@ package translator
 
 import (
 	"crypto/sha256"
+	"slices"
 
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
@@ -44,6 +46,15 @@ func New(txID string, rws ExRWSet, keyTranslator KeyTranslator) *Translator {
 func (t *Translator) Write(action interface{}) error {
 	logger.Debugf("checking transaction with txID '%s'", t.TxID)
 
+	if driver.IsSupplyCounterTxID(t.TxID) {
+		return errors.Errorf("invalid transaction id [%s], the prefix [%s] is reserved", t.TxID, driver.SupplyCounterTxIDPrefix)
+	}
+	if t.TxID == driver.RevocationListTxID {
+		return errors.Errorf("invalid transaction id [%s], it is reserved", t.TxID)
+	}
+	if driver.IsFrozenTokenTxID(t.TxID) {
+		return errors.Errorf("invalid transaction id [%s], the prefix [%s] is reserved", t.TxID, driver.FrozenTokenTxIDPrefix)
+	}
 	err := t.checkProcess(action)
 	if err != nil {
 		return err
@@ -111,6 +122,19 @@ func (t *Translator) ReadSetupParameters() ([]byte, error) {
 	return raw, nil
 }
 
+// ReadRevocationList returns the revocation list stored in the namespace, nil if none has been stored yet
+func (t *Translator) ReadRevocationList() ([]byte, error) {
+	key, err := t.KeyTranslator.CreateRevocationListKey()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create revocation list key")
+	}
+	raw, err := t.RWSet.GetState(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get revocation list")
+	}
+	return raw, nil
+}
+
 func (t *Translator) AddPublicParamsDependency() error {
 	setupKey, err := t.KeyTranslator.CreateSetupHashKey()
 	if err != nil {
@@ -198,6 +222,12 @@ func (t *Translator) checkAction(tokenAction interface{}) error {
 		return t.checkTransfer(action)
 	case SetupAction:
 		return nil
+	case SupplyAction:
+		return nil
+	case RevocationAction:
+		return nil
+	case FreezeAction:
+		return nil
 	default:
 		return errors.Errorf("unknown token action: %T", action)
 	}
@@ -247,15 +277,82 @@ func (t *Translator) commitAction(tokenAction interface{}) (err error) {
 		err = t.commitTransferAction(action)
 	case SetupAction:
 		err = t.commitSetupAction(action)
+	case SupplyAction:
+		err = t.commitSupplyAction(action)
+	case RevocationAction:
+		err = t.commitRevocationAction(action)
+	case FreezeAction:
+		err = t.commitFreezeAction(action)
 	}
 	return
 }
 
+func (t *Translator) commitRevocationAction(revocationAction RevocationAction) error {
+	key, err := t.KeyTranslator.CreateRevocationListKey()
+	if err != nil {
+		return errors.Wrapf(err, "failed to create revocation list key")
+	}
+	if err := t.RWSet.SetState(key, revocationAction.GetRevocationList()); err != nil {
+		return errors.Wrapf(err, "failed to write revocation list")
+	}
+	return nil
+}
+
+// commitFreezeAction stores the frozen marker of each token to freeze, and removes the one of each token to unfreeze
+func (t *Translator) commitFreezeAction(freezeAction FreezeAction) error {
+	for _, tokenID := range freezeAction.GetFrozenTokens() {
+		id := driver.FrozenTokenID(tokenID)
+		key, err := t.KeyTranslator.CreateOutputKey(id.TxId, id.Index)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create frozen marker key for [%s]", tokenID)
+		}
+		if freezeAction.IsUnfreeze() {
+			if err := t.RWSet.DeleteState(key); err != nil {
+				return errors.Wrapf(err, "failed to remove frozen marker of [%s]", tokenID)
+			}
+			continue
+		}
+		if err := t.RWSet.SetState(key, NotEmpty); err != nil {
+			return errors.Wrapf(err, "failed to write frozen marker of [%s]", tokenID)
+		}
+	}
+	return nil
+}
+
+// commitSupplyAction stores the new circulating supply of each token type.
+// The validator reads the counters with no read dependency, therefore, each counter is read here before it is written,
+// so that two requests that change the same counter cannot both commit, and no change is lost.
+func (t *Translator) commitSupplyAction(supplyAction SupplyAction) error {
+	counters := supplyAction.GetSupplyCounters()
+	tokenTypes := make([]token.Type, 0, len(counters))
+	for tokenType := range counters {
+		tokenTypes = append(tokenTypes, tokenType)
+	}
+	slices.Sort(tokenTypes)
+	for _, tokenType := range tokenTypes {
+		key, err := t.KeyTranslator.CreateSupplyKey(string(tokenType))
+		if err != nil {
+			return errors.Wrapf(err, "failed to create supply key for token type [%s]", tokenType)
+		}
+		if _, err := t.RWSet.GetState(key); err != nil {
+			return errors.Wrapf(err, "failed to read supply for token type [%s]", tokenType)
+		}
+		if err := t.RWSet.SetState(key, counters[tokenType]); err != nil {
+			return errors.Wrapf(err, "failed to write supply for token type [%s]", tokenType)
+		}
+	}
+	return nil
+}
+
 func (t *Translator) commitSetupAction(setup SetupAction) error {
 	raw, err := setup.GetSetupParameters()
 	if err != nil {
 		return err
 	}
+	previous, err := t.ReadSetupParameters()
+	if err != nil {
+		return err
+	}
 	setupKey, err := t.KeyTranslator.CreateSetupKey()
 	if err != nil {
 		return err
@@ -284,7 +381,7 @@ func (t *Translator) commitSetupAction(setup SetupAction) error {
 		return err
 	}
 
-	return nil
+	return t.commitPublicParamsEpoch(previous, raw, digest)
 }
 
 func (t *Translator) commitIssueAction(issueAction IssueAction) error {
@@ -430,6 +527,19 @@ func (t *Translator) checkInputs(action ActionWithInputs) error {
 			return errors.Wrapf(err, "invalid transfer: input must exist")
 		}
 	}
+
+	// the validator checks the frozen markers with no read dependency,
+	// we read them here so that a freeze committed concurrently invalidates the transaction
+	for _, input := range inputs {
+		frozen := driver.FrozenTokenID(input)
+		key, err := t.KeyTranslator.CreateOutputKey(frozen.TxId, frozen.Index)
+		if err != nil {
+			return errors.Wrapf(err, "invalid transfer: failed creating frozen marker key for [%v]", input)
+		}
+		if _, err := t.RWSet.GetState(key); err != nil {
+			return errors.Wrapf(err, "failed to read frozen marker of [%v]", input)
+		}
+	}
 	return nil
 }
 
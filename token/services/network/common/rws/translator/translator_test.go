@@ -9,6 +9,8 @@ package translator_test
 import (
 	"strconv"
 
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator/mock"
@@ -317,4 +319,231 @@ var _ = Describe("Translator", func() {
 			})
 		})
 	})
+
+	Describe("Supply", func() {
+		When("supply action is valid", func() {
+			It("succeeds", func() {
+				err := writer.Write(&common.SupplyAction{Supplies: map[token.Type][]byte{
+					"USD": []byte("100"),
+					"EUR": []byte("50"),
+				}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(2))
+
+				ns, id, out := fakeRWSet.SetStateArgsForCall(0)
+				Expect(ns).To(Equal(tokenNameSpace))
+				key, err := keyTranslator.CreateSupplyKey("EUR")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(key))
+				Expect(out).To(Equal([]byte("50")))
+
+				ns, id, out = fakeRWSet.SetStateArgsForCall(1)
+				Expect(ns).To(Equal(tokenNameSpace))
+				key, err = keyTranslator.CreateSupplyKey("USD")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(key))
+				Expect(out).To(Equal([]byte("100")))
+
+				key, err = translator.StateKey(keyTranslator, driver.SupplyCounterID("USD"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(key), "the validator reads the supply counter under the supply key")
+
+				// each counter is read before it is written, to add a read dependency on it
+				Expect(fakeRWSet.GetStateCallCount()).To(Equal(2))
+				_, id = fakeRWSet.GetStateArgsForCall(1)
+				Expect(id).To(Equal(key))
+			})
+		})
+		When("two issues change the same counter concurrently", func() {
+			It("only one of them commits", func() {
+				ledger := newVersionedLedger()
+				supplyCaps := map[token.Type]uint64{"USD": 100}
+				key, err := keyTranslator.CreateSupplyKey("USD")
+				Expect(err).NotTo(HaveOccurred())
+
+				// both issues are endorsed against the same circulating supply, and both are within the cap
+				endorse := func(txID string) *versionedRWSet {
+					attributes := driver.ValidationAttributes{}
+					Expect(common.IncreaseSupply(ledger, attributes, supplyCaps, "USD", 60)).To(Succeed())
+					rws := ledger.newRWSet()
+					Expect(translator.New(txID, translator.NewRWSetWrapper(rws, tokenNameSpace, txID), keyTranslator).Write(common.NewSupplyActionFromAttributes(attributes))).To(Succeed())
+					return rws
+				}
+				rws1 := endorse("tx1")
+				rws2 := endorse("tx2")
+
+				// they are committed in the same block, the second fails the version check of the counter
+				Expect(ledger.commit(rws1)).To(Succeed())
+				Expect(ledger.commit(rws2)).To(MatchError(ContainSubstring("read conflict")))
+				Expect(ledger.state[key]).To(Equal(driver.MarshalSupply(60)))
+			})
+		})
+		When("the transaction id is reserved", func() {
+			BeforeEach(func() {
+				writer = translator.New("supply.USD", translator.NewRWSetWrapper(fakeRWSet, tokenNameSpace, "supply.USD"), keyTranslator)
+			})
+			It("fails", func() {
+				err := writer.Write(fakeissue)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("invalid transaction id [supply.USD], the prefix [supply.] is reserved"))
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Freeze", func() {
+		When("freeze action is valid", func() {
+			It("stores the frozen markers", func() {
+				err := writer.Write(&common.FreezeAction{TokenIDs: []*token.ID{{TxId: "tx1", Index: 2}}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(1))
+
+				ns, id, out := fakeRWSet.SetStateArgsForCall(0)
+				Expect(ns).To(Equal(tokenNameSpace))
+				key, err := keyTranslator.CreateOutputKey("frozen.tx1", 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(key))
+				Expect(out).To(Equal(translator.NotEmpty))
+			})
+		})
+		When("unfreeze action is valid", func() {
+			It("removes the frozen markers", func() {
+				err := writer.Write(&common.FreezeAction{TokenIDs: []*token.ID{{TxId: "tx1", Index: 2}}, Unfreeze: true})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(0))
+				Expect(fakeRWSet.DeleteStateCallCount()).To(Equal(1))
+
+				ns, id := fakeRWSet.DeleteStateArgsForCall(0)
+				Expect(ns).To(Equal(tokenNameSpace))
+				key, err := keyTranslator.CreateOutputKey("frozen.tx1", 2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(key))
+			})
+		})
+		When("a transfer spends a token frozen concurrently", func() {
+			It("only one of them commits", func() {
+				ledger := newVersionedLedger()
+				tokenID := &token.ID{TxId: "tx1", Index: 2}
+				key, err := keyTranslator.CreateOutputSNKey(tokenID.TxId, tokenID.Index, []byte("token"))
+				Expect(err).NotTo(HaveOccurred())
+				ledger.state[key] = []byte{1}
+
+				freeze := ledger.newRWSet()
+				Expect(translator.New("tx2", translator.NewRWSetWrapper(freeze, tokenNameSpace, "tx2"), keyTranslator).Write(&common.FreezeAction{TokenIDs: []*token.ID{tokenID}})).To(Succeed())
+
+				// the transfer is endorsed while the token is not frozen yet
+				frozen, err := driver.IsTokenFrozen(ledger, tokenID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(frozen).To(BeFalse())
+				faketransfer.GetInputsReturns([]*token.ID{tokenID})
+				faketransfer.GetSerializedInputsReturns([][]byte{[]byte("token")}, nil)
+				transfer := ledger.newRWSet()
+				Expect(translator.New("tx3", translator.NewRWSetWrapper(transfer, tokenNameSpace, "tx3"), keyTranslator).Write(faketransfer)).To(Succeed())
+
+				// they are committed in the same block, the transfer fails the version check of the frozen marker
+				Expect(ledger.commit(freeze)).To(Succeed())
+				Expect(ledger.commit(transfer)).To(MatchError(ContainSubstring("read conflict")))
+			})
+		})
+		When("the transaction id is reserved", func() {
+			BeforeEach(func() {
+				writer = translator.New("frozen.tx1", translator.NewRWSetWrapper(fakeRWSet, tokenNameSpace, "frozen.tx1"), keyTranslator)
+			})
+			It("fails", func() {
+				err := writer.Write(fakeissue)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("invalid transaction id [frozen.tx1], the prefix [frozen.] is reserved"))
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Revocation list", func() {
+		When("revocation action is valid", func() {
+			It("succeeds", func() {
+				err := writer.Write(&common.RevocationAction{List: []byte("list")})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(1))
+
+				ns, id, out := fakeRWSet.SetStateArgsForCall(0)
+				Expect(ns).To(Equal(tokenNameSpace))
+				key, err := keyTranslator.CreateRevocationListKey()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(key))
+				Expect(out).To(Equal([]byte("list")))
+				key, err = translator.StateKey(keyTranslator, driver.RevocationListID())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(id).To(Equal(key), "the validator reads the revocation list under its own key")
+			})
+		})
+		When("the transaction id is reserved", func() {
+			BeforeEach(func() {
+				writer = translator.New(driver.RevocationListTxID, translator.NewRWSetWrapper(fakeRWSet, tokenNameSpace, driver.RevocationListTxID), keyTranslator)
+			})
+			It("fails", func() {
+				err := writer.Write(fakeissue)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("invalid transaction id [revocation.list], it is reserved"))
+				Expect(fakeRWSet.SetStateCallCount()).To(Equal(0))
+			})
+		})
+	})
 })
+
+// versionedLedger is a key-value store that checks, on commit, that the keys read by a transaction
+// have not changed since they have been read
+type versionedLedger struct {
+	state    map[string][]byte
+	versions map[string]int
+}
+
+func newVersionedLedger() *versionedLedger {
+	return &versionedLedger{state: map[string][]byte{}, versions: map[string]int{}}
+}
+
+// GetState reads the state with no read dependency, as the validator does
+func (l *versionedLedger) GetState(id token.ID) ([]byte, error) {
+	key, err := translator.StateKey(&keys.Translator{}, id)
+	if err != nil {
+		return nil, err
+	}
+	return l.state[key], nil
+}
+
+func (l *versionedLedger) newRWSet() *versionedRWSet {
+	return &versionedRWSet{ledger: l, reads: map[string]int{}, writes: map[string][]byte{}}
+}
+
+func (l *versionedLedger) commit(rws *versionedRWSet) error {
+	for key, version := range rws.reads {
+		if l.versions[key] != version {
+			return errors.Errorf("read conflict on [%s]", key)
+		}
+	}
+	for key, value := range rws.writes {
+		l.state[key] = value
+		l.versions[key]++
+	}
+	return nil
+}
+
+type versionedRWSet struct {
+	ledger *versionedLedger
+	reads  map[string]int
+	writes map[string][]byte
+}
+
+func (r *versionedRWSet) SetState(_ string, key string, value []byte) error {
+	r.writes[key] = value
+	return nil
+}
+
+func (r *versionedRWSet) GetState(_ string, key string) ([]byte, error) {
+	r.reads[key] = r.ledger.versions[key]
+	return r.ledger.state[key], nil
+}
+
+func (r *versionedRWSet) DeleteState(_ string, key string) error {
+	r.writes[key] = nil
+	return nil
+}
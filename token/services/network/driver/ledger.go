@@ -10,4 +10,6 @@ package driver
 type Ledger interface {
 	// Status returns the status of the transaction
 	Status(id string) (ValidationCode, error)
+	// Height returns the current height of the ledger
+	Height() (uint64, error)
 }
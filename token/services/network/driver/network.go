@@ -34,6 +34,12 @@ func (t *TxID) String() string {
 	return fmt.Sprintf("[%s:%s]", base64.StdEncoding.EncodeToString(t.Nonce), base64.StdEncoding.EncodeToString(t.Creator))
 }
 
+// ApprovalRequest is a token request to be approved in the namespace of the given token management service
+type ApprovalRequest struct {
+	TMS        *token2.ManagementService
+	RequestRaw []byte
+}
+
 // Network models a backend that stores tokens
 type Network interface {
 	// Name returns the name of the network
@@ -55,8 +61,12 @@ type Network interface {
 	// RequestApproval requests approval for the passed request and returns the returned envelope
 	RequestApproval(context view.Context, tms *token2.ManagementService, requestRaw []byte, signer view.Identity, txID TxID) (Envelope, error)
 
+	// RequestApprovals requests approval for the passed requests, each bound to a different namespace,
+	// and returns the envelope of a single transaction that commits all of them atomically
+	RequestApprovals(context view.Context, requests []*ApprovalRequest, signer view.Identity, txID TxID) (Envelope, error)
+
 	// ComputeTxID computes the network transaction id from the passed abstract transaction id
-	ComputeTxID(id *TxID) string
+	ComputeTxID(id *TxID) (string, error)
 
 	// FetchPublicParameters returns the public parameters for the network.
 	// If namespace is not supported, the argument is ignored.
@@ -68,6 +78,10 @@ type Network interface {
 	// AreTokensSpent retrieves the spent flag for the passed ids
 	AreTokensSpent(context context.Context, namespace string, tokenIDs []*token.ID, meta []string) ([]bool, error)
 
+	// QueryRevocationList returns the revocation list of issuers and auditors stored in the passed namespace,
+	// nil if none has been stored yet
+	QueryRevocationList(context context.Context, namespace string) ([]byte, error)
+
 	// LocalMembership returns the local membership
 	LocalMembership() LocalMembership
 
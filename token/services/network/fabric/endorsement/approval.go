@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package endorsement
 
 import (
+	context2 "context"
+	"sync"
 	"time"
 
 	fabric2 "github.com/hyperledger-labs/fabric-smart-client/platform/fabric"
@@ -14,8 +16,10 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
 	driver2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
@@ -35,6 +39,15 @@ type RequestApprovalView struct {
 	Nonce []byte
 	// Endorsers are the identities of the FSC node that play the role of endorser
 	Endorsers []view.Identity
+	// Requests, if not empty, are additional token requests, each bound to a different namespace,
+	// to be committed in the same transaction of the main token request.
+	Requests []*NamespaceRequest
+}
+
+// NamespaceRequest is a token request to be committed in the namespace of the given TMS
+type NamespaceRequest struct {
+	TMSID      token2.TMSID
+	RequestRaw []byte
 }
 
 func (r *RequestApprovalView) Call(context view.Context) (interface{}, error) {
@@ -71,6 +84,11 @@ func (r *RequestApprovalView) Call(context view.Context) (interface{}, error) {
 			return nil, errors.WithMessagef(err, "failed to set token request transient")
 		}
 	}
+	if len(r.Requests) != 0 {
+		if err := tx.SetTransientState("token_requests", r.Requests); err != nil {
+			return nil, errors.WithMessagef(err, "failed to set token requests transient")
+		}
+	}
 
 	logger.Debugf("Request Endorsement on tx [%s] to [%v]...", tx.ID(), r.Endorsers)
 	_, err = context.RunView(endorser.NewParallelCollectEndorsementsOnProposalView(
@@ -94,6 +112,7 @@ func (r *RequestApprovalView) Call(context view.Context) (interface{}, error) {
 }
 
 type Translator interface {
+	common2.EpochReader
 	AddPublicParamsDependency() error
 	CommitTokenRequest(raw []byte, storeHash bool) ([]byte, error)
 	Write(action any) error
@@ -104,10 +123,17 @@ type TranslatorProviderFunc = func(txID string, namespace string, rws *fabric2.R
 type RequestApprovalResponderView struct {
 	keyTranslator translator.KeyTranslator
 	getTranslator TranslatorProviderFunc
+
+	epochsLock sync.Mutex
+	epochs     map[string]*common2.EpochValidators[*token2.Validator]
 }
 
 func NewRequestApprovalResponderView(keyTranslator translator.KeyTranslator, getTranslator TranslatorProviderFunc) *RequestApprovalResponderView {
-	return &RequestApprovalResponderView{keyTranslator: keyTranslator, getTranslator: getTranslator}
+	return &RequestApprovalResponderView{
+		keyTranslator: keyTranslator,
+		getTranslator: getTranslator,
+		epochs:        map[string]*common2.EpochValidators[*token2.Validator]{},
+	}
 }
 
 func (r *RequestApprovalResponderView) Call(context view.Context) (interface{}, error) {
@@ -136,14 +162,53 @@ func (r *RequestApprovalResponderView) Call(context view.Context) (interface{},
 		return nil, errors.Errorf("failed to get token request from transient [%s], it is empty", tx.ID())
 	}
 	requestAnchor := string(tx.GetTransient("RequestAnchor"))
+	if token2.IsBoundAnchor(requestAnchor) {
+		return nil, errors.Errorf("invalid request anchor [%s] for [%s], it cannot carry a binding", requestAnchor, tx.ID())
+	}
 	if len(requestAnchor) == 0 {
 		requestAnchor = tx.ID()
 	}
 
-	logger.Debugf("evaluate token request on TMS [%s]", tmsID)
-	tms := token2.GetManagementService(context, token2.WithTMSID(tmsID))
-	if tms == nil {
-		return nil, errors.Errorf("cannot find TMS for [%s]", tmsID)
+	// collect the token requests to approve, one per namespace
+	requests := []*NamespaceRequest{{TMSID: tmsID, RequestRaw: requestRaw}}
+	if len(tx.GetTransient("token_requests")) != 0 {
+		var others []*NamespaceRequest
+		if err := tx.GetTransientState("token_requests", &others); err != nil {
+			return nil, errors.WithMessagef(err, "failed to get token requests from transient [%s]", tx.ID())
+		}
+		requests = append(requests, others...)
+	}
+	tmss := make([]*token2.ManagementService, len(requests))
+	namespaces := make([]string, len(requests))
+	requestsRaw := make([][]byte, len(requests))
+	seen := map[string]bool{}
+	for i, request := range requests {
+		if len(request.RequestRaw) == 0 {
+			return nil, errors.Errorf("failed to get token request for [%s] from transient [%s], it is empty", request.TMSID, tx.ID())
+		}
+		if request.TMSID.Network != tmsID.Network || request.TMSID.Channel != tmsID.Channel {
+			return nil, errors.Errorf("token request for [%s] does not belong to the network and channel of [%s]", request.TMSID, tmsID)
+		}
+		if seen[request.TMSID.Namespace] {
+			return nil, errors.Errorf("more than one token request for namespace [%s] in [%s]", request.TMSID.Namespace, tx.ID())
+		}
+		seen[request.TMSID.Namespace] = true
+		namespaces[i] = request.TMSID.Namespace
+		requestsRaw[i] = request.RequestRaw
+
+		logger.Debugf("evaluate token request on TMS [%s]", request.TMSID)
+		tmss[i] = token2.GetManagementService(context, token2.WithTMSID(request.TMSID))
+		if tmss[i] == nil {
+			return nil, errors.Errorf("cannot find TMS for [%s]", request.TMSID)
+		}
+	}
+	tms := tmss[0]
+
+	// the token requests of a multi-namespace transaction are signed over the binding of all of them,
+	// therefore a token request is rejected if any of the others is missing
+	anchor, err := token2.BoundAnchorFromRaw(requestAnchor, namespaces, requestsRaw)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to bind the token requests of [%s]", tx.ID())
 	}
 
 	rws, err := tx.RWSet()
@@ -157,18 +222,36 @@ func (r *RequestApprovalResponderView) Call(context view.Context) (interface{},
 		return nil, errors.WithMessagef(err, "cannot find fabric network for [%s]", tms.Network())
 	}
 
-	// validate token request
-	logger.Debugf("Validate TX [%s]", tx.ID())
-	actions, validationMetadata, err := r.validate(context, tms, tx, requestAnchor, requestRaw, func(id token.ID) ([]byte, error) {
-		key, err := r.keyTranslator.CreateOutputKey(id.TxId, id.Index)
+	// the ledger height is made available to the validator for the scripts that depend on it
+	validationContext := context.Context()
+	if ch, err := fns.Channel(tms.Channel()); err != nil {
+		logger.Warnf("cannot find channel [%s], the block height will not be available to the validator: %s", tms.Channel(), err)
+	} else if info, err := ch.Ledger().GetLedgerInfo(); err != nil {
+		logger.Warnf("cannot get ledger info for [%s], the block height will not be available to the validator: %s", tms.Channel(), err)
+	} else {
+		validationContext = driver2.WithBlockHeight(validationContext, info.Height)
+	}
+
+	// validate token requests
+	actions := make([][]any, len(requests))
+	validationMetadata := make([]map[string][]byte, len(requests))
+	for i, request := range requests {
+		logger.Debugf("Validate TX [%s] on namespace [%s]", tx.ID(), tmss[i].Namespace())
+		namespace := tmss[i].Namespace()
+		epochs, err := r.getTranslator(tx.ID(), namespace, rws)
 		if err != nil {
-			return nil, errors.WithMessagef(err, "failed to create token key for id [%s]", id)
+			return nil, errors.Wrapf(err, "failed to get translator for tx [%s]", tx.ID())
+		}
+		actions[i], validationMetadata[i], err = r.validate(context, driver2.WithNamespace(validationContext, namespace), tmss[i], tx, epochs, anchor, request.RequestRaw, func(id token.ID) ([]byte, error) {
+			key, err := translator.StateKey(r.keyTranslator, id)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to create token key for id [%s]", id)
+			}
+			return rws.GetDirectState(namespace, key)
+		})
+		if err != nil {
+			return nil, err
 		}
-		return rws.GetDirectState(tms.Namespace(), key)
-	})
-
-	if err != nil {
-		return nil, err
 	}
 
 	// endorse
@@ -178,11 +261,12 @@ func (r *RequestApprovalResponderView) Call(context view.Context) (interface{},
 		return nil, err
 	}
 
-	// write actions into the transaction
-	logger.Debugf("Translate TX [%s]", tx.ID())
-	err = r.translate(tms, tx, validationMetadata, rws, actions...)
-	if err != nil {
-		return nil, err
+	// write actions into the transaction, each token request in its own namespace
+	for i := range requests {
+		logger.Debugf("Translate TX [%s] on namespace [%s]", tx.ID(), tmss[i].Namespace())
+		if err := r.translate(tmss[i], tx, validationMetadata[i], rws, actions[i]...); err != nil {
+			return nil, err
+		}
 	}
 
 	logger.Debugf("Endorse proposal for TX [%s]", tx.ID())
@@ -225,8 +309,10 @@ func (r *RequestApprovalResponderView) translate(
 
 func (r *RequestApprovalResponderView) validate(
 	context view.Context,
+	validationContext context2.Context,
 	tms *token2.ManagementService,
 	tx *endorser.Transaction,
+	epochs common2.EpochReader,
 	anchor string,
 	requestRaw []byte,
 	getState driver2.GetStateFnc,
@@ -237,8 +323,12 @@ func (r *RequestApprovalResponderView) validate(
 	if err != nil {
 		return nil, nil, errors.WithMessagef(err, "failed to get validator [%s:%s]", tms.Network(), tms.Channel())
 	}
+	validator, err = r.selectValidator(context, tms, epochs, validator, requestRaw)
+	if err != nil {
+		return nil, nil, err
+	}
 	logger.Debugf("Unmarshal and verify with metadata for TX [%s]", tx.ID())
-	actions, meta, err := validator.UnmarshallAndVerifyWithMetadata(context.Context(), token2.NewLedgerFromGetter(getState), anchor, requestRaw)
+	actions, meta, err := validator.UnmarshallAndVerifyWithMetadata(validationContext, token2.NewLedgerFromGetter(getState), anchor, requestRaw)
 	if err != nil {
 		return nil, nil, errors.WithMessagef(err, "failed to verify token request for [%s]", tx.ID())
 	}
@@ -258,6 +348,56 @@ func (r *RequestApprovalResponderView) validate(
 	return actions, meta, nil
 }
 
+// selectValidator returns the validator of the public parameters the passed token request has been prepared with,
+// as long as they belong to an epoch within the grace window of the namespace, see PublicParamsGraceEpochsKey
+func (r *RequestApprovalResponderView) selectValidator(
+	context view.Context,
+	tms *token2.ManagementService,
+	reader common2.EpochReader,
+	current *token2.Validator,
+	requestRaw []byte,
+) (*token2.Validator, error) {
+	epochs, err := r.epochValidators(context, tms)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := epochs.Select(reader, current, tms.PublicParametersManager().PublicParamsHash(), requestRaw)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to select validator for [%s]", tms.ID())
+	}
+	return validator, nil
+}
+
+func (r *RequestApprovalResponderView) epochValidators(context view.Context, tms *token2.ManagementService) (*common2.EpochValidators[*token2.Validator], error) {
+	r.epochsLock.Lock()
+	defer r.epochsLock.Unlock()
+	if epochs, ok := r.epochs[tms.ID().String()]; ok {
+		return epochs, nil
+	}
+
+	ds, err := core.GetTokenDriverService(context)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get token driver service")
+	}
+	var graceEpochs uint64
+	if err := tms.Configuration().UnmarshalKey(PublicParamsGraceEpochsKey, &graceEpochs); err != nil {
+		return nil, errors.WithMessagef(err, "failed to load [%s]", PublicParamsGraceEpochsKey)
+	}
+	epochs := common2.NewEpochValidators(graceEpochs, func(ppRaw []byte) (*token2.Validator, error) {
+		pp, err := ds.PublicParametersFromBytes(ppRaw)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to unmarshal public parameters")
+		}
+		v, err := ds.NewDefaultValidator(pp)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to instantiate validator")
+		}
+		return token2.NewValidator(v), nil
+	})
+	r.epochs[tms.ID().String()] = epochs
+	return epochs, nil
+}
+
 func (r *RequestApprovalResponderView) endorserID(tms *token2.ManagementService, fns *fabric2.NetworkService) (view.Identity, error) {
 	var endorserIDLabel string
 	if err := tms.Configuration().UnmarshalKey("services.network.fabric.fsc_endorsement.id", &endorserIDLabel); err != nil {
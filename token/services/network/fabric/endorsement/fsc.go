@@ -22,6 +22,8 @@ const (
 	AmIAnEndorserKey = "services.network.fabric.fsc_endorsement.endorser"
 	EndorsersKey     = "services.network.fabric.fsc_endorsement.endorsers"
 	PolicyType       = "services.network.fabric.fsc_endorsement.policy.type"
+	// PublicParamsGraceEpochsKey is the number of past public parameters epochs whose token requests are still approved
+	PublicParamsGraceEpochsKey = "services.network.fabric.fsc_endorsement.publicParamsGraceEpochs"
 
 	OneOutNPolicy = "1outn"
 	AllPolicy     = "all"
@@ -100,23 +102,61 @@ func NewFSCService(
 }
 
 func (e *FSCService) Endorse(context view.Context, requestRaw []byte, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	endorsers := e.endorsers()
+	logger.Debugf("request approval via fts endrosers with policy [%s]: [%d]...", e.PolicyType, len(endorsers))
+
+	return e.requestApproval(context, &RequestApprovalView{
+		TMSID:      e.TmsID,
+		RequestRaw: requestRaw,
+		TxID:       txID,
+		Endorsers:  endorsers,
+	})
+}
+
+// EndorseRequests requests the approval of several token requests, each bound to a different namespace, served by the passed services.
+// The token requests are committed in a single transaction. Therefore, the endorsers of each namespace must be able to
+// validate the token requests of all the namespaces.
+func EndorseRequests(context view.Context, services []*FSCService, requests []*NamespaceRequest, txID driver.TxID) (driver.Envelope, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("no token request to endorse")
+	}
+	if len(services) != len(requests) {
+		return nil, errors.Errorf("expected [%d] endorsement services, got [%d]", len(requests), len(services))
+	}
+	seen := map[string]bool{}
 	var endorsers []view.Identity
+	for _, service := range services {
+		for _, endorser := range service.endorsers() {
+			if !seen[endorser.UniqueID()] {
+				seen[endorser.UniqueID()] = true
+				endorsers = append(endorsers, endorser)
+			}
+		}
+	}
+	logger.Debugf("request approval of [%d] token requests via fts endrosers [%d]...", len(requests), len(endorsers))
+
+	return services[0].requestApproval(context, &RequestApprovalView{
+		TMSID:      requests[0].TMSID,
+		RequestRaw: requests[0].RequestRaw,
+		TxID:       txID,
+		Endorsers:  endorsers,
+		Requests:   requests[1:],
+	})
+}
+
+func (e *FSCService) endorsers() []view.Identity {
 	switch e.PolicyType {
 	case OneOutNPolicy:
-		endorsers = []view.Identity{e.Endorsers[rand.Intn(len(e.Endorsers))]}
+		return []view.Identity{e.Endorsers[rand.Intn(len(e.Endorsers))]}
 	case AllPolicy:
-		endorsers = e.Endorsers
+		return e.Endorsers
 	default:
-		endorsers = e.Endorsers
+		return e.Endorsers
 	}
-	logger.Debugf("request approval via fts endrosers with policy [%s]: [%d]...", e.PolicyType, len(endorsers))
+}
 
-	envBoxed, err := e.ViewManager.InitiateView(&RequestApprovalView{
-		TMSID:      e.TmsID,
-		RequestRaw: requestRaw,
-		TxID:       txID,
-		Endorsers:  endorsers,
-	}, context.Context())
+func (e *FSCService) requestApproval(context view.Context, v *RequestApprovalView) (driver.Envelope, error) {
+	envBoxed, err := e.ViewManager.InitiateView(v, context.Context())
 	if err != nil {
 		return nil, errors.WithMessagef(err, "failed to request approval")
 	}
@@ -37,6 +37,7 @@ const (
 	QueryPublicParamsFunction = "queryPublicParams"
 	QueryTokensFunctions      = "queryTokens"
 	AreTokensSpent            = "areTokensSpent"
+	QueryRevocationList       = "queryRevocationList"
 )
 
 var logger = logging.MustGetLogger("token-sdk.network.fabric")
@@ -71,6 +72,14 @@ func (l *ledger) Status(id string) (driver.ValidationCode, error) {
 	}
 }
 
+func (l *ledger) Height() (uint64, error) {
+	info, err := l.l.GetLedgerInfo()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get ledger info")
+	}
+	return info.Height, nil
+}
+
 type ViewManager interface {
 	InitiateView(view view2.View, ctx context.Context) (interface{}, error)
 }
@@ -257,7 +266,30 @@ func (n *Network) RequestApproval(context view.Context, tms *token2.ManagementSe
 	return endorsement.Endorse(context, requestRaw, signer, txID)
 }
 
-func (n *Network) ComputeTxID(id *driver.TxID) string {
+// RequestApprovals requests the approval of the passed token requests in a single transaction.
+// This is supported only when all the involved namespaces are endorsed by FSC endorsers.
+func (n *Network) RequestApprovals(context view.Context, requests []*driver.ApprovalRequest, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	services := make([]*endorsement.FSCService, len(requests))
+	namespaceRequests := make([]*endorsement.NamespaceRequest, len(requests))
+	for i, request := range requests {
+		service, err := n.endorsementServiceProvider.Get(request.TMS.ID())
+		if err != nil {
+			return nil, errors.Wrapf(err, "network not connected [%s]", request.TMS.ID())
+		}
+		fscService, ok := service.(*endorsement.FSCService)
+		if !ok {
+			return nil, errors.Errorf("namespace [%s] is not endorsed by FSC endorsers, cannot commit multiple namespaces atomically", request.TMS.Namespace())
+		}
+		services[i] = fscService
+		namespaceRequests[i] = &endorsement.NamespaceRequest{
+			TMSID:      request.TMS.ID(),
+			RequestRaw: request.RequestRaw,
+		}
+	}
+	return endorsement.EndorseRequests(context, services, namespaceRequests, txID)
+}
+
+func (n *Network) ComputeTxID(id *driver.TxID) (string, error) {
 	logger.Debugf("compute tx id for [%s]", id.String())
 	temp := &fabric.TxID{
 		Nonce:   id.Nonce,
@@ -266,7 +298,7 @@ func (n *Network) ComputeTxID(id *driver.TxID) string {
 	res := n.n.TransactionManager().ComputeTxID(temp)
 	id.Nonce = temp.Nonce
 	id.Creator = temp.Creator
-	return res
+	return res, nil
 }
 
 func (n *Network) FetchPublicParameters(namespace string) ([]byte, error) {
@@ -281,6 +313,14 @@ func (n *Network) AreTokensSpent(ctx context.Context, namespace string, tokenIDs
 	return n.spentTokenQueryExecutor.QuerySpentTokens(ctx, namespace, tokenIDs, meta)
 }
 
+func (n *Network) QueryRevocationList(ctx context.Context, namespace string) ([]byte, error) {
+	raw, err := n.ch.Chaincode(namespace).Query(QueryRevocationList).WithContext(ctx).Call()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to query the token chaincode for the revocation list")
+	}
+	return raw, nil
+}
+
 func (n *Network) LocalMembership() driver.LocalMembership {
 	return &lm{
 		lm: n.n.LocalMembership(),
@@ -13,17 +13,23 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
+	"strconv"
 	"sync"
 
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 var logger = logging.MustGetLogger("token-sdk.tcc")
@@ -34,8 +40,11 @@ const (
 	QueryTokensFunctions      = "queryTokens"
 	AreTokensSpent            = "areTokensSpent"
 	QueryStates               = "queryStates"
+	QueryRevocationList       = "queryRevocationList"
 
 	PublicParamsPathVarEnv = "PUBLIC_PARAMS_FILE_PATH"
+	// PublicParamsGraceEpochsVarEnv is the number of past public parameters epochs whose token requests are still accepted
+	PublicParamsGraceEpochsVarEnv = "PUBLIC_PARAMS_GRACE_EPOCHS"
 )
 
 type Agent interface {
@@ -69,6 +78,8 @@ type TokenChaincode struct {
 
 	PPDigest             []byte
 	TokenServicesFactory func([]byte) (PublicParameters, Validator, error)
+	// Epochs selects the validator of the token requests prepared with the public parameters of past epochs
+	Epochs *common2.EpochValidators[Validator]
 }
 
 func (cc *TokenChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
@@ -78,6 +89,9 @@ func (cc *TokenChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	if err != nil {
 		return shim.Error(fmt.Sprintf("failed to get public parameters: %s", err))
 	}
+	if _, err := cc.GetValidator(Params); err != nil {
+		return shim.Error(fmt.Sprintf("failed to set up public parameters: %s", err))
+	}
 
 	w := translator.New(stub.GetTxID(), translator.NewRWSetWrapper(&rwsWrapper{stub: stub}, "", stub.GetTxID()), &keys.Translator{})
 	if err := w.Write(&SetupAction{SetupParameters: ppRaw}); err != nil {
@@ -135,6 +149,8 @@ func (cc *TokenChaincode) Invoke(stub shim.ChaincodeStubInterface) (res pb.Respo
 				return shim.Error("request to check if tokens are spent is empty")
 			}
 			return cc.AreTokensSpent(args[1], stub)
+		case QueryRevocationList:
+			return cc.QueryRevocationList(stub)
 		case QueryStates:
 			if len(args) != 2 {
 				return shim.Error("request to query states is empty")
@@ -192,12 +208,54 @@ func (cc *TokenChaincode) Initialize(builtInParams string) error {
 	if err != nil {
 		return errors.Wrap(err, "failed to instantiate public parameter manager and validator")
 	}
+	if err := checkPublicParameters(ppm); err != nil {
+		return err
+	}
 	cc.PublicParameters = ppm
 	cc.Validator = validator
+	cc.PPDigest = hash.Hashable(ppRaw).Raw()
+
+	graceEpochs, err := cc.ReadGraceEpochs()
+	if err != nil {
+		return err
+	}
+	cc.Epochs = common2.NewEpochValidators(graceEpochs, func(ppRaw []byte) (Validator, error) {
+		ppm, validator, err := cc.TokenServicesFactory(ppRaw)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPublicParameters(ppm); err != nil {
+			return nil, err
+		}
+		return validator, nil
+	})
+
+	return nil
+}
 
+// checkPublicParameters returns an error if the passed public parameters need what the token chaincode cannot provide.
+// This is the case of the revocation of the idemix owners, that requires the height of the ledger at validation time.
+func checkPublicParameters(pp PublicParameters) error {
+	if driver.IsOwnerRevocationEnabled(pp) {
+		return errors.New("the public parameters enable the revocation of the idemix owners, which requires the ledger height at validation time, the token chaincode cannot provide it")
+	}
 	return nil
 }
 
+// ReadGraceEpochs returns the number of past public parameters epochs whose token requests are still accepted,
+// as set by the environment variable PUBLIC_PARAMS_GRACE_EPOCHS. It defaults to zero.
+func (cc *TokenChaincode) ReadGraceEpochs() (uint64, error) {
+	v := os.Getenv(PublicParamsGraceEpochsVarEnv)
+	if len(v) == 0 {
+		return 0, nil
+	}
+	graceEpochs, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s [%s]", PublicParamsGraceEpochsVarEnv, v)
+	}
+	return graceEpochs, nil
+}
+
 func (cc *TokenChaincode) ReadParamsFromFile() string {
 	publicParamsPath := os.Getenv(PublicParamsPathVarEnv)
 	if publicParamsPath == "" {
@@ -222,10 +280,23 @@ func (cc *TokenChaincode) ProcessRequest(raw []byte, stub shim.ChaincodeStubInte
 	if err != nil {
 		return shim.Error(err.Error())
 	}
+	w := translator.New(stub.GetTxID(), translator.NewRWSetWrapper(&rwsWrapper{stub: stub}, "", stub.GetTxID()), &keys.Translator{})
+	validator, err = cc.Epochs.Select(w, validator, cc.PPDigest, raw)
+	if err != nil {
+		return shim.Error("failed to select validator: " + err.Error())
+	}
+
+	// the namespace is the name of this chaincode, the signatures of the administrative actions cover it
+	validationContext := context.Background()
+	if namespace, err := chaincodeName(stub); err != nil {
+		logger.Warnf("cannot get the chaincode name, the namespace will not be available to the validator: %s", err)
+	} else {
+		validationContext = driver.WithNamespace(validationContext, namespace)
+	}
 
 	// Verify
 	actions, attributes, err := validator.UnmarshallAndVerifyWithMetadata(
-		context.Background(),
+		validationContext,
 		&ledger{stub: stub, keyTranslator: &keys.Translator{}},
 		stub.GetTxID(),
 		raw,
@@ -235,7 +306,6 @@ func (cc *TokenChaincode) ProcessRequest(raw []byte, stub shim.ChaincodeStubInte
 	}
 
 	// Write
-	w := translator.New(stub.GetTxID(), translator.NewRWSetWrapper(&rwsWrapper{stub: stub}, "", stub.GetTxID()), &keys.Translator{})
 	for _, action := range actions {
 		err = w.Write(action)
 		if err != nil {
@@ -254,6 +324,34 @@ func (cc *TokenChaincode) ProcessRequest(raw []byte, stub shim.ChaincodeStubInte
 	return shim.Success(nil)
 }
 
+// chaincodeName returns the name of the chaincode invoked by the proposal under execution
+func chaincodeName(stub shim.ChaincodeStubInterface) (string, error) {
+	sp, err := stub.GetSignedProposal()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get signed proposal")
+	}
+	if sp == nil {
+		return "", errors.New("no signed proposal")
+	}
+	proposal := &pb.Proposal{}
+	if err := proto.Unmarshal(sp.ProposalBytes, protoadapt.MessageV2Of(proposal)); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal proposal")
+	}
+	payload := &pb.ChaincodeProposalPayload{}
+	if err := proto.Unmarshal(proposal.Payload, protoadapt.MessageV2Of(payload)); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal chaincode proposal payload")
+	}
+	spec := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(payload.Input, protoadapt.MessageV2Of(spec)); err != nil {
+		return "", errors.Wrap(err, "failed to unmarshal chaincode invocation spec")
+	}
+	name := spec.GetChaincodeSpec().GetChaincodeId().GetName()
+	if len(name) == 0 {
+		return "", errors.New("no chaincode name in the proposal")
+	}
+	return name, nil
+}
+
 func (cc *TokenChaincode) QueryPublicParams(stub shim.ChaincodeStubInterface) pb.Response {
 	w := translator.New(stub.GetTxID(), translator.NewRWSetWrapper(&rwsWrapper{stub: stub}, "", stub.GetTxID()), &keys.Translator{})
 	raw, err := w.ReadSetupParameters()
@@ -269,6 +367,16 @@ func (cc *TokenChaincode) QueryPublicParams(stub shim.ChaincodeStubInterface) pb
 	return shim.Success(raw)
 }
 
+func (cc *TokenChaincode) QueryRevocationList(stub shim.ChaincodeStubInterface) pb.Response {
+	w := translator.New(stub.GetTxID(), translator.NewRWSetWrapper(&rwsWrapper{stub: stub}, "", stub.GetTxID()), &keys.Translator{})
+	raw, err := w.ReadRevocationList()
+	if err != nil {
+		return shim.Error("failed to retrieve revocation list: " + err.Error())
+	}
+	logger.Debugf("query revocation list, size [%d]", len(raw))
+	return shim.Success(raw)
+}
+
 func (cc *TokenChaincode) QueryTokens(idsRaw []byte, stub shim.ChaincodeStubInterface) pb.Response {
 	var ids []*token2.ID
 	if err := json.Unmarshal(idsRaw, &ids); err != nil {
@@ -354,7 +462,7 @@ type ledger struct {
 }
 
 func (l *ledger) GetState(id token2.ID) ([]byte, error) {
-	key, err := l.keyTranslator.CreateOutputKey(id.TxId, id.Index)
+	key, err := translator.StateKey(l.keyTranslator, id)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting token key for [%v]", id)
 	}
@@ -9,11 +9,15 @@ import (
 	"encoding/base64"
 	"os"
 
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	chaincode2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/fabric/tcc"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/fabric/tcc/mock"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
 )
 
 var _ = Describe("ccvalidator", func() {
@@ -61,6 +65,18 @@ var _ = Describe("ccvalidator", func() {
 				Expect(response.Status).To(Equal(int32(200)))
 			})
 		})
+		Context("when the public parameters enable the revocation of the idemix owners", func() {
+			BeforeEach(func() {
+				chaincode.TokenServicesFactory = func(i []byte) (chaincode2.PublicParameters, chaincode2.Validator, error) {
+					return &revocationPublicParameters{PublicParametersManager: fakePPM}, fakeValidator, nil
+				}
+			})
+			It("fails, the ledger height is not available to the validator", func() {
+				response := chaincode.Init(fakestub)
+				Expect(response.Status).To(Equal(int32(500)))
+				Expect(response.Message).To(ContainSubstring("the token chaincode cannot provide it"))
+			})
+		})
 	})
 
 	Describe("Invoke", func() {
@@ -81,6 +97,25 @@ var _ = Describe("ccvalidator", func() {
 				Expect(response).NotTo(BeNil())
 				Expect(response.Status).To(Equal(int32(200)))
 			})
+			It("passes the chaincode name as the namespace to the validator", func() {
+				spec, err := proto.Marshal(protoadapt.MessageV2Of(&pb.ChaincodeInvocationSpec{
+					ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: "token-chaincode"}},
+				}))
+				Expect(err).NotTo(HaveOccurred())
+				payload, err := proto.Marshal(protoadapt.MessageV2Of(&pb.ChaincodeProposalPayload{Input: spec}))
+				Expect(err).NotTo(HaveOccurred())
+				proposal, err := proto.Marshal(protoadapt.MessageV2Of(&pb.Proposal{Payload: payload}))
+				Expect(err).NotTo(HaveOccurred())
+				fakestub.GetSignedProposalReturns(&pb.SignedProposal{ProposalBytes: proposal}, nil)
+
+				response := chaincode.Invoke(fakestub)
+				Expect(response.Status).To(Equal(int32(200)))
+				Expect(fakeValidator.UnmarshallAndVerifyWithMetadataCallCount()).To(Equal(1))
+				ctx, _, _, _ := fakeValidator.UnmarshallAndVerifyWithMetadataArgsForCall(0)
+				namespace, ok := driver.NamespaceFromContext(ctx)
+				Expect(ok).To(BeTrue())
+				Expect(namespace).To(Equal("token-chaincode"))
+			})
 		})
 
 		Context("When VerifyTokenRequest fails", func() {
@@ -103,3 +138,16 @@ var _ = Describe("ccvalidator", func() {
 
 	})
 })
+
+// revocationPublicParameters are public parameters that set a revocation public key
+type revocationPublicParameters struct {
+	*mock.PublicParametersManager
+}
+
+func (p *revocationPublicParameters) RevocationPublicKey() []byte {
+	return []byte("revocation public key")
+}
+
+func (p *revocationPublicParameters) RevocationEpochLength() uint64 {
+	return 100
+}
@@ -0,0 +1,119 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package local
+
+import (
+	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/config"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EnabledKey is the key, in the configuration of a TMS, that enables the local ledger for the network of the TMS
+	EnabledKey = "local.enabled"
+	// GraceEpochsKey is the key, in the configuration of a TMS, of the number of past public parameters epochs
+	// whose token requests are still accepted in the namespace of the TMS
+	GraceEpochsKey = "local.publicParamsGraceEpochs"
+)
+
+// Driver instantiates networks backed by the in-process ledger returned by GetLedger.
+// A network is served by this driver only if at least one of the TMSs configured for it has the local ledger enabled.
+type Driver struct {
+	configService     *config.Service
+	identityProvider  IdentityProvider
+	validatorProvider ValidatorProvider
+}
+
+func NewLocalDriver(
+	configService *config.Service,
+	tmsProvider *token.ManagementServiceProvider,
+	identityProvider driver2.IdentityProvider,
+	tokenDriverService *core.TokenDriverService,
+) driver.Driver {
+	return NewDriver(configService, identityProvider, &tmsValidatorProvider{
+		configService:      configService,
+		tmsProvider:        tmsProvider,
+		tokenDriverService: tokenDriverService,
+	})
+}
+
+func NewDriver(configService *config.Service, identityProvider IdentityProvider, validatorProvider ValidatorProvider) *Driver {
+	return &Driver{
+		configService:     configService,
+		identityProvider:  identityProvider,
+		validatorProvider: validatorProvider,
+	}
+}
+
+func (d *Driver) New(network, channel string) (driver.Network, error) {
+	if len(channel) != 0 {
+		return nil, errors.Errorf("local network [%s] does not support channels, got [%s]", network, channel)
+	}
+	enabled, err := d.isEnabled(network)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, errors.Errorf("local ledger not enabled for network [%s]", network)
+	}
+	return NewNetwork(network, GetLedger(network), d.identityProvider, d.configService, d.validatorProvider), nil
+}
+
+func (d *Driver) isEnabled(network string) (bool, error) {
+	configurations, err := d.configService.Configurations()
+	if err != nil {
+		return false, errors.WithMessagef(err, "failed to get tms configurations")
+	}
+	for _, c := range configurations {
+		if c.ID().Network == network && len(c.ID().Channel) == 0 && c.GetBool(EnabledKey) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type tmsValidatorProvider struct {
+	configService      *config.Service
+	tmsProvider        *token.ManagementServiceProvider
+	tokenDriverService *core.TokenDriverService
+}
+
+func (p *tmsValidatorProvider) Validator(network, namespace string) (Validator, error) {
+	tms, err := p.tmsProvider.GetManagementService(token.WithNetwork(network), token.WithNamespace(namespace))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get tms for [%s:%s]", network, namespace)
+	}
+	return tms.Validator()
+}
+
+func (p *tmsValidatorProvider) PublicParamsValidator(_, _ string, ppRaw []byte) (Validator, error) {
+	pp, err := p.tokenDriverService.PublicParametersFromBytes(ppRaw)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to unmarshal public parameters")
+	}
+	v, err := p.tokenDriverService.NewDefaultValidator(pp)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to instantiate validator")
+	}
+	return token.NewValidator(v), nil
+}
+
+func (p *tmsValidatorProvider) GraceEpochs(network, namespace string) uint64 {
+	c, err := p.configService.ConfigurationFor(network, "", namespace)
+	if err != nil {
+		return 0
+	}
+	var graceEpochs uint64
+	if err := c.UnmarshalKey(GraceEpochsKey, &graceEpochs); err != nil {
+		logger.Warnf("invalid [%s] for [%s:%s]: [%s]", GraceEpochsKey, network, namespace, err)
+		return 0
+	}
+	return graceEpochs
+}
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package local
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/pkg/errors"
+)
+
+// NamespaceRequest is a token request bound to a namespace
+type NamespaceRequest struct {
+	Namespace string
+	Request   []byte
+}
+
+// Envelope is the transaction broadcast to the local ledger.
+// It carries the token requests to commit atomically, each in its own namespace.
+type Envelope struct {
+	ID       string
+	Requests []*NamespaceRequest
+}
+
+func (e *Envelope) Bytes() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (e *Envelope) FromBytes(raw []byte) error {
+	return json.Unmarshal(raw, e)
+}
+
+func (e *Envelope) TxID() string {
+	return e.ID
+}
+
+func (e *Envelope) String() string {
+	return fmt.Sprintf("local envelope [%s] with [%d] token request(s)", e.ID, len(e.Requests))
+}
+
+// anchor returns the anchor the token requests of the envelope are verified against.
+// The token requests of a multi-namespace transaction are signed over the binding of all of them.
+func (e *Envelope) anchor() (string, error) {
+	namespaces := make([]string, len(e.Requests))
+	requestsRaw := make([][]byte, len(e.Requests))
+	for i, request := range e.Requests {
+		namespaces[i] = request.Namespace
+		requestsRaw[i] = request.Request
+	}
+	anchor, err := token.BoundAnchorFromRaw(e.ID, namespaces, requestsRaw)
+	if err != nil {
+		return "", errors.WithMessagef(err, "failed to bind the token requests of [%s]", e.ID)
+	}
+	return anchor, nil
+}
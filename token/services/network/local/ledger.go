@@ -0,0 +1,304 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package local
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
+	"github.com/pkg/errors"
+)
+
+var (
+	ledgersLock sync.Mutex
+	ledgers     = map[string]*Ledger{}
+)
+
+// GetLedger returns the ledger of the passed network.
+// All the local networks with the same name, in the same process, share the same ledger.
+func GetLedger(network string) *Ledger {
+	ledgersLock.Lock()
+	defer ledgersLock.Unlock()
+	l, ok := ledgers[network]
+	if !ok {
+		l = NewLedger()
+		ledgers[network] = l
+	}
+	return l
+}
+
+// Ledger is an in-memory ordered ledger.
+// Transactions are committed one at a time, in the order they are received.
+// A transaction is either applied in full or discarded.
+type Ledger struct {
+	KeyTranslator translator.KeyTranslator
+
+	lock   sync.RWMutex
+	states map[string][]byte
+	// txs are the committed transactions, valid or not, in commit order
+	txs      []*committedTx
+	statuses map[string]*txStatus
+	// listeners are the finality listeners indexed by transaction id, the empty id is for the listeners of any transaction
+	listeners map[string][]*listenerEntry
+	// committed is closed, and replaced, each time a transaction is committed
+	committed chan struct{}
+}
+
+type committedTx struct {
+	id     string
+	writes map[string][]byte
+}
+
+type txStatus struct {
+	code    driver.ValidationCode
+	message string
+}
+
+type listenerEntry struct {
+	namespace string
+	listener  driver.FinalityListener
+}
+
+// NewLedger returns a new empty Ledger
+func NewLedger() *Ledger {
+	return &Ledger{
+		KeyTranslator: &keys.Translator{},
+		states:        map[string][]byte{},
+		statuses:      map[string]*txStatus{},
+		listeners:     map[string][]*listenerEntry{},
+		committed:     make(chan struct{}),
+	}
+}
+
+// GetState returns the value bound to the passed key in the passed namespace, nil if the key does not exist
+func (l *Ledger) GetState(namespace string, key string) ([]byte, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.states[ledgerKey(namespace, key)], nil
+}
+
+// Status returns the status of the passed transaction, Unknown if the transaction has not been committed
+func (l *Ledger) Status(txID string) (driver.ValidationCode, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	status, ok := l.statuses[txID]
+	if !ok {
+		return driver.Unknown, nil
+	}
+	return status.code, nil
+}
+
+// Height returns the number of transactions committed so far, each transaction is committed in its own block
+func (l *Ledger) Height() (uint64, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return uint64(len(l.statuses)), nil
+}
+
+// Commit commits the transaction with the passed id.
+// The passed function populates the read-write set of the transaction, it is called with the ledger lock held and
+// must access the ledger only through the passed read-write set. It also gets the height the transaction is committed at.
+// If it fails, the transaction is marked as invalid and its writes are discarded,
+// otherwise the writes are applied and the transaction is marked as valid.
+// The finality listeners are notified in either case.
+// Commit returns an error only if the transaction cannot be committed at all, for instance, because its id has been already used.
+func (l *Ledger) Commit(txID string, f func(rws translator.RWSet, height uint64) error) error {
+	l.lock.Lock()
+	if _, ok := l.statuses[txID]; ok {
+		l.lock.Unlock()
+		return errors.Errorf("transaction [%s] already committed", txID)
+	}
+	rws := &rwSet{ledger: l, writes: map[string][]byte{}}
+	status := &txStatus{code: driver.Valid}
+	if err := f(rws, uint64(len(l.statuses))); err != nil {
+		logger.Debugf("transaction [%s] is invalid: [%s]", txID, err)
+		status = &txStatus{code: driver.Invalid, message: err.Error()}
+		rws.writes = map[string][]byte{}
+	}
+	for key, value := range rws.writes {
+		if len(value) == 0 {
+			delete(l.states, key)
+			continue
+		}
+		l.states[key] = value
+	}
+	l.txs = append(l.txs, &committedTx{id: txID, writes: rws.writes})
+	l.statuses[txID] = status
+	var listeners []*listenerEntry
+	listeners = append(listeners, l.listeners[""]...)
+	listeners = append(listeners, l.listeners[txID]...)
+	delete(l.listeners, txID)
+	events := make([]*event, len(listeners))
+	for i, entry := range listeners {
+		events[i] = l.event(txID, status, entry)
+	}
+	close(l.committed)
+	l.committed = make(chan struct{})
+	l.lock.Unlock()
+
+	logger.Debugf("transaction [%s] committed with status [%d]", txID, status.code)
+	for _, e := range events {
+		go e.fire()
+	}
+	return nil
+}
+
+// Setup writes the passed public parameters to the passed namespace, as the token chaincode does when it is instantiated
+func (l *Ledger) Setup(namespace string, ppRaw []byte) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	rws := &rwSet{ledger: l, writes: map[string][]byte{}}
+	w := translator.New("", translator.NewRWSetWrapper(rws, namespace, ""), l.KeyTranslator)
+	if err := w.Write(&setupAction{raw: ppRaw}); err != nil {
+		return errors.WithMessagef(err, "failed to write public parameters to [%s]", namespace)
+	}
+	for key, value := range rws.writes {
+		l.states[key] = value
+	}
+	return nil
+}
+
+// AddFinalityListener registers a listener for the status of the passed transaction, in the passed namespace.
+// If the transaction has been already committed, the listener is called immediately.
+// If the transaction id is empty, the listener is called for any transaction committed from now on.
+func (l *Ledger) AddFinalityListener(namespace string, txID string, listener driver.FinalityListener) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	entry := &listenerEntry{namespace: namespace, listener: listener}
+	if status, ok := l.statuses[txID]; ok && len(txID) != 0 {
+		go l.event(txID, status, entry).fire()
+		return nil
+	}
+	l.listeners[txID] = append(l.listeners[txID], entry)
+	return nil
+}
+
+// RemoveFinalityListener unregisters the passed listener
+func (l *Ledger) RemoveFinalityListener(txID string, listener driver.FinalityListener) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	entries := l.listeners[txID]
+	for i, entry := range entries {
+		if entry.listener == listener {
+			l.listeners[txID] = append(entries[:i], entries[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Errorf("listener was not registered")
+}
+
+// LookupKey waits for a transaction, committed starting from the passed transaction id, that writes the passed key
+// in the passed namespace, and returns the written value.
+// If startingTxID is empty or unknown, the search starts from the first transaction.
+// The search fails if the timeout elapses or, if stopOnLastTx is true, when the last committed transaction is reached.
+func (l *Ledger) LookupKey(namespace string, startingTxID string, key string, timeout time.Duration, stopOnLastTx bool) ([]byte, error) {
+	k := ledgerKey(namespace, key)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	next := 0
+	l.lock.RLock()
+	for i, tx := range l.txs {
+		if tx.id == startingTxID {
+			next = i
+			break
+		}
+	}
+	l.lock.RUnlock()
+	for {
+		l.lock.RLock()
+		for ; next < len(l.txs); next++ {
+			if value, ok := l.txs[next].writes[k]; ok && len(value) != 0 {
+				l.lock.RUnlock()
+				return value, nil
+			}
+		}
+		committed := l.committed
+		var last string
+		if len(l.txs) != 0 {
+			last = l.txs[len(l.txs)-1].id
+		}
+		l.lock.RUnlock()
+
+		if stopOnLastTx {
+			return nil, errors.Errorf("transaction [%s] reached, stop scan.", last)
+		}
+		select {
+		case <-committed:
+		case <-ctx.Done():
+			return nil, errors.Errorf("context done")
+		}
+	}
+}
+
+func (l *Ledger) event(txID string, status *txStatus, entry *listenerEntry) *event {
+	e := &event{txID: txID, status: status, listener: entry.listener}
+	if status.code == driver.Valid {
+		e.tokenRequestHash = l.tokenRequestHash(entry.namespace, txID)
+	}
+	return e
+}
+
+// tokenRequestHash returns the hash of the token request committed by the passed transaction in the passed namespace.
+// It must be called with the lock held.
+func (l *Ledger) tokenRequestHash(namespace string, txID string) []byte {
+	key, err := l.KeyTranslator.CreateTokenRequestKey(txID)
+	if err != nil {
+		logger.Errorf("failed to create token request key for [%s]: [%s]", txID, err)
+		return nil
+	}
+	return l.states[ledgerKey(namespace, key)]
+}
+
+type event struct {
+	txID             string
+	status           *txStatus
+	tokenRequestHash []byte
+	listener         driver.FinalityListener
+}
+
+func (e *event) fire() {
+	e.listener.OnStatus(context.Background(), e.txID, e.status.code, e.status.message, e.tokenRequestHash)
+}
+
+func ledgerKey(namespace string, key string) string {
+	return namespace + "\x00" + key
+}
+
+// rwSet buffers the writes of a transaction until it is committed.
+// As in Fabric, reads return the committed state, they do not see the writes of the same transaction.
+type rwSet struct {
+	ledger *Ledger
+	writes map[string][]byte
+}
+
+func (r *rwSet) SetState(namespace string, key string, value []byte) error {
+	r.writes[ledgerKey(namespace, key)] = value
+	return nil
+}
+
+func (r *rwSet) GetState(namespace string, key string) ([]byte, error) {
+	// the ledger lock is held by Commit
+	return r.ledger.states[ledgerKey(namespace, key)], nil
+}
+
+func (r *rwSet) DeleteState(namespace string, key string) error {
+	r.writes[ledgerKey(namespace, key)] = nil
+	return nil
+}
+
+type setupAction struct {
+	raw []byte
+}
+
+func (s *setupAction) GetSetupParameters() ([]byte, error) {
+	return s.raw, nil
+}
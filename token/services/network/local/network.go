@@ -0,0 +1,424 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package local
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	driver2 "github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttx"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.network.local")
+
+type IdentityProvider interface {
+	DefaultIdentity() view.Identity
+}
+
+// Validator validates token requests
+type Validator interface {
+	UnmarshallAndVerifyWithMetadata(ctx context.Context, ledger token2.Ledger, anchor string, raw []byte) ([]interface{}, map[string][]byte, error)
+}
+
+// ValidatorProvider returns the validator of the token requests of the given namespace
+type ValidatorProvider interface {
+	Validator(network, namespace string) (Validator, error)
+}
+
+// EpochValidatorProvider is a ValidatorProvider that can also instantiate the validator for the public parameters of
+// a past epoch. If the ValidatorProvider of a network implements it, the network accepts the token requests prepared
+// with the public parameters of a past epoch, within the grace window of the namespace.
+type EpochValidatorProvider interface {
+	ValidatorProvider
+	// PublicParamsValidator returns a validator for the passed raw public parameters
+	PublicParamsValidator(network, namespace string, ppRaw []byte) (Validator, error)
+	// GraceEpochs returns the number of past public parameters epochs whose token requests are still accepted in the given namespace
+	GraceEpochs(network, namespace string) uint64
+}
+
+// Network is a network backed by an in-process ledger.
+// Token requests are validated and translated into writes when broadcast, as the token chaincode does, and
+// committed right away, in the order they are received.
+type Network struct {
+	name              string
+	ledger            *Ledger
+	ip                IdentityProvider
+	nsFinder          common2.Configuration
+	validatorProvider ValidatorProvider
+
+	epochsLock sync.Mutex
+	epochs     map[string]*common2.EpochValidators[Validator]
+}
+
+func NewNetwork(
+	name string,
+	ledger *Ledger,
+	ip IdentityProvider,
+	nsFinder common2.Configuration,
+	validatorProvider ValidatorProvider,
+) *Network {
+	return &Network{
+		name:              name,
+		ledger:            ledger,
+		ip:                ip,
+		nsFinder:          nsFinder,
+		validatorProvider: validatorProvider,
+		epochs:            map[string]*common2.EpochValidators[Validator]{},
+	}
+}
+
+func (n *Network) Name() string {
+	return n.name
+}
+
+func (n *Network) Channel() string {
+	return ""
+}
+
+func (n *Network) Normalize(opt *token2.ServiceOptions) (*token2.ServiceOptions, error) {
+	if len(opt.Network) == 0 {
+		opt.Network = n.name
+	}
+	if opt.Network != n.name {
+		return nil, errors.Errorf("invalid network [%s], expected [%s]", opt.Network, n.name)
+	}
+
+	if len(opt.Channel) != 0 {
+		return nil, errors.Errorf("invalid channel [%s], expected []", opt.Channel)
+	}
+
+	if len(opt.Namespace) == 0 {
+		if ns, err := n.nsFinder.LookupNamespace(opt.Network, opt.Channel); err == nil {
+			logger.Debugf("no namespace specified, found namespace [%s] for [%s:%s]", ns, opt.Network, opt.Channel)
+			opt.Namespace = ns
+		} else {
+			logger.Errorf("no namespace specified, and no default namespace found [%s], use default [%s]", err, ttx.TokenNamespace)
+			opt.Namespace = ttx.TokenNamespace
+		}
+	}
+	if opt.PublicParamsFetcher == nil {
+		opt.PublicParamsFetcher = common2.NewPublicParamsFetcher(n, opt.Namespace)
+	}
+	return opt, nil
+}
+
+func (n *Network) Connect(string) ([]token2.ServiceOption, error) {
+	return nil, nil
+}
+
+// Broadcast commits the passed envelope to the ledger.
+// Each token request in the envelope is validated against the current state of the ledger and translated into writes.
+// If any of them is invalid, the whole transaction is marked as invalid.
+// As for the other networks, an invalid transaction is not an error, its status is delivered to the finality listeners.
+func (n *Network) Broadcast(_ context.Context, blob interface{}) error {
+	var env *Envelope
+	switch b := blob.(type) {
+	case *Envelope:
+		env = b
+	case []byte:
+		env = &Envelope{}
+		if err := env.FromBytes(b); err != nil {
+			return errors.Wrap(err, "failed to unmarshal envelope")
+		}
+	default:
+		return errors.Errorf("unsupported blob type [%T]", blob)
+	}
+	if len(env.ID) == 0 {
+		return errors.New("invalid envelope, empty transaction id")
+	}
+
+	// get the validators before locking the ledger
+	validators := make([]Validator, len(env.Requests))
+	for i, request := range env.Requests {
+		v, err := n.validatorProvider.Validator(n.name, request.Namespace)
+		if err != nil {
+			return errors.WithMessagef(err, "failed to get validator for namespace [%s]", request.Namespace)
+		}
+		validators[i] = v
+	}
+
+	anchor, err := env.anchor()
+	if err != nil {
+		return err
+	}
+
+	return n.ledger.Commit(env.ID, func(rws translator.RWSet, height uint64) error {
+		for i, request := range env.Requests {
+			if err := n.process(validators[i], rws, height, env.ID, anchor, request); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (n *Network) NewEnvelope() driver.Envelope {
+	return &Envelope{}
+}
+
+// RequestApproval checks that the passed token request is valid against the current state of the ledger and
+// returns the envelope that commits it.
+func (n *Network) RequestApproval(context view.Context, tms *token2.ManagementService, requestRaw []byte, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	return n.RequestApprovals(context, []*driver.ApprovalRequest{{TMS: tms, RequestRaw: requestRaw}}, signer, txID)
+}
+
+// RequestApprovals checks that the passed token requests are valid against the current state of the ledger and
+// returns the envelope that commits all of them atomically.
+func (n *Network) RequestApprovals(context view.Context, requests []*driver.ApprovalRequest, _ view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("no token request to approve")
+	}
+	id, err := n.ComputeTxID(&txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to compute transaction id")
+	}
+	env := &Envelope{ID: id}
+	for _, request := range requests {
+		env.Requests = append(env.Requests, &NamespaceRequest{
+			Namespace: request.TMS.Namespace(),
+			Request:   request.RequestRaw,
+		})
+	}
+	anchor, err := env.anchor()
+	if err != nil {
+		return nil, err
+	}
+	height, err := n.ledger.Height()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get ledger height")
+	}
+	for _, request := range requests {
+		validator, err := request.TMS.Validator()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to get validator for [%s]", request.TMS.ID())
+		}
+		_, _, err = validator.UnmarshallAndVerifyWithMetadata(
+			driver2.WithBlockHeight(driver2.WithNamespace(context.Context(), request.TMS.Namespace()), height),
+			token2.NewLedgerFromGetter(n.getStateFnc(request.TMS.Namespace(), n.ledger.GetState)),
+			anchor,
+			request.RequestRaw,
+		)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to verify token request for [%s]", env.ID)
+		}
+	}
+	return env, nil
+}
+
+// ComputeTxID computes the transaction id as the hex encoding of the sha256 hash of nonce and creator.
+// A random nonce is generated, if not set.
+func (n *Network) ComputeTxID(id *driver.TxID) (string, error) {
+	if len(id.Nonce) == 0 {
+		nonce := make([]byte, 24)
+		if _, err := rand.Read(nonce); err != nil {
+			return "", errors.Wrap(err, "failed to generate nonce")
+		}
+		id.Nonce = nonce
+	}
+	h := sha256.New()
+	h.Write(id.Nonce)
+	h.Write(id.Creator)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FetchPublicParameters returns the public parameters stored in the passed namespace.
+// If the namespace has not been set up yet, the public parameters are loaded from the path given by the configuration
+// of the TMS bound to the namespace, and written to the ledger.
+func (n *Network) FetchPublicParameters(namespace string) ([]byte, error) {
+	w := translator.New("", translator.NewRWSetWrapper(&ledgerRWSet{ledger: n.ledger}, namespace, ""), n.ledger.KeyTranslator)
+	raw, err := w.ReadSetupParameters()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read public parameters from [%s]", namespace)
+	}
+	if len(raw) != 0 {
+		return raw, nil
+	}
+
+	tmsConfig, err := n.nsFinder.ConfigurationFor(n.name, "", namespace)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "public parameters not found in [%s] and no configuration available", namespace)
+	}
+	path := tmsConfig.GetString("publicParameters.path")
+	if len(path) == 0 {
+		return nil, errors.Errorf("public parameters not found in [%s] and no path configured", namespace)
+	}
+	raw, err = os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read public parameters from [%s]", path)
+	}
+	if err := n.ledger.Setup(namespace, raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func (n *Network) QueryTokens(_ context.Context, namespace string, IDs []*token.ID) ([][]byte, error) {
+	w := translator.New("", translator.NewRWSetWrapper(&ledgerRWSet{ledger: n.ledger}, namespace, ""), n.ledger.KeyTranslator)
+	return w.QueryTokens(IDs)
+}
+
+// QueryRevocationList returns the revocation list stored in the passed namespace, nil if none has been stored yet
+func (n *Network) QueryRevocationList(_ context.Context, namespace string) ([]byte, error) {
+	w := translator.New("", translator.NewRWSetWrapper(&ledgerRWSet{ledger: n.ledger}, namespace, ""), n.ledger.KeyTranslator)
+	return w.ReadRevocationList()
+}
+
+// AreTokensSpent returns, for each token, true if its output does not exist on the ledger anymore.
+// As for the token chaincode, the tokens are identified by their output keys.
+func (n *Network) AreTokensSpent(_ context.Context, namespace string, tokenIDs []*token.ID, _ []string) ([]bool, error) {
+	ids := make([]string, len(tokenIDs))
+	for i, id := range tokenIDs {
+		key, err := n.ledger.KeyTranslator.CreateOutputKey(id.TxId, id.Index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot compute spent id for [%v]", id)
+		}
+		ids[i] = key
+	}
+	w := translator.New("", translator.NewRWSetWrapper(&ledgerRWSet{ledger: n.ledger}, namespace, ""), n.ledger.KeyTranslator)
+	return w.AreTokensSpent(ids, false)
+}
+
+func (n *Network) LocalMembership() driver.LocalMembership {
+	return &lm{ip: n.ip}
+}
+
+func (n *Network) AddFinalityListener(namespace string, txID string, listener driver.FinalityListener) error {
+	return n.ledger.AddFinalityListener(namespace, txID, listener)
+}
+
+func (n *Network) RemoveFinalityListener(txID string, listener driver.FinalityListener) error {
+	return n.ledger.RemoveFinalityListener(txID, listener)
+}
+
+func (n *Network) LookupTransferMetadataKey(namespace string, startingTxID string, key string, timeout time.Duration, stopOnLastTx bool) ([]byte, error) {
+	k, err := n.ledger.KeyTranslator.CreateTransferActionMetadataKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to generate transfer action metadata key from [%s]", key)
+	}
+	logger.Debugf("lookup transfer metadata key [%s] from [%s] in namespace [%s]", key, startingTxID, namespace)
+	return n.ledger.LookupKey(namespace, startingTxID, k, timeout, stopOnLastTx)
+}
+
+func (n *Network) Ledger() (driver.Ledger, error) {
+	return n.ledger, nil
+}
+
+// process validates the passed token request at the passed ledger height and translates it into writes, as the token chaincode does
+func (n *Network) process(validator Validator, rws translator.RWSet, height uint64, txID string, anchor string, request *NamespaceRequest) error {
+	w := translator.New(txID, translator.NewRWSetWrapper(rws, request.Namespace, txID), n.ledger.KeyTranslator)
+	validator, err := n.selectValidator(w, validator, request)
+	if err != nil {
+		return err
+	}
+	actions, attributes, err := validator.UnmarshallAndVerifyWithMetadata(
+		driver2.WithBlockHeight(driver2.WithNamespace(context.Background(), request.Namespace), height),
+		token2.NewLedgerFromGetter(n.getStateFnc(request.Namespace, rws.GetState)),
+		anchor,
+		request.Request,
+	)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to verify token request in [%s]", request.Namespace)
+	}
+
+	for _, action := range actions {
+		if err := w.Write(action); err != nil {
+			return errors.WithMessagef(err, "failed to write token action in [%s]", request.Namespace)
+		}
+	}
+	if err := w.AddPublicParamsDependency(); err != nil {
+		return errors.WithMessagef(err, "failed to add public params dependency in [%s]", request.Namespace)
+	}
+	if _, err := w.CommitTokenRequest(attributes[common.TokenRequestToSign], true); err != nil {
+		return errors.WithMessagef(err, "failed to write token request in [%s]", request.Namespace)
+	}
+	return nil
+}
+
+// selectValidator returns the validator for the public parameters the passed token request has been prepared with.
+// The current validator is used, unless the validator provider supports public parameters epochs.
+func (n *Network) selectValidator(w *translator.Translator, current Validator, request *NamespaceRequest) (Validator, error) {
+	provider, ok := n.validatorProvider.(EpochValidatorProvider)
+	if !ok {
+		return current, nil
+	}
+	ppRaw, err := w.ReadSetupParameters()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read public parameters of [%s]", request.Namespace)
+	}
+
+	n.epochsLock.Lock()
+	epochs, ok := n.epochs[request.Namespace]
+	if !ok {
+		epochs = common2.NewEpochValidators(provider.GraceEpochs(n.name, request.Namespace), func(ppRaw []byte) (Validator, error) {
+			return provider.PublicParamsValidator(n.name, request.Namespace, ppRaw)
+		})
+		n.epochs[request.Namespace] = epochs
+	}
+	n.epochsLock.Unlock()
+
+	validator, err := epochs.Select(w, current, hash.Hashable(ppRaw).Raw(), request.Request)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to select validator in [%s]", request.Namespace)
+	}
+	return validator, nil
+}
+
+func (n *Network) getStateFnc(namespace string, getState func(namespace, key string) ([]byte, error)) func(id token.ID) ([]byte, error) {
+	return func(id token.ID) ([]byte, error) {
+		key, err := translator.StateKey(n.ledger.KeyTranslator, id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed getting token key for [%v]", id)
+		}
+		return getState(namespace, key)
+	}
+}
+
+// ledgerRWSet gives read access to the committed state of the ledger. Writes are not supported.
+type ledgerRWSet struct {
+	ledger *Ledger
+}
+
+func (r *ledgerRWSet) SetState(string, string, []byte) error {
+	return errors.New("read-only access to the ledger")
+}
+
+func (r *ledgerRWSet) GetState(namespace string, key string) ([]byte, error) {
+	return r.ledger.GetState(namespace, key)
+}
+
+func (r *ledgerRWSet) DeleteState(string, string) error {
+	return errors.New("read-only access to the ledger")
+}
+
+type lm struct {
+	ip IdentityProvider
+}
+
+func (l *lm) DefaultIdentity() view.Identity {
+	return l.ip.DefaultIdentity()
+}
+
+// AnonymousIdentity returns the default identity, the local ledger does not require anonymity
+func (l *lm) AnonymousIdentity() (view.Identity, error) {
+	return l.ip.DefaultIdentity(), nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package local
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"testing"
+	"time"
+
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/core/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator/mock"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type validator struct {
+	actions map[string][]interface{}
+}
+
+func (v *validator) UnmarshallAndVerifyWithMetadata(ctx context.Context, ledger token2.Ledger, anchor string, raw []byte) ([]interface{}, map[string][]byte, error) {
+	actions, ok := v.actions[string(raw)]
+	if !ok {
+		return nil, nil, errors.New("invalid token request")
+	}
+	return actions, map[string][]byte{common.TokenRequestToSign: raw}, nil
+}
+
+func (v *validator) Validator(string, string) (Validator, error) {
+	return v, nil
+}
+
+type listener struct {
+	wg     sync.WaitGroup
+	lock   sync.Mutex
+	txID   string
+	status int
+	msg    string
+	hash   []byte
+}
+
+func newListener() *listener {
+	l := &listener{}
+	l.wg.Add(1)
+	return l
+}
+
+func (l *listener) OnStatus(_ context.Context, txID string, status int, message string, tokenRequestHash []byte) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.txID, l.status, l.msg, l.hash = txID, status, message, tokenRequestHash
+	l.wg.Done()
+}
+
+func newTestNetwork(t *testing.T) *Network {
+	issue := &mock.IssueAction{}
+	issue.NumOutputsReturns(2)
+	issue.GetSerializedOutputsReturns([][]byte{[]byte("o1"), []byte("o2")}, nil)
+
+	transfer := &mock.TransferAction{}
+	transfer.NumOutputsReturns(1)
+	transfer.SerializeOutputAtReturns([]byte("o3"), nil)
+	transfer.GetInputsReturns([]*token.ID{{TxId: "tx1", Index: 0}})
+	transfer.GetSerializedInputsReturns([][]byte{[]byte("o1")}, nil)
+	transfer.GetMetadataReturns(map[string][]byte{"key": []byte("value")})
+
+	v := &validator{actions: map[string][]interface{}{
+		"issue":    {issue},
+		"transfer": {transfer},
+	}}
+	ledger := NewLedger()
+	assert.NoError(t, ledger.Setup("ns", []byte("pp")))
+	return NewNetwork("local", ledger, nil, nil, v)
+}
+
+func TestBroadcast(t *testing.T) {
+	n := newTestNetwork(t)
+
+	pp, err := n.FetchPublicParameters("ns")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("pp"), pp)
+
+	// a listener for any transaction, and a listener for tx1
+	all := newListener()
+	all.wg.Add(3)
+	assert.NoError(t, n.AddFinalityListener("ns", "", all))
+	l1 := newListener()
+	assert.NoError(t, n.AddFinalityListener("ns", "tx1", l1))
+
+	assert.NoError(t, n.Broadcast(context.Background(), &Envelope{ID: "tx1", Requests: []*NamespaceRequest{{Namespace: "ns", Request: []byte("issue")}}}))
+	l1.wg.Wait()
+	h := sha256.Sum256([]byte("issue"))
+	assert.Equal(t, "tx1", l1.txID)
+	assert.Equal(t, driver.Valid, l1.status)
+	assert.Equal(t, h[:], l1.hash)
+
+	tokens, err := n.QueryTokens(context.Background(), "ns", []*token.ID{{TxId: "tx1", Index: 0}, {TxId: "tx1", Index: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("o1"), []byte("o2")}, tokens)
+	spent, err := n.AreTokensSpent(context.Background(), "ns", []*token.ID{{TxId: "tx1", Index: 0}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false}, spent)
+
+	// the transfer spends tx1:0
+	raw, err := (&Envelope{ID: "tx2", Requests: []*NamespaceRequest{{Namespace: "ns", Request: []byte("transfer")}}}).Bytes()
+	assert.NoError(t, err)
+	assert.NoError(t, n.Broadcast(context.Background(), raw))
+	spent, err = n.AreTokensSpent(context.Background(), "ns", []*token.ID{{TxId: "tx1", Index: 0}, {TxId: "tx1", Index: 1}, {TxId: "tx2", Index: 0}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, spent)
+
+	// rejected by the validator
+	l3 := newListener()
+	assert.NoError(t, n.AddFinalityListener("ns", "tx3", l3))
+	assert.NoError(t, n.Broadcast(context.Background(), &Envelope{ID: "tx3", Requests: []*NamespaceRequest{{Namespace: "ns", Request: []byte("garbage")}}}))
+	l3.wg.Wait()
+	assert.Equal(t, driver.Invalid, l3.status)
+	assert.Contains(t, l3.msg, "invalid token request")
+	assert.Nil(t, l3.hash)
+
+	// double spending
+	assert.NoError(t, n.Broadcast(context.Background(), &Envelope{ID: "tx4", Requests: []*NamespaceRequest{{Namespace: "ns", Request: []byte("transfer")}}}))
+	all.wg.Wait()
+
+	l, err := n.Ledger()
+	assert.NoError(t, err)
+	for txID, expected := range map[string]driver.ValidationCode{"tx1": driver.Valid, "tx2": driver.Valid, "tx3": driver.Invalid, "tx4": driver.Invalid, "tx5": driver.Unknown} {
+		status, err := l.Status(txID)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, status, "unexpected status for [%s]", txID)
+	}
+
+	// a listener added after the commit is called immediately
+	l2 := newListener()
+	assert.NoError(t, n.AddFinalityListener("ns", "tx2", l2))
+	l2.wg.Wait()
+	assert.Equal(t, driver.Valid, l2.status)
+
+	// the transaction id cannot be reused
+	assert.ErrorContains(t, n.Broadcast(context.Background(), &Envelope{ID: "tx1"}), "transaction [tx1] already committed")
+}
+
+func TestLookupTransferMetadataKey(t *testing.T) {
+	n := newTestNetwork(t)
+	assert.NoError(t, n.Broadcast(context.Background(), &Envelope{ID: "tx1", Requests: []*NamespaceRequest{{Namespace: "ns", Request: []byte("issue")}}}))
+
+	_, err := n.LookupTransferMetadataKey("ns", "tx1", "key", time.Second, true)
+	assert.ErrorContains(t, err, "transaction [tx1] reached, stop scan.")
+	_, err = n.LookupTransferMetadataKey("ns", "tx1", "key", 100*time.Millisecond, false)
+	assert.ErrorContains(t, err, "context done")
+
+	// the key is written while waiting
+	var value []byte
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		value, err = n.LookupTransferMetadataKey("ns", "tx1", "key", 10*time.Second, false)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, n.Broadcast(context.Background(), &Envelope{ID: "tx2", Requests: []*NamespaceRequest{{Namespace: "ns", Request: []byte("transfer")}}}))
+	wg.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	// the key has been already committed
+	value, err = n.LookupTransferMetadataKey("ns", "", "key", time.Second, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
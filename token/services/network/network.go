@@ -31,6 +31,9 @@ const (
 	Unknown = driver.Unknown // Transaction is unknown
 )
 
+// ApprovalRequest is a token request to be approved in the namespace of the given token management service
+type ApprovalRequest = driver.ApprovalRequest
+
 var logger = logging.MustGetLogger("token-sdk.network")
 
 // FinalityListener is the interface that must be implemented to receive transaction status change notifications
@@ -154,6 +157,11 @@ func (l *Ledger) Status(id string) (ValidationCode, string, error) {
 	return ValidationCode(vc), "", nil
 }
 
+// Height returns the current height of the ledger
+func (l *Ledger) Height() (uint64, error) {
+	return l.l.Height()
+}
+
 // Network provides access to the remote network
 type Network struct {
 	n driver.Network
@@ -201,16 +209,32 @@ func (n *Network) RequestApproval(context view.Context, tms *token.ManagementSer
 	return &Envelope{e: env}, nil
 }
 
+// RequestApprovals requests approval for the given token requests, each bound to a different namespace.
+// The returned envelope is a single transaction that commits all the token requests, or none of them.
+func (n *Network) RequestApprovals(context view.Context, requests []*ApprovalRequest, signer view.Identity, txID TxID) (*Envelope, error) {
+	env, err := n.n.RequestApprovals(context, requests, signer, driver.TxID{
+		Nonce:   txID.Nonce,
+		Creator: txID.Creator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{e: env}, nil
+}
+
 // ComputeTxID computes the transaction ID in the target network format for the given tx id
-func (n *Network) ComputeTxID(id *TxID) string {
+func (n *Network) ComputeTxID(id *TxID) (string, error) {
 	temp := &driver.TxID{
 		Nonce:   id.Nonce,
 		Creator: id.Creator,
 	}
-	txID := n.n.ComputeTxID(temp)
+	txID, err := n.n.ComputeTxID(temp)
+	if err != nil {
+		return "", err
+	}
 	id.Nonce = temp.Nonce
 	id.Creator = temp.Creator
-	return txID
+	return txID, nil
 }
 
 // FetchPublicParameters returns the public parameters for the given namespace
@@ -228,6 +252,12 @@ func (n *Network) AreTokensSpent(context context.Context, namespace string, toke
 	return n.n.AreTokensSpent(context, namespace, tokenIDs, meta)
 }
 
+// QueryRevocationList returns the revocation list of issuers and auditors stored in the given namespace, see driver.RevocationList.
+// It returns nil if no revocation list has been stored yet.
+func (n *Network) QueryRevocationList(context context.Context, namespace string) ([]byte, error) {
+	return n.n.QueryRevocationList(context, namespace)
+}
+
 // LocalMembership returns the local membership for this network
 func (n *Network) LocalMembership() *LocalMembership {
 	return &LocalMembership{lm: n.n.LocalMembership()}
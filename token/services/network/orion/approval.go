@@ -7,10 +7,12 @@ SPDX-License-Identifier: Apache-2.0
 package orion
 
 import (
+	"sync"
 	"time"
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/orion"
 	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/hash"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/core"
@@ -18,6 +20,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	common3 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
 	driver2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/network/driver"
@@ -38,6 +41,20 @@ type ApprovalRequest struct {
 	Namespace string
 	TxID      string
 	Request   []byte
+	// Requests, if not empty, are additional token requests, each bound to a different namespace,
+	// to be committed in the same transaction of the main token request.
+	Requests []*NamespaceRequest
+}
+
+// NamespaceRequest is a token request to be committed in the given namespace
+type NamespaceRequest struct {
+	Namespace string
+	Request   []byte
+}
+
+// namespaceRequests returns all the token requests carried by this approval request, starting from the main one
+func (r *ApprovalRequest) namespaceRequests() []*NamespaceRequest {
+	return append([]*NamespaceRequest{{Namespace: r.Namespace, Request: r.Request}}, r.Requests...)
 }
 
 type ApprovalResponse struct {
@@ -51,6 +68,8 @@ type RequestApprovalView struct {
 	RequestRaw []byte
 	Signer     view.Identity
 	TxID       string
+	// Requests, if not empty, are additional token requests to be committed in the same transaction
+	Requests []*NamespaceRequest
 }
 
 func NewRequestApprovalView(
@@ -88,6 +107,7 @@ func (r *RequestApprovalView) Call(context view.Context) (interface{}, error) {
 		Namespace: r.Namespace,
 		TxID:      r.TxID,
 		Request:   r.RequestRaw,
+		Requests:  r.Requests,
 	}
 	span.AddEvent("send_approval_request")
 	if err := session.SendWithContext(context.Context(), request); err != nil {
@@ -112,6 +132,9 @@ type RequestApprovalResponderView struct {
 	dbManager     *DBManager
 	statusCache   TxStatusResponseCache
 	keyTranslator translator.KeyTranslator
+
+	epochsLock sync.Mutex
+	epochs     map[string]*common3.EpochValidators[driver.Validator]
 }
 
 func (r *RequestApprovalResponderView) Call(context view.Context) (interface{}, error) {
@@ -149,13 +172,24 @@ func (r *RequestApprovalResponderView) process(context view.Context, request *Ap
 		return nil, errors.Wrapf(err, "failed to get session manager for network [%s]", request.Network)
 	}
 	span.AddEvent("fetch_public_params")
-	pp, err := sm.PublicParameters(ds, request.Namespace)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get public parameters for network [%s]", request.Network)
-	}
-	validator, err := ds.NewDefaultValidator(pp)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create validator")
+	requests := request.namespaceRequests()
+	validators := make([]driver.Validator, len(requests))
+	for i, nr := range requests {
+		pp, err := sm.PublicParameters(ds, nr.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get public parameters for network [%s] and namespace [%s]", request.Network, nr.Namespace)
+		}
+		if err := checkPublicParameters(pp); err != nil {
+			return nil, errors.WithMessagef(err, "invalid public parameters for network [%s] and namespace [%s]", request.Network, nr.Namespace)
+		}
+		validators[i], err = ds.NewDefaultValidator(pp)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create validator")
+		}
+		validators[i], err = r.selectValidator(ds, sm, request.Network, nr, pp, validators[i])
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// commit
@@ -171,7 +205,7 @@ func (r *RequestApprovalResponderView) process(context view.Context, request *Ap
 	validateErr := runner.RunWithErrors(func() (bool, error) {
 		span.AddEvent("try_validate")
 		var retry bool
-		envelopeRaw, retry, err = r.validate(context, request, validator)
+		envelopeRaw, retry, err = r.validate(context, request, validators)
 		if err == nil {
 			return true, nil
 		}
@@ -209,7 +243,7 @@ func (r *RequestApprovalResponderView) process(context view.Context, request *Ap
 	return envelopeRaw, nil
 }
 
-func (r *RequestApprovalResponderView) validate(context view.Context, request *ApprovalRequest, validator driver.Validator) ([]byte, bool, error) {
+func (r *RequestApprovalResponderView) validate(context view.Context, request *ApprovalRequest, validators []driver.Validator) ([]byte, bool, error) {
 	span := trace.SpanFromContext(context.Context())
 
 	sm, err := r.dbManager.GetSessionManager(request.Network)
@@ -220,19 +254,36 @@ func (r *RequestApprovalResponderView) validate(context view.Context, request *A
 	if err != nil {
 		return nil, true, errors.Wrapf(err, "failed to create session to orion network [%s]", request.Network)
 	}
-	qe, err := oSession.QueryExecutor(request.Namespace)
-	if err != nil {
-		return nil, true, errors.Wrapf(err, "failed to get query executor for orion network [%s]", request.Network)
-	}
-	span.AddEvent("validate_request")
-	actions, attributes, err := token.NewValidator(validator).UnmarshallAndVerifyWithMetadata(
-		context.Context(),
-		&LedgerWrapper{qe: qe, keyTranslator: &translator.HashedKeyTranslator{KT: &keys.Translator{}}},
-		request.TxID,
-		request.Request,
-	)
+	requests := request.namespaceRequests()
+	namespaces := make([]string, len(requests))
+	requestsRaw := make([][]byte, len(requests))
+	for i, nr := range requests {
+		namespaces[i] = nr.Namespace
+		requestsRaw[i] = nr.Request
+	}
+	// the token requests of a multi-namespace transaction are signed over the binding of all of them,
+	// therefore a token request is rejected if any of the others is missing
+	anchor, err := token.BoundAnchorFromRaw(request.TxID, namespaces, requestsRaw)
 	if err != nil {
-		return nil, false, errors.Wrapf(err, "failed to unmarshall and verify request")
+		return nil, false, errors.WithMessagef(err, "failed to bind the token requests of [%s]", request.TxID)
+	}
+	actions := make([][]any, len(requests))
+	attributes := make([]map[string][]byte, len(requests))
+	for i, nr := range requests {
+		qe, err := oSession.QueryExecutor(nr.Namespace)
+		if err != nil {
+			return nil, true, errors.Wrapf(err, "failed to get query executor for orion network [%s]", request.Network)
+		}
+		span.AddEvent("validate_request")
+		actions[i], attributes[i], err = token.NewValidator(validators[i]).UnmarshallAndVerifyWithMetadata(
+			driver.WithNamespace(context.Context(), nr.Namespace),
+			&LedgerWrapper{qe: qe, keyTranslator: &translator.HashedKeyTranslator{KT: &keys.Translator{}}},
+			anchor,
+			nr.Request,
+		)
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "failed to unmarshall and verify request for namespace [%s]", nr.Namespace)
+		}
 	}
 
 	// Write
@@ -242,20 +293,28 @@ func (r *RequestApprovalResponderView) validate(context view.Context, request *A
 	}
 	rws := &TxRWSWrapper{
 		me: sm.CustodianID,
-		db: request.Namespace,
 		tx: tx,
 	}
-	t := translator.New(request.TxID, translator.NewRWSetWrapper(rws, "", request.TxID), &translator.HashedKeyTranslator{KT: &keys.Translator{}})
-	for _, action := range actions {
-		err = t.Write(action)
+	t, err := translator.NewMulti(request.TxID, rws, &translator.HashedKeyTranslator{KT: &keys.Translator{}}, namespaces...)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to create translator for transaction [%s]", request.TxID)
+	}
+	var h []byte
+	for i, namespace := range namespaces {
+		for _, action := range actions[i] {
+			err = t.Write(namespace, action)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "failed to write action")
+			}
+		}
+		span.AddEvent("commit_token_request")
+		hash, err := t.CommitTokenRequest(namespace, attributes[i][common.TokenRequestToSign], true)
 		if err != nil {
-			return nil, false, errors.Wrapf(err, "failed to write action")
+			return nil, false, errors.Wrapf(err, "failed to commit token request")
+		}
+		if i == 0 {
+			h = hash
 		}
-	}
-	span.AddEvent("commit_token_request")
-	h, err := t.CommitTokenRequest(attributes[common.TokenRequestToSign], true)
-	if err != nil {
-		return nil, false, errors.Wrapf(err, "failed to commit token request")
 	}
 
 	// close transaction
@@ -271,13 +330,84 @@ func (r *RequestApprovalResponderView) validate(context view.Context, request *A
 	return envelopeRaw, false, nil
 }
 
+// selectValidator returns the validator of the public parameters the passed token request has been prepared with,
+// as long as they belong to an epoch within the grace window of the namespace, see Custodian.PublicParamsGraceEpochs
+func (r *RequestApprovalResponderView) selectValidator(
+	ds *core.TokenDriverService,
+	sm *SessionManager,
+	network string,
+	nr *NamespaceRequest,
+	pp driver.PublicParameters,
+	current driver.Validator,
+) (driver.Validator, error) {
+	epochs, err := r.epochValidators(ds, network, nr.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	oSession, err := sm.GetSession()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create session to orion network [%s]", network)
+	}
+	qe, err := oSession.QueryExecutor(nr.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get query executor for orion network [%s]", network)
+	}
+	ppRaw, err := pp.Serialize()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize public parameters")
+	}
+	reader := translator.New("", translator.NewRWSetWrapper(&ReadOnlyRWSWrapper{qe: qe}, "", ""), r.keyTranslator)
+	validator, err := epochs.Select(reader, current, hash.Hashable(ppRaw).Raw(), nr.Request)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to select validator for namespace [%s]", nr.Namespace)
+	}
+	return validator, nil
+}
+
+func (r *RequestApprovalResponderView) epochValidators(ds *core.TokenDriverService, network, namespace string) (*common3.EpochValidators[driver.Validator], error) {
+	r.epochsLock.Lock()
+	defer r.epochsLock.Unlock()
+	if r.epochs == nil {
+		r.epochs = map[string]*common3.EpochValidators[driver.Validator]{}
+	}
+	if epochs, ok := r.epochs[network+namespace]; ok {
+		return epochs, nil
+	}
+
+	graceEpochs, err := GetPublicParamsGraceEpochs(r.dbManager.ConfigProvider, network, namespace)
+	if err != nil {
+		return nil, err
+	}
+	epochs := common3.NewEpochValidators(graceEpochs, func(ppRaw []byte) (driver.Validator, error) {
+		pp, err := ds.PublicParametersFromBytes(ppRaw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal public parameters")
+		}
+		if err := checkPublicParameters(pp); err != nil {
+			return nil, err
+		}
+		return ds.NewDefaultValidator(pp)
+	})
+	r.epochs[network+namespace] = epochs
+	return epochs, nil
+}
+
+// checkPublicParameters returns an error if the passed public parameters need what the custodian cannot provide.
+// This is the case of the revocation of the idemix owners, that requires the height of the ledger at validation time.
+func checkPublicParameters(pp driver.PublicParameters) error {
+	if driver.IsOwnerRevocationEnabled(pp) {
+		return errors.New("the public parameters enable the revocation of the idemix owners, which requires the ledger height at validation time, orion cannot provide it")
+	}
+	return nil
+}
+
 type LedgerWrapper struct {
 	qe            *orion.SessionQueryExecutor
 	keyTranslator translator.KeyTranslator
 }
 
 func (l *LedgerWrapper) GetState(id token2.ID) ([]byte, error) {
-	key, err := l.keyTranslator.CreateOutputKey(id.TxId, id.Index)
+	key, err := translator.StateKey(l.keyTranslator, id)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed getting token key for [%v]", id)
 	}
@@ -49,6 +49,21 @@ func GetCustodian(cp configProvider, network string) (string, error) {
 	return "", errors.Errorf("no token-sdk configuration for network %s", network)
 }
 
+// GetPublicParamsGraceEpochs returns the number of past public parameters epochs whose token requests
+// are still approved by the custodian in the passed namespace. It defaults to zero.
+func GetPublicParamsGraceEpochs(cp configProvider, network, namespace string) (uint64, error) {
+	tmsConfigs, err := tmss(cp)
+	if err != nil {
+		return 0, err
+	}
+	for _, config := range tmsConfigs {
+		if config.Network == network && config.Namespace == namespace && config.Orion != nil && config.Orion.Custodian != nil {
+			return config.Orion.Custodian.PublicParamsGraceEpochs, nil
+		}
+	}
+	return 0, nil
+}
+
 func tmss(cp configProvider) (map[string]*TMS, error) {
 	var boxedConfig map[interface{}]interface{}
 	if err := cp.UnmarshalKey("token.tms", &boxedConfig); err != nil {
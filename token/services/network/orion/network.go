@@ -162,10 +162,14 @@ func (n *Network) GetTransient(id string) (driver.TransientMap, error) {
 }
 
 func (n *Network) RequestApproval(context view.Context, tms *token2.ManagementService, requestRaw []byte, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	id, err := n.ComputeTxID(&txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to compute transaction id")
+	}
 	envBoxed, err := view2.GetManager(context).InitiateView(NewRequestApprovalView(
 		n.dbManager,
 		n.n.Name(), tms.Namespace(),
-		requestRaw, signer, n.ComputeTxID(&txID),
+		requestRaw, signer, id,
 	), context.Context())
 	if err != nil {
 		return nil, err
@@ -173,7 +177,36 @@ func (n *Network) RequestApproval(context view.Context, tms *token2.ManagementSe
 	return envBoxed.(driver.Envelope), nil
 }
 
-func (n *Network) ComputeTxID(id *driver.TxID) string {
+// RequestApprovals asks the custodian to approve the passed token requests and commit them in a single transaction
+func (n *Network) RequestApprovals(context view.Context, requests []*driver.ApprovalRequest, signer view.Identity, txID driver.TxID) (driver.Envelope, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("no token request to approve")
+	}
+	others := make([]*NamespaceRequest, 0, len(requests)-1)
+	for _, request := range requests[1:] {
+		others = append(others, &NamespaceRequest{
+			Namespace: request.TMS.Namespace(),
+			Request:   request.RequestRaw,
+		})
+	}
+	id, err := n.ComputeTxID(&txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to compute transaction id")
+	}
+	v := NewRequestApprovalView(
+		n.dbManager,
+		n.n.Name(), requests[0].TMS.Namespace(),
+		requests[0].RequestRaw, signer, id,
+	)
+	v.Requests = others
+	envBoxed, err := view2.GetManager(context).InitiateView(v, context.Context())
+	if err != nil {
+		return nil, err
+	}
+	return envBoxed.(driver.Envelope), nil
+}
+
+func (n *Network) ComputeTxID(id *driver.TxID) (string, error) {
 	logger.Debugf("compute tx id for [%s]", id.String())
 	temp := &orion.TxID{
 		Nonce:   id.Nonce,
@@ -182,7 +215,7 @@ func (n *Network) ComputeTxID(id *driver.TxID) string {
 	res := n.n.TransactionManager().ComputeTxID(temp)
 	id.Nonce = temp.Nonce
 	id.Creator = temp.Creator
-	return res
+	return res, nil
 }
 
 func (n *Network) FetchPublicParameters(namespace string) ([]byte, error) {
@@ -193,6 +226,14 @@ func (n *Network) FetchPublicParameters(namespace string) ([]byte, error) {
 	return pp.([]byte), nil
 }
 
+func (n *Network) QueryRevocationList(ctx context.Context, namespace string) ([]byte, error) {
+	raw, err := n.viewManager.InitiateView(NewRevocationListRequestView(n.Name(), namespace), ctx)
+	if err != nil {
+		return nil, err
+	}
+	return raw.([]byte), nil
+}
+
 func (n *Network) QueryTokens(context context.Context, namespace string, IDs []*token.ID) ([][]byte, error) {
 	return n.tokenQueryExecutor.QueryTokens(context, namespace, IDs)
 }
@@ -254,6 +295,11 @@ func (l *ledger) Status(id string) (driver.ValidationCode, error) {
 	return boxed.(*TxStatusResponse).Status, nil
 }
 
+// Height is not supported, the orion network does not expose the height of the ledger
+func (l *ledger) Height() (uint64, error) {
+	return 0, errors.New("ledger height not supported by orion")
+}
+
 type FinalityListener struct {
 	root        driver.FinalityListener
 	network     string
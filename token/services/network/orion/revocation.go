@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package orion
+
+import (
+	"github.com/hyperledger-labs/fabric-smart-client/platform/orion"
+	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/keys"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network/common/rws/translator"
+	session2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/json/session"
+	"github.com/pkg/errors"
+)
+
+type RevocationListRequest struct {
+	Network   string
+	Namespace string
+}
+
+type RevocationListResponse struct {
+	Raw []byte
+}
+
+type RevocationListRequestView struct {
+	Network   string
+	Namespace string
+}
+
+func NewRevocationListRequestView(network string, namespace string) *RevocationListRequestView {
+	return &RevocationListRequestView{Network: network, Namespace: namespace}
+}
+
+func (v *RevocationListRequestView) Call(context view.Context) (interface{}, error) {
+	cp := view2.GetConfigService(context)
+	isCustodian, err := IsCustodian(cp)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check if custodian")
+	}
+	if isCustodian {
+		// I'm a custodian, connect directly to orion
+		return ReadRevocationList(context, v.Network, v.Namespace)
+	}
+
+	custodian, err := GetCustodian(cp, v.Network)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get custodian identifier")
+	}
+	logger.Debugf("custodian: %s", custodian)
+	session, err := session2.NewJSON(context, context.Initiator(), view2.GetIdentityProvider(context).Identity(custodian))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get session to custodian [%s]", custodian)
+	}
+	request := &RevocationListRequest{
+		Network:   v.Network,
+		Namespace: v.Namespace,
+	}
+	if err := session.SendWithContext(context.Context(), request); err != nil {
+		return nil, errors.Wrapf(err, "failed to send request to custodian [%s]", custodian)
+	}
+	response := &RevocationListResponse{}
+	if err := session.Receive(response); err != nil {
+		return nil, errors.Wrapf(err, "failed to receive response from custodian [%s]", custodian)
+	}
+	return response.Raw, nil
+}
+
+type RevocationListRequestResponderView struct{}
+
+func (v *RevocationListRequestResponderView) Call(context view.Context) (interface{}, error) {
+	// receive request
+	session := session2.JSON(context)
+	request := &RevocationListRequest{}
+	if err := session.Receive(request); err != nil {
+		return nil, errors.Wrapf(err, "failed to receive request")
+	}
+	logger.Debugf("request: %+v", request)
+
+	raw, err := ReadRevocationList(context, request.Network, request.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get revocation list from orion network [%s]", request.Network)
+	}
+	if err := session.SendWithContext(context.Context(), &RevocationListResponse{Raw: raw}); err != nil {
+		return nil, errors.Wrapf(err, "failed to send response")
+	}
+	return nil, nil
+}
+
+// ReadRevocationList returns the revocation list stored in the passed namespace, nil if none has been stored yet
+func ReadRevocationList(context token.ServiceProvider, network, namespace string) ([]byte, error) {
+	ons, err := orion.GetOrionNetworkService(context, network)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get orion network service for network [%s]", network)
+	}
+	custodianID, err := GetCustodian(view2.GetConfigService(context), network)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get custodian identifier")
+	}
+	oSession, err := ons.SessionManager().NewSession(custodianID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create session to orion network [%s]", network)
+	}
+	qe, err := oSession.QueryExecutor(namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get query executor for orion network [%s]", network)
+	}
+	w := translator.New("", translator.NewRWSetWrapper(&ReadOnlyRWSWrapper{qe: qe}, "", ""), &translator.HashedKeyTranslator{KT: &keys.Translator{}})
+	raw, err := w.ReadRevocationList()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve revocation list")
+	}
+	return raw, nil
+}
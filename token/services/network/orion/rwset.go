@@ -36,6 +36,8 @@ func (r *ReadOnlyRWSWrapper) DeleteState(namespace string, key string) error {
 	panic("programming error: this should not be called")
 }
 
+// TxRWSWrapper writes into an orion transaction.
+// The passed namespace is used as the target database, if not empty. Otherwise, the default database is used.
 type TxRWSWrapper struct {
 	me string
 	db string
@@ -45,7 +47,7 @@ type TxRWSWrapper struct {
 func (r *TxRWSWrapper) SetState(namespace string, key string, value []byte) error {
 	key = orionKey(key)
 	return r.tx.Put(
-		r.db, key, value,
+		r.database(namespace), key, value,
 		&types.AccessControl{
 			ReadWriteUsers: otx.UsersMap(r.me),
 		},
@@ -54,12 +56,19 @@ func (r *TxRWSWrapper) SetState(namespace string, key string, value []byte) erro
 
 func (r *TxRWSWrapper) GetState(namespace string, key string) ([]byte, error) {
 	key = orionKey(key)
-	return r.tx.Get(r.db, key)
+	return r.tx.Get(r.database(namespace), key)
 }
 
 func (r *TxRWSWrapper) DeleteState(namespace string, key string) error {
 	key = orionKey(key)
-	return r.tx.Delete(r.db, key)
+	return r.tx.Delete(r.database(namespace), key)
+}
+
+func (r *TxRWSWrapper) database(namespace string) string {
+	if len(namespace) != 0 {
+		return namespace
+	}
+	return r.db
 }
 
 type RWSWrapper struct {
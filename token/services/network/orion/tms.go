@@ -36,6 +36,8 @@ type Orion struct {
 type Custodian struct {
 	ID      string `yaml:"id"`
 	Enabled bool   `yaml:"enabled,omitempty"`
+	// PublicParamsGraceEpochs is the number of past public parameters epochs whose token requests are still approved
+	PublicParamsGraceEpochs uint64 `yaml:"publicParamsGraceEpochs,omitempty"`
 }
 
 type TMS struct {
@@ -53,6 +53,9 @@ func InstallViews(viewRegistry ResponderRegistry, dbManager *DBManager, statusCa
 	if err := viewRegistry.RegisterResponder(&RequestQueryTokensResponderView{}, &RequestQueryTokensView{}); err != nil {
 		return err
 	}
+	if err := viewRegistry.RegisterResponder(&RevocationListRequestResponderView{}, &RevocationListRequestView{}); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -30,6 +30,14 @@ func WithAuditor(auditor view.Identity) TxOption {
 	}
 }
 
+// WithAuditors sets additional auditors to ask for auditing
+func WithAuditors(auditors ...view.Identity) TxOption {
+	return func(o *ttx.TxOptions) error {
+		o.Auditors = append(o.Auditors, auditors...)
+		return nil
+	}
+}
+
 type Transaction struct {
 	*ttx.Transaction
 }
@@ -15,12 +15,33 @@ import (
 
 const (
 	defaultDriver                 = driver.Sherdlock
+	defaultStrategy               = FirstFit
 	defaultLeaseExpiry            = 3 * time.Minute
 	defaultLeaseCleanupTickPeriod = 1 * time.Minute
 	defaultNumRetries             = 3
 	defaultRetryInterval          = 5 * time.Second
 )
 
+// SelectionStrategy tells the order in which the sherdlock selector tries to lock the tokens of a wallet
+type SelectionStrategy string
+
+const (
+	// FirstFit locks the tokens in the order returned by the token store, possibly permuted, until the quantity is covered
+	FirstFit SelectionStrategy = "first-fit"
+	// LargestFirst locks the largest tokens first, to use as few tokens as possible
+	LargestFirst SelectionStrategy = "largest-first"
+	// SmallestSufficient locks the smallest token covering the quantity on its own, if any, and falls back to LargestFirst
+	SmallestSufficient SelectionStrategy = "smallest-sufficient"
+	// ExactMatch searches, with a bounded branch-and-bound, for tokens summing up to the quantity, so that no change is needed.
+	// It falls back to LargestFirst
+	ExactMatch SelectionStrategy = "exact-match"
+	// DustConsolidation locks the smallest tokens first, so that the dust is merged into the change
+	DustConsolidation SelectionStrategy = "dust-consolidation"
+
+	// StrategyKey is the key, in the configuration of a TMS, of the selection strategy of its tokens
+	StrategyKey = "selector.strategy"
+)
+
 type configService interface {
 	UnmarshalKey(key string, rawVal interface{}) error
 }
@@ -31,6 +52,11 @@ type Config struct {
 	NumRetries             int           `yaml:"numRetries,omitempty"`
 	LeaseExpiry            time.Duration `yaml:"leaseExpiry,omitempty"`
 	LeaseCleanupTickPeriod time.Duration `yaml:"leaseCleanupTickPeriod,omitempty"`
+	// Strategy is the default selection strategy of the TMSs, each TMS can set its own under StrategyKey
+	Strategy SelectionStrategy `yaml:"strategy,omitempty"`
+	// Replicas are the labels of the FSC nodes sharing the wallets of this node.
+	// If set, the token locks are leases agreed by the replicas, instead of entries of the token lock database.
+	Replicas []string `yaml:"replicas,omitempty"`
 }
 
 // New returns a SelectorConfig with the values from the token.selector key
@@ -77,3 +103,30 @@ func (c *Config) GetLeaseCleanupTickPeriod() time.Duration {
 	}
 	return defaultLeaseCleanupTickPeriod
 }
+
+func (c *Config) GetReplicas() []string {
+	return c.Replicas
+}
+
+type tmsConfiguration interface {
+	IsSet(key string) bool
+	UnmarshalKey(key string, rawVal interface{}) error
+}
+
+// GetStrategy returns the selection strategy of the TMS with the passed configuration.
+// The strategy of the TMS takes precedence over the default one.
+func (c *Config) GetStrategy(tmsConfig tmsConfiguration) (SelectionStrategy, error) {
+	if tmsConfig != nil && tmsConfig.IsSet(StrategyKey) {
+		var strategy SelectionStrategy
+		if err := tmsConfig.UnmarshalKey(StrategyKey, &strategy); err != nil {
+			return "", errors.Wrapf(err, "invalid config for key [%s]", StrategyKey)
+		}
+		if len(strategy) != 0 {
+			return strategy, nil
+		}
+	}
+	if c.Strategy != "" {
+		return c.Strategy, nil
+	}
+	return defaultStrategy, nil
+}
@@ -54,6 +54,8 @@ const (
 
 type FetcherProvider interface {
 	GetFetcher(tmsID token.TMSID) (tokenFetcher, error)
+	// Metrics returns the metrics shared by the fetchers and the selectors
+	Metrics() *Metrics
 }
 
 type fetchFunc func(db *tokendb.DB, notifier *tokendb.Notifier, m *Metrics) tokenFetcher
@@ -97,6 +99,10 @@ func (p *fetcherProvider) GetFetcher(tmsID token.TMSID) (tokenFetcher, error) {
 	return p.fetch(tokenDB, tokenNotifier, p.metrics), nil
 }
 
+func (p *fetcherProvider) Metrics() *Metrics {
+	return p.metrics
+}
+
 // mixedFetcher combines both eager and lazy strategies
 // In this example we return the eager result only the first time and all subsequent request are served by the lazy fetcher
 // Other implementations can make different combinations, e.g. fresh results under a threshold (e.g. 10ms) can be served by the eager fetcher
@@ -16,6 +16,7 @@ import (
 	common2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/driver/sql"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/postgres"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/config"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/testutils"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/stretchr/testify/assert"
@@ -93,7 +94,7 @@ func createManager(pgConnStr string, backoff time.Duration, maxRetries int) (tes
 	}
 
 	fetcher := newMixedFetcher(tokenDB.(common2.TestTokenDB), newMetrics(&disabled.Provider{}))
-	manager := NewManager(fetcher, lockDB, testutils.TokenQuantityPrecision, backoff, maxRetries, 0, 0)
+	manager := NewManager(fetcher, lockDB, testutils.TokenQuantityPrecision, backoff, maxRetries, 0, 0, config.FirstFit, nil, nil)
 
 	return testutils.NewEnhancedManager(manager, tokenDB.(common2.TestTokenDB)), nil
 }
@@ -15,10 +15,23 @@ const (
 	fetcherTypeLabel tracing.LabelName = "fetcher_type"
 	lazy             string            = "lazy"
 	eager            string            = "eager"
+
+	strategyLabel tracing.LabelName = "strategy"
+	outcomeLabel  tracing.LabelName = "outcome"
+	// exact is the outcome of a selection whose tokens sum up to the requested quantity, no change is needed
+	exact string = "exact"
+	// change is the outcome of a selection whose tokens exceed the requested quantity
+	change string = "change"
+	// failed is the outcome of a selection that did not cover the requested quantity
+	failed string = "failed"
 )
 
 type Metrics struct {
 	UnspentTokensInvocations metrics.Counter
+	// Selections counts the selections by strategy and outcome
+	Selections metrics.Counter
+	// SelectedTokens observes the number of tokens picked by the successful selections, by strategy
+	SelectedTokens metrics.Histogram
 }
 
 func newMetrics(p metrics.Provider) *Metrics {
@@ -29,5 +42,18 @@ func newMetrics(p metrics.Provider) *Metrics {
 			Help:       "The number of invocations",
 			LabelNames: []string{fetcherTypeLabel},
 		}),
+		Selections: p.NewCounter(metrics.CounterOpts{
+			Namespace:  "sherdlock",
+			Name:       "selections",
+			Help:       "The number of token selections by strategy and outcome",
+			LabelNames: []string{strategyLabel, outcomeLabel},
+		}),
+		SelectedTokens: p.NewHistogram(metrics.HistogramOpts{
+			Namespace:  "sherdlock",
+			Name:       "selected_tokens",
+			Help:       "The number of tokens picked by a token selection",
+			LabelNames: []string{strategyLabel},
+			Buckets:    []float64{1, 2, 3, 5, 10, 20, 50, 100},
+		}),
 	}
 }
@@ -13,7 +13,9 @@ import (
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/collections"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/interop/timelock"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/config"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/types/transaction"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
@@ -34,17 +36,31 @@ type Iterator[V any] interface {
 	Next() V
 }
 
+// WalletFilter tells if the tokens owned by the passed wallet can be selected
+type WalletFilter = func(walletID string) bool
+
+// SelectableWallet is the default wallet filter.
+// It excludes the wallets of time-locked tokens, they can be spent only by releasing them.
+func SelectableWallet(walletID string) bool {
+	return !timelock.IsLockedWallet(walletID)
+}
+
 type tokenLocker interface {
 	TryLock(*token2.ID) bool
 	UnlockAll() error
 }
 
 type selector struct {
-	logger    logging.Logger
-	cache     iterator[*token2.UnspentTokenInWallet]
-	fetcher   tokenFetcher
-	locker    tokenLocker
-	precision uint64
+	logger       logging.Logger
+	cache        iterator[*token2.UnspentTokenInWallet]
+	fetcher      tokenFetcher
+	locker       tokenLocker
+	precision    uint64
+	walletFilter WalletFilter
+	strategy     config.SelectionStrategy
+	// order sorts the fetched tokens according to the strategy, nil keeps the order of the fetcher
+	order   OrderFunc
+	metrics *Metrics
 }
 
 type stubbornSelector struct {
@@ -81,23 +97,47 @@ func NewStubbornSelector(logger logging.Logger, tokenDB tokenFetcher, lockDB tok
 
 func NewSelector(logger logging.Logger, tokenDB tokenFetcher, lockDB tokenLocker, precision uint64) *selector {
 	return &selector{
-		logger:    logger,
-		cache:     collections.NewEmptyIterator[*token2.UnspentTokenInWallet](),
-		fetcher:   tokenDB,
-		locker:    lockDB,
-		precision: precision,
+		logger:       logger,
+		cache:        collections.NewEmptyIterator[*token2.UnspentTokenInWallet](),
+		fetcher:      tokenDB,
+		locker:       lockDB,
+		precision:    precision,
+		walletFilter: SelectableWallet,
+		strategy:     config.FirstFit,
 	}
 }
 
+// withStrategy sets the selection strategy of this selector, and the metrics to report its selections
+func (s *selector) withStrategy(strategy config.SelectionStrategy, order OrderFunc, m *Metrics) *selector {
+	s.strategy = strategy
+	s.order = order
+	s.metrics = m
+	return s
+}
+
 func (s *selector) Select(owner token.OwnerFilter, q string, currency token2.Type) ([]*token2.ID, token2.Quantity, error) {
+	ids, sum, err := s.selectTokens(owner, q, currency)
+	s.report(ids, sum, q, err)
+	return ids, sum, err
+}
+
+func (s *selector) selectTokens(owner token.OwnerFilter, q string, currency token2.Type) ([]*token2.ID, token2.Quantity, error) {
 	if s.isClosed() {
 		return nil, nil, errors.Errorf("selector is already closed")
 	}
+	if !s.walletFilter(owner.ID()) {
+		return nil, nil, errors.Errorf("tokens of wallet [%s] cannot be selected", owner.ID())
+	}
 	quantity, err := token2.ToQuantity(q, s.precision)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "failed to create quantity")
 	}
 	sum, selected, tokensLockedByOthersExist, immediateRetries := token2.NewZeroQuantity(s.precision), collections.NewSet[*token2.ID](), true, 0
+	// the tokens left from a previous selection are sorted for this quantity
+	if s.cache, err = s.sort(s.cache, quantity); err != nil {
+		err2 := s.locker.UnlockAll()
+		return nil, nil, errors.Wrapf(err, "failed to sort tokens for [%s:%s] - unlock: %v", owner.ID(), currency, err2)
+	}
 	for {
 		if t, err := s.cache.Next(); err != nil {
 			err2 := s.locker.UnlockAll()
@@ -132,9 +172,17 @@ func (s *selector) Select(owner token.OwnerFilter, q string, currency token2.Typ
 				err2 := s.locker.UnlockAll()
 				return nil, nil, errors.Wrapf(err, "failed to reload tokens for retry %d [%s:%s] - unlock: %v", immediateRetries, owner.ID(), currency, err2)
 			}
+			// only the quantity still missing must be covered by the fetched tokens
+			missing := token2.NewZeroQuantity(s.precision).Add(quantity).Sub(sum)
+			if s.cache, err = s.sort(s.cache, missing); err != nil {
+				err2 := s.locker.UnlockAll()
+				return nil, nil, errors.Wrapf(err, "failed to sort tokens for retry %d [%s:%s] - unlock: %v", immediateRetries, owner.ID(), currency, err2)
+			}
 
 			immediateRetries++
 			tokensLockedByOthersExist = false
+		} else if !s.walletFilter(t.WalletID) {
+			s.logger.Debugf("Skip token [%v], its wallet [%s] cannot be selected", t.Id, t.WalletID)
 		} else if locked := s.locker.TryLock(t.Id); !locked {
 			s.logger.Debugf("Tried to lock token [%v], but it was already locked by another process", t)
 			tokensLockedByOthersExist = true
@@ -155,6 +203,57 @@ func (s *selector) Select(owner token.OwnerFilter, q string, currency token2.Typ
 	}
 }
 
+// sort returns the tokens of the passed iterator in the order of the selection strategy, to cover the passed target.
+// The tokens of the wallets that cannot be selected are dropped.
+func (s *selector) sort(it iterator[*token2.UnspentTokenInWallet], target token2.Quantity) (iterator[*token2.UnspentTokenInWallet], error) {
+	if s.order == nil {
+		return it, nil
+	}
+	defer it.Close()
+	var candidates []*Candidate
+	for {
+		t, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			break
+		}
+		if !s.walletFilter(t.WalletID) {
+			continue
+		}
+		q, err := token2.ToQuantity(t.Quantity, s.precision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid token [%s] found", t.Id)
+		}
+		candidates = append(candidates, &Candidate{Token: t, Quantity: q.ToBigInt()})
+	}
+	ordered := s.order(candidates, target.ToBigInt())
+	tokens := make([]*token2.UnspentTokenInWallet, len(ordered))
+	for i, c := range ordered {
+		tokens[i] = c.Token
+	}
+	return collections.NewSliceIterator(tokens), nil
+}
+
+// report updates the metrics with the outcome of a selection
+func (s *selector) report(ids []*token2.ID, sum token2.Quantity, q string, err error) {
+	if s.metrics == nil {
+		return
+	}
+	strategy := string(s.strategy)
+	if err != nil {
+		s.metrics.Selections.With(strategyLabel, strategy, outcomeLabel, failed).Add(1)
+		return
+	}
+	outcome := change
+	if quantity, err := token2.ToQuantity(q, s.precision); err == nil && sum.Cmp(quantity) == 0 {
+		outcome = exact
+	}
+	s.metrics.Selections.With(strategyLabel, strategy, outcomeLabel, outcome).Add(1)
+	s.metrics.SelectedTokens.With(strategyLabel, strategy).Observe(float64(len(ids)))
+}
+
 func (s *selector) Close() error {
 	if s.isClosed() {
 		return errors.New("selector is already closed")
@@ -193,12 +292,14 @@ func (l *locker) UnlockAll() error {
 	return l.Locker.UnlockByTxID(l.txID)
 }
 
-func NewSherdSelector(txID transaction.ID, fetcher tokenFetcher, lockDB Locker, precision uint64, backoff time.Duration, maxRetriesAfterBackoff int) tokenSelectorUnlocker {
+func NewSherdSelector(txID transaction.ID, fetcher tokenFetcher, lockDB Locker, precision uint64, backoff time.Duration, maxRetriesAfterBackoff int, strategy config.SelectionStrategy, order OrderFunc, m *Metrics) tokenSelectorUnlocker {
 	logger := logger.Named(fmt.Sprintf("selector-%s", txID))
 	locker := &locker{txID: txID, Locker: lockDB}
 	if backoff < 0 {
-		return NewSelector(logger, fetcher, locker, precision)
+		return NewSelector(logger, fetcher, locker, precision).withStrategy(strategy, order, m)
 	} else {
-		return NewStubbornSelector(logger, fetcher, locker, precision, backoff, maxRetriesAfterBackoff)
+		s := NewStubbornSelector(logger, fetcher, locker, precision, backoff, maxRetriesAfterBackoff)
+		s.selector.withStrategy(strategy, order, m)
+		return s
 	}
 }
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sherdlock
+
+import (
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/collections"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+type fetcherMock []*token2.UnspentTokenInWallet
+
+func (f fetcherMock) UnspentTokensIteratorBy(walletID string, currency token2.Type) (iterator[*token2.UnspentTokenInWallet], error) {
+	return collections.NewSliceIterator(f), nil
+}
+
+type lockerMock struct{}
+
+func (l *lockerMock) TryLock(*token2.ID) bool { return true }
+
+func (l *lockerMock) UnlockAll() error { return nil }
+
+type ownerFilter string
+
+func (o ownerFilter) ID() string { return string(o) }
+
+func TestSelectSkipsTimelockWallets(t *testing.T) {
+	fetcher := fetcherMock{
+		{Id: &token2.ID{TxId: "a"}, WalletID: "timelock.recipientalice", Type: "USD", Quantity: "0x10"},
+		{Id: &token2.ID{TxId: "b"}, WalletID: "alice", Type: "USD", Quantity: "0x5"},
+	}
+
+	// time-locked tokens are never picked
+	ids, sum, err := NewSelector(logger, fetcher, &lockerMock{}, 64).Select(ownerFilter("alice"), "5", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, []*token2.ID{{TxId: "b"}}, ids)
+	assert.Equal(t, "5", sum.Decimal())
+
+	_, _, err = NewSelector(logger, fetcher, &lockerMock{}, 64).Select(ownerFilter("alice"), "6", "USD")
+	assert.ErrorIs(t, err, token.SelectorInsufficientFunds)
+
+	// the wallets of time-locked tokens cannot be used for selection
+	_, _, err = NewSelector(logger, fetcher, &lockerMock{}, 64).Select(ownerFilter("timelock.recipientalice"), "5", "USD")
+	assert.EqualError(t, err, "tokens of wallet [timelock.recipientalice] cannot be selected")
+}
@@ -34,6 +34,7 @@ func NewService(fetcherProvider FetcherProvider, tokenLockDBManager *tokenlockdb
 		numRetries:             cfg.GetNumRetries(),
 		leaseExpiry:            cfg.GetLeaseExpiry(),
 		leaseCleanupTickPeriod: cfg.GetLeaseCleanupTickPeriod(),
+		cfg:                    cfg,
 	}
 	return &SelectorService{
 		managerLazyCache: lazy2.NewProviderWithKeyMapper(key, loader.load),
@@ -55,6 +56,7 @@ type loader struct {
 	retryInterval          time.Duration
 	leaseExpiry            time.Duration
 	leaseCleanupTickPeriod time.Duration
+	cfg                    *config.Config
 }
 
 func (s *loader) load(tms *token.ManagementService) (token.SelectorManager, error) {
@@ -70,6 +72,14 @@ func (s *loader) load(tms *token.ManagementService) (token.SelectorManager, erro
 	if err != nil {
 		return nil, errors.Errorf("failed to create token fetcher: %v", err)
 	}
+	strategy, err := s.cfg.GetStrategy(tms.Configuration())
+	if err != nil {
+		return nil, errors.Errorf("failed to get selection strategy: %v", err)
+	}
+	order, err := getStrategy(strategy)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid selection strategy for TMS [%s]", tms.ID())
+	}
 	return NewManager(
 		fetcher,
 		tokenLockDB,
@@ -78,6 +88,9 @@ func (s *loader) load(tms *token.ManagementService) (token.SelectorManager, erro
 		s.numRetries,
 		s.leaseExpiry,
 		s.leaseCleanupTickPeriod,
+		strategy,
+		order,
+		s.fetcherProvider.Metrics(),
 	), nil
 }
 
@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sherdlock
+
+import (
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/config"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// maxExactMatchTries bounds the number of branches explored by the exact-match search
+const maxExactMatchTries = 100000
+
+// Candidate is a token the selector can lock, with its quantity
+type Candidate struct {
+	Token    *token2.UnspentTokenInWallet
+	Quantity *big.Int
+}
+
+// OrderFunc returns the passed candidates in the order the selector tries to lock them to cover the passed target.
+// The selector stops as soon as the locked tokens cover the target, skipping the tokens locked by other processes.
+type OrderFunc = func(candidates []*Candidate, target *big.Int) []*Candidate
+
+var (
+	strategiesMu sync.RWMutex
+	// strategies maps the selection strategies to their order functions.
+	// A nil function keeps the order of the token fetcher.
+	strategies = map[config.SelectionStrategy]OrderFunc{
+		config.FirstFit:           nil,
+		config.LargestFirst:       largestFirst,
+		config.SmallestSufficient: smallestSufficient,
+		config.ExactMatch:         exactMatch,
+		config.DustConsolidation:  smallestFirst,
+	}
+)
+
+// RegisterStrategy makes the passed selection strategy available to the selectors
+func RegisterStrategy(strategy config.SelectionStrategy, order OrderFunc) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[strategy] = order
+}
+
+func getStrategy(strategy config.SelectionStrategy) (OrderFunc, error) {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+	order, ok := strategies[strategy]
+	if !ok {
+		return nil, errors.Errorf("undefined selection strategy [%s]", strategy)
+	}
+	return order, nil
+}
+
+func byQuantityDesc(a, b *Candidate) int { return b.Quantity.Cmp(a.Quantity) }
+
+func byQuantityAsc(a, b *Candidate) int { return a.Quantity.Cmp(b.Quantity) }
+
+func largestFirst(candidates []*Candidate, _ *big.Int) []*Candidate {
+	ordered := slices.Clone(candidates)
+	slices.SortStableFunc(ordered, byQuantityDesc)
+	return ordered
+}
+
+func smallestFirst(candidates []*Candidate, _ *big.Int) []*Candidate {
+	ordered := slices.Clone(candidates)
+	slices.SortStableFunc(ordered, byQuantityAsc)
+	return ordered
+}
+
+func smallestSufficient(candidates []*Candidate, target *big.Int) []*Candidate {
+	ordered := largestFirst(candidates, target)
+	// the last sufficient token in descending order is the smallest one
+	best := -1
+	for i, c := range ordered {
+		if c.Quantity.Cmp(target) < 0 {
+			break
+		}
+		best = i
+	}
+	if best <= 0 {
+		return ordered
+	}
+	smallest := ordered[best]
+	return append([]*Candidate{smallest}, slices.Delete(ordered, best, best+1)...)
+}
+
+func exactMatch(candidates []*Candidate, target *big.Int) []*Candidate {
+	ordered := largestFirst(candidates, target)
+	subset := findExactMatch(ordered, target)
+	if len(subset) == 0 {
+		return ordered
+	}
+	picked := make(map[int]bool, len(subset))
+	for _, i := range subset {
+		picked[i] = true
+	}
+	result := make([]*Candidate, 0, len(ordered))
+	for _, i := range subset {
+		result = append(result, ordered[i])
+	}
+	for i, c := range ordered {
+		if !picked[i] {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// findExactMatch returns the indexes of candidates, sorted by descending quantity, whose quantities sum up to the target.
+// The search is a depth-first branch-and-bound that includes, or excludes, each candidate in turn.
+// A branch is cut when its sum exceeds the target, or when the remaining candidates cannot reach it.
+// It returns nil if no match is found within maxExactMatchTries branches.
+func findExactMatch(ordered []*Candidate, target *big.Int) []int {
+	if target.Sign() <= 0 {
+		return nil
+	}
+	// remaining[i] is the sum of the quantities from i on
+	remaining := make([]*big.Int, len(ordered)+1)
+	remaining[len(ordered)] = big.NewInt(0)
+	for i := len(ordered) - 1; i >= 0; i-- {
+		remaining[i] = new(big.Int).Add(remaining[i+1], ordered[i].Quantity)
+	}
+
+	tries := 0
+	var picked []int
+	var search func(i int, sum *big.Int) bool
+	search = func(i int, sum *big.Int) bool {
+		tries++
+		switch sum.Cmp(target) {
+		case 0:
+			return true
+		case 1:
+			return false
+		}
+		if i == len(ordered) || tries > maxExactMatchTries {
+			return false
+		}
+		if new(big.Int).Add(sum, remaining[i]).Cmp(target) < 0 {
+			return false
+		}
+		// include the candidate
+		picked = append(picked, i)
+		if search(i+1, new(big.Int).Add(sum, ordered[i].Quantity)) {
+			return true
+		}
+		picked = picked[:len(picked)-1]
+		// exclude the candidate, and those of the same quantity, as they lead to the same sums
+		j := i + 1
+		for j < len(ordered) && ordered[j].Quantity.Cmp(ordered[i].Quantity) == 0 {
+			j++
+		}
+		return search(j, sum)
+	}
+	if search(0, big.NewInt(0)) {
+		return picked
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sherdlock
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/selector/config"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func candidates(quantities ...int64) []*Candidate {
+	cs := make([]*Candidate, len(quantities))
+	for i, q := range quantities {
+		cs[i] = &Candidate{
+			Token:    &token2.UnspentTokenInWallet{Id: &token2.ID{TxId: fmt.Sprintf("tx%d", i)}},
+			Quantity: big.NewInt(q),
+		}
+	}
+	return cs
+}
+
+func quantities(cs []*Candidate) []int64 {
+	qs := make([]int64, len(cs))
+	for i, c := range cs {
+		qs[i] = c.Quantity.Int64()
+	}
+	return qs
+}
+
+func TestStrategies(t *testing.T) {
+	testCases := []struct {
+		strategy config.SelectionStrategy
+		target   int64
+		expected []int64
+	}{
+		{strategy: config.LargestFirst, target: 7, expected: []int64{10, 6, 4, 3, 1}},
+		{strategy: config.DustConsolidation, target: 7, expected: []int64{1, 3, 4, 6, 10}},
+		{strategy: config.SmallestSufficient, target: 5, expected: []int64{6, 10, 4, 3, 1}},
+		// no single token is sufficient
+		{strategy: config.SmallestSufficient, target: 11, expected: []int64{10, 6, 4, 3, 1}},
+		{strategy: config.ExactMatch, target: 7, expected: []int64{6, 1, 10, 4, 3}},
+		{strategy: config.ExactMatch, target: 13, expected: []int64{10, 3, 6, 4, 1}},
+		// no exact match, fall back to largest-first
+		{strategy: config.ExactMatch, target: 25, expected: []int64{10, 6, 4, 3, 1}},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s-%d", tc.strategy, tc.target), func(t *testing.T) {
+			order, err := getStrategy(tc.strategy)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, quantities(order(candidates(3, 10, 1, 6, 4), big.NewInt(tc.target))))
+		})
+	}
+
+	order, err := getStrategy(config.FirstFit)
+	assert.NoError(t, err)
+	assert.Nil(t, order)
+
+	_, err = getStrategy("unknown")
+	assert.EqualError(t, err, "undefined selection strategy [unknown]")
+}
+
+func TestFindExactMatch(t *testing.T) {
+	ordered := largestFirst(candidates(5, 5, 5, 2, 2), nil)
+	assert.Equal(t, []int{0, 3, 4}, findExactMatch(ordered, big.NewInt(9)))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, findExactMatch(ordered, big.NewInt(19)))
+	assert.Nil(t, findExactMatch(ordered, big.NewInt(8)))
+	assert.Nil(t, findExactMatch(ordered, big.NewInt(20)))
+	assert.Nil(t, findExactMatch(ordered, big.NewInt(0)))
+}
+
+func TestSelectWithStrategy(t *testing.T) {
+	fetcher := fetcherMock{
+		{Id: &token2.ID{TxId: "a"}, WalletID: "alice", Type: "USD", Quantity: "0x3"},
+		{Id: &token2.ID{TxId: "b"}, WalletID: "alice", Type: "USD", Quantity: "0xa"},
+		{Id: &token2.ID{TxId: "c"}, WalletID: "alice", Type: "USD", Quantity: "0x4"},
+		{Id: &token2.ID{TxId: "d"}, WalletID: "timelock.recipientalice", Type: "USD", Quantity: "0x7"},
+	}
+
+	testCases := []struct {
+		strategy config.SelectionStrategy
+		expected []*token2.ID
+		sum      string
+	}{
+		{strategy: config.FirstFit, expected: []*token2.ID{{TxId: "a"}, {TxId: "b"}}, sum: "13"},
+		{strategy: config.LargestFirst, expected: []*token2.ID{{TxId: "b"}}, sum: "10"},
+		{strategy: config.SmallestSufficient, expected: []*token2.ID{{TxId: "b"}}, sum: "10"},
+		{strategy: config.ExactMatch, expected: []*token2.ID{{TxId: "c"}, {TxId: "a"}}, sum: "7"},
+		{strategy: config.DustConsolidation, expected: []*token2.ID{{TxId: "a"}, {TxId: "c"}}, sum: "7"},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.strategy), func(t *testing.T) {
+			order, err := getStrategy(tc.strategy)
+			assert.NoError(t, err)
+			s := NewSelector(logger, fetcher, &lockerMock{}, 64).withStrategy(tc.strategy, order, nil)
+			ids, sum, err := s.Select(ownerFilter("alice"), "7", "USD")
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tc.expected, ids)
+			assert.Equal(t, tc.sum, sum.Decimal())
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tokendb
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/pkg/errors"
+)
+
+type (
+	TokenChange          = driver.TokenChange
+	TokenChangeOperation = driver.TokenChangeOperation
+)
+
+const (
+	TokenInserted = driver.TokenInserted
+	TokenDeleted  = driver.TokenDeleted
+)
+
+const (
+	// changesPageSize is the number of changes read from the change log at once
+	changesPageSize = 100
+	// changesPollInterval is how often the change log is read when no notification arrives.
+	// It covers the notifications lost while reconnecting, and the databases without notifications.
+	changesPollInterval = 5 * time.Second
+	// changesPruneInterval is how often the changes older than the retention are pruned
+	changesPruneInterval = time.Hour
+	// DefaultTokenChangesRetention is how long the token changes are kept, if not configured otherwise
+	DefaultTokenChangesRetention = 7 * 24 * time.Hour
+)
+
+// ErrTokenChangesPruned is returned when subscribing from a cursor that precedes pruned changes.
+// A consumer that lags behind the retention must rebuild its state.
+var ErrTokenChangesPruned = driver.ErrTokenChangesPruned
+
+// TokenChangeCallback processes a token change.
+// If it returns an error, the change is delivered again later.
+type TokenChangeCallback func(TokenChange) error
+
+// changeFeed delivers the token change log to the in-process subscribers.
+// Each subscriber reads the change log from its own cursor, and the notifications of the database wake them all up.
+type changeFeed struct {
+	notifier     driver.TokenNotifier
+	pollInterval time.Duration
+
+	subscribeMutex sync.Mutex
+	subscribed     bool
+	mutex          sync.RWMutex
+	wakeups        map[chan struct{}]struct{}
+}
+
+func newChangeFeed(notifier driver.TokenNotifier) *changeFeed {
+	return &changeFeed{
+		notifier:     notifier,
+		pollInterval: changesPollInterval,
+		wakeups:      map[chan struct{}]struct{}{},
+	}
+}
+
+func (f *changeFeed) subscribe(ctx context.Context, after uint64, callback TokenChangeCallback) error {
+	if _, err := f.notifier.TokenChanges(after, 1); err != nil {
+		return errors.WithMessagef(err, "cannot subscribe from [%d]", after)
+	}
+	f.subscribeMutex.Lock()
+	if !f.subscribed {
+		if err := f.notifier.Subscribe(f.wake); err != nil {
+			f.subscribeMutex.Unlock()
+			return errors.Wrapf(err, "failed subscribing to the token notifications")
+		}
+		f.subscribed = true
+	}
+	f.subscribeMutex.Unlock()
+
+	wakeup := make(chan struct{}, 1)
+	f.mutex.Lock()
+	f.wakeups[wakeup] = struct{}{}
+	f.mutex.Unlock()
+
+	go f.deliver(ctx, after, callback, wakeup)
+	return nil
+}
+
+// wake signals all the subscribers that new changes might be available
+func (f *changeFeed) wake(driver2.Operation, map[driver2.ColumnKey]string) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	for wakeup := range f.wakeups {
+		select {
+		case wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (f *changeFeed) deliver(ctx context.Context, cursor uint64, callback TokenChangeCallback, wakeup chan struct{}) {
+	defer func() {
+		f.mutex.Lock()
+		delete(f.wakeups, wakeup)
+		f.mutex.Unlock()
+	}()
+
+	ticker := time.NewTicker(f.pollInterval)
+	defer ticker.Stop()
+	for {
+		var err error
+		if cursor, err = f.catchUp(ctx, cursor, callback); err != nil {
+			logger.Errorf("stopping the delivery of the token changes: %s", err)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-wakeup:
+		case <-ticker.C:
+		}
+	}
+}
+
+// catchUp delivers the changes recorded after the passed cursor and returns the cursor of the last change processed.
+// It returns an error only if the delivery cannot continue.
+func (f *changeFeed) catchUp(ctx context.Context, cursor uint64, callback TokenChangeCallback) (uint64, error) {
+	for ctx.Err() == nil {
+		changes, err := f.notifier.TokenChanges(cursor, changesPageSize)
+		if errors.Is(err, ErrTokenChangesPruned) {
+			return cursor, err
+		}
+		if err != nil {
+			logger.Errorf("failed reading the token changes after [%d]: %s", cursor, err)
+			return cursor, nil
+		}
+		for _, change := range changes {
+			if ctx.Err() != nil {
+				return cursor, nil
+			}
+			if err := callback(change); err != nil {
+				logger.Warnf("failed processing the token change [%d], it will be delivered again: %s", change.Cursor, err)
+				return cursor, nil
+			}
+			cursor = change.Cursor
+		}
+		if len(changes) < changesPageSize {
+			return cursor, nil
+		}
+	}
+	return cursor, nil
+}
+
+// prune removes, periodically, the changes older than the passed retention.
+// All the replicas sharing the database prune, and pruning twice is harmless.
+func (f *changeFeed) prune(retention time.Duration) {
+	ticker := time.NewTicker(changesPruneInterval)
+	defer ticker.Stop()
+	for {
+		if err := f.notifier.PruneTokenChanges(time.Now().Add(-retention)); err != nil {
+			logger.Errorf("failed pruning the token changes older than [%s]: %s", retention, err)
+		}
+		<-ticker.C
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tokendb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver"
+	sql2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/services/db/driver/sql"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/common"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/sql/sqlite"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+	_ "modernc.org/sqlite"
+)
+
+type changeCollector struct {
+	mutex   sync.Mutex
+	changes []TokenChange
+	fail    map[uint64]bool
+}
+
+func (c *changeCollector) callback(change TokenChange) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.fail[change.Cursor] {
+		delete(c.fail, change.Cursor)
+		return fmt.Errorf("failed processing [%d]", change.Cursor)
+	}
+	c.changes = append(c.changes, change)
+	return nil
+}
+
+func (c *changeCollector) cursors() []uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	cursors := make([]uint64, len(c.changes))
+	for i, change := range c.changes {
+		cursors[i] = change.Cursor
+	}
+	return cursors
+}
+
+func (c *changeCollector) failed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.fail) == 0
+}
+
+func (c *changeCollector) last() TokenChange {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.changes[len(c.changes)-1]
+}
+
+func TestSubscribeTokenChanges(t *testing.T) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", path.Join(t.TempDir(), "db.sqlite")))
+	assert.NoError(t, err)
+	defer common.Close(db)
+	opts := common.NewDBOpts{TablePrefix: "test", CreateSchema: true, Driver: sql2.SQLite}
+	tokenDB, err := sqlite.NewTokenDB(db, db, opts)
+	assert.NoError(t, err)
+	tokenNotifier, err := sqlite.NewTokenNotifier(db, db, opts)
+	assert.NoError(t, err)
+	notifier, err := newNotifier(tokenNotifier)
+	assert.NoError(t, err)
+	notifier.feed.pollInterval = time.Hour
+
+	store := func(index uint64) {
+		assert.NoError(t, tokenDB.(*common.TokenDB).StoreToken(driver.TokenRecord{
+			TxID:           "tx1",
+			Index:          index,
+			OwnerRaw:       []byte{1, 2, 3},
+			OwnerType:      "idemix",
+			OwnerIdentity:  []byte{},
+			OwnerWalletID:  "alice",
+			Ledger:         []byte("ledger"),
+			LedgerMetadata: []byte{},
+			Quantity:       "0x02",
+			Type:           "TST",
+			Amount:         2,
+			Owner:          true,
+		}, nil))
+	}
+	store(0)
+
+	// the subscribers first catch up with the change log
+	ctx, cancel := context.WithCancel(context.Background())
+	alice := &changeCollector{}
+	assert.NoError(t, notifier.SubscribeTokenChanges(ctx, 0, alice.callback))
+	bob := &changeCollector{fail: map[uint64]bool{2: true}}
+	assert.NoError(t, notifier.SubscribeTokenChanges(context.Background(), 0, bob.callback))
+	assert.Eventually(t, func() bool { return len(alice.cursors()) == 1 && len(bob.cursors()) == 1 }, time.Second, 10*time.Millisecond)
+
+	// the notifications wake all the subscribers up, and the changes that fail are delivered again
+	store(1)
+	assert.NoError(t, tokenDB.DeleteTokens("tx2", &token.ID{TxId: "tx1", Index: 0}))
+	notifier.feed.wake(driver2.Insert, nil)
+	assert.Eventually(t, func() bool { return len(alice.cursors()) == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []uint64{1, 2, 3}, alice.cursors())
+	assert.Eventually(t, func() bool { return bob.failed() }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []uint64{1}, bob.cursors())
+	notifier.feed.wake(driver2.Insert, nil)
+	assert.Eventually(t, func() bool { return len(bob.cursors()) == 3 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []uint64{1, 2, 3}, bob.cursors())
+
+	assert.Equal(t, TokenChange{Cursor: 3, Operation: TokenDeleted, TokenID: token.ID{TxId: "tx1", Index: 0}, WalletID: "alice", Type: "TST", Quantity: "0x02", SpentBy: "tx2"}, bob.last())
+
+	// a restarted consumer resumes from its last cursor
+	cancel()
+	store(2)
+	restarted := &changeCollector{}
+	assert.NoError(t, notifier.SubscribeTokenChanges(context.Background(), alice.cursors()[2], restarted.callback))
+	assert.Eventually(t, func() bool { return len(restarted.cursors()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []uint64{4}, restarted.cursors())
+	assert.Equal(t, []uint64{1, 2, 3}, alice.cursors())
+}
+
+func TestSubscribeTokenChangesPruned(t *testing.T) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)", path.Join(t.TempDir(), "db.sqlite")))
+	assert.NoError(t, err)
+	defer common.Close(db)
+	opts := common.NewDBOpts{TablePrefix: "test", CreateSchema: true, Driver: sql2.SQLite}
+	tokenDB, err := sqlite.NewTokenDB(db, db, opts)
+	assert.NoError(t, err)
+	tokenNotifier, err := sqlite.NewTokenNotifier(db, db, opts)
+	assert.NoError(t, err)
+	notifier, err := newNotifier(tokenNotifier)
+	assert.NoError(t, err)
+	notifier.feed.pollInterval = time.Hour
+
+	for index := uint64(0); index < 2; index++ {
+		assert.NoError(t, tokenDB.(*common.TokenDB).StoreToken(driver.TokenRecord{
+			TxID:           "tx1",
+			Index:          index,
+			OwnerRaw:       []byte{1, 2, 3},
+			OwnerType:      "idemix",
+			OwnerIdentity:  []byte{},
+			OwnerWalletID:  "alice",
+			Ledger:         []byte("ledger"),
+			LedgerMetadata: []byte{},
+			Quantity:       "0x02",
+			Type:           "TST",
+			Amount:         2,
+			Owner:          true,
+		}, nil))
+	}
+	assert.NoError(t, notifier.PruneTokenChanges(time.Now().Add(time.Hour)))
+
+	// a consumer that did not process the pruned changes cannot subscribe, the others can
+	err = notifier.SubscribeTokenChanges(context.Background(), 1, (&changeCollector{}).callback)
+	assert.ErrorIs(t, err, ErrTokenChangesPruned)
+	assert.NoError(t, notifier.SubscribeTokenChanges(context.Background(), 2, (&changeCollector{}).callback))
+}
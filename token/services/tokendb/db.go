@@ -7,11 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package tokendb
 
 import (
+	"context"
 	"reflect"
+	"time"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
 	"github.com/pkg/errors"
 )
 
@@ -20,14 +23,46 @@ type (
 	NotifierManager = db.Manager[*Notifier]
 )
 
+// Notifier notifies the changes of the tokens stored in a database
 type Notifier struct {
 	driver.TokenNotifier
+	feed *changeFeed
 }
 
-var managerType = reflect.TypeOf((*Manager)(nil))
+// SubscribeTokenChanges delivers, in order, the token changes recorded after the passed cursor,
+// and then the new ones as they are recorded, until the context is done.
+// The changes come from all the replicas sharing the database.
+// A consumer that stores the cursor of the last change it processed resumes from it, after a restart, without gaps.
+// Cursor 0 delivers the whole change log.
+// If changes after the cursor have been pruned, it returns ErrTokenChangesPruned,
+// and a subscriber that lags behind the retention stops receiving changes.
+func (n *Notifier) SubscribeTokenChanges(ctx context.Context, after uint64, callback TokenChangeCallback) error {
+	return n.feed.subscribe(ctx, after, callback)
+}
+
+var (
+	managerType = reflect.TypeOf((*Manager)(nil))
+	logger      = logging.MustGetLogger("token-sdk.tokendb")
+)
 
+// NewNotifierManager returns a manager of notifiers that keep the token changes for DefaultTokenChangesRetention
 func NewNotifierManager(dh *db.DriverHolder, keys ...string) *NotifierManager {
-	return db.MappedManager[driver.TokenNotifier, *Notifier](dh.NewTokenNotifierManager(keys...), func(p driver.TokenNotifier) (*Notifier, error) { return &Notifier{p}, nil })
+	return NewNotifierManagerWithRetention(dh, DefaultTokenChangesRetention, keys...)
+}
+
+// NewNotifierManagerWithRetention returns a manager of notifiers that keep the token changes for the passed duration.
+// A non-positive retention keeps them forever.
+func NewNotifierManagerWithRetention(dh *db.DriverHolder, retention time.Duration, keys ...string) *NotifierManager {
+	return db.MappedManager[driver.TokenNotifier, *Notifier](dh.NewTokenNotifierManager(keys...), func(p driver.TokenNotifier) (*Notifier, error) {
+		n, err := newNotifier(p)
+		if err != nil {
+			return nil, err
+		}
+		if retention > 0 {
+			go n.feed.prune(retention)
+		}
+		return n, nil
+	})
 }
 
 func NewManager(dh *db.DriverHolder, keys ...string) *Manager {
@@ -65,6 +100,10 @@ func (d *DB) NewTransaction() (*Transaction, error) {
 	return &Transaction{TokenDBTransaction: tx}, nil
 }
 
+func newNotifier(p driver.TokenNotifier) (*Notifier, error) {
+	return &Notifier{TokenNotifier: p, feed: newChangeFeed(p)}, nil
+}
+
 func newDB(p driver.TokenDB) (*DB, error) {
 	return &DB{TokenDB: p}, nil
 }
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package tokenlockdb
 
 import (
+	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/lazy"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
 )
@@ -20,3 +22,9 @@ func NewManager(dh *db.DriverHolder, keys ...string) *Manager {
 type DB struct{ driver.TokenLockDB }
 
 func newDB(p driver.TokenLockDB) (*DB, error) { return &DB{TokenLockDB: p}, nil }
+
+// NewManagerFromProvider returns a manager whose token lock databases are returned by the passed provider,
+// instead of being opened by the database drivers
+func NewManagerFromProvider(provider lazy.Provider[token.TMSID, driver.TokenLockDB]) *Manager {
+	return db.MappedManager[driver.TokenLockDB, *DB](&db.Manager[driver.TokenLockDB]{Provider: provider}, newDB)
+}
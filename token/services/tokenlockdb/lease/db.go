@@ -0,0 +1,214 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lease
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/lazy"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokenlockdb"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/types/transaction"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+var logger = logging.MustGetLogger("token-sdk.tokenlockdb.lease")
+
+// RequestType is the type of lease request sent to the other replicas
+type RequestType int
+
+const (
+	// Acquire asks for the lease on a token
+	Acquire RequestType = iota
+	// Release gives back the lease on a token
+	Release
+	// ReleaseByTxID gives back all the leases of a consumer transaction
+	ReleaseByTxID
+)
+
+// Request is the message a replica sends to the other replicas to acquire, or release, leases
+type Request struct {
+	TMSID        token2.TMSID
+	Type         RequestType
+	TokenID      *token.ID
+	ConsumerTxID transaction.ID
+}
+
+// Response is the answer to a Request
+type Response struct {
+	// Granted is true if the lease has been granted, or released
+	Granted bool
+}
+
+// Transport delivers the lease requests to the other replicas
+type Transport interface {
+	Send(ctx context.Context, replica view.Identity, request *Request) (*Response, error)
+}
+
+// LockDB is a driver.TokenLockDB whose locks are leases agreed by a majority of the replicas.
+// Each replica keeps its own lease table. A token is locked if the replica locking it, and enough other replicas,
+// grant the lease to the consumer transaction. Two replicas competing for the same token cannot both reach the majority.
+// No database is shared by the replicas.
+type LockDB struct {
+	tmsID     token2.TMSID
+	table     *Table
+	replicas  []view.Identity
+	transport Transport
+}
+
+func NewLockDB(tmsID token2.TMSID, replicas []view.Identity, transport Transport) *LockDB {
+	return &LockDB{
+		tmsID:     tmsID,
+		table:     NewTable(),
+		replicas:  replicas,
+		transport: transport,
+	}
+}
+
+func (d *LockDB) Lock(tokenID *token.ID, consumerTxID transaction.ID) error {
+	if !d.table.Acquire(*tokenID, consumerTxID) {
+		return errors.Errorf("token [%s] already leased", tokenID)
+	}
+	granted := d.broadcast(d.replicas, &Request{TMSID: d.tmsID, Type: Acquire, TokenID: tokenID, ConsumerTxID: consumerTxID})
+	// this replica granted the lease too
+	if len(granted)+1 >= d.quorum() {
+		return nil
+	}
+
+	// roll back the partial leases
+	d.table.Release(*tokenID, consumerTxID)
+	d.broadcast(granted, &Request{TMSID: d.tmsID, Type: Release, TokenID: tokenID, ConsumerTxID: consumerTxID})
+	return errors.Errorf("token [%s] leased by another replica, granted by [%d] out of [%d] replicas, required [%d]", tokenID, len(granted)+1, len(d.replicas)+1, d.quorum())
+}
+
+func (d *LockDB) UnlockByTxID(consumerTxID transaction.ID) error {
+	d.table.ReleaseByTxID(consumerTxID)
+	// the leases of the replicas that cannot be reached expire
+	d.broadcast(d.replicas, &Request{TMSID: d.tmsID, Type: ReleaseByTxID, ConsumerTxID: consumerTxID})
+	return nil
+}
+
+// Cleanup removes the leases older than the passed expiry.
+// Each replica expires the leases it has granted, including those of the replicas that exited unexpectedly.
+func (d *LockDB) Cleanup(leaseExpiry time.Duration) error {
+	if removed := d.table.Expire(leaseExpiry); removed != 0 {
+		logger.Debugf("expired [%d] leases for [%s]", removed, d.tmsID)
+	}
+	return nil
+}
+
+func (d *LockDB) Close() error {
+	return nil
+}
+
+// Handle serves a request received from another replica
+func (d *LockDB) Handle(request *Request) *Response {
+	switch request.Type {
+	case Acquire:
+		if request.TokenID == nil {
+			return &Response{}
+		}
+		return &Response{Granted: d.table.Acquire(*request.TokenID, request.ConsumerTxID)}
+	case Release:
+		if request.TokenID != nil {
+			d.table.Release(*request.TokenID, request.ConsumerTxID)
+		}
+		return &Response{Granted: true}
+	case ReleaseByTxID:
+		d.table.ReleaseByTxID(request.ConsumerTxID)
+		return &Response{Granted: true}
+	default:
+		return &Response{}
+	}
+}
+
+// quorum returns the number of replicas, including this one, that must grant a lease
+func (d *LockDB) quorum() int {
+	return (len(d.replicas)+1)/2 + 1
+}
+
+// broadcast sends the passed request to the passed replicas in parallel, and returns those that granted it
+func (d *LockDB) broadcast(replicas []view.Identity, request *Request) []view.Identity {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		granted []view.Identity
+	)
+	for _, replica := range replicas {
+		wg.Add(1)
+		go func(replica view.Identity) {
+			defer wg.Done()
+			response, err := d.transport.Send(context.Background(), replica, request)
+			if err != nil {
+				logger.Warnf("failed sending lease request [%d] for [%s] to replica [%s]: %s", request.Type, request.ConsumerTxID, replica, err)
+				return
+			}
+			if response.Granted {
+				mu.Lock()
+				granted = append(granted, replica)
+				mu.Unlock()
+			}
+		}(replica)
+	}
+	wg.Wait()
+	return granted
+}
+
+// Service holds the lease tables of the token management services of this replica
+type Service struct {
+	replicas  []view.Identity
+	transport Transport
+
+	mu  sync.Mutex
+	dbs map[string]*LockDB
+}
+
+// NewService returns a service whose leases are agreed with the passed replicas, this replica excluded
+func NewService(replicas []view.Identity, transport Transport) *Service {
+	return &Service{
+		replicas:  replicas,
+		transport: transport,
+		dbs:       map[string]*LockDB{},
+	}
+}
+
+// LockDB returns the lease table of the passed token management service
+func (s *Service) LockDB(tmsID token2.TMSID) *LockDB {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	db, ok := s.dbs[tmsID.String()]
+	if !ok {
+		db = NewLockDB(tmsID, s.replicas, s.transport)
+		s.dbs[tmsID.String()] = db
+	}
+	return db
+}
+
+// IsReplica returns true if the passed identity is one of the other replicas
+func (s *Service) IsReplica(id view.Identity) bool {
+	return slices.ContainsFunc(s.replicas, id.Equal)
+}
+
+// Handle serves a request received from another replica
+func (s *Service) Handle(request *Request) *Response {
+	return s.LockDB(request.TMSID).Handle(request)
+}
+
+// Manager returns a token lock database manager backed by this service
+func (s *Service) Manager() *tokenlockdb.Manager {
+	return tokenlockdb.NewManagerFromProvider(lazy.NewProviderWithKeyMapper(
+		func(tmsID token2.TMSID) string { return tmsID.String() },
+		func(tmsID token2.TMSID) (driver.TokenLockDB, error) { return s.LockDB(tmsID), nil },
+	))
+}
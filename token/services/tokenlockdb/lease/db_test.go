@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lease
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var tmsID = token2.TMSID{Network: "network", Channel: "channel", Namespace: "namespace"}
+
+// network delivers the lease requests to the services of the replicas, unless a replica is down
+type network struct {
+	mu       sync.Mutex
+	services map[string]*Service
+	down     map[string]bool
+}
+
+func (n *network) Send(_ context.Context, replica view.Identity, request *Request) (*Response, error) {
+	n.mu.Lock()
+	s, ok := n.services[replica.UniqueID()]
+	down := n.down[replica.UniqueID()]
+	n.mu.Unlock()
+	if !ok || down {
+		return nil, errors.Errorf("replica [%s] unreachable", replica)
+	}
+	return s.Handle(request), nil
+}
+
+func newReplicas(names ...string) (*network, []*LockDB) {
+	n := &network{services: map[string]*Service{}, down: map[string]bool{}}
+	dbs := make([]*LockDB, len(names))
+	for i, name := range names {
+		var others []view.Identity
+		for _, other := range names {
+			if other != name {
+				others = append(others, view.Identity(other))
+			}
+		}
+		s := NewService(others, n)
+		n.services[view.Identity(name).UniqueID()] = s
+		dbs[i] = s.LockDB(tmsID)
+	}
+	return n, dbs
+}
+
+func TestLock(t *testing.T) {
+	n, dbs := newReplicas("alice", "bob", "charlie")
+	tok := &token.ID{TxId: "tx1", Index: 0}
+
+	// a majority of the replicas grants the lease
+	assert.NoError(t, dbs[0].Lock(tok, "consumer1"))
+	assert.Error(t, dbs[0].Lock(tok, "consumer1"))
+	assert.Error(t, dbs[1].Lock(tok, "consumer2"))
+	assert.Error(t, dbs[2].Lock(tok, "consumer3"))
+	for _, db := range dbs {
+		assert.Equal(t, 1, db.table.Len())
+	}
+
+	// released everywhere
+	assert.NoError(t, dbs[0].UnlockByTxID("consumer1"))
+	for _, db := range dbs {
+		assert.Equal(t, 0, db.table.Len())
+	}
+	assert.NoError(t, dbs[1].Lock(tok, "consumer2"))
+
+	// a replica down does not prevent the majority
+	n.down[view.Identity("charlie").UniqueID()] = true
+	tok2 := &token.ID{TxId: "tx2", Index: 0}
+	assert.NoError(t, dbs[0].Lock(tok2, "consumer1"))
+	assert.Error(t, dbs[1].Lock(tok2, "consumer2"))
+	n.down[view.Identity("bob").UniqueID()] = true
+	tok3 := &token.ID{TxId: "tx3", Index: 0}
+	assert.Error(t, dbs[0].Lock(tok3, "consumer1"))
+	// the partial lease has been rolled back, only those of tx1 and tx2 are left
+	assert.Equal(t, 2, dbs[0].table.Len())
+}
+
+func TestLockConflict(t *testing.T) {
+	_, dbs := newReplicas("alice", "bob")
+	tok := &token.ID{TxId: "tx1", Index: 0}
+
+	// two replicas competing for the same token cannot both reach the majority
+	assert.True(t, dbs[1].table.Acquire(*tok, "consumer2"))
+	assert.Error(t, dbs[0].Lock(tok, "consumer1"))
+	assert.Equal(t, 0, dbs[0].table.Len())
+	assert.Equal(t, 1, dbs[1].table.Len())
+}
+
+func TestCleanup(t *testing.T) {
+	_, dbs := newReplicas("alice", "bob", "charlie")
+	tok := &token.ID{TxId: "tx1", Index: 0}
+	assert.NoError(t, dbs[0].Lock(tok, "consumer1"))
+
+	assert.NoError(t, dbs[1].Cleanup(time.Hour))
+	assert.Equal(t, 1, dbs[1].table.Len())
+	time.Sleep(10 * time.Millisecond)
+	assert.NoError(t, dbs[1].Cleanup(time.Millisecond))
+	assert.Equal(t, 0, dbs[1].table.Len())
+
+	// the remaining leases are still a majority
+	assert.Error(t, dbs[1].Lock(tok, "consumer2"))
+}
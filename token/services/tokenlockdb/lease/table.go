@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lease
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/types/transaction"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
+)
+
+type lease struct {
+	consumerTxID transaction.ID
+	createdAt    time.Time
+}
+
+// Table keeps the leases granted by a replica, both to itself and to the other replicas
+type Table struct {
+	mu     sync.Mutex
+	leases map[token.ID]lease
+}
+
+func NewTable() *Table {
+	return &Table{leases: map[token.ID]lease{}}
+}
+
+// Acquire grants the lease on the passed token to the passed consumer transaction.
+// It returns false if the token is already leased, even to the same transaction, as the token lock databases do.
+// Therefore, the selector does not count twice a token it has already locked.
+func (t *Table) Acquire(id token.ID, consumerTxID transaction.ID) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.leases[id]; ok {
+		return false
+	}
+	t.leases[id] = lease{consumerTxID: consumerTxID, createdAt: time.Now()}
+	return true
+}
+
+// Release removes the lease on the passed token, if it is granted to the passed consumer transaction
+func (t *Table) Release(id token.ID, consumerTxID transaction.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if l, ok := t.leases[id]; ok && l.consumerTxID == consumerTxID {
+		delete(t.leases, id)
+	}
+}
+
+// ReleaseByTxID removes all the leases granted to the passed consumer transaction
+func (t *Table) ReleaseByTxID(consumerTxID transaction.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, l := range t.leases {
+		if l.consumerTxID == consumerTxID {
+			delete(t.leases, id)
+		}
+	}
+}
+
+// Expire removes the leases older than the passed expiry, and returns how many have been removed
+func (t *Table) Expire(leaseExpiry time.Duration) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	deadline := time.Now().Add(-leaseExpiry)
+	removed := 0
+	for id, l := range t.leases {
+		if l.createdAt.Before(deadline) {
+			delete(t.leases, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Len returns the number of leases in the table
+func (t *Table) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.leases)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lease
+
+import (
+	"context"
+	"time"
+
+	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	driver2 "github.com/hyperledger-labs/fabric-smart-client/platform/view/driver"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	session2 "github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/json/session"
+	"github.com/pkg/errors"
+)
+
+// DefaultRequestTimeout is the time a replica waits for the answer to a lease request
+const DefaultRequestTimeout = 5 * time.Second
+
+type ViewManager interface {
+	InitiateView(view view2.View, ctx context.Context) (interface{}, error)
+}
+
+// NewFSCService returns a service whose leases are agreed with the passed replicas over FSC sessions.
+// The replicas are the labels of the FSC nodes sharing the wallets of this node. The identity of this node is skipped.
+// It registers the view that serves the lease requests of the other replicas.
+func NewFSCService(replicaLabels []string, identityProvider driver2.IdentityProvider, viewManager ViewManager, viewRegistry driver2.Registry) (*Service, error) {
+	me := identityProvider.DefaultIdentity()
+	replicas := make([]view.Identity, 0, len(replicaLabels))
+	for _, label := range replicaLabels {
+		replica := identityProvider.Identity(label)
+		if replica.IsNone() {
+			return nil, errors.Errorf("cannot find identity for replica [%s]", label)
+		}
+		if replica.Equal(me) {
+			continue
+		}
+		replicas = append(replicas, replica)
+	}
+	logger.Infof("token locks leased with [%d] other replicas", len(replicas))
+
+	s := NewService(replicas, &viewTransport{viewManager: viewManager, timeout: DefaultRequestTimeout})
+	if err := viewRegistry.RegisterResponder(&RequestLeaseResponderView{service: s}, &RequestLeaseView{}); err != nil {
+		return nil, errors.WithMessage(err, "failed to register lease responder view")
+	}
+	return s, nil
+}
+
+type viewTransport struct {
+	viewManager ViewManager
+	timeout     time.Duration
+}
+
+func (t *viewTransport) Send(ctx context.Context, replica view.Identity, request *Request) (*Response, error) {
+	boxed, err := t.viewManager.InitiateView(&RequestLeaseView{Replica: replica, Request: request, Timeout: t.timeout}, ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, ok := boxed.(*Response)
+	if !ok {
+		return nil, errors.Errorf("expected *Response, got [%T]", boxed)
+	}
+	return response, nil
+}
+
+// RequestLeaseView sends a lease request to a replica and waits for its answer
+type RequestLeaseView struct {
+	Replica view.Identity
+	Request *Request
+	Timeout time.Duration
+}
+
+func (r *RequestLeaseView) Call(context view.Context) (interface{}, error) {
+	session, err := session2.NewJSON(context, context.Initiator(), r.Replica)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get session to replica [%s]", r.Replica)
+	}
+	if err := session.SendWithContext(context.Context(), r.Request); err != nil {
+		return nil, errors.Wrapf(err, "failed to send lease request to replica [%s]", r.Replica)
+	}
+	response := &Response{}
+	if err := session.ReceiveWithTimeout(response, r.Timeout); err != nil {
+		return nil, errors.Wrapf(err, "failed to receive lease response from replica [%s]", r.Replica)
+	}
+	return response, nil
+}
+
+// RequestLeaseResponderView serves the lease requests of the other replicas
+type RequestLeaseResponderView struct {
+	service *Service
+}
+
+func (r *RequestLeaseResponderView) Call(context view.Context) (interface{}, error) {
+	session := session2.JSON(context)
+	request := &Request{}
+	if err := session.Receive(request); err != nil {
+		return nil, errors.Wrapf(err, "failed to receive lease request")
+	}
+	caller := session.Info().Caller
+	logger.Debugf("got lease request [%d] for [%s] from [%s]", request.Type, request.ConsumerTxID, caller)
+	if !r.service.IsReplica(caller) {
+		return nil, errors.Errorf("lease request from [%s], not a replica", caller)
+	}
+	if err := session.SendWithContext(context.Context(), r.service.Handle(request)); err != nil {
+		return nil, errors.Wrapf(err, "failed to send lease response")
+	}
+	return nil, nil
+}
@@ -70,10 +70,12 @@ type TokenToAppend struct {
 	ownerType             string
 	ownerIdentity         token.Identity
 	ownerWalletID         string
+	ownerEnrollmentID     string
 	owners                []string
 	issuer                token.Identity
 	precision             uint64
 	flags                 Flags
+	ppHash                token.PPHash
 }
 
 type transaction struct {
@@ -136,22 +138,24 @@ func (t *transaction) AppendToken(ctx context.Context, tta TokenToAppend) error
 
 	span.AddEvent("store_token")
 	err = t.tx.StoreToken(ctx, tokendb.TokenRecord{
-		TxID:           tta.txID,
-		Index:          tta.index,
-		IssuerRaw:      tta.issuer,
-		OwnerRaw:       tta.tok.Owner,
-		OwnerType:      tta.ownerType,
-		OwnerIdentity:  tta.ownerIdentity,
-		OwnerWalletID:  tta.ownerWalletID,
-		Ledger:         tta.tokenOnLedger,
-		LedgerFormat:   tta.tokenOnLedgerFormat,
-		LedgerMetadata: tta.tokenOnLedgerMetadata,
-		Quantity:       tta.tok.Quantity,
-		Type:           tta.tok.Type,
-		Amount:         q.ToBigInt().Uint64(),
-		Owner:          tta.flags.Mine,
-		Auditor:        tta.flags.Auditor,
-		Issuer:         tta.flags.Issuer,
+		TxID:              tta.txID,
+		Index:             tta.index,
+		IssuerRaw:         tta.issuer,
+		OwnerRaw:          tta.tok.Owner,
+		OwnerType:         tta.ownerType,
+		OwnerIdentity:     tta.ownerIdentity,
+		OwnerWalletID:     tta.ownerWalletID,
+		OwnerEnrollmentID: tta.ownerEnrollmentID,
+		Ledger:            tta.tokenOnLedger,
+		LedgerFormat:      tta.tokenOnLedgerFormat,
+		LedgerMetadata:    tta.tokenOnLedgerMetadata,
+		Quantity:          tta.tok.Quantity,
+		Type:              tta.tok.Type,
+		Amount:            q.ToBigInt().Uint64(),
+		Owner:             tta.flags.Mine,
+		Auditor:           tta.flags.Auditor,
+		Issuer:            tta.flags.Issuer,
+		PublicParamsHash:  tta.ppHash,
 	}, tta.owners)
 	if err != nil && !errors2.HasCause(err, driver.UniqueKeyViolation) {
 		return errors.Wrapf(err, "cannot store token in db")
@@ -205,6 +209,15 @@ func (t *transaction) SetSpendableFlag(ctx context.Context, value bool, ids []*t
 	return nil
 }
 
+func (t *transaction) SetFrozenFlag(ctx context.Context, value bool, ids []*token2.ID) error {
+	for _, id := range ids {
+		if err := t.tx.SetFrozen(ctx, *id, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *transaction) SetSpendableBySupportedTokenTypes(ctx context.Context, supportedTokens []token2.Format) error {
 	return t.tx.SetSpendableBySupportedTokenFormats(ctx, supportedTokens)
 }
@@ -88,6 +88,10 @@ func (t *Tokens) Append(ctx context.Context, tmsID token.TMSID, txID string, req
 	if err != nil {
 		return errors.WithMessagef(err, "transaction [%s], failed to extract actions", txID)
 	}
+	freezes, err := freezeActions(request)
+	if err != nil {
+		return errors.WithMessagef(err, "transaction [%s], failed to extract freeze actions", txID)
+	}
 
 	logger.Debugf("transaction [%s] start db transaction", txID)
 	span.AddEvent("create_new_tx")
@@ -118,6 +122,13 @@ func (t *Tokens) Append(ctx context.Context, tmsID token.TMSID, txID string, req
 	if err != nil {
 		return errors.WithMessagef(err, "transaction [%s], failed to delete tokens", txID)
 	}
+	span.AddEvent("freeze_tokens")
+	for _, freeze := range freezes {
+		err = ts.SetFrozenFlag(ctx, !freeze.Unfreeze, freeze.TokenIDs)
+		if err != nil {
+			return errors.WithMessagef(err, "transaction [%s], failed to set frozen flag", txID)
+		}
+	}
 	span.AddEvent("commit")
 	if err = ts.Commit(); err != nil {
 		return errors.WithMessagef(err, "transaction [%s], failed to commit tokens to database", txID)
@@ -207,6 +218,29 @@ func (t *Tokens) SetSpendableFlag(value bool, ids ...*token2.ID) error {
 	return tx.Commit()
 }
 
+// SetFrozenFlag sets the frozen flag of the passed tokens.
+// Frozen tokens are neither selected for spending nor counted in the balance.
+func (t *Tokens) SetFrozenFlag(value bool, ids ...*token2.ID) error {
+	tx, err := t.Storage.NewTransaction()
+	if err != nil {
+		return errors.Wrapf(err, "failed initiating transaction")
+	}
+	if err := tx.SetFrozenFlag(context.TODO(), value, ids); err != nil {
+		if err2 := tx.Rollback(); err2 != nil {
+			logger.Errorf("failed rolling back transaction that set frozen flag [%s]", err2)
+		}
+		return errors.Wrapf(err, "failed setting frozen flag")
+	}
+	return tx.Commit()
+}
+
+// UnspentTokenIDsByEnrollmentID returns the identifiers of the unspent tokens, known to this node,
+// whose owner has the passed enrollment ID.
+// An auditor can use them to freeze all the tokens of an enrollment ID.
+func (t *Tokens) UnspentTokenIDsByEnrollmentID(ctx context.Context, enrollmentID string) ([]*token2.ID, error) {
+	return t.Storage.tokenDB.UnspentTokenIDsByEnrollmentID(ctx, enrollmentID)
+}
+
 func (t *Tokens) SetSpendableBySupportedTokenTypes(types []token2.Format) error {
 	tx, err := t.Storage.NewTransaction()
 	if err != nil {
@@ -359,10 +393,30 @@ func (t *Tokens) extractActions(tmsID token.TMSID, txID string, request *token.R
 		return nil, nil, errors.WithMessagef(err, "failed to get request's outputs")
 	}
 	toSpend, toAppend := t.parse(auth, txID, md, is, os, auditorFlag, precision, graphHiding)
+	// record the public parameters epoch the new tokens come from
+	ppHash := request.PublicParamsHash()
+	for i := range toAppend {
+		toAppend[i].ppHash = ppHash
+	}
 	logger.Debugf("transaction [%s] parsed [%d] inputs and [%d] outputs", txID, len(toSpend), len(toAppend))
 	return toSpend, toAppend, nil
 }
 
+// freezeActions returns the freeze actions carried by the passed request
+func freezeActions(request *token.Request) ([]*driver.Freeze, error) {
+	if request.Actions == nil {
+		return nil, nil
+	}
+	freezes := make([]*driver.Freeze, len(request.Actions.FreezeActions))
+	for i, raw := range request.Actions.FreezeActions {
+		freezes[i] = &driver.Freeze{}
+		if err := freezes[i].FromBytes(raw); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal freeze action at [%d]", i)
+		}
+	}
+	return freezes, nil
+}
+
 // parse returns the tokens to store and spend as the result of a transaction
 func (t *Tokens) parse(
 	auth driver.Authorization,
@@ -435,6 +489,7 @@ func (t *Tokens) parse(
 			ownerType:             ownerType,
 			ownerIdentity:         ownerIdentity,
 			ownerWalletID:         ownerWalletID,
+			ownerEnrollmentID:     output.EnrollmentID,
 			owners:                ids,
 			issuer:                output.Issuer,
 			precision:             precision,
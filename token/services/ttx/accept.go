@@ -161,6 +161,10 @@ func (s *AcceptView) respondToSignatureRequests(context view.Context) error {
 		if err != nil {
 			return errors.Wrapf(err, "failed signing request")
 		}
+		sigma, err = attachNonRevocationProof(context, tms, signatureRequest.Signer, signer, signatureRequest.MessageToSign(), sigma)
+		if err != nil {
+			return errors.Wrapf(err, "failed signing request")
+		}
 		if logger.IsEnabledFor(zapcore.DebugLevel) {
 			logger.Debugf("Send back signature...")
 		}
@@ -117,12 +117,15 @@ func (r *RegisterAuditorView) Call(context view.Context) (interface{}, error) {
 }
 
 type AuditingViewInitiator struct {
-	tx    *Transaction
-	local bool
+	tx      *Transaction
+	party   view.Identity
+	local   bool
+	auditor token.Identity
+	sigma   []byte
 }
 
-func newAuditingViewInitiator(tx *Transaction, local bool) *AuditingViewInitiator {
-	return &AuditingViewInitiator{tx: tx, local: local}
+func newAuditingViewInitiator(tx *Transaction, party view.Identity, local bool) *AuditingViewInitiator {
+	return &AuditingViewInitiator{tx: tx, party: party, local: local}
 }
 
 func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error) {
@@ -148,7 +151,7 @@ func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error)
 		return nil, errors.WithMessage(err, "failed to read audit event")
 	}
 	span.AddEvent("received_message")
-	logger.Debugf("reply received from %s", a.tx.Opts.Auditor)
+	logger.Debugf("reply received from %s", a.party)
 
 	// Check signature
 	signed, err := a.tx.MarshallToAudit()
@@ -156,10 +159,10 @@ func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error)
 		return nil, errors.Wrapf(err, "failed marshalling message to sign")
 	}
 	if logger.IsEnabledFor(zapcore.DebugLevel) {
-		logger.Debugf("Verifying auditor signature on [%s][%s][%s]", a.tx.Opts.Auditor.UniqueID(), hash.Hashable(signed).String(), a.tx.ID())
+		logger.Debugf("Verifying auditor signature on [%s][%s][%s]", a.party.UniqueID(), hash.Hashable(signed).String(), a.tx.ID())
 	}
 
-	validAuditing := false
+	var auditor token.Identity
 	span.AddEvent("validate_auditing")
 	for _, auditorID := range a.tx.TokenService().PublicParametersManager().PublicParameters().Auditors() {
 		v, err := a.tx.TokenService().SigService().AuditorVerifier(auditorID)
@@ -174,23 +177,29 @@ func (a *AuditingViewInitiator) Call(context view.Context) (interface{}, error)
 			if logger.IsEnabledFor(zapcore.DebugLevel) {
 				logger.Debugf("auditor signature verified [%s][%s][%s]", auditorID, base64.StdEncoding.EncodeToString(signature), hash.Hashable(signed))
 			}
-			validAuditing = true
+			auditor = auditorID
 			break
 		}
 	}
-	if !validAuditing {
+	if auditor.IsNone() {
 		return nil, errors.Errorf("failed verifying auditor signature [%s][%s]", hash.Hashable(signed).String(), a.tx.TokenRequest.Anchor)
 	}
-	span.AddEvent("append_auditor_signature")
-	a.tx.TokenRequest.AddAuditorSignature(signature)
+	// the signature is added to the token request by the caller, once the signatures of all the auditors have been collected
+	a.auditor = auditor
+	a.sigma = signature
 
 	logger.Debug("auditor signature verified")
 	return session, nil
 }
 
+// Signature returns the auditor identity and signature obtained by this view
+func (a *AuditingViewInitiator) Signature() (token.Identity, []byte) {
+	return a.auditor, a.sigma
+}
+
 func (a *AuditingViewInitiator) startRemote(context view.Context) (view.Session, error) {
-	logger.Debugf("Starting remote auditing session with [%s] for [%s]", a.tx.Opts.Auditor.UniqueID(), a.tx.ID())
-	session, err := context.GetSession(a, a.tx.Opts.Auditor)
+	logger.Debugf("Starting remote auditing session with [%s] for [%s]", a.party.UniqueID(), a.tx.ID())
+	session, err := context.GetSession(a, a.party)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed getting session")
 	}
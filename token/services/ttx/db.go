@@ -25,6 +25,9 @@ const txIdLabel tracing.LabelName = "tx_id"
 
 type QueryTransactionsParams = ttxdb.QueryTransactionsParams
 
+// PaymentGroupRecord is a payment split into a chained sequence of transactions, see SplitPaymentView
+type PaymentGroupRecord = ttxdb.PaymentGroupRecord
+
 type NetworkProvider interface {
 	GetNetwork(network string, channel string) (*network.Network, error)
 }
@@ -93,6 +96,70 @@ func (a *DB) GetTransactionEndorsementAcks(id string) (map[string][]byte, error)
 	return a.ttxDB.GetTransactionEndorsementAcks(id)
 }
 
+// AddPendingTransaction stores the passed transaction whose endorsement waits for detached signatures
+func (a *DB) AddPendingTransaction(tx *Transaction) error {
+	raw, err := tx.Bytes()
+	if err != nil {
+		return errors.WithMessagef(err, "failed marshalling transaction [%s]", tx.ID())
+	}
+	return a.ttxDB.AddPendingTransaction(tx.ID(), raw)
+}
+
+// GetPendingTransaction returns the serialized pending transaction bound to the passed id, nil if not found
+func (a *DB) GetPendingTransaction(txID string) ([]byte, error) {
+	return a.ttxDB.GetPendingTransaction(txID)
+}
+
+// GetPendingTransactionIDs returns the ids of the pending transactions, oldest first
+func (a *DB) GetPendingTransactionIDs() ([]string, error) {
+	return a.ttxDB.GetPendingTransactionIDs()
+}
+
+// ReplacePendingTransaction atomically stores the passed transaction in place of the pending one with the same id,
+// the detached signatures collected for the latter are discarded
+func (a *DB) ReplacePendingTransaction(tx *Transaction) error {
+	raw, err := tx.Bytes()
+	if err != nil {
+		return errors.WithMessagef(err, "failed marshalling transaction [%s]", tx.ID())
+	}
+	return a.ttxDB.ReplacePendingTransaction(tx.ID(), raw)
+}
+
+// DeletePendingTransaction removes the pending transaction bound to the passed id
+func (a *DB) DeletePendingTransaction(txID string) error {
+	return a.ttxDB.DeletePendingTransaction(txID)
+}
+
+// AddDetachedSignature records the detached signature of the passed signer on the given pending transaction
+func (a *DB) AddDetachedSignature(txID string, signer view.Identity, sigma []byte) error {
+	return a.ttxDB.AddDetachedSignature(txID, signer, sigma)
+}
+
+// GetDetachedSignatures returns the detached signatures for the given pending transaction, indexed by the unique id of the signer
+func (a *DB) GetDetachedSignatures(txID string) (map[string][]byte, error) {
+	return a.ttxDB.GetDetachedSignatures(txID)
+}
+
+// AddPaymentGroup stores a new payment group made of the passed number of transactions, whose status is Pending
+func (a *DB) AddPaymentGroup(groupID string, parts int) error {
+	return a.ttxDB.AddPaymentGroup(groupID, parts)
+}
+
+// AddPaymentGroupTransaction binds the passed transaction to the given payment group, at the given position
+func (a *DB) AddPaymentGroupTransaction(groupID string, txID string, index int) error {
+	return a.ttxDB.AddPaymentGroupTransaction(groupID, txID, index)
+}
+
+// SetPaymentGroupStatus sets the status of the given payment group
+func (a *DB) SetPaymentGroupStatus(groupID string, status TxStatus, message string) error {
+	return a.ttxDB.SetPaymentGroupStatus(groupID, status, message)
+}
+
+// GetPaymentGroup returns the payment group with the passed identifier, nil if not found
+func (a *DB) GetPaymentGroup(groupID string) (*PaymentGroupRecord, error) {
+	return a.ttxDB.GetPaymentGroup(groupID)
+}
+
 func (a *DB) Check(context context.Context) ([]string, error) {
 	return a.checkService.Check(context)
 }
@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package ttx
 
 import (
+	"bytes"
 	"encoding/base64"
 	errors2 "errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/common/utils/collections"
@@ -19,6 +22,7 @@ import (
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/services/kvs"
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/multisig"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
@@ -87,8 +91,44 @@ func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error
 	span := trace.SpanFromContext(context.Context())
 	metrics := GetMetrics(context)
 
+	// 1. First collect signatures on the token request and 2. audit
+	auditors, err := c.collectSignaturesAndAudit(context)
+	if err != nil {
+		return nil, err
+	}
+	// 3. Endorse and return the transaction envelope
+	var env *network.Envelope
+	if !c.Opts.SkipApproval {
+		span.AddEvent("Request approval from endorser")
+		env, err = c.requestApproval(context)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed requesting approval")
+		}
+	}
+	// Distribute Env to all parties
+	if err := c.distribute(context, env, auditors); err != nil {
+		return nil, err
+	}
+
+	if logger.IsEnabledFor(zapcore.DebugLevel) {
+		logger.Debugf("CollectEndorsementsView done.")
+	}
+
+	labels := []string{
+		"network", c.tx.Network(),
+		"channel", c.tx.Channel(),
+		"namespace", c.tx.Namespace(),
+	}
+	metrics.EndorsedTransactions.With(labels...).Add(1)
+	return nil, nil
+}
+
+// collectSignaturesAndAudit collects the signatures on the token request and, unless skipped, the audit.
+// It returns the auditors that audited the transaction.
+func (c *CollectEndorsementsView) collectSignaturesAndAudit(context view.Context) ([]view.Identity, error) {
+	span := trace.SpanFromContext(context.Context())
+
 	externalWallets := make(map[string]ExternalWalletSigner)
-	// 1. First collect signatures on the token request
 	span.AddEvent("Request signatures on issues")
 	issueSigmas, err := c.requestSignaturesOnIssues(context, externalWallets)
 	if err != nil {
@@ -116,7 +156,6 @@ func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error
 		return nil, errors.New("failed setting signatures on token request, some signatures are missing")
 	}
 
-	// 2. Audit
 	var auditors []view.Identity
 	if !c.Opts.SkipAuditing {
 		span.AddEvent("Request audit")
@@ -125,53 +164,49 @@ func (c *CollectEndorsementsView) Call(context view.Context) (interface{}, error
 			return nil, errors.WithMessage(err, "failed requesting auditing")
 		}
 	}
-	// 3. Endorse and return the transaction envelope
-	var env *network.Envelope
-	if !c.Opts.SkipApproval {
-		span.AddEvent("Request approval from endorser")
-		env, err = c.requestApproval(context)
-		if err != nil {
-			return nil, errors.WithMessage(err, "failed requesting approval")
-		}
-	}
-	// Distribute Env to all parties
+	return auditors, nil
+}
+
+// distribute distributes the passed envelope to all the parties involved in the transaction and then
+// closes the audit
+func (c *CollectEndorsementsView) distribute(context view.Context, env *network.Envelope, auditors []view.Identity) error {
+	span := trace.SpanFromContext(context.Context())
+
 	distributionList := append(IssueDistributionList(c.tx.TokenRequest), TransferDistributionList(c.tx.TokenRequest)...)
 	span.AddEvent(fmt.Sprintf("Distribute envelope to %d involved parties", len(distributionList)))
 	if err := c.distributeEnvToParties(context, env, distributionList, auditors); err != nil {
 		span.RecordError(err)
-		return nil, errors.WithMessage(err, "failed distributing envelope")
+		return errors.WithMessage(err, "failed distributing envelope")
 	}
 
 	// Cleanup audit
 	span.AddEvent("Cleanup audit")
 	if err := c.cleanupAudit(context); err != nil {
 		span.RecordError(err)
-		return nil, errors.WithMessage(err, "failed cleaning up audit")
-	}
-
-	if logger.IsEnabledFor(zapcore.DebugLevel) {
-		logger.Debugf("CollectEndorsementsView done.")
-	}
-
-	labels := []string{
-		"network", c.tx.Network(),
-		"channel", c.tx.Channel(),
-		"namespace", c.tx.Namespace(),
+		return errors.WithMessage(err, "failed cleaning up audit")
 	}
-	metrics.EndorsedTransactions.With(labels...).Add(1)
-	return nil, nil
+	return nil
 }
 
 func (c *CollectEndorsementsView) requestSignaturesOnIssues(context view.Context, externalWallets map[string]ExternalWalletSigner) (map[string][]byte, error) {
 	if logger.IsEnabledFor(zapcore.DebugLevel) {
 		logger.Debugf("collecting signature on [%d] request issue", len(c.tx.TokenRequest.Metadata.Issues))
 	}
-	return c.requestSignatures(
-		c.tx.TokenRequest.IssueSigners(),
-		c.tx.TokenService().SigService().IssuerVerifier,
-		context,
-		externalWallets,
-	)
+	sigService := c.tx.TokenService().SigService()
+	var sigmas []map[string][]byte
+	for _, issue := range c.tx.TokenRequest.Issues() {
+		issuerSigmas, err := c.requestSignatures([]view.Identity{issue.Issuer}, sigService.IssuerVerifier, context, externalWallets)
+		if err != nil {
+			return nil, err
+		}
+		// the extra signers of an issue are owners, for instance, those of the upgraded tokens
+		extraSigmas, err := c.requestSignatures(issue.ExtraSigners, sigService.OwnerVerifier, context, externalWallets)
+		if err != nil {
+			return nil, err
+		}
+		sigmas = append(sigmas, issuerSigmas, extraSigmas)
+	}
+	return mergeSigmas(sigmas...), nil
 }
 
 func (c *CollectEndorsementsView) requestSignaturesOnTransfers(context view.Context, externalWallets map[string]ExternalWalletSigner) (map[string][]byte, error) {
@@ -218,20 +253,26 @@ func (c *CollectEndorsementsView) requestSignatures(signers []view.Identity, ver
 		logger.Debugf("collecting signature on request from [%s]", signerIdentity)
 
 		// Case: the identity is a multi-sig identity
-		ok, multiSigners, err := multisig.Unwrap(signerIdentity)
+		ok, multiIdentity, err := multisig.UnwrapMultiIdentity(signerIdentity)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed unwrapping multi-sig identity [%s]", signerIdentity)
 		}
 		if ok {
 			span.AddEvent(fmt.Sprintf("%d. Multi-sig signer", i))
+			multiSigners := multiIdentity.Identities
 			logger.Debugf("found multi-sig identity [%s], request multi-sig signature to [%d] parties", signerIdentity, len(multiSigners))
 			// collect the signatures from multiSigners
-			multiSignersSigmas, err := c.requestSignatures(multiSigners, verifierGetter, context, externalWallets)
+			var multiSignersSigmas map[string][]byte
+			if multiIdentity.Threshold == 0 {
+				multiSignersSigmas, err = c.requestSignatures(multiSigners, verifierGetter, context, externalWallets)
+			} else {
+				multiSignersSigmas, err = c.requestThresholdSignatures(multiIdentity, verifierGetter, context, externalWallets)
+			}
 			if err != nil {
 				return nil, errors.WithMessage(err, "failed requesting signatures")
 			}
 			logger.Debugf("collected [%d] signatures for multi-sig identity [%s]", len(multiSignersSigmas), signerIdentity)
-			sigma, err := multisig.JoinSignatures(multiSigners, multiSignersSigmas)
+			sigma, err := multisig.JoinThresholdSignatures(multiSigners, multiIdentity.Threshold, multiSignersSigmas)
 			if err != nil {
 				return nil, errors.WithMessage(err, "failed joining multi-sig signatures")
 			}
@@ -250,6 +291,10 @@ func (c *CollectEndorsementsView) requestSignatures(signers []view.Identity, ver
 			if err != nil {
 				return nil, errors.WithMessagef(err, "failed signing local for party [%s]", signerIdentity)
 			}
+			sigma, err = attachNonRevocationProof(context, c.tx.TokenService(), signerIdentity, signer, signatureRequest.MessageToSign(), sigma)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed signing local for party [%s]", signerIdentity)
+			}
 			sigmas[signerIdentity.UniqueID()] = sigma
 			span.AddEvent("Done local signing")
 			continue
@@ -265,6 +310,10 @@ func (c *CollectEndorsementsView) requestSignatures(signers []view.Identity, ver
 			if logger.IsEnabledFor(zapcore.DebugLevel) {
 				logger.Debugf("found wallet for party [%s], request external signature", signerIdentity)
 			}
+			// the non-revocation proof needs the credential of the party, which is not available here
+			if isNonRevocationProofRequired(c.tx.TokenService(), signerIdentity) {
+				return nil, errors.Errorf("external signers cannot prove the non-revocation of party [%s]", signerIdentity)
+			}
 			ews := c.Opts.ExternalWalletSigner(w.ID())
 			if ews == nil {
 				return nil, errors.Errorf("no external wallet signer found for [%s][%s]", w.ID(), signerIdentity)
@@ -334,6 +383,30 @@ func (c *CollectEndorsementsView) signExternal(party view.Identity, signer Exter
 	return sigma, nil
 }
 
+// requestThresholdSignatures requests a signature to each co-owner of the passed threshold multi-sig identity.
+// A co-owner that fails to sign does not abort the endorsement as long as the threshold can still be met.
+// All the co-owners are asked, so that those that approved the spend do not wait for a signature request that never comes.
+func (c *CollectEndorsementsView) requestThresholdSignatures(multiIdentity *multisig.MultiIdentity, verifierGetter verifierGetterFunc, context view.Context, externalWallets map[string]ExternalWalletSigner) (map[string][]byte, error) {
+	required := multiIdentity.RequiredSignatures()
+	sigmas := make(map[string][]byte, len(multiIdentity.Identities))
+	var failures []error
+	for _, signer := range multiIdentity.Identities {
+		sigma, err := c.requestSignatures([]view.Identity{signer}, verifierGetter, context, externalWallets)
+		if err != nil {
+			logger.Warnf("co-owner [%s] failed to sign [%s]: [%s]", signer, c.tx.ID(), err)
+			failures = append(failures, err)
+			if len(multiIdentity.Identities)-len(failures) < required {
+				return nil, errors.WithMessagef(errors2.Join(failures...), "threshold of [%d] signatures cannot be met", required)
+			}
+			continue
+		}
+		for k, v := range sigma {
+			sigmas[k] = v
+		}
+	}
+	return sigmas, nil
+}
+
 func (c *CollectEndorsementsView) signRemote(context view.Context, party view.Identity, signatureRequest *SignatureRequest, verifierGetter verifierGetterFunc) ([]byte, error) {
 	session, err := context.GetSession(context.Initiator(), party)
 	if err != nil {
@@ -366,7 +439,8 @@ func (c *CollectEndorsementsView) signRemote(context view.Context, party view.Id
 		)
 	}
 
-	err = verifier.Verify(signatureRequest.MessageToSign(), sigma)
+	// the signature might carry the non-revocation proof of the party, the validator checks it
+	err = verifier.Verify(signatureRequest.MessageToSign(), driver.StripNonRevocationProof(sigma))
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed verifying signature [%s] from [%s]", sigma, party)
 	}
@@ -407,32 +481,83 @@ func (c *CollectEndorsementsView) requestApproval(context view.Context) (*networ
 }
 
 func (c *CollectEndorsementsView) requestAudit(context view.Context) ([]view.Identity, error) {
-	auditors := c.tx.TokenService().PublicParametersManager().PublicParameters().Auditors()
+	pp := c.tx.TokenService().PublicParametersManager().PublicParameters()
+	auditors := pp.Auditors()
 	logger.Debugf("# auditors in public parameters [%d]", len(auditors))
-	if len(c.tx.TokenService().PublicParametersManager().PublicParameters().Auditors()) == 0 {
+	if len(auditors) == 0 {
+		return nil, nil
+	}
+
+	parties := c.auditorParties()
+	if len(parties) == 0 {
+		logger.Warnf("no auditor specified, skip auditing, but # auditors in public parameters is [%d]", len(auditors))
 		return nil, nil
 	}
 
-	if !c.tx.Opts.Auditor.IsNone() {
+	// ask the auditors in parallel
+	initiators := make([]*AuditingViewInitiator, len(parties))
+	sessions := make([]view.Session, len(parties))
+	errs := make([]error, len(parties))
+	var wg sync.WaitGroup
+	for i, party := range parties {
 		if logger.IsEnabledFor(zapcore.DebugLevel) {
-			logger.Debugf("ask auditing to [%s]", c.tx.Opts.Auditor)
+			logger.Debugf("ask auditing to [%s]", party)
 		}
-		local := view2.GetSigService(context).IsMe(c.tx.Opts.Auditor)
-		sessionBoxed, err := context.RunView(newAuditingViewInitiator(c.tx, local))
-		if err != nil {
-			return nil, errors.WithMessagef(err, "failed requesting auditing from [%s]", c.tx.Opts.Auditor.String())
+		initiators[i] = newAuditingViewInitiator(c.tx, party, view2.GetSigService(context).IsMe(party))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sessionBoxed, err := context.RunView(initiators[i])
+			if err != nil {
+				errs[i] = errors.WithMessagef(err, "failed requesting auditing from [%s]", parties[i].String())
+				return
+			}
+			sessions[i] = sessionBoxed.(view.Session)
+		}(i)
+	}
+	wg.Wait()
+
+	// collect the signatures
+	var audited []view.Identity
+	sigmas := map[string][]byte{}
+	for i, party := range parties {
+		if errs[i] != nil {
+			logger.Errorf("auditing failed for [%s]: [%s]", party, errs[i])
+			continue
 		}
-		c.sessions[c.tx.Opts.Auditor.String()] = sessionBoxed.(view.Session)
-		return []view.Identity{c.tx.Opts.Auditor}, nil
-	} else {
-		logger.Warnf("no auditor specified, skip auditing, but # auditors in public parameters is [%d]", len(auditors))
+		c.sessions[party.UniqueID()] = sessions[i]
+		audited = append(audited, party)
+		auditor, sigma := initiators[i].Signature()
+		sigmas[auditor.UniqueID()] = sigma
+	}
+	if !c.tx.TokenRequest.SetAuditorSignatures(sigmas) {
+		if err := errors2.Join(errs...); err != nil {
+			return nil, errors.WithMessagef(err, "collected [%d] auditor signatures, [%d] required", len(sigmas), pp.AuditorsThreshold())
+		}
+		return nil, errors.Errorf("collected [%d] auditor signatures, [%d] required", len(sigmas), pp.AuditorsThreshold())
 	}
-	return nil, nil
+	return audited, nil
+}
+
+// auditorParties returns the auditors to ask for auditing, without duplicates
+func (c *CollectEndorsementsView) auditorParties() []view.Identity {
+	var parties []view.Identity
+	for _, party := range append([]view.Identity{c.tx.Opts.Auditor}, c.tx.Opts.Auditors...) {
+		if party.IsNone() || slices.ContainsFunc(parties, party.Equal) {
+			continue
+		}
+		parties = append(parties, party)
+	}
+	return parties
 }
 
 func (c *CollectEndorsementsView) cleanupAudit(context view.Context) error {
-	if !c.tx.Opts.Auditor.IsNone() {
-		session, err := c.getSession(context, c.tx.Opts.Auditor)
+	for _, party := range c.auditorParties() {
+		if _, ok := c.sessions[party.UniqueID()]; !ok {
+			// auditing failed for this party, no session to close
+			continue
+		}
+		session, err := c.getSession(context, party)
 		if err != nil {
 			return errors.Wrap(err, "failed getting auditor's session")
 		}
@@ -775,11 +900,10 @@ func (f *ReceiveTransactionView) unmarshalAsSignatureRequest(context view.Contex
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to receive transaction")
 	}
-	k, err := kvs.CreateCompositeKey("signatureRequest", []string{tx.ID()})
+	k, err := signatureRequestKey(tx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate key to store signature request")
+		return nil, err
 	}
-	k = base64.StdEncoding.EncodeToString([]byte(k))
 	if kvss, err := context.GetService(&kvs.KVS{}); err != nil {
 		return nil, errors.Wrap(err, "failed to get KVS from context")
 	} else if err := kvss.(*kvs.KVS).Put(k, base64.StdEncoding.EncodeToString(raw)); err != nil {
@@ -811,24 +935,34 @@ func NewEndorseView(tx *Transaction) *EndorseView {
 // to be processed at time of committing.
 // 4. It sends back an ack.
 func (s *EndorseView) Call(context view.Context) (interface{}, error) {
+	if err := s.sign(context); err != nil {
+		return nil, err
+	}
+	if err := s.accept(context); err != nil {
+		return nil, err
+	}
+	return s.tx, nil
+}
+
+// sign processes the signature requests on the transaction
+func (s *EndorseView) sign(context view.Context) error {
 	// Process signature requests
 	logger.Debugf("check expected number of requests to sign for txid [%s]", s.tx.ID())
 	requestsToBeSigned, err := requestsToBeSigned(s.tx.Request())
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed collecting requests of signature")
+		return errors.Wrapf(err, "failed collecting requests of signature")
 	}
 
 	logger.Debugf("expect [%d] requests to sign for txid [%s]", len(requestsToBeSigned), s.tx.ID())
 
 	session := context.Session()
-	k, err := kvs.CreateCompositeKey("signatureRequest", []string{s.tx.ID()})
+	k, err := signatureRequestKey(s.tx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate key to store signature request")
+		return err
 	}
-	k = base64.StdEncoding.EncodeToString([]byte(k))
 	kvss, err := context.GetService(&kvs.KVS{})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get KVS from context")
+		return errors.Wrap(err, "failed to get KVS from context")
 	}
 	storage := kvss.(*kvs.KVS)
 	for i := range requestsToBeSigned {
@@ -837,7 +971,7 @@ func (s *EndorseView) Call(context view.Context) (interface{}, error) {
 
 		if i == 0 && storage.Exists(k) {
 			if err := kvss.(*kvs.KVS).Get(k, &srRaw); err != nil {
-				return nil, errors.Wrap(err, "failed to to store signature request")
+				return errors.Wrap(err, "failed to to store signature request")
 			}
 		} else {
 			if logger.IsEnabledFor(zapcore.DebugLevel) {
@@ -845,45 +979,66 @@ func (s *EndorseView) Call(context view.Context) (interface{}, error) {
 			}
 			srRaw, err = ReadMessage(session, time.Minute)
 			if err != nil {
-				return nil, errors.Wrap(err, "failed reading signature request")
+				return errors.Wrap(err, "failed reading signature request")
 			}
 		}
 		// TODO: check what is signed...
 		err = Unmarshal(srRaw, signatureRequest)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed unmarshalling signature request")
+			return errors.Wrap(err, "failed unmarshalling signature request")
+		}
+		// the token request of a multi-namespace transaction must be signed together with its binding
+		if len(s.tx.TokenRequest.Binding()) != 0 {
+			expected, err := s.tx.TokenRequest.MarshalToSign()
+			if err != nil {
+				return errors.Wrap(err, "failed marshalling token request to sign")
+			}
+			if !bytes.Equal(expected, signatureRequest.MessageToSign()) {
+				return errors.Errorf("signature request does not cover the binding of transaction [%s]", s.tx.ID())
+			}
 		}
 
 		sigService := s.tx.TokenService().SigService()
 		if !sigService.IsMe(signatureRequest.Signer) {
-			return nil, errors.Errorf("identity [%s] is not me", signatureRequest.Signer.UniqueID())
+			return errors.Errorf("identity [%s] is not me", signatureRequest.Signer.UniqueID())
 		}
 		signer, err := sigService.GetSigner(signatureRequest.Signer)
 		if err != nil {
-			return nil, errors.Wrapf(err, "cannot find signer for [%s]", signatureRequest.Signer.UniqueID())
+			return errors.Wrapf(err, "cannot find signer for [%s]", signatureRequest.Signer.UniqueID())
 		}
 		sigma, err := signer.Sign(signatureRequest.MessageToSign())
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed signing request")
+			return errors.Wrapf(err, "failed signing request")
+		}
+		sigma, err = attachNonRevocationProof(context, s.tx.TokenService(), signatureRequest.Signer, signer, signatureRequest.MessageToSign(), sigma)
+		if err != nil {
+			return errors.Wrapf(err, "failed signing request")
 		}
 		if logger.IsEnabledFor(zapcore.DebugLevel) {
 			logger.Debugf("Send back signature [%s][%s]", signatureRequest.Signer, hash.Hashable(sigma))
 		}
 		err = session.SendWithContext(context.Context(), sigma)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed sending signature back")
+			return errors.Wrapf(err, "failed sending signature back")
 		}
 	}
 
+	return nil
+}
+
+// accept receives the transaction with the envelope, stores it, and sends back an acknowledgement
+func (s *EndorseView) accept(context view.Context) error {
+	session := context.Session()
+
 	// Receive transaction with envelope
 	receivedTx, err := s.receiveTransaction(context)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed receiving transaction")
+		return errors.Wrapf(err, "failed receiving transaction")
 	}
 
 	// Store transaction in the token transaction database
 	if err := StoreTransactionRecords(context, s.tx); err != nil {
-		return nil, errors.Wrapf(err, "failed storing transaction records %s", s.tx.ID())
+		return errors.Wrapf(err, "failed storing transaction records %s", s.tx.ID())
 	}
 
 	// Send back an acknowledgement
@@ -892,29 +1047,29 @@ func (s *EndorseView) Call(context view.Context) (interface{}, error) {
 	}
 	signer, err := view2.GetSigService(context).GetSigner(view2.GetIdentityProvider(context).DefaultIdentity())
 	if err != nil {
-		return nil, errors.WithMessagef(err, "failed to get signer for default identity")
+		return errors.WithMessagef(err, "failed to get signer for default identity")
 	}
 	sigma, err := signer.Sign(receivedTx.FromRaw)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to sign ack response")
+		return errors.WithMessage(err, "failed to sign ack response")
 	}
 	if logger.IsEnabledFor(zapcore.DebugLevel) {
 		logger.Debugf("ack response: [%s] from [%s]", hash.Hashable(sigma), view2.GetIdentityProvider(context).DefaultIdentity())
 	}
 	if err := session.SendWithContext(context.Context(), sigma); err != nil {
-		return nil, errors.WithMessage(err, "failed sending ack")
+		return errors.WithMessage(err, "failed sending ack")
 	}
 
 	// cache the token request into the tokens db
 	t, err := tokens.GetService(context, s.tx.TMSID())
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get tokens db for [%s]", s.tx.TMSID())
+		return errors.Wrapf(err, "failed to get tokens db for [%s]", s.tx.TMSID())
 	}
 	if err := t.CacheRequest(s.tx.TMSID(), s.tx.TokenRequest); err != nil {
 		logger.Warnf("failed to cache token request [%s], this might cause delay, investigate when possible: [%s]", s.tx.TokenRequest.Anchor, err)
 	}
 
-	return s.tx, nil
+	return nil
 }
 
 func (s *EndorseView) receiveTransaction(context view.Context) (*Transaction, error) {
@@ -939,6 +1094,16 @@ func (s *EndorseView) receiveTransaction(context view.Context) (*Transaction, er
 	return tx, nil
 }
 
+// signatureRequestKey returns the key under which the signature request received for the passed transaction is stored.
+// The key includes the namespace because the transactions of a MultiNamespaceTransaction share the same id.
+func signatureRequestKey(tx *Transaction) (string, error) {
+	k, err := kvs.CreateCompositeKey("signatureRequest", []string{tx.ID(), tx.Namespace()})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate key to store signature request")
+	}
+	return base64.StdEncoding.EncodeToString([]byte(k)), nil
+}
+
 func requestsToBeSigned(request *token.Request) ([]any, error) {
 	var res []any
 	transfers := request.Transfers()
@@ -0,0 +1,398 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MultiNamespaceTransaction is a token transaction that spans several namespaces of the same network and channel.
+// It holds a Transaction per namespace, each with its own token request, all bound to the same network transaction id.
+// Once endorsed, the token requests of all the namespaces are committed in a single network transaction,
+// therefore either all of them are committed or none.
+// This makes it possible, for instance, to implement delivery-versus-payment between two token namespaces.
+type MultiNamespaceTransaction struct {
+	Transactions []*Transaction
+}
+
+type multiNamespacePayload struct {
+	Transactions [][]byte
+}
+
+// NewMultiNamespaceTransaction returns a new multi-namespace transaction with a Transaction for each of the passed TMS ids.
+// The TMS ids must refer to distinct namespaces of the same network and channel.
+// The passed options are applied to each transaction, the TMS id excluded.
+func NewMultiNamespaceTransaction(context view.Context, signer view.Identity, tmsIDs []token.TMSID, opts ...TxOption) (*MultiNamespaceTransaction, error) {
+	if len(tmsIDs) == 0 {
+		return nil, errors.New("no namespace specified")
+	}
+	tx := &MultiNamespaceTransaction{}
+	for i, tmsID := range tmsIDs {
+		legOpts := append(append([]TxOption{}, opts...), WithTMSID(tmsID))
+		if i > 0 {
+			// all transactions share the network transaction id of the first one
+			legOpts = append(legOpts, WithNetworkTxID(tx.Transactions[0].NetworkTxID()))
+		}
+		leg, err := NewTransaction(context, signer, legOpts...)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed creating transaction for [%s]", tmsID)
+		}
+		tx.Transactions = append(tx.Transactions, leg)
+	}
+	if err := tx.checkConsistency(); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// NewMultiNamespaceTransactionFromBytes returns a new multi-namespace transaction from the passed bytes
+func NewMultiNamespaceTransactionFromBytes(context view.Context, raw []byte) (*MultiNamespaceTransaction, error) {
+	payload := &multiNamespacePayload{}
+	if err := Unmarshal(raw, payload); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshalling multi-namespace transaction")
+	}
+	if len(payload.Transactions) == 0 {
+		return nil, errors.New("invalid multi-namespace transaction, no transaction found")
+	}
+	tx := &MultiNamespaceTransaction{}
+	for _, legRaw := range payload.Transactions {
+		leg, err := NewTransactionFromBytes(context, legRaw)
+		if err != nil {
+			return nil, err
+		}
+		tx.Transactions = append(tx.Transactions, leg)
+	}
+	if err := tx.checkConsistency(); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// ReceiveMultiNamespaceTransaction receives a multi-namespace transaction from the session of the passed context
+// and checks its validity
+func ReceiveMultiNamespaceTransaction(context view.Context) (*MultiNamespaceTransaction, error) {
+	raw, err := ReadMessage(context.Session(), time.Minute*4)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to receive multi-namespace transaction")
+	}
+	tx, err := NewMultiNamespaceTransactionFromBytes(context, raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.IsValid(); err != nil {
+		return nil, errors.WithMessagef(err, "invalid multi-namespace transaction [%s]", tx.ID())
+	}
+	return tx, nil
+}
+
+// ID returns the transaction id shared by all the namespaces
+func (t *MultiNamespaceTransaction) ID() string {
+	return t.Transactions[0].ID()
+}
+
+// Network returns the network of the transaction
+func (t *MultiNamespaceTransaction) Network() string {
+	return t.Transactions[0].Network()
+}
+
+// Channel returns the channel of the transaction
+func (t *MultiNamespaceTransaction) Channel() string {
+	return t.Transactions[0].Channel()
+}
+
+// Namespaces returns the namespaces spanned by the transaction
+func (t *MultiNamespaceTransaction) Namespaces() []string {
+	namespaces := make([]string, len(t.Transactions))
+	for i, leg := range t.Transactions {
+		namespaces[i] = leg.Namespace()
+	}
+	return namespaces
+}
+
+// Transaction returns the transaction bound to the passed namespace, nil if not found
+func (t *MultiNamespaceTransaction) Transaction(namespace string) *Transaction {
+	for _, leg := range t.Transactions {
+		if leg.Namespace() == namespace {
+			return leg
+		}
+	}
+	return nil
+}
+
+// Bytes returns the serialized version of the transaction
+func (t *MultiNamespaceTransaction) Bytes() ([]byte, error) {
+	payload := &multiNamespacePayload{Transactions: make([][]byte, len(t.Transactions))}
+	for i, leg := range t.Transactions {
+		raw, err := leg.Bytes()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed marshalling transaction for namespace [%s]", leg.Namespace())
+		}
+		payload.Transactions[i] = raw
+	}
+	return Marshal(payload)
+}
+
+// IsValid checks that the token request of each namespace is valid and bound to the token requests of the other namespaces
+func (t *MultiNamespaceTransaction) IsValid() error {
+	if err := t.checkBinding(); err != nil {
+		return err
+	}
+	for _, leg := range t.Transactions {
+		if err := leg.IsValid(); err != nil {
+			return errors.WithMessagef(err, "invalid token request for namespace [%s]", leg.Namespace())
+		}
+	}
+	return nil
+}
+
+// Release releases the resources locked by the transaction of each namespace
+func (t *MultiNamespaceTransaction) Release() {
+	for _, leg := range t.Transactions {
+		leg.Release()
+	}
+}
+
+// Bind binds the token request of each namespace to the token requests of all the namespaces.
+// From now on, the signatures on each token request cover the token requests of all the namespaces,
+// therefore no token request can be committed without the others.
+// The token requests must not be modified afterwards.
+func (t *MultiNamespaceTransaction) Bind() error {
+	binding, err := t.binding()
+	if err != nil {
+		return err
+	}
+	for _, leg := range t.Transactions {
+		leg.TokenRequest.Bind(binding)
+	}
+	return nil
+}
+
+func (t *MultiNamespaceTransaction) binding() ([]byte, error) {
+	requests := make([]*token.NamespacedRequest, len(t.Transactions))
+	for i, leg := range t.Transactions {
+		requests[i] = &token.NamespacedRequest{Namespace: leg.Namespace(), Request: leg.TokenRequest.Actions}
+	}
+	binding, err := token.Binding(requests)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed computing the binding of transaction [%s]", t.ID())
+	}
+	return binding, nil
+}
+
+// checkBinding checks that the token request of each namespace is bound to the token requests of all the namespaces
+func (t *MultiNamespaceTransaction) checkBinding() error {
+	if len(t.Transactions) < 2 {
+		return nil
+	}
+	binding, err := t.binding()
+	if err != nil {
+		return err
+	}
+	for _, leg := range t.Transactions {
+		if !bytes.Equal(leg.TokenRequest.Binding(), binding) {
+			return errors.Errorf("token request for namespace [%s] is not bound to the token requests of all the namespaces", leg.Namespace())
+		}
+	}
+	return nil
+}
+
+func (t *MultiNamespaceTransaction) checkConsistency() error {
+	first := t.Transactions[0]
+	namespaces := map[string]bool{}
+	for _, leg := range t.Transactions {
+		if leg.Network() != first.Network() || leg.Channel() != first.Channel() {
+			return errors.Errorf("namespace [%s] does not belong to network [%s] and channel [%s]", leg.Namespace(), first.Network(), first.Channel())
+		}
+		if leg.ID() != first.ID() {
+			return errors.Errorf("transaction ids do not match [%s][%s]", leg.ID(), first.ID())
+		}
+		if namespaces[leg.Namespace()] {
+			return errors.Errorf("namespace [%s] specified more than once", leg.Namespace())
+		}
+		namespaces[leg.Namespace()] = true
+	}
+	return nil
+}
+
+type CollectMultiNamespaceEndorsementsView struct {
+	tx   *MultiNamespaceTransaction
+	opts []EndorsementsOpt
+}
+
+// NewCollectMultiNamespaceEndorsementsView returns an instance of the CollectMultiNamespaceEndorsementsView struct.
+// This view does the following:
+// 1. It binds the token requests of all the namespaces together, see MultiNamespaceTransaction.Bind.
+// Then, for each namespace, it collects the signatures and the audit of the token request, as CollectEndorsementsView does.
+// 2. It requests the approval of all the token requests in a single network transaction.
+// 3. For each namespace, it distributes the approved transaction to the involved parties.
+// Therefore, a party involved in more than one namespace receives, in the order of the namespaces, first the signature requests
+// of each namespace, and then the approved transaction of each namespace. See EndorseMultiNamespaceView.
+func NewCollectMultiNamespaceEndorsementsView(tx *MultiNamespaceTransaction, opts ...EndorsementsOpt) *CollectMultiNamespaceEndorsementsView {
+	return &CollectMultiNamespaceEndorsementsView{tx: tx, opts: opts}
+}
+
+func (c *CollectMultiNamespaceEndorsementsView) Call(context view.Context) (interface{}, error) {
+	span := trace.SpanFromContext(context.Context())
+	metrics := GetMetrics(context)
+
+	// the signatures of each namespace must cover the token requests of all the namespaces
+	if len(c.tx.Transactions) > 1 {
+		if err := c.tx.Bind(); err != nil {
+			return nil, err
+		}
+	}
+
+	views := make([]*CollectEndorsementsView, len(c.tx.Transactions))
+	auditors := make([][]view.Identity, len(c.tx.Transactions))
+	for i, leg := range c.tx.Transactions {
+		views[i] = NewCollectEndorsementsView(leg, c.opts...)
+		var err error
+		auditors[i], err = views[i].collectSignaturesAndAudit(context)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed collecting endorsements for namespace [%s]", leg.Namespace())
+		}
+	}
+
+	var env *network.Envelope
+	if !views[0].Opts.SkipApproval {
+		span.AddEvent("Request approval from endorser")
+		var err error
+		env, err = c.requestApproval(context)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed requesting approval")
+		}
+	}
+
+	for i, leg := range c.tx.Transactions {
+		if err := views[i].distribute(context, env, auditors[i]); err != nil {
+			return nil, errors.WithMessagef(err, "failed distributing transaction for namespace [%s]", leg.Namespace())
+		}
+		labels := []string{
+			"network", leg.Network(),
+			"channel", leg.Channel(),
+			"namespace", leg.Namespace(),
+		}
+		metrics.EndorsedTransactions.With(labels...).Add(1)
+	}
+	return nil, nil
+}
+
+func (c *CollectMultiNamespaceEndorsementsView) requestApproval(context view.Context) (*network.Envelope, error) {
+	requests := make([]*network.ApprovalRequest, len(c.tx.Transactions))
+	for i, leg := range c.tx.Transactions {
+		requestRaw, err := leg.TokenRequest.RequestToBytes()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed marshalling request for namespace [%s]", leg.Namespace())
+		}
+		requests[i] = &network.ApprovalRequest{
+			TMS:        leg.TokenRequest.TokenService,
+			RequestRaw: requestRaw,
+		}
+	}
+	first := c.tx.Transactions[0]
+	env, err := network.GetInstance(context, first.Network(), first.Channel()).RequestApprovals(
+		context,
+		requests,
+		first.Signer,
+		first.Payload.TxID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, leg := range c.tx.Transactions {
+		leg.Envelope = env
+	}
+	return env, nil
+}
+
+type EndorseMultiNamespaceView struct {
+	txs []*Transaction
+}
+
+// NewEndorseMultiNamespaceView returns an instance of the EndorseMultiNamespaceView.
+// It is the counterpart of CollectMultiNamespaceEndorsementsView for a party involved in the passed transactions,
+// that must be listed in the same order of the namespaces of the multi-namespace transaction.
+// The view does the following:
+// 1. It checks that the transactions are bound to the same token requests,
+// and it processes the signature requests of each transaction, that must cover the binding.
+// 2. It receives, stores, and acknowledges the approved version of each transaction.
+func NewEndorseMultiNamespaceView(txs ...*Transaction) *EndorseMultiNamespaceView {
+	return &EndorseMultiNamespaceView{txs: txs}
+}
+
+func (e *EndorseMultiNamespaceView) Call(context view.Context) (interface{}, error) {
+	if len(e.txs) == 0 {
+		return nil, errors.New("no transaction to endorse")
+	}
+	binding := e.txs[0].TokenRequest.Binding()
+	if len(binding) == 0 {
+		return nil, errors.Errorf("transaction [%s] is not bound to the token requests of the other namespaces", e.txs[0].ID())
+	}
+	for _, tx := range e.txs {
+		if tx.ID() != e.txs[0].ID() || !bytes.Equal(tx.TokenRequest.Binding(), binding) {
+			return nil, errors.Errorf("transaction for namespace [%s] is not bound to the same token requests", tx.Namespace())
+		}
+	}
+	views := make([]*EndorseView, len(e.txs))
+	for i, tx := range e.txs {
+		views[i] = NewEndorseView(tx)
+		if err := views[i].sign(context); err != nil {
+			return nil, errors.WithMessagef(err, "failed signing transaction for namespace [%s]", tx.Namespace())
+		}
+	}
+	txs := make([]*Transaction, len(e.txs))
+	for i, v := range views {
+		if err := v.accept(context); err != nil {
+			return nil, errors.WithMessagef(err, "failed accepting transaction for namespace [%s]", e.txs[i].Namespace())
+		}
+		txs[i] = v.tx
+	}
+	return txs, nil
+}
+
+type multiNamespaceOrderingAndFinalityView struct {
+	tx      *MultiNamespaceTransaction
+	timeout time.Duration
+}
+
+// NewMultiNamespaceOrderingAndFinalityView returns a new instance of the multiNamespaceOrderingAndFinalityView struct.
+// The view does the following:
+// 1. It broadcasts the network transaction shared by all the namespaces to the proper backend.
+// 2. It waits for finality of the transaction in each namespace.
+func NewMultiNamespaceOrderingAndFinalityView(tx *MultiNamespaceTransaction) *multiNamespaceOrderingAndFinalityView {
+	return &multiNamespaceOrderingAndFinalityView{tx: tx, timeout: finalityTimeout}
+}
+
+func (o *multiNamespaceOrderingAndFinalityView) Call(ctx view.Context) (interface{}, error) {
+	// the envelope is the same for all the namespaces, broadcast it once
+	if err := (&orderingView{}).broadcast(ctx, o.tx.Transactions[0]); err != nil {
+		return nil, err
+	}
+	for _, leg := range o.tx.Transactions {
+		t, err := tokens.GetService(ctx, leg.TMSID())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get tokens db for [%s]", leg.TMSID())
+		}
+		if err := t.CacheRequest(leg.TMSID(), leg.TokenRequest); err != nil {
+			logger.Warnf("failed to cache token request [%s], this might cause delay, investigate when possible: [%s]", leg.TokenRequest.Anchor, err)
+		}
+	}
+	for _, leg := range o.tx.Transactions {
+		if _, err := ctx.RunView(NewFinalityView(leg, WithTimeout(o.timeout))); err != nil {
+			return nil, errors.WithMessagef(err, "failed waiting for finality of namespace [%s]", leg.Namespace())
+		}
+	}
+	return nil, nil
+}
@@ -97,10 +97,12 @@ type answer struct {
 	party    view.Identity
 }
 
-// RequestSpendView sends a SpendRequest to all parties and waits for their responses
+// RequestSpendView sends a SpendRequest to all parties and waits for their responses.
+// It completes once enough parties approve the spend to meet the threshold of the multisig identity owning the token.
 type RequestSpendView struct {
 	unspentToken *token.UnspentToken
 	parties      []view.Identity
+	threshold    int
 	options      *token2.ServiceOptions
 
 	err     error
@@ -117,7 +119,7 @@ func NewRequestSpendView(unspentToken *token.UnspentToken, opts ...token2.Servic
 		return &RequestSpendView{err: errors.Wrap(err, "failed to compile service options")}
 	}
 
-	ok, identities, err := multisig.Unwrap(unspentToken.Owner)
+	ok, multiIdentity, err := multisig.UnwrapMultiIdentity(unspentToken.Owner)
 	if err != nil {
 		return &RequestSpendView{err: errors.Wrap(err, "failed to unwrap identities")}
 	}
@@ -127,7 +129,8 @@ func NewRequestSpendView(unspentToken *token.UnspentToken, opts ...token2.Servic
 
 	return &RequestSpendView{
 		unspentToken: unspentToken,
-		parties:      identities,
+		parties:      multiIdentity.Identities,
+		threshold:    multiIdentity.RequiredSignatures(),
 		options:      serviceOptions,
 	}
 }
@@ -154,28 +157,53 @@ func (c *RequestSpendView) Call(context view.Context) (interface{}, error) {
 		return nil, errors.Errorf("failed getting TMS for [%s]", c.options.TMSID())
 	}
 	areMe := tms.SigService().AreMe(c.parties...)
+	// the parties that are me approve the spend
+	approvals := 0
 	for _, party := range c.parties {
 		logger.Debugf("notify party [%s] about request...", party)
 		if slices.Contains(areMe, party.UniqueID()) {
 			// it is me, skip
 			logger.Debugf("notify party [%s] about request, it is me, skipping...", party)
+			approvals++
 			continue
 		}
 		go c.collectSpendRequestAnswers(context, party, requestRaw, answerChannel)
 		counter++
 	}
 
-	for i := 0; i < counter; i++ {
+	var timeout <-chan time.Time
+	if c.timeout > 0 {
+		timer := time.NewTimer(c.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	failures := 0
+	for i := 0; i < counter && approvals < c.threshold; i++ {
 		span.AddEvent("wait_for_answer")
-		// TODO: put a timeout
-		a := <-answerChannel
+		var a *answer
+		select {
+		case a = <-answerChannel:
+		case <-timeout:
+			return nil, errors.Errorf("timeout waiting for approvals, got [%d] out of [%d]", approvals, c.threshold)
+		}
 		span.AddEvent("received_answer")
-		if a.err != nil {
-			return nil, errors.Wrapf(a.err, "got failure [%s] from [%s]", a.party.String(), a.err)
+		err := a.err
+		if err == nil {
+			err = a.response.Err
 		}
-		if a.response.Err != nil {
-			return nil, errors.Wrapf(a.response.Err, "got failure [%s] from [%s]", a.party.String(), a.response.Err)
+		if err == nil {
+			approvals++
+			continue
 		}
+		// a party that does not approve is tolerated as long as the threshold can still be met
+		failures++
+		if len(c.parties)-failures < c.threshold {
+			return nil, errors.Wrapf(err, "got failure [%s] from [%s]", a.party.String(), err)
+		}
+		logger.Warnf("party [%s] did not approve the spend: [%s]", a.party, err)
+	}
+	if approvals < c.threshold {
+		return nil, errors.Errorf("not enough approvals, got [%d] out of [%d]", approvals, c.threshold)
 	}
 	return nil, nil
 }
@@ -0,0 +1,412 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	view2 "github.com/hyperledger-labs/fabric-smart-client/platform/view"
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/idemix"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/utils/json/session"
+	"github.com/pkg/errors"
+)
+
+// RevocationAuthorityKey is the key, in the configuration of a TMS, of the identity of the FSC node
+// of the revocation authority, the node the owners get the credential revocation information (CRI) from
+const RevocationAuthorityKey = "revocation.authority"
+
+// CRIRequest is the request sent to the revocation authority to get the CRI of the current epoch.
+// The owner authenticates with a fresh identity of its wallet, never used in a transaction,
+// so that the revocation authority cannot link the identities the owner spends its tokens with.
+type CRIRequest struct {
+	TMSID     token.TMSID
+	Owner     token.Identity
+	AuditInfo []byte
+}
+
+// CRI is the credential revocation information of an epoch, signed by the revocation authority
+type CRI struct {
+	Epoch uint64
+	Raw   []byte
+}
+
+// CRISigner signs the CRI of the epochs, see idemix.CRISigner
+type CRISigner interface {
+	SignCRI(epoch uint64) ([]byte, error)
+}
+
+// IsOwnerRevocationEnabled returns true if the public parameters of the passed TMS require
+// the signatures of the idemix owners to carry a non-revocation proof
+func IsOwnerRevocationEnabled(tms *token.ManagementService) bool {
+	return driver.IsOwnerRevocationEnabled(tms.PublicParametersManager().PublicParameters().PublicParameters)
+}
+
+// CurrentRevocationEpoch returns the current epoch of the revocation authority of the passed TMS,
+// as determined by the height of the ledger
+func CurrentRevocationEpoch(sp token.ServiceProvider, tms *token.ManagementService) (uint64, error) {
+	pp, ok := tms.PublicParametersManager().PublicParameters().PublicParameters.(driver.RevocationAuthorityPublicParameters)
+	if !ok {
+		return 0, errors.Errorf("owner revocation not supported by [%s]", tms.ID())
+	}
+	ledger, err := network.GetInstance(sp, tms.Network(), tms.Channel()).Ledger()
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed to get ledger for [%s]", tms.ID())
+	}
+	height, err := ledger.Height()
+	if err != nil {
+		return 0, errors.WithMessagef(err, "failed to get ledger height for [%s]", tms.ID())
+	}
+	return driver.RevocationEpoch(height, pp.RevocationEpochLength()), nil
+}
+
+// RevocationAuthority returns the identity of the FSC node of the revocation authority of the passed TMS, see RevocationAuthorityKey
+func RevocationAuthority(sp token.ServiceProvider, tms *token.ManagementService) (view.Identity, error) {
+	var label string
+	if err := tms.Configuration().UnmarshalKey(RevocationAuthorityKey, &label); err != nil {
+		return nil, errors.Wrapf(err, "invalid config for key [%s]", RevocationAuthorityKey)
+	}
+	if len(label) == 0 {
+		return nil, errors.Errorf("no revocation authority configured for [%s]", tms.ID())
+	}
+	authority, err := view2.GetEndpointService(sp).GetIdentity(label, nil)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to resolve revocation authority [%s]", label)
+	}
+	return authority, nil
+}
+
+// RequestCRIView is the view used by an owner to get from the revocation authority
+// the CRI of the current epoch for the credential of one of its wallets
+type RequestCRIView struct {
+	TMSID  token.TMSID
+	Wallet string
+}
+
+func NewRequestCRIView(tmsID token.TMSID, wallet string) *RequestCRIView {
+	return &RequestCRIView{TMSID: tmsID, Wallet: wallet}
+}
+
+// RequestCRI runs RequestCRIView with the passed arguments
+func RequestCRI(context view.Context, tmsID token.TMSID, wallet string) (*CRI, error) {
+	criBoxed, err := context.RunView(NewRequestCRIView(tmsID, wallet))
+	if err != nil {
+		return nil, err
+	}
+	return criBoxed.(*CRI), nil
+}
+
+func (r *RequestCRIView) Call(context view.Context) (interface{}, error) {
+	tms := token.GetManagementService(context, token.WithTMSID(r.TMSID))
+	if tms == nil {
+		return nil, errors.Errorf("tms not found for [%s]", r.TMSID)
+	}
+	w := tms.WalletManager().OwnerWallet(r.Wallet)
+	if w == nil {
+		return nil, errors.Errorf("owner wallet [%s] not found", r.Wallet)
+	}
+	authority, err := RevocationAuthority(context, tms)
+	if err != nil {
+		return nil, err
+	}
+	// authenticate with a fresh identity, the revocation authority learns nothing about the identities used on the ledger
+	recipientData, err := w.GetRecipientData()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get recipient data from wallet [%s]", r.Wallet)
+	}
+
+	session, err := session.NewJSON(context, r, authority)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get session to the revocation authority [%s]", authority)
+	}
+	err = session.SendWithContext(context.Context(), &CRIRequest{
+		TMSID:     tms.ID(),
+		Owner:     recipientData.Identity,
+		AuditInfo: recipientData.AuditInfo,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to send CRI request")
+	}
+	cri := &CRI{}
+	if err := session.ReceiveWithTimeout(cri, time.Minute); err != nil {
+		return nil, errors.Wrap(err, "failed to receive CRI")
+	}
+
+	// check the epoch, the signature of the revocation authority is checked by the validators
+	epoch, err := CurrentRevocationEpoch(context, tms)
+	if err != nil {
+		return nil, err
+	}
+	if !driver.IsRevocationEpochValidIn(cri.Epoch, epoch) {
+		return nil, errors.Errorf("expired CRI, epoch [%d], current epoch [%d]", cri.Epoch, epoch)
+	}
+	return cri, nil
+}
+
+// RevokedHandles tells the revocation authority which revocation handles have been revoked
+type RevokedHandles interface {
+	// IsRevoked returns true if the passed revocation handle has been revoked
+	IsRevoked(rh string) (bool, error)
+}
+
+// RevokedHandleSet is an in-memory RevokedHandles
+type RevokedHandleSet struct {
+	lock    sync.RWMutex
+	handles map[string]struct{}
+}
+
+func NewRevokedHandleSet(rhs ...string) *RevokedHandleSet {
+	s := &RevokedHandleSet{handles: map[string]struct{}{}}
+	s.Revoke(rhs...)
+	return s
+}
+
+// Revoke revokes the passed revocation handles, the owners bound to them get no CRI from then on.
+// The CRI is the same for all the owners, therefore, to reject the transactions of these owners,
+// their revocation handles must also be added to the revocation list stored on the ledger,
+// see driver.RevocationList.OwnerRevocationHandles.
+func (s *RevokedHandleSet) Revoke(rhs ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, rh := range rhs {
+		s.handles[rh] = struct{}{}
+	}
+}
+
+// Reinstate removes the passed revocation handles from the set
+func (s *RevokedHandleSet) Reinstate(rhs ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, rh := range rhs {
+		delete(s.handles, rh)
+	}
+}
+
+func (s *RevokedHandleSet) IsRevoked(rh string) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.handles[rh]
+	return ok, nil
+}
+
+// CRIResponderView is the view used by the revocation authority to hand out the CRI of the current epoch
+// to the owners whose revocation handle has not been revoked.
+// The idemix library supports only the algorithm without revocation, whose CRI is the same for all the credentials,
+// therefore a revoked owner can still get the CRI from another owner. The validators reject the revoked owners
+// by checking the revocation handle disclosed by the non-revocation proof against the revocation list stored on the ledger.
+type CRIResponderView struct {
+	Revoked RevokedHandles
+	Signer  CRISigner
+}
+
+func NewCRIResponderView(revoked RevokedHandles, signer CRISigner) *CRIResponderView {
+	return &CRIResponderView{Revoked: revoked, Signer: signer}
+}
+
+func (r *CRIResponderView) Call(context view.Context) (interface{}, error) {
+	session := session.JSON(context)
+	request := &CRIRequest{}
+	if err := session.ReceiveWithTimeout(request, time.Minute); err != nil {
+		return nil, errors.Wrap(err, "failed to receive CRI request")
+	}
+	tms := token.GetManagementService(context, token.WithTMSID(request.TMSID))
+	if tms == nil {
+		return nil, errors.Errorf("tms not found for [%s]", request.TMSID)
+	}
+	if !IsOwnerRevocationEnabled(tms) {
+		return nil, errors.Errorf("owner revocation not enabled for [%s]", request.TMSID)
+	}
+
+	// match the owner identity to its audit info and extract the revocation handle
+	if err := tms.WalletManager().RegisterRecipientIdentity(&token.RecipientData{
+		Identity:  request.Owner,
+		AuditInfo: request.AuditInfo,
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to register owner identity [%s]", request.Owner)
+	}
+	rh, err := tms.WalletManager().GetRevocationHandle(request.Owner)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get revocation handle of [%s]", request.Owner)
+	}
+	revoked, err := r.Revoked.IsRevoked(rh)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check revocation handle of [%s]", request.Owner)
+	}
+	if revoked {
+		return nil, errors.Errorf("identity [%s] has been revoked", request.Owner)
+	}
+
+	epoch, err := CurrentRevocationEpoch(context, tms)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := r.Signer.SignCRI(epoch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign CRI")
+	}
+	cri := &CRI{Epoch: epoch, Raw: raw}
+	if err := session.SendWithContext(context.Context(), cri); err != nil {
+		return nil, errors.Wrap(err, "failed to send CRI")
+	}
+	return cri, nil
+}
+
+// cris caches the CRIs of the owner wallets
+var cris = newCRICache()
+
+type criCache struct {
+	lock       sync.Mutex
+	cris       map[string]*CRI
+	refreshing map[string]struct{}
+}
+
+func newCRICache() *criCache {
+	return &criCache{
+		cris:       map[string]*CRI{},
+		refreshing: map[string]struct{}{},
+	}
+}
+
+func (c *criCache) get(key string) (*CRI, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cri, ok := c.cris[key]
+	return cri, ok
+}
+
+func (c *criCache) put(key string, cri *CRI) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cris[key] = cri
+}
+
+// startRefresh returns true if no refresh of the passed key is in progress, and marks one as started
+func (c *criCache) startRefresh(key string) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.refreshing[key]; ok {
+		return false
+	}
+	c.refreshing[key] = struct{}{}
+	return true
+}
+
+func (c *criCache) endRefresh(key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.refreshing, key)
+}
+
+// currentCRI returns the CRI of the passed wallet valid in the current epoch.
+// A cached CRI is reused while it is valid. When the cached CRI has been issued in the previous epoch,
+// a new one is requested in background, so that the owner does not wait for the revocation authority
+// at each signature. The revocation authority is contacted synchronously, by means of run, only when
+// no valid CRI is cached.
+func currentCRI(sp token.ServiceProvider, run func(view.View) (interface{}, error), tms *token.ManagementService, wallet string) (*CRI, error) {
+	epoch, err := CurrentRevocationEpoch(sp, tms)
+	if err != nil {
+		return nil, err
+	}
+	key := tms.ID().String() + wallet
+	if cri, ok := cris.get(key); ok && driver.IsRevocationEpochValidIn(cri.Epoch, epoch) {
+		if cri.Epoch != epoch && cris.startRefresh(key) {
+			go func() {
+				defer cris.endRefresh(key)
+				boxed, err := view2.GetManager(sp).InitiateView(NewRequestCRIView(tms.ID(), wallet), context.Background())
+				if err != nil {
+					logger.Warnf("failed to refresh CRI for wallet [%s]: [%s]", wallet, err)
+					return
+				}
+				cris.put(key, boxed.(*CRI))
+			}()
+		}
+		return cri, nil
+	}
+	boxed, err := run(NewRequestCRIView(tms.ID(), wallet))
+	if err != nil {
+		return nil, err
+	}
+	cri := boxed.(*CRI)
+	cris.put(key, cri)
+	return cri, nil
+}
+
+// isNonRevocationProofRequired returns true if the signatures of the passed owner must carry a non-revocation proof.
+// Only the signatures of idemix owners carry a proof.
+func isNonRevocationProofRequired(tms *token.ManagementService, owner token.Identity) bool {
+	if !IsOwnerRevocationEnabled(tms) {
+		return false
+	}
+	typed, err := identity.UnmarshalTypedIdentity(owner)
+	return err == nil && typed.Type == idemix.IdentityType
+}
+
+// withNonRevocationProof attaches to the passed signature of the passed owner on the passed message a non-revocation proof,
+// if the public parameters of the passed TMS require it. The proof is generated by the signer of the owner with
+// the CRI of the current epoch.
+func withNonRevocationProof(sp token.ServiceProvider, run func(view.View) (interface{}, error), tms *token.ManagementService, owner token.Identity, signer token.Signer, message []byte, sigma []byte) ([]byte, error) {
+	if !isNonRevocationProofRequired(tms, owner) {
+		return sigma, nil
+	}
+	prover, ok := signer.(idemix.NonRevocationProver)
+	if !ok {
+		return nil, errors.Errorf("the signer of [%s] cannot prove non-revocation", owner)
+	}
+	w := tms.WalletManager().OwnerWallet(owner)
+	if w == nil {
+		return nil, errors.Errorf("no owner wallet found for [%s]", owner)
+	}
+	cri, err := currentCRI(sp, run, tms, w.ID())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get CRI for [%s]", owner)
+	}
+	proof, rh, err := prover.ProveNonRevocation(message, cri.Raw)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to prove non-revocation of [%s]", owner)
+	}
+	nrs := &driver.NonRevokedSignature{Signature: sigma, NonRevocationProof: proof, RevocationHandle: rh}
+	return nrs.Bytes()
+}
+
+// attachNonRevocationProof attaches to the passed signature of the passed owner a non-revocation proof, if needed.
+// See withNonRevocationProof.
+func attachNonRevocationProof(context view.Context, tms *token.ManagementService, owner token.Identity, signer token.Signer, message []byte, sigma []byte) ([]byte, error) {
+	run := func(v view.View) (interface{}, error) { return context.RunView(v) }
+	return withNonRevocationProof(context, run, tms, owner, signer, message, sigma)
+}
+
+// NonRevocationSigner attaches to the signatures of an owner a non-revocation proof, if needed.
+// It is used to sign on behalf of the owners nested in other owners, such as the recipient of an htlc script,
+// whose signatures are not processed by the endorsement views.
+type NonRevocationSigner struct {
+	sp     token.ServiceProvider
+	tms    *token.ManagementService
+	owner  token.Identity
+	signer token.Signer
+}
+
+func NewNonRevocationSigner(sp token.ServiceProvider, tms *token.ManagementService, owner token.Identity, signer token.Signer) *NonRevocationSigner {
+	return &NonRevocationSigner{sp: sp, tms: tms, owner: owner, signer: signer}
+}
+
+func (s *NonRevocationSigner) Sign(message []byte) ([]byte, error) {
+	sigma, err := s.signer.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+	return withNonRevocationProof(s.sp, s.initiateView, s.tms, s.owner, s.signer, message, sigma)
+}
+
+func (s *NonRevocationSigner) initiateView(v view.View) (interface{}, error) {
+	return view2.GetManager(s.sp).InitiateView(v, context.Background())
+}
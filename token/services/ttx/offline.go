@@ -0,0 +1,300 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/pkg/errors"
+)
+
+// OfflineSigningFormatVersion is the version of the format of the offline signature requests and responses
+const OfflineSigningFormatVersion = 1
+
+// OfflineSignatureRequest is the payload exported to an offline signer, for instance an air-gapped machine holding
+// the secret key of a remote owner wallet. It is encoded in JSON.
+type OfflineSignatureRequest struct {
+	// Version is the version of the format
+	Version int
+	// TMSID identifies the token management service the transaction belongs to
+	TMSID token.TMSID
+	// TxID is the id of the transaction to sign
+	TxID string
+	// Signer is the identity whose signature is requested
+	Signer view.Identity
+	// Request is the token request, as returned by token.Request.Bytes, to let the offline signer inspect what it signs
+	Request []byte
+	// Message is the message to sign, as returned by token.Request.MarshalToSign
+	Message []byte
+}
+
+// Bytes returns the JSON encoding of the request
+func (r *OfflineSignatureRequest) Bytes() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// FromBytes decodes the request from the passed JSON encoding
+func (r *OfflineSignatureRequest) FromBytes(raw []byte) error {
+	if err := json.Unmarshal(raw, r); err != nil {
+		return errors.Wrap(err, "failed unmarshalling offline signature request")
+	}
+	if r.Version != OfflineSigningFormatVersion {
+		return errors.Errorf("unsupported offline signature request version [%d], expected [%d]", r.Version, OfflineSigningFormatVersion)
+	}
+	return nil
+}
+
+// Sign signs the message of the request with the passed signer, and returns the detached signature
+func (r *OfflineSignatureRequest) Sign(signer token.Signer) (*OfflineSignature, error) {
+	sigma, err := signer.Sign(r.Message)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed signing transaction [%s]", r.TxID)
+	}
+	return &OfflineSignature{
+		Version: OfflineSigningFormatVersion,
+		TMSID:   r.TMSID,
+		TxID:    r.TxID,
+		Signer:  r.Signer,
+		Sigma:   sigma,
+	}, nil
+}
+
+// OfflineSignature is the detached signature produced by an offline signer. It is encoded in JSON.
+type OfflineSignature struct {
+	// Version is the version of the format
+	Version int
+	// TMSID identifies the token management service the transaction belongs to
+	TMSID token.TMSID
+	// TxID is the id of the signed transaction
+	TxID string
+	// Signer is the identity that produced the signature
+	Signer view.Identity
+	// Sigma is the signature
+	Sigma []byte
+}
+
+// Bytes returns the JSON encoding of the signature
+func (s *OfflineSignature) Bytes() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// FromBytes decodes the signature from the passed JSON encoding
+func (s *OfflineSignature) FromBytes(raw []byte) error {
+	if err := json.Unmarshal(raw, s); err != nil {
+		return errors.Wrap(err, "failed unmarshalling offline signature")
+	}
+	if s.Version != OfflineSigningFormatVersion {
+		return errors.Errorf("unsupported offline signature version [%d], expected [%d]", s.Version, OfflineSigningFormatVersion)
+	}
+	return nil
+}
+
+type RequestOfflineSignaturesView struct {
+	tx *Transaction
+}
+
+// NewRequestOfflineSignaturesView returns an instance of the RequestOfflineSignaturesView.
+// The view pauses the endorsement of the passed transaction, whose transfers must be signed by remote owner wallets,
+// meaning wallets whose secret keys are not available to this node.
+// The view does the following:
+// 1. It stores the transaction in the token transaction database as pending.
+// 2. It returns a signature request, see OfflineSignatureRequest, for each of the signers bound to a remote owner wallet.
+// The endorsement resumes with the SubmitOfflineSignaturesView, once the detached signatures are available.
+func NewRequestOfflineSignaturesView(tx *Transaction) *RequestOfflineSignaturesView {
+	return &RequestOfflineSignaturesView{tx: tx}
+}
+
+func (r *RequestOfflineSignaturesView) Call(context view.Context) (interface{}, error) {
+	signers, err := offlineSigners(r.tx)
+	if err != nil {
+		return nil, err
+	}
+	if len(signers) == 0 {
+		return nil, errors.Errorf("no signer of transaction [%s] belongs to a remote wallet", r.tx.ID())
+	}
+	for _, signer := range signers {
+		// the non-revocation proof needs the credential of the signer, offline signers cannot generate it
+		if isNonRevocationProofRequired(r.tx.TokenService(), signer.identity) {
+			return nil, errors.Errorf("offline signers cannot prove the non-revocation of [%s]", signer.identity)
+		}
+	}
+	message, err := r.tx.TokenRequest.MarshalToSign()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed marshalling message to sign for [%s]", r.tx.ID())
+	}
+	request, err := r.tx.TokenRequest.Bytes()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed marshalling token request for [%s]", r.tx.ID())
+	}
+
+	db := Get(context, r.tx.TokenService())
+	if db == nil {
+		return nil, errors.Errorf("failed to get db for [%s]", r.tx.TMSID())
+	}
+	if err := db.AddPendingTransaction(r.tx); err != nil {
+		return nil, errors.WithMessagef(err, "failed storing pending transaction [%s]", r.tx.ID())
+	}
+
+	requests := make([]*OfflineSignatureRequest, len(signers))
+	for i, signer := range signers {
+		requests[i] = &OfflineSignatureRequest{
+			Version: OfflineSigningFormatVersion,
+			TMSID:   r.tx.TMSID(),
+			TxID:    r.tx.ID(),
+			Signer:  signer.identity,
+			Request: request,
+			Message: message,
+		}
+	}
+	return requests, nil
+}
+
+type SubmitOfflineSignaturesView struct {
+	signatures []*OfflineSignature
+	opts       []EndorsementsOpt
+}
+
+// NewSubmitOfflineSignaturesView returns an instance of the SubmitOfflineSignaturesView.
+// The passed signatures must refer to the same pending transaction.
+// The view does the following:
+// 1. It verifies the passed detached signatures and stores them in the token transaction database.
+// 2. If the detached signatures of all the remote signers are available, it resumes the endorsement of the pending
+// transaction with the CollectEndorsementsView, customized with the passed options, and returns the endorsed transaction.
+// Otherwise, it returns nil, and the transaction stays pending.
+func NewSubmitOfflineSignaturesView(signatures []*OfflineSignature, opts ...EndorsementsOpt) *SubmitOfflineSignaturesView {
+	return &SubmitOfflineSignaturesView{signatures: signatures, opts: opts}
+}
+
+func (s *SubmitOfflineSignaturesView) Call(context view.Context) (interface{}, error) {
+	if len(s.signatures) == 0 {
+		return nil, errors.New("no signature submitted")
+	}
+	tmsID, txID := s.signatures[0].TMSID, s.signatures[0].TxID
+	tms := token.GetManagementService(context, token.WithTMSID(tmsID))
+	if tms == nil {
+		return nil, errors.Errorf("no token management service for [%s]", tmsID)
+	}
+	db := Get(context, tms)
+	if db == nil {
+		return nil, errors.Errorf("failed to get db for [%s]", tmsID)
+	}
+	raw, err := db.GetPendingTransaction(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting pending transaction [%s]", txID)
+	}
+	if len(raw) == 0 {
+		return nil, errors.Errorf("transaction [%s] is not pending", txID)
+	}
+	tx, err := NewTransactionFromBytes(context, raw)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed unmarshalling pending transaction [%s]", txID)
+	}
+	message, err := tx.TokenRequest.MarshalToSign()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed marshalling message to sign for [%s]", txID)
+	}
+	signers, err := offlineSigners(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// verify and store the detached signatures
+	for _, signature := range s.signatures {
+		if signature.TMSID != tmsID || signature.TxID != txID {
+			return nil, errors.Errorf("signature for [%s:%s] does not refer to transaction [%s:%s]", signature.TMSID, signature.TxID, tmsID, txID)
+		}
+		if !containsSigner(signers, signature.Signer) {
+			return nil, errors.Errorf("[%s] is not a remote signer of transaction [%s]", signature.Signer, txID)
+		}
+		verifier, err := tms.SigService().OwnerVerifier(signature.Signer)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed getting verifier for [%s]", signature.Signer)
+		}
+		if err := verifier.Verify(message, signature.Sigma); err != nil {
+			return nil, errors.WithMessagef(err, "invalid signature of [%s] on transaction [%s]", signature.Signer, txID)
+		}
+		if err := db.AddDetachedSignature(txID, signature.Signer, signature.Sigma); err != nil {
+			return nil, errors.WithMessagef(err, "failed storing signature of [%s] on transaction [%s]", signature.Signer, txID)
+		}
+	}
+
+	// resume the endorsement, if all the detached signatures are available
+	sigmas, err := db.GetDetachedSignatures(txID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting detached signatures of transaction [%s]", txID)
+	}
+	signer := &detachedSignatures{sigmas: sigmas}
+	opts := append([]EndorsementsOpt{}, s.opts...)
+	for _, offline := range signers {
+		if _, ok := sigmas[offline.identity.UniqueID()]; !ok {
+			logger.Debugf("transaction [%s] still waits for the signature of [%s]", txID, offline.identity)
+			return nil, nil
+		}
+		opts = append(opts, WithExternalWalletSigner(offline.walletID, signer))
+	}
+	if _, err := context.RunView(NewCollectEndorsementsView(tx, opts...)); err != nil {
+		return nil, errors.WithMessagef(err, "failed resuming endorsement of transaction [%s]", txID)
+	}
+	if err := db.DeletePendingTransaction(txID); err != nil {
+		return nil, errors.WithMessagef(err, "failed deleting pending transaction [%s]", txID)
+	}
+	return tx, nil
+}
+
+// detachedSignatures is an ExternalWalletSigner that serves signatures produced offline
+type detachedSignatures struct {
+	sigmas map[string][]byte
+}
+
+func (d *detachedSignatures) Sign(party view.Identity, message []byte) ([]byte, error) {
+	sigma, ok := d.sigmas[party.UniqueID()]
+	if !ok {
+		return nil, errors.Errorf("no detached signature found for [%s]", party)
+	}
+	return sigma, nil
+}
+
+func (d *detachedSignatures) Done() error {
+	return nil
+}
+
+type offlineSigner struct {
+	identity view.Identity
+	walletID string
+}
+
+// offlineSigners returns the signers of the transfers of the passed transaction that are bound to a remote owner wallet
+func offlineSigners(tx *Transaction) ([]offlineSigner, error) {
+	tms := tx.TokenService()
+	var signers []offlineSigner
+	for _, identity := range tx.TokenRequest.TransferSigners() {
+		if containsSigner(signers, identity) {
+			continue
+		}
+		if _, err := tms.SigService().GetSigner(identity); err == nil {
+			continue
+		}
+		w := tms.WalletManager().OwnerWallet(identity)
+		if w == nil || !w.Remote() {
+			continue
+		}
+		signers = append(signers, offlineSigner{identity: identity, walletID: w.ID()})
+	}
+	return signers, nil
+}
+
+func containsSigner(signers []offlineSigner, identity view.Identity) bool {
+	for _, signer := range signers {
+		if bytes.Equal(signer.identity, identity) {
+			return true
+		}
+	}
+	return false
+}
@@ -11,11 +11,13 @@ import (
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/identity/multisig"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
 )
 
 type TxOptions struct {
 	Auditor                   view.Identity
+	Auditors                  []view.Identity
 	TMSID                     token.TMSID
 	NoTransactionVerification bool
 	Timeout                   time.Duration
@@ -45,6 +47,16 @@ func WithAuditor(auditor view.Identity) TxOption {
 	}
 }
 
+// WithAuditors sets the auditors to ask for auditing, in addition to the one set with WithAuditor.
+// The auditors are contacted in parallel, and enough of them must sign
+// to meet the auditors' threshold in the public parameters.
+func WithAuditors(auditors ...view.Identity) TxOption {
+	return func(o *TxOptions) error {
+		o.Auditors = append(o.Auditors, auditors...)
+		return nil
+	}
+}
+
 func WithNetwork(network string) TxOption {
 	return func(o *TxOptions) error {
 		o.TMSID.Network = network
@@ -182,6 +194,26 @@ func WithRecipientWalletID(walletID string) token.ServiceOption {
 	}
 }
 
+// WithMultisigPolicy is used to set the signature policy of the multisig identity requested with RequestMultisigIdentity.
+// By default, all the co-owners must sign.
+func WithMultisigPolicy(policy multisig.Policy) token.ServiceOption {
+	return func(options *token.ServiceOptions) error {
+		if options.Params == nil {
+			options.Params = map[string]interface{}{}
+		}
+		options.Params["MultisigPolicy"] = policy
+		return nil
+	}
+}
+
+func getMultisigPolicy(opts *token.ServiceOptions) multisig.Policy {
+	pBoxed, ok := opts.Params["MultisigPolicy"]
+	if !ok {
+		return multisig.Policy{}
+	}
+	return pBoxed.(multisig.Policy)
+}
+
 func getRecipientWalletID(opts *token.ServiceOptions) string {
 	wBoxed, ok := opts.Params["RecipientWalletID"]
 	if !ok {
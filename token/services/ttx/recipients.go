@@ -77,6 +77,8 @@ func (r Recipients) Identities() []view.Identity {
 type RequestRecipientIdentityView struct {
 	TMSID      token.TMSID
 	Recipients Recipients
+	// Policy is the signature policy of the multisig identity, if more than one recipient is requested
+	Policy multisig.Policy
 }
 
 // RequestRecipientIdentity executes the RequestRecipientIdentityView.
@@ -109,6 +111,7 @@ func RequestRecipientIdentity(context view.Context, recipient view.Identity, opt
 
 // RequestMultisigIdentity collects the recipient identities from all the passed identities.
 // It merges them into a single multisig identity and distributes it to all the participants.
+// Use WithMultisigPolicy to require only a threshold of the participants to sign.
 func RequestMultisigIdentity(context view.Context, ids []view.Identity, opts ...token.ServiceOption) (token.Identity, error) {
 	options, err := CompileServiceOptions(opts...)
 	if err != nil {
@@ -126,6 +129,7 @@ func RequestMultisigIdentity(context view.Context, ids []view.Identity, opts ...
 		&RequestRecipientIdentityView{
 			TMSID:      options.TMSID(),
 			Recipients: recipients,
+			Policy:     getMultisigPolicy(options),
 		},
 		options.Duration,
 	)
@@ -229,7 +233,7 @@ func (f *RequestRecipientIdentityView) callWithRecipientData(context view.Contex
 
 func (f *RequestRecipientIdentityView) aggregateAndDistribute(context view.Context, tms *token.ManagementService, recipients []token.Identity, local []bool) (token.Identity, error) {
 	// prepare identity
-	multisigIdentity, err := multisig.WrapIdentities(recipients...)
+	multisigIdentity, err := multisig.WrapIdentitiesWithPolicy(f.Policy, recipients...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed wrapping identities")
 	}
@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"bytes"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+// IsStale returns true if the token request of this transaction has been prepared with public parameters
+// that are not the current ones of the token management service.
+// A stale transaction is still accepted by the validators within their grace window, see RebuildTransaction otherwise.
+func (t *Transaction) IsStale() bool {
+	return !bytes.Equal(t.TokenRequest.PublicParamsHash(), t.TokenService().PublicParametersManager().PublicParamsHash())
+}
+
+// RebuildTransaction prepares again the token request of the passed transaction under the current public parameters.
+// The transaction keeps its id, and the issues, transfers, and redeems keep their issuers, owners, values, and spent tokens.
+// Each issue is rebuilt as a single action with all its outputs.
+// Only issues without inputs, transfers, and redeems whose change goes back to the sender are supported.
+// The signatures collected for the previous token request are no longer valid.
+func RebuildTransaction(context view.Context, tx *Transaction) error {
+	tms := token.GetManagementService(context, token.WithTMSID(tx.TMSID()))
+	if tms == nil {
+		return errors.Errorf("no token management service for [%s]", tx.TMSID())
+	}
+	old := tx.TokenRequest
+	inputs, outputs, err := old.InputsAndOutputs()
+	if err != nil {
+		return errors.WithMessagef(err, "failed getting inputs and outputs of transaction [%s]", tx.ID())
+	}
+	numIssueInputs, numIssueOutputs, numOutputs := 0, 0, 0
+	for _, issue := range old.Metadata.Issues {
+		numIssueInputs += len(issue.Inputs)
+		numIssueOutputs += len(issue.Outputs)
+	}
+	numOutputs = numIssueOutputs
+	for _, transfer := range old.Metadata.Transfers {
+		numOutputs += len(transfer.Outputs)
+	}
+	if numIssueInputs != 0 {
+		return errors.Errorf("cannot rebuild transaction [%s], issues with inputs are not supported", tx.ID())
+	}
+	if outputs.Count() != numOutputs {
+		return errors.Errorf("cannot rebuild transaction [%s], the metadata of some outputs is missing", tx.ID())
+	}
+
+	request, err := tms.NewRequest(tx.ID())
+	if err != nil {
+		return errors.WithMessagef(err, "failed creating token request for [%s]", tx.ID())
+	}
+	ctx := context.Context()
+
+	// issues, each one with all its outputs
+	issueOutputs := outputs.Outputs()[:numIssueOutputs]
+	for i := range old.Metadata.Issues {
+		var issuer token.Identity
+		var typ token2.Type
+		var values []uint64
+		var owners []token.Identity
+		for _, output := range issueOutputs {
+			if output.ActionIndex != i {
+				continue
+			}
+			v, err := toUint64(output.Quantity)
+			if err != nil {
+				return errors.WithMessagef(err, "cannot rebuild issue [%d] of transaction [%s]", i, tx.ID())
+			}
+			issuer, typ = output.Issuer, output.Type
+			values = append(values, v)
+			owners = append(owners, output.Owner)
+		}
+		if len(values) == 0 {
+			return errors.Errorf("cannot rebuild issue [%d] of transaction [%s], no outputs", i, tx.ID())
+		}
+		wallet := tms.WalletManager().IssuerWallet(issuer)
+		if wallet == nil {
+			return errors.Errorf("cannot rebuild issue [%d] of transaction [%s], issuer wallet not found for [%s]", i, tx.ID(), issuer)
+		}
+		if _, err := request.IssueMany(ctx, wallet, typ, values, owners); err != nil {
+			return errors.WithMessagef(err, "failed rebuilding issue [%d] of transaction [%s]", i, tx.ID())
+		}
+	}
+
+	// transfers and redeems
+	transferOutputs := outputs.Outputs()[numIssueOutputs:]
+	for i := range old.Metadata.Transfers {
+		actionInputs := inputs.Filter(func(t *token.Input) bool { return t.ActionIndex == i })
+		if actionInputs.Count() == 0 {
+			return errors.Errorf("cannot rebuild transfer [%d] of transaction [%s], no inputs", i, tx.ID())
+		}
+		sender := actionInputs.At(0).Owner
+		wallet := tms.WalletManager().OwnerWallet(sender)
+		if wallet == nil {
+			return errors.Errorf("cannot rebuild transfer [%d] of transaction [%s], owner wallet not found for [%s]", i, tx.ID(), sender)
+		}
+		ids := actionInputs.IDs()
+
+		var typ token2.Type
+		var values []uint64
+		var owners []token.Identity
+		var redeemed []uint64
+		for _, output := range transferOutputs {
+			if output.ActionIndex != i {
+				continue
+			}
+			v, err := toUint64(output.Quantity)
+			if err != nil {
+				return errors.WithMessagef(err, "cannot rebuild transfer [%d] of transaction [%s]", i, tx.ID())
+			}
+			typ = output.Type
+			if output.Owner.IsNone() {
+				redeemed = append(redeemed, v)
+				continue
+			}
+			values = append(values, v)
+			owners = append(owners, output.Owner)
+		}
+
+		switch {
+		case len(redeemed) == 0:
+			if _, err := request.Transfer(ctx, wallet, typ, values, owners, token.WithTokenIDs(ids...)); err != nil {
+				return errors.WithMessagef(err, "failed rebuilding transfer [%d] of transaction [%s]", i, tx.ID())
+			}
+		case len(redeemed) == 1 && len(owners) <= 1 && (len(owners) == 0 || wallet.Contains(owners[0])):
+			if err := request.Redeem(ctx, wallet, typ, redeemed[0], token.WithTokenIDs(ids...)); err != nil {
+				return errors.WithMessagef(err, "failed rebuilding redeem [%d] of transaction [%s]", i, tx.ID())
+			}
+		default:
+			return errors.Errorf("cannot rebuild transfer [%d] of transaction [%s], unsupported shape", i, tx.ID())
+		}
+	}
+
+	for k, v := range old.AllApplicationMetadata() {
+		request.SetApplicationMetadata(k, v)
+	}
+	tx.TMS = tms
+	tx.TokenRequest = request
+	tx.Envelope = nil
+	return nil
+}
+
+// toUint64 returns the passed quantity as an uint64, the token request api takes values of this size.
+// It fails, instead of truncating, if the quantity does not fit.
+func toUint64(q token2.Quantity) (uint64, error) {
+	v := q.ToBigInt()
+	if !v.IsUint64() {
+		return 0, errors.Errorf("quantity [%s] does not fit in 64 bits", q.Decimal())
+	}
+	return v.Uint64(), nil
+}
+
+type RebuildPendingTransactionsView struct {
+	tmsID token.TMSID
+}
+
+// NewRebuildPendingTransactionsView returns an instance of the RebuildPendingTransactionsView.
+// The view is meant to run after a rotation of the public parameters of the passed token management service.
+// It rebuilds, see RebuildTransaction, the pending transactions, see RequestOfflineSignaturesView, that are stale,
+// and stores them back as pending. The detached signatures collected for them are discarded.
+// The view returns the rebuilt transactions, new offline signatures must be requested for them.
+func NewRebuildPendingTransactionsView(tmsID token.TMSID) *RebuildPendingTransactionsView {
+	return &RebuildPendingTransactionsView{tmsID: tmsID}
+}
+
+func (r *RebuildPendingTransactionsView) Call(context view.Context) (interface{}, error) {
+	tms := token.GetManagementService(context, token.WithTMSID(r.tmsID))
+	if tms == nil {
+		return nil, errors.Errorf("no token management service for [%s]", r.tmsID)
+	}
+	db := Get(context, tms)
+	if db == nil {
+		return nil, errors.Errorf("failed to get db for [%s]", r.tmsID)
+	}
+	txIDs, err := db.GetPendingTransactionIDs()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting pending transactions")
+	}
+	var rebuilt []*Transaction
+	for _, txID := range txIDs {
+		raw, err := db.GetPendingTransaction(txID)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed getting pending transaction [%s]", txID)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		tx, err := NewTransactionFromBytes(context, raw)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed unmarshalling pending transaction [%s]", txID)
+		}
+		if !tx.IsStale() {
+			continue
+		}
+		logger.Debugf("rebuilding stale pending transaction [%s]", txID)
+		if err := RebuildTransaction(context, tx); err != nil {
+			return nil, err
+		}
+		if err := db.ReplacePendingTransaction(tx); err != nil {
+			return nil, errors.WithMessagef(err, "failed storing rebuilt pending transaction [%s]", txID)
+		}
+		rebuilt = append(rebuilt, tx)
+	}
+	return rebuilt, nil
+}
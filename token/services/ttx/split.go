@@ -0,0 +1,195 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"encoding/hex"
+	"math/big"
+	"slices"
+	"strconv"
+
+	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PaymentGroupKey is the application metadata key, of the transactions of a split payment, whose value is the identifier of the payment group
+	PaymentGroupKey = "ttx.payment.group"
+	// PaymentPartKey is the application metadata key, of the transactions of a split payment, whose value is the position of the transaction in the group
+	PaymentPartKey = "ttx.payment.part"
+)
+
+// PaymentPart is a transfer of a split payment
+type PaymentPart struct {
+	// TokenIDs are the tokens spent by the transfer
+	TokenIDs []*token2.ID
+	// Value is the value paid by the transfer, the rest of the tokens goes back to the sender
+	Value uint64
+}
+
+// SplitPayment returns the transfers a payment of the passed value must be split into, so that each transfer spends
+// at most maxInputs of the passed tokens, all of the same type. The largest tokens are spent first to keep the number of transfers low.
+// If maxInputs is not positive, the payment is not split.
+// It returns an error wrapping token.SelectorInsufficientFunds if the tokens do not cover the value.
+func SplitPayment(tokens []*token2.UnspentToken, value uint64, maxInputs int, precision uint64) ([]*PaymentPart, error) {
+	if value == 0 {
+		return nil, errors.Errorf("value is zero")
+	}
+	type candidate struct {
+		id       *token2.ID
+		quantity *big.Int
+	}
+	candidates := make([]*candidate, len(tokens))
+	for i, t := range tokens {
+		q, err := token2.ToQuantity(t.Quantity, precision)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid quantity for token [%s]", t.Id)
+		}
+		candidates[i] = &candidate{id: t.Id, quantity: q.ToBigInt()}
+	}
+	slices.SortStableFunc(candidates, func(a, b *candidate) int { return b.quantity.Cmp(a.quantity) })
+
+	remaining := new(big.Int).SetUint64(value)
+	var parts []*PaymentPart
+	part, sum := &PaymentPart{}, big.NewInt(0)
+	for _, c := range candidates {
+		part.TokenIDs = append(part.TokenIDs, c.id)
+		sum.Add(sum, c.quantity)
+		if sum.Cmp(remaining) >= 0 {
+			part.Value = remaining.Uint64()
+			return append(parts, part), nil
+		}
+		if maxInputs > 0 && len(part.TokenIDs) == maxInputs {
+			part.Value = sum.Uint64()
+			remaining.Sub(remaining, sum)
+			parts = append(parts, part)
+			part, sum = &PaymentPart{}, big.NewInt(0)
+		}
+	}
+	return nil, errors.Wrapf(token.SelectorInsufficientFunds, "the tokens do not cover the payment of [%d]", value)
+}
+
+type SplitPaymentView struct {
+	wallet    *token.OwnerWallet
+	typ       token2.Type
+	value     uint64
+	recipient view.Identity
+	opts      []TxOption
+}
+
+// NewSplitPaymentView returns a view that pays the passed value, of the given type, from the passed wallet to the recipient
+// with a chained sequence of transfers, each within the action limits of the TMS, see token.ActionLimits.
+// The transfers are tracked as a payment group in the token transaction database, whose status is the status of the whole payment.
+// Each transfer is endorsed, audited, and committed before the next one is assembled. Its endorsement runs in a PaymentPartView,
+// for which the recipient must register a responder. If a transfer fails, the payment group is marked as Deleted and the
+// following transfers are not assembled, while the committed ones stay on the ledger.
+// The view returns the identifier of the payment group.
+func NewSplitPaymentView(wallet *token.OwnerWallet, typ token2.Type, value uint64, recipient view.Identity, opts ...TxOption) *SplitPaymentView {
+	return &SplitPaymentView{wallet: wallet, typ: typ, value: value, recipient: recipient, opts: opts}
+}
+
+func (v *SplitPaymentView) Call(context view.Context) (interface{}, error) {
+	txOpts, err := CompileOpts(v.opts...)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed compiling tx options")
+	}
+	tms := token.GetManagementService(context, token.WithTMSID(txOpts.TMSID))
+	if tms == nil {
+		return nil, errors.Errorf("no token management service for [%s]", txOpts.TMSID)
+	}
+	pp := tms.PublicParametersManager().PublicParameters()
+	if pp == nil {
+		return nil, errors.Errorf("public parameters not set yet for TMS [%s]", tms.ID())
+	}
+	db := Get(context, tms)
+	if db == nil {
+		return nil, errors.Errorf("failed to get db for [%s]", tms.ID())
+	}
+	limits, err := tms.Configuration().ActionLimits()
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed getting action limits")
+	}
+	unspent, err := v.wallet.ListUnspentTokens(token.WithType(v.typ))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed listing the tokens of wallet [%s]", v.wallet.ID())
+	}
+	parts, err := SplitPayment(unspent.Tokens, v.value, limits.MaxInputs, pp.Precision())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed splitting the payment")
+	}
+
+	nonce, err := GetRandomNonce()
+	if err != nil {
+		return nil, err
+	}
+	groupID := hex.EncodeToString(nonce)
+	if err := db.AddPaymentGroup(groupID, len(parts)); err != nil {
+		return nil, errors.WithMessagef(err, "failed storing payment group [%s]", groupID)
+	}
+	logger.Debugf("split payment of [%d:%s] into [%d] transfers, group [%s]", v.value, v.typ, len(parts), groupID)
+	for i, part := range parts {
+		partView := &PaymentPartView{
+			GroupID:   groupID,
+			Index:     i,
+			Part:      part,
+			Wallet:    v.wallet,
+			Type:      v.typ,
+			Recipient: v.recipient,
+			Opts:      v.opts,
+			db:        db,
+		}
+		// each transfer opens its own sessions with the recipient
+		if _, err := context.RunView(partView, view.AsInitiator()); err != nil {
+			message := "transfer " + strconv.Itoa(i) + " failed: " + err.Error()
+			if err2 := db.SetPaymentGroupStatus(groupID, Deleted, message); err2 != nil {
+				logger.Errorf("failed setting status of payment group [%s]: [%s]", groupID, err2)
+			}
+			return nil, errors.WithMessagef(err, "failed transfer [%d] of payment group [%s]", i, groupID)
+		}
+	}
+	if err := db.SetPaymentGroupStatus(groupID, Confirmed, ""); err != nil {
+		return nil, errors.WithMessagef(err, "failed setting status of payment group [%s]", groupID)
+	}
+	return groupID, nil
+}
+
+// PaymentPartView assembles, endorses, and commits a transfer of a split payment, see SplitPaymentView
+type PaymentPartView struct {
+	GroupID   string
+	Index     int
+	Part      *PaymentPart
+	Wallet    *token.OwnerWallet
+	Type      token2.Type
+	Recipient view.Identity
+	Opts      []TxOption
+
+	db *DB
+}
+
+func (p *PaymentPartView) Call(context view.Context) (interface{}, error) {
+	tx, err := NewAnonymousTransaction(context, p.Opts...)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed creating transaction")
+	}
+	tx.SetApplicationMetadata(PaymentGroupKey, []byte(p.GroupID))
+	tx.SetApplicationMetadata(PaymentPartKey, []byte(strconv.Itoa(p.Index)))
+	if err := tx.Transfer(p.Wallet, p.Type, []uint64{p.Part.Value}, []view.Identity{p.Recipient}, token.WithTokenIDs(p.Part.TokenIDs...)); err != nil {
+		return nil, errors.WithMessagef(err, "failed preparing transfer")
+	}
+	if err := p.db.AddPaymentGroupTransaction(p.GroupID, tx.ID(), p.Index); err != nil {
+		return nil, errors.WithMessagef(err, "failed binding [%s] to payment group [%s]", tx.ID(), p.GroupID)
+	}
+	if _, err := context.RunView(NewCollectEndorsementsView(tx)); err != nil {
+		return nil, errors.WithMessagef(err, "failed collecting endorsements for [%s]", tx.ID())
+	}
+	if _, err := context.RunView(NewOrderingAndFinalityView(tx)); err != nil {
+		return nil, errors.WithMessagef(err, "failed committing [%s]", tx.ID())
+	}
+	return tx, nil
+}
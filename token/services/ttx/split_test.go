@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ttx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger-labs/fabric-token-sdk/token"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func unspentTokens(quantities ...uint64) []*token2.UnspentToken {
+	tokens := make([]*token2.UnspentToken, len(quantities))
+	for i, q := range quantities {
+		tokens[i] = &token2.UnspentToken{
+			Id:       &token2.ID{TxId: fmt.Sprintf("tx%d", i)},
+			Type:     "USD",
+			Quantity: fmt.Sprintf("0x%x", q),
+		}
+	}
+	return tokens
+}
+
+func TestSplitPayment(t *testing.T) {
+	tokens := unspentTokens(1, 5, 2, 4, 3)
+
+	// no limit, a single transfer spending the largest tokens
+	parts, err := SplitPayment(tokens, 8, 0, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, []*PaymentPart{{TokenIDs: []*token2.ID{{TxId: "tx1"}, {TxId: "tx3"}}, Value: 8}}, parts)
+
+	// at most two inputs per transfer
+	parts, err = SplitPayment(tokens, 14, 2, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, []*PaymentPart{
+		{TokenIDs: []*token2.ID{{TxId: "tx1"}, {TxId: "tx3"}}, Value: 9},
+		{TokenIDs: []*token2.ID{{TxId: "tx4"}, {TxId: "tx2"}}, Value: 5},
+	}, parts)
+
+	// the last transfer gets the change
+	parts, err = SplitPayment(tokens, 13, 2, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, []*PaymentPart{
+		{TokenIDs: []*token2.ID{{TxId: "tx1"}, {TxId: "tx3"}}, Value: 9},
+		{TokenIDs: []*token2.ID{{TxId: "tx4"}, {TxId: "tx2"}}, Value: 4},
+	}, parts)
+
+	// all the tokens, one per transfer
+	parts, err = SplitPayment(tokens, 15, 1, 64)
+	assert.NoError(t, err)
+	assert.Len(t, parts, 5)
+
+	_, err = SplitPayment(tokens, 16, 2, 64)
+	assert.ErrorIs(t, err, token.SelectorInsufficientFunds)
+	_, err = SplitPayment(tokens, 0, 2, 64)
+	assert.EqualError(t, err, "value is zero")
+}
@@ -8,10 +8,13 @@ package ttx
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/hyperledger-labs/fabric-smart-client/platform/view/view"
 	"github.com/hyperledger-labs/fabric-token-sdk/token"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/network"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/tokens"
+	"github.com/hyperledger-labs/fabric-token-sdk/token/services/ttxdb"
 	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 	"go.uber.org/zap/zapcore"
@@ -42,6 +45,9 @@ type Transaction struct {
 	Opts            *TxOptions
 	Context         context.Context
 	FromRaw         []byte
+	// RedeemedSupply gives access to the quantity of tokens redeemed so far.
+	// It is used to check the supply caps locally.
+	RedeemedSupply token.RedeemedSupplyProvider
 }
 
 // NewAnonymousTransaction returns a new anonymous token transaction customized with the passed opts
@@ -109,7 +115,10 @@ func NewTransaction(context view.Context, signer view.Identity, opts ...TxOption
 		}
 		txID = network.TxID{Creator: signer}
 	}
-	id := networkService.ComputeTxID(&txID)
+	id, err := networkService.ComputeTxID(&txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing transaction id")
+	}
 	tr, err := tms.NewRequest(id)
 	if err != nil {
 		return nil, errors.WithMessage(err, "failed init token request")
@@ -131,6 +140,7 @@ func NewTransaction(context view.Context, signer view.Identity, opts ...TxOption
 		NetworkProvider: networkProvider,
 		Opts:            txOpts,
 		Context:         context.Context(),
+		RedeemedSupply:  &redeemedSupplyProvider{sp: context, tmsID: tms.ID()},
 	}
 	context.OnError(tx.Release)
 	return tx, nil
@@ -159,6 +169,7 @@ func NewTransactionFromBytes(context view.Context, raw []byte) (*Transaction, er
 	)
 	tx.TMS = tms
 	tx.NetworkProvider = networkProvider
+	tx.RedeemedSupply = &redeemedSupplyProvider{sp: context, tmsID: tms.ID()}
 	tx.TokenRequest.SetTokenService(tms)
 	if tx.ID() != tx.TokenRequest.ID() {
 		return nil, errors.Errorf("invalid transaction, transaction ids do not match [%s][%s]", tx.ID(), tx.TokenRequest.ID())
@@ -233,11 +244,41 @@ func (t *Transaction) Bytes(eIDs ...string) ([]byte, error) {
 }
 
 // Issue appends a new Issue operation to the TokenRequest inside this transaction
+// The issue is refused if it would make the circulating supply of the token type exceed its supply cap, if any.
 func (t *Transaction) Issue(wallet *token.IssuerWallet, receiver view.Identity, typ token2.Type, q uint64, opts ...token.IssueOption) error {
+	if err := t.checkSupplyCap(wallet, typ, q); err != nil {
+		return err
+	}
 	_, err := t.TokenRequest.Issue(t.Context, wallet, receiver, typ, q, opts...)
 	return err
 }
 
+// checkSupplyCap returns an error if issuing the passed quantity would make the circulating supply
+// of the passed token type exceed its supply cap.
+// The circulating supply is the supply outstanding for the passed wallet plus the tokens already issued in this transaction.
+func (t *Transaction) checkSupplyCap(wallet *token.IssuerWallet, typ token2.Type, q uint64) error {
+	supplyCap, ok := t.TMS.PublicParametersManager().PublicParameters().SupplyCap(typ)
+	if !ok {
+		return nil
+	}
+	supply, err := wallet.OutstandingSupply(typ, t.RedeemedSupply)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get the outstanding supply of type [%s]", typ)
+	}
+	outputs, err := t.TokenRequest.Outputs()
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get the outputs of the transaction")
+	}
+	supply.Add(supply, outputs.Filter(func(o *token.Output) bool {
+		return len(o.Issuer) != 0 && o.Type == typ
+	}).Sum())
+	supply.Add(supply, new(big.Int).SetUint64(q))
+	if supply.Cmp(new(big.Int).SetUint64(supplyCap)) > 0 {
+		return errors.Errorf("issuing [%d] tokens of type [%s] exceeds the supply cap [%d]", q, typ, supplyCap)
+	}
+	return nil
+}
+
 // Transfer appends a new Transfer operation to the TokenRequest inside this transaction
 func (t *Transaction) Transfer(wallet *token.OwnerWallet, typ token2.Type, values []uint64, owners []view.Identity, opts ...token.TransferOption) error {
 	_, err := t.TokenRequest.Transfer(t.Context, wallet, typ, values, owners, opts...)
@@ -259,6 +300,64 @@ func (t *Transaction) Upgrade(
 	return err
 }
 
+// Freeze freezes the passed tokens on behalf of the passed compliance authorities.
+// The transaction cannot contain any issue or transfer action.
+func (t *Transaction) Freeze(ids []*token2.ID, authorities ...view.Identity) error {
+	return t.TokenRequest.Freeze(ids, false, authorities...)
+}
+
+// Unfreeze unfreezes the passed tokens on behalf of the passed compliance authorities.
+// The transaction cannot contain any issue or transfer action.
+func (t *Transaction) Unfreeze(ids []*token2.ID, authorities ...view.Identity) error {
+	return t.TokenRequest.Freeze(ids, true, authorities...)
+}
+
+// FreezeEnrollmentID freezes all the unspent tokens, known to this node, whose owner has the passed enrollment ID.
+// Enrollment IDs are not visible on the ledger, therefore, the tokens are listed from the token store of this node,
+// usually the one of an auditor, and frozen by ID. The tokens the enrollment ID receives afterward are not frozen.
+func (t *Transaction) FreezeEnrollmentID(context view.Context, enrollmentID string, authorities ...view.Identity) error {
+	ids, err := t.tokensOfEnrollmentID(context, enrollmentID)
+	if err != nil {
+		return err
+	}
+	return t.TokenRequest.Freeze(ids, false, authorities...)
+}
+
+// UnfreezeEnrollmentID unfreezes all the unspent tokens, known to this node, whose owner has the passed enrollment ID.
+// See FreezeEnrollmentID.
+func (t *Transaction) UnfreezeEnrollmentID(context view.Context, enrollmentID string, authorities ...view.Identity) error {
+	ids, err := t.tokensOfEnrollmentID(context, enrollmentID)
+	if err != nil {
+		return err
+	}
+	return t.TokenRequest.Freeze(ids, true, authorities...)
+}
+
+func (t *Transaction) tokensOfEnrollmentID(context view.Context, enrollmentID string) ([]*token2.ID, error) {
+	if len(enrollmentID) == 0 {
+		return nil, errors.New("no enrollment ID passed")
+	}
+	db, err := tokens.GetService(context, t.TMSID())
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get tokens db for [%s]", t.TMSID())
+	}
+	ids, err := db.UnspentTokenIDsByEnrollmentID(context.Context(), enrollmentID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to list the tokens of enrollment ID [%s]", enrollmentID)
+	}
+	if len(ids) == 0 {
+		return nil, errors.Errorf("no unspent token found for enrollment ID [%s]", enrollmentID)
+	}
+	return ids, nil
+}
+
+// Clawback moves the passed tokens to the passed recovery owner, on behalf of the passed compliance authorities,
+// without the signature of their owners.
+func (t *Transaction) Clawback(ids []*token2.ID, recoveryOwner view.Identity, authorities []view.Identity, opts ...token.TransferOption) error {
+	_, err := t.TokenRequest.Clawback(t.Context, ids, recoveryOwner, authorities, opts...)
+	return err
+}
+
 func (t *Transaction) Outputs() (*token.OutputStream, error) {
 	return t.TokenRequest.Outputs()
 }
@@ -359,3 +458,17 @@ func (t *Transaction) appendPayload(payload *Payload) error {
 	// }
 	// return nil
 }
+
+// redeemedSupplyProvider gets the redeemed supply from the token transaction db of the given TMS
+type redeemedSupplyProvider struct {
+	sp    token.ServiceProvider
+	tmsID token.TMSID
+}
+
+func (p *redeemedSupplyProvider) RedeemedSupply(tokenType token2.Type) (*big.Int, error) {
+	db, err := ttxdb.GetByTMSId(p.sp, p.tmsID)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get ttxdb for [%s]", p.tmsID)
+	}
+	return db.RedeemedSupply(tokenType)
+}
@@ -236,6 +236,15 @@ func (r *UpgradeTokensResponderView) Call(context view.Context) (interface{}, er
 		return nil, errors.Errorf("agreement TMSID mismatch [%v] != [%v]", agreement.TMSID, request.TMSID)
 	}
 
+	// check the requester owns the tokens to upgrade
+	ok, err := tms.TokensService().CheckUpgradeProof(agreement.Challenge, request.Proof, request.Tokens)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check upgrade proof")
+	}
+	if !ok {
+		return nil, errors.Errorf("invalid upgrade proof")
+	}
+
 	if err := tms.WalletManager().RegisterRecipientIdentity(&request.RecipientData); err != nil {
 		return nil, errors.Wrapf(err, "failed to register recipient identity")
 	}
@@ -251,6 +260,16 @@ func (r *UpgradeTokensResponderView) Call(context view.Context) (interface{}, er
 		}
 		return nil, errors.Wrapf(err, "failed binding [%s] to [%s]", request.RecipientData.Identity, caller)
 	}
+	// The owners of the tokens to upgrade sign the issue transaction, they are reachable via the caller
+	for _, tok := range request.Tokens {
+		clearToken, _, _, _, err := tms.TokensService().Deobfuscate(tok.Token, tok.TokenMetadata)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to deobfuscate token [%s]", tok.ID)
+		}
+		if err := view2.GetEndpointService(context).Bind(caller, clearToken.Owner); err != nil {
+			return nil, errors.Wrapf(err, "failed binding [%s] to [%s]", view.Identity(clearToken.Owner), caller)
+		}
+	}
 
 	return request, nil
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/common"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/db/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/services/logging"
+	token2 "github.com/hyperledger-labs/fabric-token-sdk/token/token"
 	"github.com/pkg/errors"
 )
 
@@ -72,6 +73,8 @@ const (
 	Transfer = driver.Transfer
 	// Redeem is the action type for redeeming tokens.
 	Redeem = driver.Redeem
+	// Clawback is the action type for moving tokens to a recovery owner on behalf of the compliance authorities.
+	Clawback = driver.Clawback
 )
 
 // TransactionRecord is a more finer-grained version of a movement record.
@@ -95,6 +98,9 @@ type MovementRecord = driver.MovementRecord
 // in that action.
 type ValidationRecord = driver.ValidationRecord
 
+// PaymentGroupRecord is a payment split into a chained sequence of transactions
+type PaymentGroupRecord = driver.PaymentGroupRecord
+
 // TransactionIterator is an iterator over transaction records
 type TransactionIterator struct {
 	it driver.TransactionIterator
@@ -184,6 +190,19 @@ func (d *DB) Transactions(params QueryTransactionsParams) (driver.TransactionIte
 	return d.db.QueryTransactions(params)
 }
 
+// RedeemedSupply returns the total amount of tokens of the passed type redeemed by confirmed transactions
+func (d *DB) RedeemedSupply(tokenType token2.Type) (*big.Int, error) {
+	sum, err := d.db.SumTransactionAmounts(QueryTransactionsParams{
+		ActionTypes: []ActionType{Redeem},
+		Statuses:    []TxStatus{Confirmed},
+		TokenTypes:  []token2.Type{tokenType},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to sum redeem transactions")
+	}
+	return sum, nil
+}
+
 // TokenRequests returns an iterator over the token requests matching the passed params
 func (d *DB) TokenRequests(params QueryTokenRequestsParams) (driver.TokenRequestIterator, error) {
 	return d.db.QueryTokenRequests(params)
@@ -231,7 +250,7 @@ func (d *DB) AppendTransactionRecord(req *token.Request) error {
 		record.Anchor,
 		raw,
 		req.Metadata.Application,
-		req.TokenService.PublicParametersManager().PublicParamsHash(),
+		req.PublicParamsHash(),
 	); err != nil {
 		w.Rollback()
 		return errors.WithMessagef(err, "append token request for txid [%s] failed", record.Anchor)
@@ -298,6 +317,62 @@ func (d *DB) GetTransactionEndorsementAcks(txID string) (map[string][]byte, erro
 	return d.db.GetTransactionEndorsementAcks(txID)
 }
 
+// AddPendingTransaction stores the passed serialized transaction whose endorsement waits for detached signatures
+func (d *DB) AddPendingTransaction(txID string, tx []byte) error {
+	return d.db.AddPendingTransaction(txID, tx)
+}
+
+// GetPendingTransaction returns the pending transaction bound to the passed transaction id, nil if not found
+func (d *DB) GetPendingTransaction(txID string) ([]byte, error) {
+	return d.db.GetPendingTransaction(txID)
+}
+
+// GetPendingTransactionIDs returns the ids of the pending transactions, oldest first
+func (d *DB) GetPendingTransactionIDs() ([]string, error) {
+	return d.db.GetPendingTransactionIDs()
+}
+
+// ReplacePendingTransaction atomically replaces the pending transaction bound to the passed transaction id
+// and removes its detached signatures
+func (d *DB) ReplacePendingTransaction(txID string, tx []byte) error {
+	return d.db.ReplacePendingTransaction(txID, tx)
+}
+
+// DeletePendingTransaction removes the pending transaction bound to the passed transaction id and its detached signatures
+func (d *DB) DeletePendingTransaction(txID string) error {
+	return d.db.DeletePendingTransaction(txID)
+}
+
+// AddDetachedSignature records the detached signature of the passed signer on the given pending transaction
+func (d *DB) AddDetachedSignature(txID string, signer token.Identity, sigma []byte) error {
+	return d.db.AddDetachedSignature(txID, signer, sigma)
+}
+
+// GetDetachedSignatures returns the detached signatures for the given pending transaction, indexed by the unique id of the signer
+func (d *DB) GetDetachedSignatures(txID string) (map[string][]byte, error) {
+	return d.db.GetDetachedSignatures(txID)
+}
+
+// AddPaymentGroup stores a new payment group made of the passed number of transactions, whose status is Pending
+func (d *DB) AddPaymentGroup(groupID string, parts int) error {
+	return d.db.AddPaymentGroup(groupID, parts)
+}
+
+// AddPaymentGroupTransaction binds the passed transaction to the given payment group, at the given position
+func (d *DB) AddPaymentGroupTransaction(groupID string, txID string, index int) error {
+	return d.db.AddPaymentGroupTransaction(groupID, txID, index)
+}
+
+// SetPaymentGroupStatus sets the status of the given payment group
+func (d *DB) SetPaymentGroupStatus(groupID string, status TxStatus, message string) error {
+	return d.db.SetPaymentGroupStatus(groupID, status, message)
+}
+
+// GetPaymentGroup returns the payment group with the passed identifier, nil if not found
+func (d *DB) GetPaymentGroup(groupID string) (*PaymentGroupRecord, error) {
+	return d.db.GetPaymentGroup(groupID)
+}
+
 // AppendValidationRecord appends the given validation metadata related to the given transaction id
 func (d *DB) AppendValidationRecord(txID string, tokenRequest []byte, meta map[string][]byte, ppHash driver2.PPHash) error {
 	logger.Debugf("appending new validation record... [%s]", txID)
@@ -354,6 +429,9 @@ func TransactionRecords(record *token.AuditRecord, timestamp time.Time) (txs []T
 			inEID = inEIDs[0]
 		}
 
+		// a clawback spends the inputs without the signature of their owners
+		clawback := ins.Count() != 0 && ins.At(0).Clawback
+
 		outEIDs := ous.EnrollmentIDs()
 		outEIDs = append(outEIDs, "")
 		outTT := ous.TokenTypes()
@@ -365,7 +443,9 @@ func TransactionRecords(record *token.AuditRecord, timestamp time.Time) (txs []T
 				}
 
 				tt := driver.Issue
-				if len(inEIDs) != 0 {
+				if clawback {
+					tt = driver.Clawback
+				} else if len(inEIDs) != 0 {
 					if len(outEID) == 0 {
 						tt = driver.Redeem
 					} else {
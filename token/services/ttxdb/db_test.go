@@ -37,6 +37,10 @@ func TestDB(t *testing.T) {
 	assert.NoError(t, err)
 
 	TEndorserAcks(t, db1, db2)
+
+	redeemed, err := db1.RedeemedSupply("USD")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), redeemed.Int64())
 }
 
 func TEndorserAcks(t *testing.T, db1, db2 *ttxdb.DB) {
@@ -164,6 +168,23 @@ func TestTransactionRecords(t *testing.T) {
 			Status:       driver.Pending,
 		},
 	}, recs)
+
+	// Clawback
+	input = clawback()
+	recs, err = ttxdb.TransactionRecords(&input, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []driver.TransactionRecord{
+		{
+			TxID:         input.Anchor,
+			ActionType:   driver.Clawback,
+			SenderEID:    "alice",
+			RecipientEID: "recovery",
+			TokenType:    "TOK",
+			Amount:       big.NewInt(10),
+			Timestamp:    now,
+			Status:       driver.Pending,
+		},
+	}, recs)
 }
 
 func TestMovementRecords(t *testing.T) {
@@ -266,6 +287,28 @@ func simpleTransfer() token.AuditRecord {
 	}
 }
 
+func clawback() token.AuditRecord {
+	input1 := &token.Input{
+		ActionIndex:  0,
+		EnrollmentID: "alice",
+		Type:         "TOK",
+		Quantity:     token2.NewQuantityFromUInt64(10),
+		Clawback:     true,
+	}
+	output1 := &token.Output{
+		ActionIndex:  0,
+		EnrollmentID: "recovery",
+		Type:         "TOK",
+		Quantity:     token2.NewQuantityFromUInt64(10),
+		Clawback:     true,
+	}
+	return token.AuditRecord{
+		Anchor:  "test",
+		Inputs:  token.NewInputStream(qsMock{}, []*token.Input{input1}, 64),
+		Outputs: token.NewOutputStream([]*token.Output{output1}, 64),
+	}
+}
+
 func transferWithChange() token.AuditRecord {
 	input1 := &token.Input{
 		ActionIndex:  0,
@@ -46,6 +46,8 @@ type Output struct {
 	LedgerOutputMetadata []byte
 	// Issuer is the identity of the issuer of this output, if any
 	Issuer driver.Identity
+	// Clawback is true if this output has been created by a clawback
+	Clawback bool
 }
 
 func (o Output) ID(txID string) *token.ID {
@@ -182,6 +184,8 @@ type Input struct {
 	RevocationHandler string
 	Type              token.Type
 	Quantity          token.Quantity
+	// Clawback is true if this input is spent by a clawback, without the signature of its owner
+	Clawback bool
 }
 
 // InputStream models a stream over a set of inputs (Input).
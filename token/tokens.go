@@ -31,6 +31,11 @@ func (t *TokensService) GenUpgradeProof(id []byte, tokens []token.LedgerToken) (
 	return t.ts.GenUpgradeProof(id, tokens)
 }
 
+// CheckUpgradeProof checks the upgrade proof for the given challenge and tokens
+func (t *TokensService) CheckUpgradeProof(id []byte, proof []byte, tokens []token.LedgerToken) (bool, error) {
+	return t.ts.CheckUpgradeProof(id, proof, tokens)
+}
+
 // SupportedTokenFormats returns the supported token formats
 func (t *TokensService) SupportedTokenFormats() []token.Format {
 	return t.ts.SupportedTokenFormats()
@@ -16,6 +16,12 @@ import (
 // Ledger models a read-only ledger
 type Ledger = driver.ValidatorLedger
 
+// ValidationRequest is a token request to be verified as part of a batch
+type ValidationRequest = driver.ValidationRequest
+
+// ValidationResult is the outcome of the verification of a ValidationRequest
+type ValidationResult = driver.ValidationResult
+
 // Validator validates a token request
 type Validator struct {
 	backend driver.Validator
@@ -55,6 +61,12 @@ func (c *Validator) UnmarshallAndVerifyWithMetadata(ctx context.Context, ledger
 	return res, meta, nil
 }
 
+// UnmarshallAndVerifyBatch unmarshalls and verifies the passed token requests, possibly in parallel.
+// It returns a result for each request, in the same order.
+func (c *Validator) UnmarshallAndVerifyBatch(ctx context.Context, requests []*ValidationRequest) []*ValidationResult {
+	return c.backend.VerifyTokenRequestsFromRaw(ctx, requests)
+}
+
 type stateGetter struct {
 	f driver.GetStateFnc
 }
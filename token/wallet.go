@@ -8,6 +8,7 @@ package token
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/hyperledger-labs/fabric-token-sdk/token/driver"
 	"github.com/hyperledger-labs/fabric-token-sdk/token/token"
@@ -297,15 +298,28 @@ func (o *OwnerWallet) ListUnspentTokensIterator(opts ...ListTokensOption) (*Unsp
 	return &UnspentTokensIterator{UnspentTokensIterator: it}, nil
 }
 
-// Balance returns the sun of the amounts, with 64 bits of precision, of the tokens with type and EID equal to those passed as arguments.
+// Balance returns the sum of the amounts, with 64 bits of precision, of the tokens with type and EID equal to those passed as arguments.
+// It returns an error if the sum does not fit 64 bits, use BigBalance in that case.
 func (o *OwnerWallet) Balance(opts ...ListTokensOption) (uint64, error) {
-	compiledOpts, err := CompileListTokensOption(opts...)
+	sum, err := o.BigBalance(opts...)
 	if err != nil {
 		return 0, err
 	}
+	if !sum.IsUint64() {
+		return 0, errors.Errorf("balance [%s] of wallet [%s] exceeds 64 bits", sum, o.ID())
+	}
+	return sum.Uint64(), nil
+}
+
+// BigBalance returns the sum of the amounts, with arbitrary precision, of the tokens with type and EID equal to those passed as arguments.
+func (o *OwnerWallet) BigBalance(opts ...ListTokensOption) (*big.Int, error) {
+	compiledOpts, err := CompileListTokensOption(opts...)
+	if err != nil {
+		return nil, err
+	}
 	sum, err := o.w.Balance(compiledOpts)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	return sum, nil
 }
@@ -352,6 +366,39 @@ func (i *IssuerWallet) ListIssuedTokens(opts ...ListTokensOption) (*token.Issued
 	return i.w.HistoryTokens(compiledOpts)
 }
 
+// RedeemedSupplyProvider gives access to the quantity of tokens that have been redeemed
+type RedeemedSupplyProvider interface {
+	// RedeemedSupply returns the total quantity of tokens of the passed type that have been redeemed
+	RedeemedSupply(tokenType token.Type) (*big.Int, error)
+}
+
+// OutstandingSupply returns the quantity of tokens of the passed type that have been issued by this wallet and not redeemed yet.
+// The redeemed quantity is obtained from the passed provider, usually the token transaction db.
+func (i *IssuerWallet) OutstandingSupply(tokenType token.Type, redeemed RedeemedSupplyProvider) (*big.Int, error) {
+	issuedTokens, err := i.ListIssuedTokens(WithType(tokenType))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to list issued tokens of type [%s]", tokenType)
+	}
+	precision := i.managementService.PublicParametersManager().PublicParameters().Precision()
+	supply := big.NewInt(0)
+	for _, issuedToken := range issuedTokens.Tokens {
+		q, err := token.ToQuantity(issuedToken.Quantity, precision)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to parse quantity [%s]", issuedToken.Quantity)
+		}
+		supply.Add(supply, q.ToBigInt())
+	}
+	redeemedSupply, err := redeemed.RedeemedSupply(tokenType)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to get the redeemed supply of type [%s]", tokenType)
+	}
+	supply.Sub(supply, redeemedSupply)
+	if supply.Sign() < 0 {
+		supply.SetInt64(0)
+	}
+	return supply, nil
+}
+
 func CompileListTokensOption(opts ...ListTokensOption) (*driver.ListTokensOptions, error) {
 	txOptions := &ListTokensOptions{}
 	for _, opt := range opts {